@@ -0,0 +1,419 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// アプリケーション全体の設定を保持する構造体
+type Config struct {
+	Server  ServerConfig  `yaml:"server" toml:"server"`
+	DB      DBConfig      `yaml:"db" toml:"db"`
+	Log     LogConfig     `yaml:"log" toml:"log"`
+	Paths   PathConfig    `yaml:"paths" toml:"paths"`
+	Cookie  CookieConfig  `yaml:"cookie" toml:"cookie"`
+	Session SessionConfig `yaml:"session" toml:"session"`
+	Storage StorageConfig `yaml:"storage" toml:"storage"`
+	Trash   TrashConfig   `yaml:"trash" toml:"trash"`
+}
+
+// HTTPサーバーの設定
+type ServerConfig struct {
+	// 待ち受けるアドレス（例：":3000"）
+	Addr string `yaml:"addr" toml:"addr"`
+	// リクエストの読み込み・レスポンスの書き込み・keep-aliveの待機の制限時間
+	ReadTimeout  Duration `yaml:"read_timeout" toml:"read_timeout"`
+	WriteTimeout Duration `yaml:"write_timeout" toml:"write_timeout"`
+	IdleTimeout  Duration `yaml:"idle_timeout" toml:"idle_timeout"`
+	// 終了時に処理中のリクエストの完了を待つ制限時間
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+}
+
+// 設定ファイルで"30s"のような文字列で指定できる時間
+type Duration struct {
+	time.Duration
+}
+
+// 文字列の時間を変換する
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
+
+// データベースの設定
+type DBConfig struct {
+	// ストレージの種類（mysql・sqlite・memory）
+	// memoryはメモリ上のSQLiteで、sqliteと同じドライバーを使用する
+	Driver   string `yaml:"driver" toml:"driver"`
+	Host     string `yaml:"host" toml:"host"`
+	Port     int    `yaml:"port" toml:"port"`
+	User     string `yaml:"user" toml:"user"`
+	Password string `yaml:"password" toml:"password"`
+	Name     string `yaml:"name" toml:"name"`
+	// MySQLの接続文字列に付与するパラメータ
+	Params string `yaml:"params" toml:"params"`
+	// SQLiteのファイルの保存先
+	Path string `yaml:"path" toml:"path"`
+}
+
+// ログの設定
+type LogConfig struct {
+	// 出力するログのレベル（debug・info・warn・error）
+	Level string `yaml:"level" toml:"level"`
+}
+
+// テンプレートや静的ファイルの配置場所の設定
+type PathConfig struct {
+	Templates string `yaml:"templates" toml:"templates"`
+	Static    string `yaml:"static" toml:"static"`
+}
+
+// cookieの設定
+type CookieConfig struct {
+	Domain string `yaml:"domain" toml:"domain"`
+	Secure bool   `yaml:"secure" toml:"secure"`
+}
+
+// ログインセッションの設定
+type SessionConfig struct {
+	// ログインしてからセッションが失効するまでの時間
+	TTL Duration `yaml:"ttl" toml:"ttl"`
+}
+
+// 添付ファイルの保存先の設定
+type StorageConfig struct {
+	// 保存先の種類（local・s3）
+	Driver string `yaml:"driver" toml:"driver"`
+	// driverがlocalの場合の保存先のディレクトリ
+	Dir string   `yaml:"dir" toml:"dir"`
+	S3  S3Config `yaml:"s3" toml:"s3"`
+}
+
+// S3互換のストレージの設定
+type S3Config struct {
+	// 接続先（例："https://s3.ap-northeast-1.amazonaws.com"、"http://minio:9000"）
+	Endpoint        string `yaml:"endpoint" toml:"endpoint"`
+	Region          string `yaml:"region" toml:"region"`
+	Bucket          string `yaml:"bucket" toml:"bucket"`
+	AccessKeyID     string `yaml:"access_key_id" toml:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key" toml:"secret_access_key"`
+	// バケット名をホスト名ではなくパスに含める（MinIOなどの互換ストレージ用）
+	PathStyle bool `yaml:"path_style" toml:"path_style"`
+}
+
+// ゴミ箱の設定
+type TrashConfig struct {
+	// ゴミ箱に移してから完全に削除するまでの日数
+	RetentionDays int `yaml:"retention_days" toml:"retention_days"`
+	// 保存期間を過ぎたtodoを削除する間隔
+	PurgeInterval Duration `yaml:"purge_interval" toml:"purge_interval"`
+}
+
+// ゴミ箱に移してから完全に削除するまでの期間を返す
+func (t TrashConfig) Retention() time.Duration {
+	return time.Duration(t.RetentionDays) * 24 * time.Hour
+}
+
+// 設定を指定しなかった場合の既定値を返す
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr:            ":3000",
+			ReadTimeout:     Duration{10 * time.Second},
+			WriteTimeout:    Duration{30 * time.Second},
+			IdleTimeout:     Duration{120 * time.Second},
+			ShutdownTimeout: Duration{15 * time.Second},
+		},
+		DB: DBConfig{
+			Driver: "mysql",
+			// docker-composeで定義したDB用コンテナのサービス名
+			Host:   "db",
+			Port:   3306,
+			Params: "charset=utf8mb4&parseTime=true&loc=Local",
+			Path:   "todo.db",
+		},
+		Log:     LogConfig{Level: "info"},
+		Paths:   PathConfig{Templates: "app/templates", Static: "app/static"},
+		Cookie:  CookieConfig{Domain: "localhost", Secure: true},
+		Session: SessionConfig{TTL: Duration{7 * 24 * time.Hour}},
+		Storage: StorageConfig{
+			Driver: "local",
+			Dir:    "uploads",
+			S3:     S3Config{Region: "us-east-1", PathStyle: true},
+		},
+		Trash: TrashConfig{RetentionDays: 30, PurgeInterval: Duration{time.Hour}},
+	}
+}
+
+// 設定を読み込んで返す
+// 既定値・設定ファイル・環境変数・コマンドライン引数の順に読み込み、後のものほど優先する
+// 設定ファイルは -config 引数か環境変数CONFIG_FILEで指定する（YAMLかTOML）
+// 引数のうち、設定として解釈しなかった残り（サブコマンドなど）も返す
+func Load(args []string) (*Config, []string, error) {
+	cfg := Default()
+	fields := cfg.fields()
+
+	// コマンドライン引数の定義
+	fs := flag.NewFlagSet("todo-go-app", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML config file")
+	values := make(map[string]*string, len(fields))
+	for _, f := range fields {
+		values[f.flag] = fs.String(f.flag, "", f.usage)
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	// 設定ファイルの読み込み
+	if *configFile != "" {
+		if err := cfg.loadFile(*configFile); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 環境変数の読み込み
+	for _, f := range fields {
+		for _, env := range f.envs {
+			if v, ok := os.LookupEnv(env); ok {
+				if err := f.set(v); err != nil {
+					return nil, nil, fmt.Errorf("invalid value for %s: %w", env, err)
+				}
+				break
+			}
+		}
+	}
+
+	// 指定されたコマンドライン引数のみを反映する
+	var flagErr error
+	fs.Visit(func(fl *flag.Flag) {
+		for _, f := range fields {
+			if f.flag == fl.Name && flagErr == nil {
+				if err := f.set(*values[f.flag]); err != nil {
+					flagErr = fmt.Errorf("invalid value for -%s: %w", f.flag, err)
+				}
+			}
+		}
+	})
+	if flagErr != nil {
+		return nil, nil, flagErr
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, fs.Args(), nil
+}
+
+// 設定内容に誤りが無いかを確認する
+// 誤りがある場合は全ての内容をまとめたエラーを返す
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Server.Addr == "" {
+		errs = append(errs, errors.New("server.addr is required"))
+	}
+	if c.Server.ReadTimeout.Duration <= 0 || c.Server.WriteTimeout.Duration <= 0 || c.Server.IdleTimeout.Duration <= 0 {
+		errs = append(errs, errors.New("server timeouts must be positive"))
+	}
+	if c.Server.ShutdownTimeout.Duration <= 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout must be positive"))
+	}
+	switch c.DB.Driver {
+	case "mysql":
+		if c.DB.Host == "" {
+			errs = append(errs, errors.New("db.host is required for mysql"))
+		}
+		if c.DB.Port <= 0 || 65535 < c.DB.Port {
+			errs = append(errs, fmt.Errorf("db.port is out of range: %d", c.DB.Port))
+		}
+		if c.DB.User == "" {
+			errs = append(errs, errors.New("db.user is required for mysql"))
+		}
+		if c.DB.Name == "" {
+			errs = append(errs, errors.New("db.name is required for mysql"))
+		}
+	case "sqlite":
+		if c.DB.Path == "" {
+			errs = append(errs, errors.New("db.path is required for sqlite"))
+		}
+	case "memory":
+	default:
+		errs = append(errs, fmt.Errorf("db.driver must be one of mysql, sqlite, memory: %q", c.DB.Driver))
+	}
+	if _, err := c.Log.SlogLevel(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.Paths.Templates == "" {
+		errs = append(errs, errors.New("paths.templates is required"))
+	}
+	if c.Paths.Static == "" {
+		errs = append(errs, errors.New("paths.static is required"))
+	}
+	if c.Session.TTL.Duration <= 0 {
+		errs = append(errs, errors.New("session.ttl must be positive"))
+	}
+	switch c.Storage.Driver {
+	case "local":
+		if c.Storage.Dir == "" {
+			errs = append(errs, errors.New("storage.dir is required for local"))
+		}
+	case "s3":
+		if c.Storage.S3.Endpoint == "" {
+			errs = append(errs, errors.New("storage.s3.endpoint is required for s3"))
+		}
+		if c.Storage.S3.Region == "" {
+			errs = append(errs, errors.New("storage.s3.region is required for s3"))
+		}
+		if c.Storage.S3.Bucket == "" {
+			errs = append(errs, errors.New("storage.s3.bucket is required for s3"))
+		}
+		if c.Storage.S3.AccessKeyID == "" || c.Storage.S3.SecretAccessKey == "" {
+			errs = append(errs, errors.New("storage.s3.access_key_id and storage.s3.secret_access_key are required for s3"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("storage.driver must be one of local, s3: %q", c.Storage.Driver))
+	}
+	if c.Trash.RetentionDays <= 0 {
+		errs = append(errs, errors.New("trash.retention_days must be positive"))
+	}
+	if c.Trash.PurgeInterval.Duration <= 0 {
+		errs = append(errs, errors.New("trash.purge_interval must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+// ログのレベルをslogのレベルに変換する
+func (l LogConfig) SlogLevel() (slog.Level, error) {
+	switch strings.ToLower(l.Level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("log.level must be one of debug, info, warn, error: %q", l.Level)
+	}
+}
+
+// 設定ファイルを読み込む
+// 拡張子でYAMLとTOMLを判別する
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, c)
+	case ".toml":
+		err = toml.Unmarshal(data, c)
+	default:
+		return fmt.Errorf("unsupported config file format: %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	return nil
+}
+
+// 環境変数やコマンドライン引数から設定できる項目
+type field struct {
+	// 対応する環境変数の名前（先頭のものほど優先する）
+	envs []string
+	// 対応するコマンドライン引数の名前
+	flag  string
+	usage string
+	// 文字列の値を設定に反映する関数
+	set func(string) error
+}
+
+// 環境変数やコマンドライン引数から設定できる項目の一覧を返す
+// MYSQL_USERなどはdb.envで定義済みのため、従来の名前のまま読み込む
+func (c *Config) fields() []field {
+	return []field{
+		{envs: []string{"LISTEN_ADDR"}, flag: "addr", usage: "address to listen on", set: setString(&c.Server.Addr)},
+		{envs: []string{"READ_TIMEOUT"}, flag: "read-timeout", usage: "timeout for reading a request", set: setDuration(&c.Server.ReadTimeout)},
+		{envs: []string{"WRITE_TIMEOUT"}, flag: "write-timeout", usage: "timeout for writing a response", set: setDuration(&c.Server.WriteTimeout)},
+		{envs: []string{"IDLE_TIMEOUT"}, flag: "idle-timeout", usage: "timeout for idle keep-alive connections", set: setDuration(&c.Server.IdleTimeout)},
+		{envs: []string{"SHUTDOWN_TIMEOUT"}, flag: "shutdown-timeout", usage: "time to wait for in-flight requests on shutdown", set: setDuration(&c.Server.ShutdownTimeout)},
+		{envs: []string{"DB_DRIVER"}, flag: "db-driver", usage: "storage driver (mysql, sqlite, memory: in-memory SQLite)", set: setString(&c.DB.Driver)},
+		{envs: []string{"DB_HOST"}, flag: "db-host", usage: "database host", set: setString(&c.DB.Host)},
+		{envs: []string{"DB_PORT"}, flag: "db-port", usage: "database port", set: setInt(&c.DB.Port)},
+		{envs: []string{"DB_USER", "MYSQL_USER"}, flag: "db-user", usage: "database user", set: setString(&c.DB.User)},
+		{envs: []string{"DB_PASSWORD", "MYSQL_PASSWORD"}, flag: "db-password", usage: "database password", set: setString(&c.DB.Password)},
+		{envs: []string{"DB_NAME", "MYSQL_DATABASE"}, flag: "db-name", usage: "database name", set: setString(&c.DB.Name)},
+		{envs: []string{"DB_PARAMS"}, flag: "db-params", usage: "extra parameters of the mysql DSN", set: setString(&c.DB.Params)},
+		{envs: []string{"SQLITE_PATH"}, flag: "db-path", usage: "path to the sqlite file", set: setString(&c.DB.Path)},
+		{envs: []string{"LOG_LEVEL"}, flag: "log-level", usage: "log level (debug, info, warn, error)", set: setString(&c.Log.Level)},
+		{envs: []string{"TEMPLATE_DIR"}, flag: "templates", usage: "directory of the HTML templates", set: setString(&c.Paths.Templates)},
+		{envs: []string{"STATIC_DIR"}, flag: "static", usage: "directory of the static files", set: setString(&c.Paths.Static)},
+		{envs: []string{"COOKIE_DOMAIN"}, flag: "cookie-domain", usage: "domain attribute of cookies", set: setString(&c.Cookie.Domain)},
+		{envs: []string{"COOKIE_SECURE"}, flag: "cookie-secure", usage: "secure attribute of cookies", set: setBool(&c.Cookie.Secure)},
+		{envs: []string{"SESSION_TTL"}, flag: "session-ttl", usage: "lifetime of login sessions", set: setDuration(&c.Session.TTL)},
+		{envs: []string{"STORAGE_DRIVER"}, flag: "storage-driver", usage: "attachment storage driver (local, s3)", set: setString(&c.Storage.Driver)},
+		{envs: []string{"STORAGE_DIR"}, flag: "storage-dir", usage: "directory of attachments for the local storage", set: setString(&c.Storage.Dir)},
+		{envs: []string{"S3_ENDPOINT"}, flag: "s3-endpoint", usage: "endpoint of the S3 compatible storage", set: setString(&c.Storage.S3.Endpoint)},
+		{envs: []string{"S3_REGION"}, flag: "s3-region", usage: "region of the S3 bucket", set: setString(&c.Storage.S3.Region)},
+		{envs: []string{"S3_BUCKET"}, flag: "s3-bucket", usage: "bucket to store attachments", set: setString(&c.Storage.S3.Bucket)},
+		{envs: []string{"S3_ACCESS_KEY_ID"}, flag: "s3-access-key-id", usage: "access key ID of the S3 compatible storage", set: setString(&c.Storage.S3.AccessKeyID)},
+		{envs: []string{"S3_SECRET_ACCESS_KEY"}, flag: "s3-secret-access-key", usage: "secret access key of the S3 compatible storage", set: setString(&c.Storage.S3.SecretAccessKey)},
+		{envs: []string{"S3_PATH_STYLE"}, flag: "s3-path-style", usage: "use path-style URLs for the S3 compatible storage", set: setBool(&c.Storage.S3.PathStyle)},
+		{envs: []string{"TRASH_RETENTION_DAYS"}, flag: "trash-retention-days", usage: "days to keep deleted todos in the trash", set: setInt(&c.Trash.RetentionDays)},
+		{envs: []string{"TRASH_PURGE_INTERVAL"}, flag: "trash-purge-interval", usage: "interval of purging expired todos from the trash", set: setDuration(&c.Trash.PurgeInterval)},
+	}
+}
+
+// 文字列の設定項目に値を反映する関数を返す
+func setString(p *string) func(string) error {
+	return func(v string) error {
+		*p = v
+		return nil
+	}
+}
+
+// 数値の設定項目に値を反映する関数を返す
+func setInt(p *int) func(string) error {
+	return func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return err
+		}
+		*p = n
+		return nil
+	}
+}
+
+// 時間の設定項目に値を反映する関数を返す
+func setDuration(p *Duration) func(string) error {
+	return func(v string) error {
+		return p.UnmarshalText([]byte(v))
+	}
+}
+
+// 真偽値の設定項目に値を反映する関数を返す
+func setBool(p *bool) func(string) error {
+	return func(v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		*p = b
+		return nil
+	}
+}
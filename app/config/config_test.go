@@ -0,0 +1,295 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// テスト用の設定ファイルを作成してパスを返す
+func writeConfigFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+
+	yamlFile := writeConfigFile(t, "config.yaml", `
+server:
+  addr: ":8080"
+  shutdown_timeout: 5s
+db:
+  driver: sqlite
+  path: /tmp/yaml.db
+log:
+  level: debug
+`)
+	tomlFile := writeConfigFile(t, "config.toml", `
+[server]
+addr = ":9090"
+read_timeout = "3s"
+
+[db]
+driver = "mysql"
+host = "mysql.example.com"
+port = 13306
+user = "toml"
+name = "todo"
+
+[cookie]
+domain = "example.com"
+`)
+
+	cases := map[string]struct {
+		args      []string
+		env       map[string]string
+		check     func(t *testing.T, cfg *Config)
+		wantRest  []string
+		expectErr bool
+	}{
+		"正常ケース:従来の環境変数のみ": {
+			env: map[string]string{"MYSQL_USER": "user", "MYSQL_PASSWORD": "pass", "MYSQL_DATABASE": "todo"},
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, ":3000", cfg.Server.Addr)
+				assert.Equal(t, "mysql", cfg.DB.Driver)
+				assert.Equal(t, "db", cfg.DB.Host)
+				assert.Equal(t, 3306, cfg.DB.Port)
+				assert.Equal(t, "user", cfg.DB.User)
+				assert.Equal(t, "pass", cfg.DB.Password)
+				assert.Equal(t, "todo", cfg.DB.Name)
+				assert.Equal(t, "localhost", cfg.Cookie.Domain)
+			},
+		},
+		"正常ケース:YAMLファイル": {
+			args: []string{"-config", yamlFile},
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, ":8080", cfg.Server.Addr)
+				assert.Equal(t, 5*time.Second, cfg.Server.ShutdownTimeout.Duration)
+				assert.Equal(t, "sqlite", cfg.DB.Driver)
+				assert.Equal(t, "/tmp/yaml.db", cfg.DB.Path)
+				assert.Equal(t, "debug", cfg.Log.Level)
+				// ファイルで指定していない項目は既定値のまま
+				assert.Equal(t, "app/templates", cfg.Paths.Templates)
+			},
+		},
+		"正常ケース:環境変数で指定したTOMLファイル": {
+			env: map[string]string{"CONFIG_FILE": tomlFile},
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, ":9090", cfg.Server.Addr)
+				assert.Equal(t, 3*time.Second, cfg.Server.ReadTimeout.Duration)
+				assert.Equal(t, "mysql.example.com", cfg.DB.Host)
+				assert.Equal(t, 13306, cfg.DB.Port)
+				assert.Equal(t, "example.com", cfg.Cookie.Domain)
+			},
+		},
+		"正常ケース:環境変数が設定ファイルより優先される": {
+			args: []string{"-config", yamlFile},
+			env:  map[string]string{"LISTEN_ADDR": ":7070"},
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, ":7070", cfg.Server.Addr)
+				assert.Equal(t, "sqlite", cfg.DB.Driver)
+			},
+		},
+		"正常ケース:コマンドライン引数が環境変数より優先される": {
+			args: []string{"-addr", ":6060", "-db-driver", "memory"},
+			env:  map[string]string{"LISTEN_ADDR": ":7070", "DB_DRIVER": "sqlite"},
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, ":6060", cfg.Server.Addr)
+				assert.Equal(t, "memory", cfg.DB.Driver)
+			},
+		},
+		"正常ケース:環境変数で指定した時間": {
+			env: map[string]string{"DB_DRIVER": "memory", "WRITE_TIMEOUT": "1m", "SESSION_TTL": "12h"},
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, time.Minute, cfg.Server.WriteTimeout.Duration)
+				assert.Equal(t, 12*time.Hour, cfg.Session.TTL.Duration)
+			},
+		},
+		"正常ケース:ゴミ箱の保存期間と削除の間隔": {
+			args: []string{"-db-driver", "memory", "-trash-retention-days", "7"},
+			env:  map[string]string{"TRASH_PURGE_INTERVAL": "10m"},
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 7, cfg.Trash.RetentionDays)
+				assert.Equal(t, 7*24*time.Hour, cfg.Trash.Retention())
+				assert.Equal(t, 10*time.Minute, cfg.Trash.PurgeInterval.Duration)
+			},
+		},
+		"正常ケース:サブコマンドは残りの引数として返す": {
+			args: []string{"-db-driver", "memory", "migrate", "up"},
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "memory", cfg.DB.Driver)
+			},
+			wantRest: []string{"migrate", "up"},
+		},
+		"異常ケース:時間に変換できない環境変数": {
+			env:       map[string]string{"DB_DRIVER": "memory", "IDLE_TIMEOUT": "forever"},
+			expectErr: true,
+		},
+		"異常ケース:数値に変換できない環境変数": {
+			env:       map[string]string{"DB_DRIVER": "memory", "DB_PORT": "abc"},
+			expectErr: true,
+		},
+		"異常ケース:存在しない設定ファイル": {
+			args:      []string{"-config", filepath.Join(t.TempDir(), "missing.yaml")},
+			expectErr: true,
+		},
+		"異常ケース:未定義のコマンドライン引数": {
+			args:      []string{"-unknown", "value"},
+			expectErr: true,
+		},
+		"異常ケース:MySQLのユーザーが未指定": {
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			// 実行環境の環境変数の影響を受けないよう、空にしておく
+			for _, f := range Default().fields() {
+				for _, env := range f.envs {
+					t.Setenv(env, "")
+					os.Unsetenv(env)
+				}
+			}
+			t.Setenv("CONFIG_FILE", "")
+			os.Unsetenv("CONFIG_FILE")
+
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, rest, err := Load(tt.args)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+				assert.Nil(t, cfg)
+				return
+			}
+			if assert.NoError(t, err) {
+				tt.check(t, cfg)
+				assert.ElementsMatch(t, tt.wantRest, rest)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+
+	cases := map[string]struct {
+		modify    func(cfg *Config)
+		expectErr bool
+	}{
+		"正常ケース:メモリ上のストレージ": {
+			modify:    func(cfg *Config) { cfg.DB.Driver = "memory" },
+			expectErr: false,
+		},
+		"異常ケース:未対応のストレージ": {
+			modify:    func(cfg *Config) { cfg.DB.Driver = "postgres" },
+			expectErr: true,
+		},
+		"異常ケース:ポート番号が範囲外": {
+			modify: func(cfg *Config) {
+				cfg.DB.User = "user"
+				cfg.DB.Name = "todo"
+				cfg.DB.Port = 70000
+			},
+			expectErr: true,
+		},
+		"異常ケース:SQLiteのファイルが未指定": {
+			modify: func(cfg *Config) {
+				cfg.DB.Driver = "sqlite"
+				cfg.DB.Path = ""
+			},
+			expectErr: true,
+		},
+		"異常ケース:ログのレベルが不正": {
+			modify: func(cfg *Config) {
+				cfg.DB.Driver = "memory"
+				cfg.Log.Level = "verbose"
+			},
+			expectErr: true,
+		},
+		"異常ケース:終了時の待機時間が0": {
+			modify: func(cfg *Config) {
+				cfg.DB.Driver = "memory"
+				cfg.Server.ShutdownTimeout.Duration = 0
+			},
+			expectErr: true,
+		},
+		"異常ケース:セッションの有効期間が0": {
+			modify: func(cfg *Config) {
+				cfg.DB.Driver = "memory"
+				cfg.Session.TTL.Duration = 0
+			},
+			expectErr: true,
+		},
+		"正常ケース:S3互換のストレージ": {
+			modify: func(cfg *Config) {
+				cfg.DB.Driver = "memory"
+				cfg.Storage.Driver = "s3"
+				cfg.Storage.S3 = S3Config{Endpoint: "http://minio:9000", Region: "us-east-1", Bucket: "todo", AccessKeyID: "key", SecretAccessKey: "secret"}
+			},
+			expectErr: false,
+		},
+		"異常ケース:S3のバケットが未指定": {
+			modify: func(cfg *Config) {
+				cfg.DB.Driver = "memory"
+				cfg.Storage.Driver = "s3"
+				cfg.Storage.S3 = S3Config{Endpoint: "http://minio:9000", Region: "us-east-1", AccessKeyID: "key", SecretAccessKey: "secret"}
+			},
+			expectErr: true,
+		},
+		"異常ケース:未対応の添付ファイルの保存先": {
+			modify: func(cfg *Config) {
+				cfg.DB.Driver = "memory"
+				cfg.Storage.Driver = "gcs"
+			},
+			expectErr: true,
+		},
+		"異常ケース:ゴミ箱の保存期間が0": {
+			modify: func(cfg *Config) {
+				cfg.DB.Driver = "memory"
+				cfg.Trash.RetentionDays = 0
+			},
+			expectErr: true,
+		},
+		"異常ケース:ゴミ箱の削除の間隔が0": {
+			modify: func(cfg *Config) {
+				cfg.DB.Driver = "memory"
+				cfg.Trash.PurgeInterval.Duration = 0
+			},
+			expectErr: true,
+		},
+		"異常ケース:待ち受けるアドレスが未指定": {
+			modify: func(cfg *Config) {
+				cfg.DB.Driver = "memory"
+				cfg.Server.Addr = ""
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
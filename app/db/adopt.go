@@ -0,0 +1,89 @@
+package db
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"gorm.io/gorm"
+)
+
+// 所有者の無い（user_idが0の）todoを、指定されたメールアドレスのユーザーに引き継ぎ、引き継いだ件数を返す
+// ユーザーの導入（0003）より前に作成されたtodoは所有者が無く、どのユーザーにも表示されないため、
+// 既存のデータを使い続ける場合はmigrate adoptで引き継ぐ
+// プロジェクトはユーザーのInboxとし、並び順はユーザーの既存のtodoの末尾に作成順で並べる
+func AdoptOrphanedTodos(conn *gorm.DB, email string) (int64, error) {
+	var user models.User
+	if err := conn.Where("email = ?", email).First(&user).Error; err != nil {
+		return 0, fmt.Errorf("user %q: %w", email, err)
+	}
+
+	var adopted int64
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		var orphans []models.Todo
+		if err := tx.Unscoped().Where("user_id = ?", 0).Order("position, id").Find(&orphans).Error; err != nil {
+			return err
+		}
+		if len(orphans) == 0 {
+			return nil
+		}
+
+		inbox, err := findOrCreateInbox(tx, user.ID)
+		if err != nil {
+			return err
+		}
+		// 削除済みのtodoと並び順が重ならないよう、削除済みのものも含めて末尾を求める
+		var last string
+		if err := tx.Unscoped().Model(&models.Todo{}).Where("user_id = ?", user.ID).Select("COALESCE(MAX(position), '')").Scan(&last).Error; err != nil {
+			return err
+		}
+		for _, orphan := range orphans {
+			position, err := models.RankBetween(last, "")
+			if err != nil {
+				return err
+			}
+			// 更新日時とバージョンは、内容の変更ではないため変えない
+			result := tx.Unscoped().Model(&models.Todo{}).Where("id = ? AND user_id = ?", orphan.ID, 0).UpdateColumns(map[string]any{
+				"user_id":    user.ID,
+				"project_id": inbox.ID,
+				"position":   position,
+			})
+			if result.Error != nil {
+				return result.Error
+			}
+			adopted += result.RowsAffected
+			last = position
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return adopted, nil
+}
+
+// ユーザーのInboxを返す（まだ無い場合は作成する）
+func findOrCreateInbox(tx *gorm.DB, userID uint) (*models.Project, error) {
+	var inbox models.Project
+	result := tx.Where("user_id = ? AND inbox = ?", userID, true).Limit(1).Find(&inbox)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return &inbox, nil
+	}
+	created := models.NewInboxProject(userID)
+	if err := tx.Create(created).Error; err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// 所有者の無いtodoが残っている場合に、引き継ぐ方法を警告として出力する
+func warnOrphanedTodos(conn *gorm.DB) {
+	var count int64
+	if err := conn.Unscoped().Model(&models.Todo{}).Where("user_id = ?", 0).Count(&count).Error; err != nil || count == 0 {
+		return
+	}
+	slog.Warn("todos without an owner are not shown to any user, run `migrate adopt <email>` to assign them", "count", count)
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAdoptOrphanedTodos(t *testing.T) {
+
+	// ユーザーの導入前に作成したtodoがあるDBを、最新のスキーマに移行して返す
+	setup := func(t *testing.T) *gorm.DB {
+		conn := openEmptySQLite(t)
+		migrator, err := NewMigrator(conn, DriverSQLite)
+		if err != nil {
+			t.Fatalf("failed to load migrations: %v", err)
+		}
+		if _, err := migrator.Up(2); err != nil {
+			t.Fatalf("failed to migrate database: %v", err)
+		}
+		for _, title := range []string{"old1", "old2"} {
+			if err := conn.Exec("INSERT INTO `todos` (`created_at`, `updated_at`, `title`, `status`) VALUES (CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, ?, 0)", title).Error; err != nil {
+				t.Fatalf("Creation is failed. error: %v", err)
+			}
+		}
+		if _, err := migrator.Up(0); err != nil {
+			t.Fatalf("failed to migrate database: %v", err)
+		}
+		return conn
+	}
+
+	t.Run("正常ケース:ユーザーのInboxの末尾に引き継ぐ", func(t *testing.T) {
+		conn := setup(t)
+		user := models.User{Email: "user@example.com", PasswordHash: "hash"}
+		if err := conn.Create(&user).Error; err != nil {
+			t.Fatalf("Creation is failed. error: %v", err)
+		}
+		handler := &testHandler{conn: conn}
+		inbox, err := NewProjectRepository(handler).FindInbox(user.ID)
+		if err != nil {
+			t.Fatalf("Creation is failed. error: %v", err)
+		}
+		// 導入後に作成したtodo
+		mine := &models.Todo{UserID: user.ID, ProjectID: inbox.ID, Title: "mine", Status: models.NotStarted}
+		if err := NewTodoRepository(handler).Create(mine); err != nil {
+			t.Fatalf("Creation is failed. error: %v", err)
+		}
+
+		adopted, err := AdoptOrphanedTodos(conn, "user@example.com")
+
+		// 結果を確認
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), adopted)
+		todos, _, err := NewTodoRepository(handler).Search(models.TodoQuery{UserID: user.ID, Sort: models.SortByPosition})
+		if assert.NoError(t, err) && assert.Len(t, *todos, 3) {
+			for _, todo := range *todos {
+				assert.Equal(t, inbox.ID, todo.ProjectID)
+			}
+			assert.Equal(t, []string{"mine", "old1", "old2"}, []string{(*todos)[0].Title, (*todos)[1].Title, (*todos)[2].Title})
+		}
+		// 2回目は引き継ぐものが無い
+		adopted, err = AdoptOrphanedTodos(conn, "user@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), adopted)
+	})
+
+	t.Run("異常ケース:ユーザーが存在しない", func(t *testing.T) {
+		conn := setup(t)
+
+		_, err := AdoptOrphanedTodos(conn, "nobody@example.com")
+
+		// 結果を確認
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		var count int64
+		conn.Model(&models.Todo{}).Where("user_id = ?", 0).Count(&count)
+		assert.Equal(t, int64(2), count)
+	})
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"log/slog"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	"gorm.io/gorm"
+)
+
+// attachmentモデルのDB処理を担うリポジトリの構造体
+type attachmentRepository struct {
+	handler SqlHandler
+}
+
+// AttachmentRepositoryの新しいインスタンスを作成して返す
+func NewAttachmentRepository(sqlHandler SqlHandler) repository.AttachmentRepository {
+	attachmentRepository := attachmentRepository{handler: sqlHandler}
+	return &attachmentRepository
+}
+
+// 指定されたユーザーの、指定されたtodoの添付ファイルの情報を添付順で返す
+func (ar *attachmentRepository) FindByTodo(userID uint, todoID uint) (*[]models.Attachment, error) {
+	var attachments []models.Attachment
+	result := ar.handler.GetConnection().
+		Scopes(ownedAttachments(userID, todoID)).
+		Order("created_at, id").
+		Find(&attachments)
+	return &attachments, result.Error
+}
+
+// 指定されたユーザーの、指定されたtodoの、指定されたIDの添付ファイルの情報を検索して結果を返す
+// 他のユーザーのtodoの添付ファイルは存在しないものとして扱う
+func (ar *attachmentRepository) FindById(userID uint, todoID uint, id uint) (*models.Attachment, error) {
+	var attachment models.Attachment
+	result := ar.handler.GetConnection().
+		Scopes(ownedAttachments(userID, todoID)).
+		Where("id = ?", id).
+		First(&attachment)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &attachment, nil
+}
+
+// 渡された添付ファイルの情報を新規作成して保存する
+// todoの所有者はユースケースで確認する
+func (ar *attachmentRepository) Create(attachment *models.Attachment) error {
+	result := ar.handler.GetConnection().Create(attachment)
+	return result.Error
+}
+
+// 指定されたユーザーの、指定されたtodoの、指定されたIDの添付ファイルの情報を削除する
+func (ar *attachmentRepository) Delete(userID uint, todoID uint, id uint) error {
+	// 存在しないIDの場合でもエラーは出ないため、存否チェックをする
+	_, err := ar.FindById(userID, todoID, id)
+	if err != nil {
+		return err
+	}
+	result := ar.handler.GetConnection().Delete(&models.Attachment{}, id)
+	return result.Error
+}
+
+// リポジトリの終了処理を行う
+func (ar *attachmentRepository) Close() error {
+	// 依存先をクローズする
+	err := ar.handler.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	return err
+}
+
+// 指定されたユーザーの、指定されたtodoの添付ファイルに絞り込むスコープを返す
+// 削除済みのtodoの添付ファイルは対象外とする
+func ownedAttachments(userID uint, todoID uint) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		owned := tx.Session(&gorm.Session{NewDB: true}).
+			Model(&models.Todo{}).
+			Select("id").
+			Where("user_id = ? AND id = ?", userID, todoID)
+		return tx.Where("todo_id IN (?)", owned)
+	}
+}
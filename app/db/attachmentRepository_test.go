@@ -0,0 +1,178 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// 添付ファイルのテストで使用する添付ファイルの情報を作成する
+// todoのタイトルをキーに、ファイル名を添付順で渡す（todoの所有者が添付したものとする）
+func createAttachmentFixture(t *testing.T, db *gorm.DB, f *projectFixture, files map[string][]string) map[string]*models.Attachment {
+	created := map[string]*models.Attachment{}
+	base := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	for key, names := range files {
+		todo := f.todos[key]
+		for i, name := range names {
+			// 添付順を確定させるため、作成日時をずらす
+			attachment := &models.Attachment{
+				CreatedAt:   base.Add(time.Duration(i) * time.Minute),
+				TodoID:      todo.ID,
+				UserID:      todo.UserID,
+				FileName:    name,
+				ContentType: "text/plain",
+				Size:        1,
+				StorageKey:  fmt.Sprintf("todos/%d/%s", todo.ID, name),
+			}
+			if result := db.Create(attachment); result.Error != nil {
+				t.Fatalf("Creation is failed. error: %v", result.Error)
+			}
+			created[name] = attachment
+		}
+	}
+	return created
+}
+
+func (s *todoRepositoryTestSuite) TestAttachment() {
+
+	cases := map[string]struct {
+		// 添付ファイルの情報に対する操作
+		run func(r repository.AttachmentRepository, f *projectFixture, attachments map[string]*models.Attachment) error
+		// 操作後のtodo1の添付ファイルの名前（添付順）
+		want      []string
+		expectErr bool
+	}{
+		"正常ケース:添付": {
+			run: func(r repository.AttachmentRepository, f *projectFixture, attachments map[string]*models.Attachment) error {
+				attachment, _ := models.NewAttachment(f.todos["todo1"].ID, f.user.ID, "d.txt", []byte("d"), 1)
+				return r.Create(attachment)
+			},
+			want: []string{"a.txt", "b.txt", "d.txt"},
+		},
+		"正常ケース:削除": {
+			run: func(r repository.AttachmentRepository, f *projectFixture, attachments map[string]*models.Attachment) error {
+				return r.Delete(f.user.ID, f.todos["todo1"].ID, attachments["a.txt"].ID)
+			},
+			want: []string{"b.txt"},
+		},
+		"異常ケース:他のユーザーのtodoの添付ファイルは削除できない": {
+			run: func(r repository.AttachmentRepository, f *projectFixture, attachments map[string]*models.Attachment) error {
+				return r.Delete(f.user.ID, f.todos["other"].ID, attachments["x.txt"].ID)
+			},
+			want:      []string{"a.txt", "b.txt"},
+			expectErr: true,
+		},
+		"異常ケース:別のtodoを指定した添付ファイルは削除できない": {
+			run: func(r repository.AttachmentRepository, f *projectFixture, attachments map[string]*models.Attachment) error {
+				return r.Delete(f.user.ID, f.todos["todo2"].ID, attachments["a.txt"].ID)
+			},
+			want:      []string{"a.txt", "b.txt"},
+			expectErr: true,
+		},
+		"異常ケース:他のユーザーのtodoの添付ファイルは取得できない": {
+			run: func(r repository.AttachmentRepository, f *projectFixture, attachments map[string]*models.Attachment) error {
+				_, err := r.FindById(f.user.ID, f.todos["other"].ID, attachments["x.txt"].ID)
+				return err
+			},
+			want:      []string{"a.txt", "b.txt"},
+			expectErr: true,
+		},
+	}
+	for name, tt := range cases {
+		s.T().Run(name, func(t *testing.T) {
+			// テスト用DBに接続する
+			db, err := s.openDB()
+			if err != nil {
+				s.Failf("database connection is not established", "%v", err)
+			}
+
+			defer s.Close(db)
+
+			// 初期処理
+			sqlHandler := testHandler{conn: db}
+			attachmentRepository := NewAttachmentRepository(&sqlHandler)
+			f := s.createProjectFixture(t, db)
+			attachments := createAttachmentFixture(t, db, f, map[string][]string{
+				"todo1": {"a.txt", "b.txt"},
+				"other": {"x.txt"},
+			})
+
+			err = tt.run(attachmentRepository, f, attachments)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			got, err := attachmentRepository.FindByTodo(f.user.ID, f.todos["todo1"].ID)
+			if assert.NoError(t, err) {
+				names := []string{}
+				for _, attachment := range *got {
+					names = append(names, attachment.FileName)
+				}
+				assert.Equal(t, tt.want, names)
+			}
+		})
+	}
+}
+
+func (s *todoRepositoryTestSuite) TestAttachmentWithTodo() {
+
+	// テスト用DBに接続する
+	db, err := s.openDB()
+	if err != nil {
+		s.Failf("database connection is not established", "%v", err)
+	}
+
+	defer s.Close(db)
+
+	// 初期処理
+	sqlHandler := testHandler{conn: db}
+	todoRepository := NewTodoRepository(&sqlHandler)
+	f := s.createProjectFixture(s.T(), db)
+	createAttachmentFixture(s.T(), db, f, map[string][]string{
+		"todo1": {"a.txt", "b.txt"},
+		"todo2": {"c.txt"},
+	})
+
+	s.T().Run("正常ケース:todoの取得時に添付ファイルの情報が添付順で読み込まれる", func(t *testing.T) {
+		todo, err := todoRepository.FindById(f.user.ID, f.todos["todo1"].ID)
+		if assert.NoError(t, err) {
+			names := []string{}
+			for _, attachment := range todo.Attachments {
+				names = append(names, attachment.FileName)
+			}
+			assert.Equal(t, []string{"a.txt", "b.txt"}, names)
+		}
+	})
+
+	s.T().Run("正常ケース:todoをゴミ箱に移しても添付ファイルの情報は残る", func(t *testing.T) {
+		if err := todoRepository.Delete(f.user.ID, f.todos["todo2"].ID); err != nil {
+			t.Fatalf("Deletion is failed. error: %v", err)
+		}
+		var count int64
+		if result := db.Model(&models.Attachment{}).Where("todo_id = ?", f.todos["todo2"].ID).Count(&count); assert.NoError(t, result.Error) {
+			assert.NotZero(t, count)
+		}
+	})
+
+	s.T().Run("正常ケース:todoを完全に削除すると添付ファイルの情報も削除される", func(t *testing.T) {
+		if err := todoRepository.Purge(f.user.ID, f.todos["todo2"].ID); err != nil {
+			t.Fatalf("Purge is failed. error: %v", err)
+		}
+		var count int64
+		if result := db.Model(&models.Attachment{}).Where("todo_id = ?", f.todos["todo2"].ID).Count(&count); assert.NoError(t, result.Error) {
+			assert.Equal(t, int64(0), count)
+		}
+		// 他のtodoの添付ファイルの情報は残ること
+		if result := db.Model(&models.Attachment{}).Where("todo_id = ?", f.todos["todo1"].ID).Count(&count); assert.NoError(t, result.Error) {
+			assert.Equal(t, int64(2), count)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"log/slog"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	"gorm.io/gorm"
+)
+
+// checklistItemモデルのDB処理を担うリポジトリの構造体
+type checklistRepository struct {
+	handler SqlHandler
+}
+
+// ChecklistRepositoryの新しいインスタンスを作成して返す
+func NewChecklistRepository(sqlHandler SqlHandler) repository.ChecklistRepository {
+	checklistRepository := checklistRepository{handler: sqlHandler}
+	return &checklistRepository
+}
+
+// 指定されたユーザーの、指定されたtodoのチェックリストの項目を並び順で返す
+func (cr *checklistRepository) FindByTodo(userID uint, todoID uint) (*[]models.ChecklistItem, error) {
+	var items []models.ChecklistItem
+	result := cr.handler.GetConnection().
+		Scopes(ownedChecklistItems(userID, todoID)).
+		Order("position, id").
+		Find(&items)
+	return &items, result.Error
+}
+
+// 指定されたユーザーの、指定されたtodoの、指定されたIDの項目を検索して結果を返す
+// 他のユーザーのtodoの項目は存在しないものとして扱う
+func (cr *checklistRepository) FindById(userID uint, todoID uint, id uint) (*models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	result := cr.handler.GetConnection().
+		Scopes(ownedChecklistItems(userID, todoID)).
+		Where("id = ?", id).
+		First(&item)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &item, nil
+}
+
+// 渡された項目を新規作成して保存する
+// todoの所有者はユースケースで確認する
+func (cr *checklistRepository) Create(item *models.ChecklistItem) error {
+	result := cr.handler.GetConnection().Create(item)
+	return result.Error
+}
+
+// 渡された項目のデータを更新する
+func (cr *checklistRepository) Update(userID uint, item *models.ChecklistItem) error {
+	// Saveメソッドだと、存在しないIDの場合はCreate動作になるため、
+	// 存否チェックをする（他のユーザーのtodoの項目は更新できない）
+	_, err := cr.FindById(userID, item.TodoID, item.ID)
+	if err != nil {
+		return err
+	}
+	result := cr.handler.GetConnection().Save(item)
+	return result.Error
+}
+
+// 指定されたユーザーの、指定されたtodoの、指定されたIDの項目を削除する
+func (cr *checklistRepository) Delete(userID uint, todoID uint, id uint) error {
+	// 存在しないIDの場合でもエラーは出ないため、存否チェックをする
+	_, err := cr.FindById(userID, todoID, id)
+	if err != nil {
+		return err
+	}
+	result := cr.handler.GetConnection().Delete(&models.ChecklistItem{}, id)
+	return result.Error
+}
+
+// 指定されたtodoの項目の並び順を、渡されたIDの順に振り直す
+// IDはtodoの全ての項目を過不足なく指定する必要がある
+func (cr *checklistRepository) Reorder(userID uint, todoID uint, ids []uint) error {
+	return cr.handler.GetConnection().Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.ChecklistItem{}).Scopes(ownedChecklistItems(userID, todoID)).Count(&count).Error; err != nil {
+			return err
+		}
+		if count != int64(len(ids)) {
+			return errNotFound
+		}
+		for position, id := range ids {
+			result := tx.Model(&models.ChecklistItem{}).
+				Where("todo_id = ? AND id = ?", todoID, id).
+				Update("position", position)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected != 1 {
+				return errNotFound
+			}
+		}
+		return nil
+	})
+}
+
+// リポジトリの終了処理を行う
+func (cr *checklistRepository) Close() error {
+	// 依存先をクローズする
+	err := cr.handler.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	return err
+}
+
+// 指定されたユーザーの、指定されたtodoの項目に絞り込むスコープを返す
+// 削除済みのtodoの項目は対象外とする
+func ownedChecklistItems(userID uint, todoID uint) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		owned := tx.Session(&gorm.Session{NewDB: true}).
+			Model(&models.Todo{}).
+			Select("id").
+			Where("user_id = ? AND id = ?", userID, todoID)
+		return tx.Where("todo_id IN (?)", owned)
+	}
+}
@@ -0,0 +1,200 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// チェックリストのテストで使用する項目を作成する
+// todoのタイトルをキーに、項目のタイトルを並び順で渡す
+func createChecklistFixture(t *testing.T, db *gorm.DB, f *projectFixture, items map[string][]string) map[string]*models.ChecklistItem {
+	created := map[string]*models.ChecklistItem{}
+	for key, titles := range items {
+		for position, title := range titles {
+			item := &models.ChecklistItem{TodoID: f.todos[key].ID, Title: title, Position: position}
+			if result := db.Create(item); result.Error != nil {
+				t.Fatalf("Creation is failed. error: %v", result.Error)
+			}
+			created[title] = item
+		}
+	}
+	return created
+}
+
+func (s *todoRepositoryTestSuite) TestChecklist() {
+
+	cases := map[string]struct {
+		// 項目に対する操作
+		run func(r repository.ChecklistRepository, f *projectFixture, items map[string]*models.ChecklistItem) error
+		// 操作後のtodo1の項目のタイトル（並び順）
+		want      []string
+		expectErr bool
+	}{
+		"正常ケース:追加": {
+			run: func(r repository.ChecklistRepository, f *projectFixture, items map[string]*models.ChecklistItem) error {
+				item, _ := models.NewChecklistItem(f.todos["todo1"].ID, "d")
+				item.Position = 3
+				return r.Create(item)
+			},
+			want: []string{"a", "b", "c", "d"},
+		},
+		"正常ケース:タイトルを変更": {
+			run: func(r repository.ChecklistRepository, f *projectFixture, items map[string]*models.ChecklistItem) error {
+				item := items["b"]
+				_ = item.Rename("B")
+				return r.Update(f.user.ID, item)
+			},
+			want: []string{"a", "B", "c"},
+		},
+		"正常ケース:削除": {
+			run: func(r repository.ChecklistRepository, f *projectFixture, items map[string]*models.ChecklistItem) error {
+				return r.Delete(f.user.ID, f.todos["todo1"].ID, items["a"].ID)
+			},
+			want: []string{"b", "c"},
+		},
+		"正常ケース:並び替え": {
+			run: func(r repository.ChecklistRepository, f *projectFixture, items map[string]*models.ChecklistItem) error {
+				return r.Reorder(f.user.ID, f.todos["todo1"].ID, []uint{items["c"].ID, items["a"].ID, items["b"].ID})
+			},
+			want: []string{"c", "a", "b"},
+		},
+		"異常ケース:並び替えで項目が不足している": {
+			run: func(r repository.ChecklistRepository, f *projectFixture, items map[string]*models.ChecklistItem) error {
+				return r.Reorder(f.user.ID, f.todos["todo1"].ID, []uint{items["c"].ID, items["a"].ID})
+			},
+			want:      []string{"a", "b", "c"},
+			expectErr: true,
+		},
+		"異常ケース:並び替えで他のtodoの項目が含まれる": {
+			run: func(r repository.ChecklistRepository, f *projectFixture, items map[string]*models.ChecklistItem) error {
+				return r.Reorder(f.user.ID, f.todos["todo1"].ID, []uint{items["c"].ID, items["a"].ID, items["x"].ID})
+			},
+			want:      []string{"a", "b", "c"},
+			expectErr: true,
+		},
+		"異常ケース:他のユーザーのtodoの項目は更新できない": {
+			run: func(r repository.ChecklistRepository, f *projectFixture, items map[string]*models.ChecklistItem) error {
+				item := items["x"]
+				item.Done = true
+				return r.Update(f.user.ID, item)
+			},
+			want:      []string{"a", "b", "c"},
+			expectErr: true,
+		},
+		"異常ケース:他のユーザーのtodoの項目は削除できない": {
+			run: func(r repository.ChecklistRepository, f *projectFixture, items map[string]*models.ChecklistItem) error {
+				return r.Delete(f.user.ID, f.todos["other"].ID, items["x"].ID)
+			},
+			want:      []string{"a", "b", "c"},
+			expectErr: true,
+		},
+		"異常ケース:別のtodoを指定した項目は削除できない": {
+			run: func(r repository.ChecklistRepository, f *projectFixture, items map[string]*models.ChecklistItem) error {
+				return r.Delete(f.user.ID, f.todos["todo2"].ID, items["a"].ID)
+			},
+			want:      []string{"a", "b", "c"},
+			expectErr: true,
+		},
+	}
+	for name, tt := range cases {
+		s.T().Run(name, func(t *testing.T) {
+			// テスト用DBに接続する
+			db, err := s.openDB()
+			if err != nil {
+				s.Failf("database connection is not established", "%v", err)
+			}
+
+			defer s.Close(db)
+
+			// 初期処理
+			sqlHandler := testHandler{conn: db}
+			checklistRepository := NewChecklistRepository(&sqlHandler)
+			f := s.createProjectFixture(t, db)
+			items := createChecklistFixture(t, db, f, map[string][]string{
+				"todo1": {"a", "b", "c"},
+				"other": {"x"},
+			})
+
+			err = tt.run(checklistRepository, f, items)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			got, err := checklistRepository.FindByTodo(f.user.ID, f.todos["todo1"].ID)
+			if assert.NoError(t, err) {
+				titles := []string{}
+				for _, item := range *got {
+					titles = append(titles, item.Title)
+				}
+				assert.Equal(t, tt.want, titles)
+			}
+		})
+	}
+}
+
+func (s *todoRepositoryTestSuite) TestChecklistWithTodo() {
+
+	// テスト用DBに接続する
+	db, err := s.openDB()
+	if err != nil {
+		s.Failf("database connection is not established", "%v", err)
+	}
+
+	defer s.Close(db)
+
+	// 初期処理
+	sqlHandler := testHandler{conn: db}
+	checklistRepository := NewChecklistRepository(&sqlHandler)
+	todoRepository := NewTodoRepository(&sqlHandler)
+	f := s.createProjectFixture(s.T(), db)
+	createChecklistFixture(s.T(), db, f, map[string][]string{
+		"todo1": {"a", "b"},
+		"todo2": {"c"},
+		"other": {"x"},
+	})
+
+	s.T().Run("正常ケース:todoの取得時に項目が並び順で読み込まれる", func(t *testing.T) {
+		todo, err := todoRepository.FindById(f.user.ID, f.todos["todo1"].ID)
+		if assert.NoError(t, err) {
+			titles := []string{}
+			for _, item := range todo.ChecklistItems {
+				titles = append(titles, item.Title)
+			}
+			assert.Equal(t, []string{"a", "b"}, titles)
+		}
+	})
+
+	s.T().Run("正常ケース:他のユーザーのtodoの項目は取得できない", func(t *testing.T) {
+		items, err := checklistRepository.FindByTodo(f.user.ID, f.todos["other"].ID)
+		if assert.NoError(t, err) {
+			assert.Empty(t, *items)
+		}
+	})
+
+	s.T().Run("正常ケース:削除済みのtodoの項目は取得できない", func(t *testing.T) {
+		if err := todoRepository.Delete(f.user.ID, f.todos["todo2"].ID); err != nil {
+			t.Fatalf("Deletion is failed. error: %v", err)
+		}
+		items, err := checklistRepository.FindByTodo(f.user.ID, f.todos["todo2"].ID)
+		if assert.NoError(t, err) {
+			assert.Empty(t, *items)
+		}
+	})
+
+	s.T().Run("正常ケース:todoを物理削除すると項目も削除される", func(t *testing.T) {
+		if result := db.Unscoped().Delete(&models.Todo{}, f.todos["todo1"].ID); result.Error != nil {
+			t.Fatalf("Deletion is failed. error: %v", result.Error)
+		}
+		var count int64
+		if result := db.Model(&models.ChecklistItem{}).Where("todo_id = ?", f.todos["todo1"].ID).Count(&count); assert.NoError(t, result.Error) {
+			assert.Equal(t, int64(0), count)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"log/slog"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// commentモデルのDB処理を担うリポジトリの構造体
+type commentRepository struct {
+	handler SqlHandler
+}
+
+// CommentRepositoryの新しいインスタンスを作成して返す
+func NewCommentRepository(sqlHandler SqlHandler) repository.CommentRepository {
+	commentRepository := commentRepository{handler: sqlHandler}
+	return &commentRepository
+}
+
+// 指定されたユーザーの、指定されたtodoのコメントを投稿順で返す
+func (cr *commentRepository) FindByTodo(userID uint, todoID uint) (*[]models.Comment, error) {
+	var comments []models.Comment
+	result := cr.handler.GetConnection().
+		Scopes(ownedComments(userID, todoID), preloadCommentUser).
+		Order("created_at, id").
+		Find(&comments)
+	return &comments, result.Error
+}
+
+// 指定されたユーザーの、指定されたtodoの、指定されたIDのコメントを検索して結果を返す
+// 他のユーザーのtodoのコメントは存在しないものとして扱う
+func (cr *commentRepository) FindById(userID uint, todoID uint, id uint) (*models.Comment, error) {
+	var comment models.Comment
+	result := cr.handler.GetConnection().
+		Scopes(ownedComments(userID, todoID), preloadCommentUser).
+		Where("id = ?", id).
+		First(&comment)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &comment, nil
+}
+
+// 渡されたコメントを新規作成して保存する
+// todoの所有者はユースケースで確認する
+func (cr *commentRepository) Create(comment *models.Comment) error {
+	result := cr.handler.GetConnection().Omit(clause.Associations).Create(comment)
+	return result.Error
+}
+
+// 渡されたコメントのデータを更新する
+func (cr *commentRepository) Update(userID uint, comment *models.Comment) error {
+	// Saveメソッドだと、存在しないIDの場合はCreate動作になるため、
+	// 存否チェックをする（他のユーザーのtodoのコメントは更新できない）
+	_, err := cr.FindById(userID, comment.TodoID, comment.ID)
+	if err != nil {
+		return err
+	}
+	result := cr.handler.GetConnection().Omit(clause.Associations).Save(comment)
+	return result.Error
+}
+
+// 指定されたユーザーの、指定されたtodoの、指定されたIDのコメントを削除する
+func (cr *commentRepository) Delete(userID uint, todoID uint, id uint) error {
+	// 存在しないIDの場合でもエラーは出ないため、存否チェックをする
+	_, err := cr.FindById(userID, todoID, id)
+	if err != nil {
+		return err
+	}
+	result := cr.handler.GetConnection().Delete(&models.Comment{}, id)
+	return result.Error
+}
+
+// リポジトリの終了処理を行う
+func (cr *commentRepository) Close() error {
+	// 依存先をクローズする
+	err := cr.handler.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	return err
+}
+
+// 指定されたユーザーの、指定されたtodoのコメントに絞り込むスコープを返す
+// 削除済みのtodoのコメントは対象外とする
+func ownedComments(userID uint, todoID uint) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		owned := tx.Session(&gorm.Session{NewDB: true}).
+			Model(&models.Todo{}).
+			Select("id").
+			Where("user_id = ? AND id = ?", userID, todoID)
+		return tx.Where("todo_id IN (?)", owned)
+	}
+}
+
+// コメントを投稿したユーザーを読み込むスコープ
+func preloadCommentUser(tx *gorm.DB) *gorm.DB {
+	return tx.Preload("User")
+}
@@ -0,0 +1,191 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// コメントのテストで使用するコメントを作成する
+// todoのタイトルをキーに、コメントの本文を投稿順で渡す（todoの所有者が投稿したものとする）
+func createCommentFixture(t *testing.T, db *gorm.DB, f *projectFixture, comments map[string][]string) map[string]*models.Comment {
+	created := map[string]*models.Comment{}
+	base := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	for key, bodies := range comments {
+		todo := f.todos[key]
+		for i, body := range bodies {
+			// 投稿順を確定させるため、作成日時をずらす
+			at := base.Add(time.Duration(i) * time.Minute)
+			comment := &models.Comment{TodoID: todo.ID, UserID: todo.UserID, Body: body, CreatedAt: at, UpdatedAt: at}
+			if result := db.Create(comment); result.Error != nil {
+				t.Fatalf("Creation is failed. error: %v", result.Error)
+			}
+			created[body] = comment
+		}
+	}
+	return created
+}
+
+func (s *todoRepositoryTestSuite) TestComment() {
+
+	cases := map[string]struct {
+		// コメントに対する操作
+		run func(r repository.CommentRepository, f *projectFixture, comments map[string]*models.Comment) error
+		// 操作後のtodo1のコメントの本文（投稿順）
+		want      []string
+		expectErr bool
+	}{
+		"正常ケース:投稿": {
+			run: func(r repository.CommentRepository, f *projectFixture, comments map[string]*models.Comment) error {
+				comment, _ := models.NewComment(f.todos["todo1"].ID, f.user.ID, "d")
+				return r.Create(comment)
+			},
+			want: []string{"a", "b", "c", "d"},
+		},
+		"正常ケース:編集": {
+			run: func(r repository.CommentRepository, f *projectFixture, comments map[string]*models.Comment) error {
+				comment := comments["b"]
+				_ = comment.Edit("B")
+				return r.Update(f.user.ID, comment)
+			},
+			want: []string{"a", "B", "c"},
+		},
+		"正常ケース:削除": {
+			run: func(r repository.CommentRepository, f *projectFixture, comments map[string]*models.Comment) error {
+				return r.Delete(f.user.ID, f.todos["todo1"].ID, comments["a"].ID)
+			},
+			want: []string{"b", "c"},
+		},
+		"異常ケース:他のユーザーのtodoのコメントは編集できない": {
+			run: func(r repository.CommentRepository, f *projectFixture, comments map[string]*models.Comment) error {
+				comment := comments["x"]
+				_ = comment.Edit("X")
+				return r.Update(f.user.ID, comment)
+			},
+			want:      []string{"a", "b", "c"},
+			expectErr: true,
+		},
+		"異常ケース:他のユーザーのtodoのコメントは削除できない": {
+			run: func(r repository.CommentRepository, f *projectFixture, comments map[string]*models.Comment) error {
+				return r.Delete(f.user.ID, f.todos["other"].ID, comments["x"].ID)
+			},
+			want:      []string{"a", "b", "c"},
+			expectErr: true,
+		},
+		"異常ケース:別のtodoを指定したコメントは削除できない": {
+			run: func(r repository.CommentRepository, f *projectFixture, comments map[string]*models.Comment) error {
+				return r.Delete(f.user.ID, f.todos["todo2"].ID, comments["a"].ID)
+			},
+			want:      []string{"a", "b", "c"},
+			expectErr: true,
+		},
+	}
+	for name, tt := range cases {
+		s.T().Run(name, func(t *testing.T) {
+			// テスト用DBに接続する
+			db, err := s.openDB()
+			if err != nil {
+				s.Failf("database connection is not established", "%v", err)
+			}
+
+			defer s.Close(db)
+
+			// 初期処理
+			sqlHandler := testHandler{conn: db}
+			commentRepository := NewCommentRepository(&sqlHandler)
+			f := s.createProjectFixture(t, db)
+			comments := createCommentFixture(t, db, f, map[string][]string{
+				"todo1": {"a", "b", "c"},
+				"other": {"x"},
+			})
+
+			err = tt.run(commentRepository, f, comments)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			got, err := commentRepository.FindByTodo(f.user.ID, f.todos["todo1"].ID)
+			if assert.NoError(t, err) {
+				bodies := []string{}
+				for _, comment := range *got {
+					bodies = append(bodies, comment.Body)
+					// 投稿したユーザーが読み込まれること
+					assert.Equal(t, f.user.Email, comment.User.Email)
+				}
+				assert.Equal(t, tt.want, bodies)
+			}
+		})
+	}
+}
+
+func (s *todoRepositoryTestSuite) TestCommentWithTodo() {
+
+	// テスト用DBに接続する
+	db, err := s.openDB()
+	if err != nil {
+		s.Failf("database connection is not established", "%v", err)
+	}
+
+	defer s.Close(db)
+
+	// 初期処理
+	sqlHandler := testHandler{conn: db}
+	commentRepository := NewCommentRepository(&sqlHandler)
+	todoRepository := NewTodoRepository(&sqlHandler)
+	f := s.createProjectFixture(s.T(), db)
+	createCommentFixture(s.T(), db, f, map[string][]string{
+		"todo1": {"a", "b"},
+		"todo2": {"c"},
+		"other": {"x"},
+	})
+
+	s.T().Run("正常ケース:todoの取得時にコメントが投稿順で読み込まれる", func(t *testing.T) {
+		todo, err := todoRepository.FindById(f.user.ID, f.todos["todo1"].ID)
+		if assert.NoError(t, err) {
+			bodies := []string{}
+			for _, comment := range todo.Comments {
+				bodies = append(bodies, comment.Body)
+				assert.Equal(t, f.user.Email, comment.User.Email)
+			}
+			assert.Equal(t, []string{"a", "b"}, bodies)
+		}
+	})
+
+	s.T().Run("正常ケース:他のユーザーのtodoのコメントは取得できない", func(t *testing.T) {
+		comments, err := commentRepository.FindByTodo(f.user.ID, f.todos["other"].ID)
+		if assert.NoError(t, err) {
+			assert.Empty(t, *comments)
+		}
+	})
+
+	s.T().Run("正常ケース:todoをゴミ箱に移してもコメントは残る", func(t *testing.T) {
+		if err := todoRepository.Delete(f.user.ID, f.todos["todo2"].ID); err != nil {
+			t.Fatalf("Deletion is failed. error: %v", err)
+		}
+		var count int64
+		if result := db.Model(&models.Comment{}).Where("todo_id = ?", f.todos["todo2"].ID).Count(&count); assert.NoError(t, result.Error) {
+			assert.NotZero(t, count)
+		}
+	})
+
+	s.T().Run("正常ケース:todoを完全に削除するとコメントも削除される", func(t *testing.T) {
+		if err := todoRepository.Purge(f.user.ID, f.todos["todo2"].ID); err != nil {
+			t.Fatalf("Purge is failed. error: %v", err)
+		}
+		var count int64
+		if result := db.Model(&models.Comment{}).Where("todo_id = ?", f.todos["todo2"].ID).Count(&count); assert.NoError(t, result.Error) {
+			assert.Equal(t, int64(0), count)
+		}
+		// 他のtodoのコメントは残ること
+		if result := db.Model(&models.Comment{}).Where("todo_id = ?", f.todos["todo1"].ID).Count(&count); assert.NoError(t, result.Error) {
+			assert.Equal(t, int64(2), count)
+		}
+	})
+}
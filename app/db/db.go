@@ -2,11 +2,9 @@ package db
 
 import (
 	"fmt"
-	"os"
 
+	"github.com/MinadukiSekina/todo-go-app/app/config"
 	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
-	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
@@ -23,66 +21,96 @@ type sqlHandler struct {
 }
 
 // データベース接続を取得する
-// 接続が初期化されていない場合は初期化を行う
 func (handler *sqlHandler) GetConnection() *gorm.DB {
-	if handler == nil || !handler.initialized {
-		Init()
-	}
 	return handler.conn
 }
 
 // シングルトンインスタンスを保持するグローバル変数
 var handler *sqlHandler
 
+// 設定で指定されたストレージに、GORMを使用して接続を確立する
+func Open(cfg config.DBConfig) (*gorm.DB, error) {
+	dialector, err := NewDialector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	dB, err := gorm.Open(dialector, NewGormConfig(cfg.Driver))
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to database: %w", err)
+	}
+	if err := configurePool(cfg.Driver, dB); err != nil {
+		return nil, err
+	}
+	return dB, nil
+}
+
 // データベース接続を初期化する
-// 環境変数から接続情報を取得し、GORMを使用して接続を確立する
-// 接続後、Todoモデルのマイグレーションを実行する
-func Init() {
+// 接続後、スキーマが最新であることを確認する
+// メモリ上のストレージは起動のたびに空になるため、マイグレーションを適用する
+func Init(cfg config.DBConfig) error {
 	if handler != nil && handler.initialized {
-		return
+		return nil
 	}
 
-	// db.envに定義したDB関係の環境変数を取得
-	dbUser := os.Getenv("MYSQL_USER")
-	dbPassword := os.Getenv("MYSQL_PASSWORD")
-	dbName := os.Getenv("MYSQL_DATABASE")
-	// tcp（）の中にdocker-composeで定義したDB用コンテナのサービス名を入れれば、
-	// 自動的にホストとポートを読み取ってくれる
-	dsn := fmt.Sprintf(
-		"%s:%s@tcp(db)/%s?charset=utf8mb4&parseTime=true&loc=Local",
-		dbUser,
-		dbPassword,
-		dbName,
-	)
-	dB, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
-
+	dB, err := Open(cfg)
 	if err != nil {
-		panic("Could not connect to database.")
+		return err
 	}
-
-	// マイグレーションを行う
-	dB.AutoMigrate(&models.Todo{})
+	if err := checkSchema(dB, cfg.Driver); err != nil {
+		if db, dbErr := dB.DB(); dbErr == nil {
+			db.Close()
+		}
+		return err
+	}
+	warnOrphanedTodos(dB)
 
 	handler = &sqlHandler{
 		conn:        dB,
 		initialized: true,
 	}
+	return nil
+}
+
+// スキーマが最新であることを確認する
+// 未適用のマイグレーションがある場合はエラーを返す
+func checkSchema(conn *gorm.DB, driver string) error {
+	migrator, err := NewMigrator(conn, driver)
+	if err != nil {
+		return err
+	}
+	if driver == DriverMemory {
+		_, err := migrator.Up(0)
+		return err
+	}
+
+	pending, err := migrator.Pending()
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("database schema is behind: %d pending migration(s), run `migrate up` first", len(pending))
+	}
+	return nil
 }
 
 // SqlHandlerのインスタンスを取得する
 // インスタンスが存在しない場合は初期化を行う
-func GetSqlHandler() *sqlHandler {
+func GetSqlHandler(cfg config.DBConfig) (*sqlHandler, error) {
 	if handler == nil || !handler.initialized {
-		Init()
+		if err := Init(cfg); err != nil {
+			return nil, err
+		}
 	}
-	return handler
+	return handler, nil
 }
 
 // ハンドラーの終了処理を行う
+// 複数のリポジトリから呼ばれても、接続を閉じるのは一度だけとする
 func (handler *sqlHandler) Close() error {
 	if handler.conn == nil || !handler.initialized {
 		return nil
 	}
+	handler.initialized = false
 	db, err := handler.conn.DB()
 	if err != nil {
 		return err
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// 利用できるストレージの種類
+// DriverMySQL：docker-composeで起動するMySQL
+// DriverSQLite：ファイルに保存するSQLite
+// DriverMemory：プロセス内のメモリに保存するSQLite（終了時にデータは破棄される）
+// DriverMemoryはGoのメモリ上の独自の実装ではなく、SQLiteのインメモリデータベースを使用する
+// そのため、SQLiteのドライバーが必要で、SQL・制約の扱いもDriverSQLiteと同じになる
+const (
+	DriverMySQL  = "mysql"
+	DriverSQLite = "sqlite"
+	DriverMemory = "memory"
+)
+
+// SQLiteで外部キー制約を有効にするためのパラメータ
+const sqlitePragma = "_pragma=foreign_keys(1)"
+
+// 設定で指定された種類のストレージに接続するためのDialectorを返す
+func NewDialector(cfg config.DBConfig) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case DriverMySQL:
+		return mysql.Open(MySQLDSN(cfg)), nil
+	case DriverSQLite:
+		return sqlite.Open(SQLiteDSN(cfg.Path)), nil
+	case DriverMemory:
+		return sqlite.Open(MemoryDSN(uuid.NewString())), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", cfg.Driver)
+	}
+}
+
+// MySQLへの接続文字列を組み立てる
+func MySQLDSN(cfg config.DBConfig) string {
+	dsn := fmt.Sprintf(
+		"%s:%s@tcp(%s)/%s",
+		cfg.User,
+		cfg.Password,
+		net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		cfg.Name,
+	)
+	if cfg.Params != "" {
+		dsn += "?" + cfg.Params
+	}
+	return dsn
+}
+
+// ファイルに保存するSQLiteへの接続文字列を組み立てる
+func SQLiteDSN(path string) string {
+	return fmt.Sprintf("file:%s?%s", path, sqlitePragma)
+}
+
+// 指定された名前の、メモリ上のSQLiteへの接続文字列を組み立てる
+// 接続ごとに別のDBとならないよう、同じ名前の接続でキャッシュを共有する
+// 名前が異なれば別のDBとなるため、ハンドラーごとに一意の名前を指定する
+func MemoryDSN(name string) string {
+	return fmt.Sprintf("file:%s?mode=memory&cache=shared&%s", name, sqlitePragma)
+}
+
+// 指定された種類のストレージ向けのGORMの設定を返す
+// 一意制約・外部キー制約の違反はGORMのエラーに変換し、さらにドメインのエラーの種類を付ける
+// SQLiteは読み出した日時をUTCとして扱うため、書き込む日時もUTCに揃える
+func NewGormConfig(driver string) *gorm.Config {
+	config := &gorm.Config{
+		TranslateError: true,
+		Plugins:        map[string]gorm.Plugin{errorTranslator{}.Name(): errorTranslator{}},
+	}
+	if driver == DriverSQLite || driver == DriverMemory {
+		config.NowFunc = func() time.Time { return time.Now().UTC() }
+	}
+	return config
+}
+
+// SQLiteは同時に書き込めないため、接続を1本に制限する
+// メモリ上のDBは全ての接続が閉じられると破棄されるため、その防止も兼ねる
+func configurePool(driver string, conn *gorm.DB) error {
+	if driver != DriverSQLite && driver != DriverMemory {
+		return nil
+	}
+	db, err := conn.DB()
+	if err != nil {
+		return err
+	}
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+	return nil
+}
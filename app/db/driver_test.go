@@ -0,0 +1,37 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestOpenMemory(t *testing.T) {
+
+	// メモリ上のストレージに接続し、終了時に閉じる
+	open := func() *gorm.DB {
+		conn, err := Open(config.DBConfig{Driver: DriverMemory})
+		if err != nil {
+			t.Fatalf("failed to open database: %v", err)
+		}
+		t.Cleanup(func() {
+			if db, err := conn.DB(); err == nil {
+				db.Close()
+			}
+		})
+		return conn
+	}
+
+	first := open()
+	second := open()
+	if err := checkSchema(first, DriverMemory); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	// 結果を確認
+	// 同じプロセスの中でも、接続ごとに別のDBとなること
+	assert.True(t, first.Migrator().HasTable("todos"))
+	assert.False(t, second.Migrator().HasTable("todos"))
+}
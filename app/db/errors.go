@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"gorm.io/gorm"
+)
+
+// 接続が閉じられたDBを操作した場合のエラーのメッセージ
+// database/sqlでは公開されていないため、メッセージで判定する
+const errDBClosedMessage = "sql: database is closed"
+
+// リポジトリで対象の存在を確認した結果、見つからなかった場合に返すエラー
+// コールバックを経由せずに返すため、あらかじめ種類を付けておく
+var errNotFound = models.NotFound(gorm.ErrRecordNotFound)
+
+// GORMのエラーを、ドメインのエラーの種類を付けたエラーに変換するプラグイン
+// 各リポジトリで変換せずに済むよう、全ての操作の最後に変換する
+type errorTranslator struct{}
+
+func (errorTranslator) Name() string {
+	return "domain_errors"
+}
+
+func (errorTranslator) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	translate := func(tx *gorm.DB) {
+		if tx.Error != nil {
+			tx.Error = translateError(tx.Error)
+		}
+	}
+	if err := callbacks.Create().After("*").Register("domain_errors:create", translate); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("*").Register("domain_errors:query", translate); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("*").Register("domain_errors:update", translate); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("*").Register("domain_errors:delete", translate); err != nil {
+		return err
+	}
+	if err := callbacks.Row().After("*").Register("domain_errors:row", translate); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("*").Register("domain_errors:raw", translate)
+}
+
+// GORM・ドライバーのエラーに、ドメインのエラーの種類を付ける
+// 元のエラーを包むため、errors.Is(err, gorm.ErrRecordNotFound)などの判定はそのまま使用できる
+// 既に種類が付いているエラーと、種類を判定できないエラーはそのまま返す
+func translateError(err error) error {
+	if err == nil || models.KindOf(err) != models.KindUnknown {
+		return err
+	}
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return models.NotFound(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return models.Conflict(err)
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return models.Validation(err)
+	case isUnavailable(err):
+		return models.Unavailable(err)
+	}
+	return err
+}
+
+// DBに接続できないために失敗したかを判定する
+func isUnavailable(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return strings.Contains(err.Error(), errDBClosedMessage)
+}
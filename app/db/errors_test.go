@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTranslateError(t *testing.T) {
+
+	domainErr := models.Validation(errors.New("invalid"))
+	unknownErr := errors.New("unknown")
+
+	cases := map[string]struct {
+		err  error
+		want models.ErrorKind
+	}{
+		"正常ケース:レコードが存在しない":       {err: gorm.ErrRecordNotFound, want: models.KindNotFound},
+		"正常ケース:一意制約の違反":          {err: gorm.ErrDuplicatedKey, want: models.KindConflict},
+		"正常ケース:外部キー制約の違反":        {err: gorm.ErrForeignKeyViolated, want: models.KindValidation},
+		"正常ケース:接続が閉じられている":       {err: errors.New("sql: database is closed"), want: models.KindUnavailable},
+		"正常ケース:接続がタイムアウトした":      {err: &net.OpError{Op: "dial", Err: context.DeadlineExceeded}, want: models.KindUnavailable},
+		"正常ケース:種類が付いたエラーはそのまま返す": {err: domainErr, want: models.KindValidation},
+		"正常ケース:判定できないエラーはそのまま返す": {err: unknownErr, want: models.KindUnknown},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := translateError(tt.err)
+			assert.Equal(t, tt.want, models.KindOf(got))
+			// 元のエラーも判定できること
+			assert.ErrorIs(t, got, tt.err)
+		})
+	}
+
+	t.Run("正常ケース:エラーが無い場合はnilを返す", func(t *testing.T) {
+		assert.NoError(t, translateError(nil))
+	})
+}
+
+func (s *todoRepositoryTestSuite) TestErrorKind() {
+
+	cases := map[string]struct {
+		run  func(handler SqlHandler, f *projectFixture) error
+		want models.ErrorKind
+	}{
+		"異常ケース:存在しないtodo": {
+			run: func(handler SqlHandler, f *projectFixture) error {
+				_, err := NewTodoRepository(handler).FindById(f.user.ID, 9999)
+				return err
+			},
+			want: models.KindNotFound,
+		},
+		"異常ケース:他のユーザーのタグへのマージ": {
+			run: func(handler SqlHandler, f *projectFixture) error {
+				return NewTagRepository(handler).Merge(f.user.ID, 9998, 9999)
+			},
+			want: models.KindNotFound,
+		},
+		"異常ケース:登録済みのメールアドレス": {
+			run: func(handler SqlHandler, f *projectFixture) error {
+				return NewUserRepository(handler).Create(&models.User{Email: f.user.Email, PasswordHash: "hash"})
+			},
+			want: models.KindConflict,
+		},
+		"異常ケース:接続が閉じられている": {
+			run: func(handler SqlHandler, f *projectFixture) error {
+				conn, err := handler.GetConnection().DB()
+				if err != nil {
+					return err
+				}
+				conn.Close()
+				_, err = NewTodoRepository(handler).FindById(f.user.ID, f.todos["todo1"].ID)
+				return err
+			},
+			want: models.KindUnavailable,
+		},
+	}
+	for name, tt := range cases {
+		s.T().Run(name, func(t *testing.T) {
+			// テスト用DBに接続する
+			db, err := s.openDB()
+			if err != nil {
+				s.Failf("database connection is not established", "%v", err)
+			}
+
+			defer s.Close(db)
+
+			// 初期処理
+			f := s.createProjectFixture(t, db)
+
+			err = tt.run(&testHandler{conn: db}, f)
+
+			// 結果を確認
+			if assert.Error(t, err) {
+				assert.Equal(t, tt.want, models.KindOf(err))
+			}
+		})
+	}
+}
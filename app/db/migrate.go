@@ -0,0 +1,262 @@
+package db
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ストレージの種類ごとのマイグレーションファイル
+// ファイル名は「{連番}_{名前}.up.sql」「{連番}_{名前}.down.sql」とする
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+// マイグレーションファイルの名前の形式
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// 1つのマイグレーションを表す構造体
+type Migration struct {
+	Version uint
+	Name    string
+	up      string
+	down    string
+}
+
+// マイグレーションの適用状況を表す構造体
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// 適用済みのマイグレーションを記録するテーブルの構造体
+type schemaMigration struct {
+	Version   uint `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// 適用済みのマイグレーションを記録するテーブルの名前
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// マイグレーションの適用・取り消しを行う構造体
+type Migrator struct {
+	conn       *gorm.DB
+	migrations []Migration
+}
+
+// 指定された種類のストレージ向けのMigratorを生成する
+func NewMigrator(conn *gorm.DB, driver string) (*Migrator, error) {
+	migrations, err := loadMigrations(dialectOf(driver))
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{conn: conn, migrations: migrations}, nil
+}
+
+// 未適用のマイグレーションを古い順に最大steps件適用する
+// stepsが0以下の場合は全て適用する
+func (m *Migrator) Up(steps int) ([]Migration, error) {
+	applied, err := m.appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, mig := range m.migrations {
+		if 0 < steps && steps <= len(done) {
+			break
+		}
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+		err := m.conn.Transaction(func(tx *gorm.DB) error {
+			if err := execStatements(tx, mig.up); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: mig.Version, Name: mig.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s failed: %w", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// 適用済みのマイグレーションを新しい順に最大steps件取り消す
+// stepsが0以下の場合は全て取り消す
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	applied, err := m.appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; 0 <= i; i-- {
+		mig := m.migrations[i]
+		if 0 < steps && steps <= len(done) {
+			break
+		}
+		if _, ok := applied[mig.Version]; !ok {
+			continue
+		}
+		err := m.conn.Transaction(func(tx *gorm.DB) error {
+			if err := execStatements(tx, mig.down); err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{}, mig.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("rollback of %04d_%s failed: %w", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// 全てのマイグレーションの適用状況を返す
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		status := MigrationStatus{Migration: mig}
+		if record, ok := applied[mig.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &record.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// 未適用のマイグレーションを返す
+func (m *Migrator) Pending() ([]Migration, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, s := range statuses {
+		if !s.Applied {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// 適用済みのマイグレーションを取得する
+// 記録用のテーブルが無い場合は作成する
+func (m *Migrator) appliedVersions() (map[uint]schemaMigration, error) {
+	if err := m.conn.Exec(
+		"CREATE TABLE IF NOT EXISTS schema_migrations (" +
+			"version BIGINT NOT NULL PRIMARY KEY, " +
+			"name VARCHAR(255) NOT NULL, " +
+			"applied_at DATETIME NOT NULL)",
+	).Error; err != nil {
+		return nil, err
+	}
+
+	var records []schemaMigration
+	if err := m.conn.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[uint]schemaMigration, len(records))
+	for _, r := range records {
+		applied[r.Version] = r
+	}
+	return applied, nil
+}
+
+// マイグレーションファイルを読み込み、連番の順に並べて返す
+func loadMigrations(dialect string) ([]Migration, error) {
+	dir := path.Join("migrations", dialect)
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[uint]*Migration{}
+	for _, entry := range entries {
+		matches := migrationFileName.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+		version, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		body, err := fs.ReadFile(migrationFiles, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[uint(version)]
+		if !ok {
+			mig = &Migration{Version: uint(version), Name: matches[2]}
+			byVersion[uint(version)] = mig
+		}
+		if matches[3] == "up" {
+			mig.up = string(body)
+		} else {
+			mig.down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.up == "" || mig.down == "" {
+			return nil, fmt.Errorf("migration %04d_%s must have both up and down files", mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// ストレージの種類に対応するSQLの方言を返す
+// メモリ上のストレージもSQLiteを使用している
+func dialectOf(driver string) string {
+	if driver == DriverMemory {
+		return DriverSQLite
+	}
+	return driver
+}
+
+// 複数のSQL文を1文ずつ実行する
+// MySQLは1回の実行で複数の文を受け付けないため、行末の「;」で分割する
+func execStatements(tx *gorm.DB, sql string) error {
+	var lines []string
+	for _, line := range strings.Split(sql, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	for _, stmt := range strings.Split(strings.Join(lines, "\n"), ";\n") {
+		stmt = strings.TrimSuffix(strings.TrimSpace(stmt), ";")
+		if stmt == "" {
+			continue
+		}
+		if err := tx.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// マイグレーションのテスト用に空のSQLiteのDBを作成する
+func openEmptySQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	conn, err := Open(config.DBConfig{Driver: DriverSQLite, Path: filepath.Join(t.TempDir(), "migrate.db")})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if db, err := conn.DB(); err == nil {
+			db.Close()
+		}
+	})
+	return conn
+}
+
+func TestMigrator(t *testing.T) {
+
+	cases := map[string]struct {
+		// マイグレーションの操作
+		run func(m *Migrator) error
+		// 操作後に未適用であるべき件数（全体の件数からの差分で指定する）
+		wantPending func(total int) int
+		// 操作後にtodosテーブルが存在するべきか
+		wantTodosTable bool
+	}{
+		"正常ケース:全て適用": {
+			run: func(m *Migrator) error {
+				_, err := m.Up(0)
+				return err
+			},
+			wantPending:    func(total int) int { return 0 },
+			wantTodosTable: true,
+		},
+		"正常ケース:1件だけ適用": {
+			run: func(m *Migrator) error {
+				_, err := m.Up(1)
+				return err
+			},
+			wantPending:    func(total int) int { return total - 1 },
+			wantTodosTable: true,
+		},
+		"正常ケース:全て適用した後に全て取り消し": {
+			run: func(m *Migrator) error {
+				if _, err := m.Up(0); err != nil {
+					return err
+				}
+				_, err := m.Down(0)
+				return err
+			},
+			wantPending:    func(total int) int { return total },
+			wantTodosTable: false,
+		},
+		"正常ケース:二重に適用しても変化しない": {
+			run: func(m *Migrator) error {
+				if _, err := m.Up(0); err != nil {
+					return err
+				}
+				done, err := m.Up(0)
+				if len(done) > 0 {
+					t.Errorf("migrations are applied twice: %v", done)
+				}
+				return err
+			},
+			wantPending:    func(total int) int { return 0 },
+			wantTodosTable: true,
+		},
+		"正常ケース:適用していない状態で取り消し": {
+			run: func(m *Migrator) error {
+				_, err := m.Down(1)
+				return err
+			},
+			wantPending:    func(total int) int { return total },
+			wantTodosTable: false,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			conn := openEmptySQLite(t)
+			migrator, err := NewMigrator(conn, DriverSQLite)
+			if err != nil {
+				t.Fatalf("failed to load migrations: %v", err)
+			}
+			total := len(migrator.migrations)
+
+			err = tt.run(migrator)
+
+			// 結果を確認
+			if assert.NoError(t, err) {
+				pending, err := migrator.Pending()
+				if assert.NoError(t, err) {
+					assert.Len(t, pending, tt.wantPending(total))
+				}
+				assert.Equal(t, tt.wantTodosTable, conn.Migrator().HasTable("todos"))
+			}
+		})
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+
+	for _, dialect := range []string{DriverMySQL, DriverSQLite} {
+		t.Run(dialect, func(t *testing.T) {
+			migrations, err := loadMigrations(dialect)
+			if assert.NoError(t, err) && assert.NotEmpty(t, migrations) {
+				// 連番が1から欠番なく並んでいること
+				for i, mig := range migrations {
+					assert.Equal(t, uint(i+1), mig.Version)
+					assert.NotEmpty(t, mig.up)
+					assert.NotEmpty(t, mig.down)
+				}
+			}
+		})
+	}
+
+	// どのストレージでも同じマイグレーションが用意されていること
+	mysqlMigrations, _ := loadMigrations(DriverMySQL)
+	sqliteMigrations, _ := loadMigrations(DriverSQLite)
+	if assert.Equal(t, len(mysqlMigrations), len(sqliteMigrations)) {
+		for i := range mysqlMigrations {
+			assert.Equal(t, mysqlMigrations[i].Name, sqliteMigrations[i].Name)
+		}
+	}
+}
+
+func TestCheckSchema(t *testing.T) {
+
+	cases := map[string]struct {
+		driver    string
+		setup     func(m *Migrator)
+		expectErr bool
+	}{
+		"正常ケース:全て適用済み": {
+			driver:    DriverSQLite,
+			setup:     func(m *Migrator) { _, _ = m.Up(0) },
+			expectErr: false,
+		},
+		"正常ケース:メモリ上のストレージは自動で適用される": {
+			driver:    DriverMemory,
+			setup:     func(m *Migrator) {},
+			expectErr: false,
+		},
+		"異常ケース:未適用のマイグレーションがある": {
+			driver:    DriverSQLite,
+			setup:     func(m *Migrator) {},
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			conn := openEmptySQLite(t)
+			migrator, err := NewMigrator(conn, tt.driver)
+			if err != nil {
+				t.Fatalf("failed to load migrations: %v", err)
+			}
+			tt.setup(migrator)
+
+			err = checkSchema(conn, tt.driver)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUniqueTodoPositionMigration(t *testing.T) {
+	conn := openEmptySQLite(t)
+	migrator, err := NewMigrator(conn, DriverSQLite)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(14); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	user := models.User{Email: "user@example.com", PasswordHash: "hash"}
+	if err := conn.Create(&user).Error; err != nil {
+		t.Fatalf("Creation is failed. error: %v", err)
+	}
+	// 同時に追加されて並び順が重複したtodo
+	for _, title := range []string{"first", "second", "third"} {
+		todo := &models.Todo{UserID: user.ID, Title: title, Status: models.NotStarted, Position: "a0"}
+		if err := conn.Create(todo).Error; err != nil {
+			t.Fatalf("Creation is failed. error: %v", err)
+		}
+	}
+
+	_, err = migrator.Up(0)
+
+	// 結果を確認
+	if assert.NoError(t, err) {
+		var todos []models.Todo
+		conn.Where("user_id = ?", user.ID).Order("position").Find(&todos)
+		if assert.Len(t, todos, 3) {
+			// 最初に追加したtodoの並び順は変えず、後から追加したtodoをその後ろに並べる
+			assert.Equal(t, []string{"first", "second", "third"}, []string{todos[0].Title, todos[1].Title, todos[2].Title})
+			assert.Equal(t, "a0", todos[0].Position)
+		}
+		// 以降は重複した並び順を保存できない
+		err := conn.Create(&models.Todo{UserID: user.ID, Title: "fourth", Status: models.NotStarted, Position: "a0"}).Error
+		assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+	}
+}
@@ -0,0 +1,11 @@
+-- AutoMigrateで作成済みの環境でもそのまま適用できるよう、存在しない場合のみ作成する
+CREATE TABLE IF NOT EXISTS `todos` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    `title` longtext,
+    `status` bigint,
+    PRIMARY KEY (`id`),
+    INDEX `idx_todos_deleted_at` (`deleted_at`)
+);
@@ -0,0 +1,5 @@
+DROP INDEX `idx_todos_due_at` ON `todos`;
+ALTER TABLE `todos`
+    DROP COLUMN `due_at`,
+    DROP COLUMN `due_has_time`,
+    DROP COLUMN `due_timezone`;
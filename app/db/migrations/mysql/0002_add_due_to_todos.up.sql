@@ -0,0 +1,5 @@
+ALTER TABLE `todos`
+    ADD COLUMN `due_at` datetime(3) NULL,
+    ADD COLUMN `due_has_time` boolean NOT NULL DEFAULT false,
+    ADD COLUMN `due_timezone` varchar(64) NOT NULL DEFAULT '';
+CREATE INDEX `idx_todos_due_at` ON `todos` (`due_at`);
@@ -0,0 +1,4 @@
+DROP INDEX `idx_todos_user_id` ON `todos`;
+ALTER TABLE `todos` DROP COLUMN `user_id`;
+DROP TABLE `sessions`;
+DROP TABLE `users`;
@@ -0,0 +1,24 @@
+CREATE TABLE `users` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    `email` varchar(255) NOT NULL,
+    `password_hash` varchar(255) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_users_email` (`email`),
+    INDEX `idx_users_deleted_at` (`deleted_at`)
+);
+-- トークンのハッシュ値（SHA-256の16進数表記）をIDとする
+CREATE TABLE `sessions` (
+    `id` char(64) NOT NULL,
+    `user_id` bigint unsigned NOT NULL,
+    `expires_at` datetime(3) NOT NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_sessions_expires_at` (`expires_at`),
+    CONSTRAINT `fk_sessions_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
+-- 既存のtodoは所有者が無い（0）状態になる
+ALTER TABLE `todos` ADD COLUMN `user_id` bigint unsigned NOT NULL DEFAULT 0;
+CREATE INDEX `idx_todos_user_id` ON `todos` (`user_id`);
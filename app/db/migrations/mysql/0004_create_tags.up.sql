@@ -0,0 +1,20 @@
+-- タグはユーザーごとに管理し、同じユーザーの中で名前を重複させない
+CREATE TABLE `tags` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `user_id` bigint unsigned NOT NULL,
+    `name` varchar(50) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_tags_user_id_name` (`user_id`, `name`),
+    CONSTRAINT `fk_tags_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
+-- todoとタグの中間テーブル
+CREATE TABLE `todo_tags` (
+    `todo_id` bigint unsigned NOT NULL,
+    `tag_id` bigint unsigned NOT NULL,
+    PRIMARY KEY (`todo_id`, `tag_id`),
+    INDEX `idx_todo_tags_tag_id` (`tag_id`),
+    CONSTRAINT `fk_todo_tags_todo` FOREIGN KEY (`todo_id`) REFERENCES `todos` (`id`) ON DELETE CASCADE,
+    CONSTRAINT `fk_todo_tags_tag` FOREIGN KEY (`tag_id`) REFERENCES `tags` (`id`) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP INDEX `idx_todos_project_id` ON `todos`;
+ALTER TABLE `todos` DROP COLUMN `project_id`;
+DROP TABLE IF EXISTS `projects`;
@@ -0,0 +1,23 @@
+-- プロジェクトはユーザーごとに管理し、同じユーザーの中で名前を重複させない
+-- inboxはプロジェクトを指定せずに作成したtodoの保存先で、ユーザーごとに1つ作成する
+CREATE TABLE `projects` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `user_id` bigint unsigned NOT NULL,
+    `name` varchar(100) NOT NULL,
+    `inbox` boolean NOT NULL DEFAULT false,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_projects_user_id_name` (`user_id`, `name`),
+    CONSTRAINT `fk_projects_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
+-- 既存のユーザーにInboxを作成し、既存のtodoを移す
+-- 所有者の無い（0）todoはプロジェクトも無い（NULL）状態になる
+INSERT INTO `projects` (`created_at`, `updated_at`, `user_id`, `name`, `inbox`)
+    SELECT NOW(3), NOW(3), `id`, 'Inbox', true FROM `users`;
+-- SQLiteに合わせて外部キー制約は付けず、プロジェクトの所有者との整合性はアプリケーションで保つ
+ALTER TABLE `todos` ADD COLUMN `project_id` bigint unsigned NULL;
+UPDATE `todos` SET `project_id` = (
+    SELECT `projects`.`id` FROM `projects` WHERE `projects`.`user_id` = `todos`.`user_id` AND `projects`.`inbox` = true
+) WHERE `user_id` <> 0;
+CREATE INDEX `idx_todos_project_id` ON `todos` (`project_id`);
@@ -0,0 +1,14 @@
+-- todoのチェックリストの項目
+-- positionはtodoの中での並び順（0始まり）で、todoを完全に削除すると項目も削除する
+CREATE TABLE `checklist_items` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `todo_id` bigint unsigned NOT NULL,
+    `title` varchar(200) NOT NULL,
+    `done` boolean NOT NULL DEFAULT false,
+    `position` int NOT NULL DEFAULT 0,
+    PRIMARY KEY (`id`),
+    INDEX `idx_checklist_items_todo_id_position` (`todo_id`, `position`),
+    CONSTRAINT `fk_checklist_items_todo` FOREIGN KEY (`todo_id`) REFERENCES `todos` (`id`) ON DELETE CASCADE
+);
@@ -0,0 +1,6 @@
+-- 一覧での手動の並び順（文字列として比較した順序で並べる）
+-- 大文字と小文字を区別して比較するため、バイナリの照合順序にする
+ALTER TABLE `todos` ADD COLUMN `position` varchar(255) CHARACTER SET ascii COLLATE ascii_bin NOT NULL DEFAULT '';
+-- 既存のtodoは作成順に並べる（IDを10桁に揃えて、最初の値「a0」の小数部とする）
+UPDATE `todos` SET `position` = CONCAT('a0', LPAD(`id`, 10, '0'), 'V');
+CREATE INDEX `idx_todos_user_id_position` ON `todos` (`user_id`, `position`);
@@ -0,0 +1,2 @@
+-- 優先度（0：中、1：低、2：高、3：緊急。既存のtodoは「中」とする）
+ALTER TABLE `todos` ADD COLUMN `priority` int NOT NULL DEFAULT 0;
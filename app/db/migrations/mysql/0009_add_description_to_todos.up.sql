@@ -0,0 +1,3 @@
+-- 説明（Markdown。最大10000文字のため、4バイトの文字のみでもtextに収まる）
+-- MySQLではtextに既定値を指定できないため、既存のtodoには暗黙の既定値（空文字列）が入る
+ALTER TABLE `todos` ADD COLUMN `description` text NOT NULL;
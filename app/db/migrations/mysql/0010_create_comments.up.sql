@@ -0,0 +1,14 @@
+-- todoについてのコメント
+-- todo・投稿したユーザーを完全に削除するとコメントも削除する
+CREATE TABLE `comments` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `todo_id` bigint unsigned NOT NULL,
+    `user_id` bigint unsigned NOT NULL,
+    `body` text NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_comments_todo_id_created_at` (`todo_id`, `created_at`),
+    CONSTRAINT `fk_comments_todo` FOREIGN KEY (`todo_id`) REFERENCES `todos` (`id`) ON DELETE CASCADE,
+    CONSTRAINT `fk_comments_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
@@ -0,0 +1,17 @@
+-- todoの添付ファイルの情報（ファイルの内容はストレージに保存する）
+-- todo・添付したユーザーを完全に削除すると情報も削除する
+CREATE TABLE `attachments` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `created_at` datetime(3) NULL,
+    `todo_id` bigint unsigned NOT NULL,
+    `user_id` bigint unsigned NOT NULL,
+    `file_name` varchar(255) NOT NULL,
+    `content_type` varchar(100) NOT NULL,
+    `size` bigint NOT NULL,
+    `storage_key` varchar(255) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_attachments_todo_id` (`todo_id`),
+    UNIQUE INDEX `idx_attachments_storage_key` (`storage_key`),
+    CONSTRAINT `fk_attachments_todo` FOREIGN KEY (`todo_id`) REFERENCES `todos` (`id`) ON DELETE CASCADE,
+    CONSTRAINT `fk_attachments_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+-- 繰り返しの規則（RRULEの形式。繰り返さない場合は空文字列）
+ALTER TABLE `todos` ADD COLUMN `recurrence` varchar(255) NOT NULL DEFAULT '';
@@ -0,0 +1,16 @@
+-- todoの変更履歴（追記のみ）
+-- todo・操作したユーザーを完全に削除すると履歴も削除する
+CREATE TABLE `todo_histories` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `created_at` datetime(3) NULL,
+    `todo_id` bigint unsigned NOT NULL,
+    `user_id` bigint unsigned NOT NULL,
+    `action` varchar(16) NOT NULL,
+    `field` varchar(32) NOT NULL DEFAULT '',
+    `old_value` text NOT NULL,
+    `new_value` text NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_todo_histories_todo_id_created_at` (`todo_id`, `created_at`),
+    CONSTRAINT `fk_todo_histories_todo` FOREIGN KEY (`todo_id`) REFERENCES `todos` (`id`) ON DELETE CASCADE,
+    CONSTRAINT `fk_todo_histories_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP INDEX `idx_todos_user_id_position` ON `todos`;
+CREATE INDEX `idx_todos_user_id_position` ON `todos` (`user_id`, `position`);
@@ -0,0 +1,12 @@
+-- 同時に追加した場合などに重複した並び順を、後から追加したtodoの値の末尾にIDを付けて解消する
+UPDATE `todos` AS `t`
+    JOIN (
+        SELECT `user_id`, `position`, MIN(`id`) AS `first_id`
+        FROM `todos`
+        GROUP BY `user_id`, `position`
+        HAVING COUNT(*) > 1
+    ) AS `d` ON `t`.`user_id` = `d`.`user_id` AND `t`.`position` = `d`.`position` AND `t`.`id` <> `d`.`first_id`
+SET `t`.`position` = CONCAT(`t`.`position`, LPAD(`t`.`id`, 10, '0'), 'V');
+-- ユーザーごとに並び順が重複しないようにする
+DROP INDEX `idx_todos_user_id_position` ON `todos`;
+CREATE UNIQUE INDEX `idx_todos_user_id_position` ON `todos` (`user_id`, `position`);
@@ -0,0 +1,3 @@
+-- 完全に削除したtodoの変更履歴は、制約を戻す前に削除する
+DELETE FROM `todo_histories` WHERE `todo_id` NOT IN (SELECT `id` FROM `todos`);
+ALTER TABLE `todo_histories` ADD CONSTRAINT `fk_todo_histories_todo` FOREIGN KEY (`todo_id`) REFERENCES `todos` (`id`) ON DELETE CASCADE;
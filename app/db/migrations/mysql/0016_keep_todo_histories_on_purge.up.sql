@@ -0,0 +1,2 @@
+-- todoを完全に削除しても変更履歴は残す（操作したユーザーを削除した場合のみ削除する）
+ALTER TABLE `todo_histories` DROP FOREIGN KEY `fk_todo_histories_todo`;
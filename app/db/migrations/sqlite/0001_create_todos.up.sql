@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `todos` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime,
+    `updated_at` datetime,
+    `deleted_at` datetime,
+    `title` text,
+    `status` integer
+);
+CREATE INDEX IF NOT EXISTS `idx_todos_deleted_at` ON `todos`(`deleted_at`);
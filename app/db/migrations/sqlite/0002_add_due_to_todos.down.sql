@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS `idx_todos_due_at`;
+ALTER TABLE `todos` DROP COLUMN `due_at`;
+ALTER TABLE `todos` DROP COLUMN `due_has_time`;
+ALTER TABLE `todos` DROP COLUMN `due_timezone`;
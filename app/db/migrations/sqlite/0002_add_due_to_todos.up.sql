@@ -0,0 +1,4 @@
+ALTER TABLE `todos` ADD COLUMN `due_at` datetime;
+ALTER TABLE `todos` ADD COLUMN `due_has_time` numeric NOT NULL DEFAULT false;
+ALTER TABLE `todos` ADD COLUMN `due_timezone` text NOT NULL DEFAULT '';
+CREATE INDEX `idx_todos_due_at` ON `todos`(`due_at`);
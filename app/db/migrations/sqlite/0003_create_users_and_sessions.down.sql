@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS `idx_todos_user_id`;
+ALTER TABLE `todos` DROP COLUMN `user_id`;
+DROP TABLE IF EXISTS `sessions`;
+DROP TABLE IF EXISTS `users`;
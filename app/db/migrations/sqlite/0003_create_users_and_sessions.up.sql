@@ -0,0 +1,21 @@
+CREATE TABLE `users` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime,
+    `updated_at` datetime,
+    `deleted_at` datetime,
+    `email` text NOT NULL,
+    `password_hash` text NOT NULL
+);
+CREATE UNIQUE INDEX `idx_users_email` ON `users`(`email`);
+CREATE INDEX `idx_users_deleted_at` ON `users`(`deleted_at`);
+-- トークンのハッシュ値（SHA-256の16進数表記）をIDとする
+CREATE TABLE `sessions` (
+    `id` text PRIMARY KEY,
+    `user_id` integer NOT NULL REFERENCES `users`(`id`) ON DELETE CASCADE,
+    `expires_at` datetime NOT NULL,
+    `created_at` datetime
+);
+CREATE INDEX `idx_sessions_expires_at` ON `sessions`(`expires_at`);
+-- 既存のtodoは所有者が無い（0）状態になる
+ALTER TABLE `todos` ADD COLUMN `user_id` integer NOT NULL DEFAULT 0;
+CREATE INDEX `idx_todos_user_id` ON `todos`(`user_id`);
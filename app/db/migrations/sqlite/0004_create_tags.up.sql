@@ -0,0 +1,16 @@
+-- タグはユーザーごとに管理し、同じユーザーの中で名前を重複させない
+CREATE TABLE `tags` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime,
+    `updated_at` datetime,
+    `user_id` integer NOT NULL REFERENCES `users`(`id`) ON DELETE CASCADE,
+    `name` text NOT NULL
+);
+CREATE UNIQUE INDEX `idx_tags_user_id_name` ON `tags`(`user_id`, `name`);
+-- todoとタグの中間テーブル
+CREATE TABLE `todo_tags` (
+    `todo_id` integer NOT NULL REFERENCES `todos`(`id`) ON DELETE CASCADE,
+    `tag_id` integer NOT NULL REFERENCES `tags`(`id`) ON DELETE CASCADE,
+    PRIMARY KEY (`todo_id`, `tag_id`)
+);
+CREATE INDEX `idx_todo_tags_tag_id` ON `todo_tags`(`tag_id`);
@@ -0,0 +1,21 @@
+-- プロジェクトはユーザーごとに管理し、同じユーザーの中で名前を重複させない
+-- inboxはプロジェクトを指定せずに作成したtodoの保存先で、ユーザーごとに1つ作成する
+CREATE TABLE `projects` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime,
+    `updated_at` datetime,
+    `user_id` integer NOT NULL REFERENCES `users`(`id`) ON DELETE CASCADE,
+    `name` text NOT NULL,
+    `inbox` numeric NOT NULL DEFAULT false
+);
+CREATE UNIQUE INDEX `idx_projects_user_id_name` ON `projects`(`user_id`, `name`);
+-- 既存のユーザーにInboxを作成し、既存のtodoを移す
+-- 所有者の無い（0）todoはプロジェクトも無い（NULL）状態になる
+INSERT INTO `projects` (`created_at`, `updated_at`, `user_id`, `name`, `inbox`)
+    SELECT CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, `id`, 'Inbox', true FROM `users`;
+-- 外部キー制約のある列は削除できないため、制約は付けずにプロジェクトの所有者との整合性はアプリケーションで保つ
+ALTER TABLE `todos` ADD COLUMN `project_id` integer NULL;
+UPDATE `todos` SET `project_id` = (
+    SELECT `projects`.`id` FROM `projects` WHERE `projects`.`user_id` = `todos`.`user_id` AND `projects`.`inbox` = true
+) WHERE `user_id` <> 0;
+CREATE INDEX `idx_todos_project_id` ON `todos`(`project_id`);
@@ -0,0 +1,12 @@
+-- todoのチェックリストの項目
+-- positionはtodoの中での並び順（0始まり）で、todoを完全に削除すると項目も削除する
+CREATE TABLE `checklist_items` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime,
+    `updated_at` datetime,
+    `todo_id` integer NOT NULL REFERENCES `todos`(`id`) ON DELETE CASCADE,
+    `title` text NOT NULL,
+    `done` numeric NOT NULL DEFAULT false,
+    `position` integer NOT NULL DEFAULT 0
+);
+CREATE INDEX `idx_checklist_items_todo_id_position` ON `checklist_items`(`todo_id`, `position`);
@@ -0,0 +1,5 @@
+-- 一覧での手動の並び順（文字列として比較した順序で並べる）
+ALTER TABLE `todos` ADD COLUMN `position` text NOT NULL DEFAULT '';
+-- 既存のtodoは作成順に並べる（IDを10桁に揃えて、最初の値「a0」の小数部とする）
+UPDATE `todos` SET `position` = 'a0' || substr('0000000000' || `id`, -10, 10) || 'V';
+CREATE INDEX `idx_todos_user_id_position` ON `todos`(`user_id`, `position`);
@@ -0,0 +1,2 @@
+-- 説明（Markdown）
+ALTER TABLE `todos` ADD COLUMN `description` text NOT NULL DEFAULT '';
@@ -0,0 +1,11 @@
+-- todoについてのコメント
+-- todo・投稿したユーザーを完全に削除するとコメントも削除する
+CREATE TABLE `comments` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime,
+    `updated_at` datetime,
+    `todo_id` integer NOT NULL REFERENCES `todos`(`id`) ON DELETE CASCADE,
+    `user_id` integer NOT NULL REFERENCES `users`(`id`) ON DELETE CASCADE,
+    `body` text NOT NULL
+);
+CREATE INDEX `idx_comments_todo_id_created_at` ON `comments`(`todo_id`, `created_at`);
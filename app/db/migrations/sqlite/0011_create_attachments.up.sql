@@ -0,0 +1,14 @@
+-- todoの添付ファイルの情報（ファイルの内容はストレージに保存する）
+-- todo・添付したユーザーを完全に削除すると情報も削除する
+CREATE TABLE `attachments` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime,
+    `todo_id` integer NOT NULL REFERENCES `todos`(`id`) ON DELETE CASCADE,
+    `user_id` integer NOT NULL REFERENCES `users`(`id`) ON DELETE CASCADE,
+    `file_name` text NOT NULL,
+    `content_type` text NOT NULL,
+    `size` integer NOT NULL,
+    `storage_key` text NOT NULL
+);
+CREATE INDEX `idx_attachments_todo_id` ON `attachments`(`todo_id`);
+CREATE UNIQUE INDEX `idx_attachments_storage_key` ON `attachments`(`storage_key`);
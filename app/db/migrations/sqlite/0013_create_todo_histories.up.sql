@@ -0,0 +1,13 @@
+-- todoの変更履歴（追記のみ）
+-- todo・操作したユーザーを完全に削除すると履歴も削除する
+CREATE TABLE `todo_histories` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime,
+    `todo_id` integer NOT NULL REFERENCES `todos`(`id`) ON DELETE CASCADE,
+    `user_id` integer NOT NULL REFERENCES `users`(`id`) ON DELETE CASCADE,
+    `action` text NOT NULL,
+    `field` text NOT NULL DEFAULT '',
+    `old_value` text NOT NULL,
+    `new_value` text NOT NULL
+);
+CREATE INDEX `idx_todo_histories_todo_id_created_at` ON `todo_histories`(`todo_id`, `created_at`);
@@ -0,0 +1,2 @@
+-- 楽観的排他制御のバージョン（内容を更新するたびに1増やす）
+ALTER TABLE `todos` ADD COLUMN `version` integer NOT NULL DEFAULT 0;
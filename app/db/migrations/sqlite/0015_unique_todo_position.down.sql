@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS `idx_todos_user_id_position`;
+CREATE INDEX `idx_todos_user_id_position` ON `todos`(`user_id`, `position`);
@@ -0,0 +1,9 @@
+-- 同時に追加した場合などに重複した並び順を、後から追加したtodoの値の末尾にIDを付けて解消する
+UPDATE `todos` SET `position` = `position` || substr('0000000000' || `id`, -10, 10) || 'V'
+WHERE EXISTS (
+    SELECT 1 FROM `todos` AS `t`
+    WHERE `t`.`user_id` = `todos`.`user_id` AND `t`.`position` = `todos`.`position` AND `t`.`id` < `todos`.`id`
+);
+-- ユーザーごとに並び順が重複しないようにする
+DROP INDEX IF EXISTS `idx_todos_user_id_position`;
+CREATE UNIQUE INDEX `idx_todos_user_id_position` ON `todos`(`user_id`, `position`);
@@ -0,0 +1,16 @@
+-- todoを完全に削除しても変更履歴は残す（操作したユーザーを削除した場合のみ削除する）
+-- SQLiteでは外部キーの制約を削除できないため、テーブルを作り直す
+CREATE TABLE `todo_histories_new` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime,
+    `todo_id` integer NOT NULL,
+    `user_id` integer NOT NULL REFERENCES `users`(`id`) ON DELETE CASCADE,
+    `action` text NOT NULL,
+    `field` text NOT NULL DEFAULT '',
+    `old_value` text NOT NULL,
+    `new_value` text NOT NULL
+);
+INSERT INTO `todo_histories_new` SELECT `id`, `created_at`, `todo_id`, `user_id`, `action`, `field`, `old_value`, `new_value` FROM `todo_histories`;
+DROP TABLE `todo_histories`;
+ALTER TABLE `todo_histories_new` RENAME TO `todo_histories`;
+CREATE INDEX `idx_todo_histories_todo_id_created_at` ON `todo_histories`(`todo_id`, `created_at`);
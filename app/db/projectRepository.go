@@ -0,0 +1,131 @@
+package db
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	"gorm.io/gorm"
+)
+
+// projectモデルのDB処理を担うリポジトリの構造体
+type projectRepository struct {
+	handler SqlHandler
+}
+
+// ProjectRepositoryの新しいインスタンスを作成して返す
+func NewProjectRepository(sqlHandler SqlHandler) repository.ProjectRepository {
+	projectRepository := projectRepository{handler: sqlHandler}
+	return &projectRepository
+}
+
+// 指定されたユーザーのプロジェクトの一覧を、todoの件数と共に返す
+// Inboxを先頭にし、それ以外は名前順とする（削除済みのtodoは件数に含めない）
+func (pr *projectRepository) FindAll(userID uint) (*[]models.Project, error) {
+	conn := pr.handler.GetConnection()
+	var projects []models.Project
+	if result := conn.Where("user_id = ?", userID).Order("inbox DESC, name").Find(&projects); result.Error != nil {
+		return nil, result.Error
+	}
+
+	var counts []struct {
+		ProjectID uint
+		Count     int64
+	}
+	result := conn.Model(&models.Todo{}).
+		Select("project_id, COUNT(*) AS count").
+		Where("user_id = ? AND project_id IS NOT NULL", userID).
+		Group("project_id").
+		Scan(&counts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	byID := make(map[uint]int64, len(counts))
+	for _, c := range counts {
+		byID[c.ProjectID] = c.Count
+	}
+	for i := range projects {
+		projects[i].TodoCount = byID[projects[i].ID]
+	}
+	return &projects, nil
+}
+
+// 指定されたユーザーの、指定されたIDのプロジェクトを検索して結果を返す
+// 他のユーザーのプロジェクトは存在しないものとして扱う
+func (pr *projectRepository) FindById(userID uint, id uint) (*models.Project, error) {
+	var project models.Project
+	result := pr.handler.GetConnection().Where("user_id = ? AND id = ?", userID, id).First(&project)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &project, nil
+}
+
+// 指定されたユーザーのInboxを返す
+// まだ無い場合（ユーザー登録の直後など）は作成する
+func (pr *projectRepository) FindInbox(userID uint) (*models.Project, error) {
+	conn := pr.handler.GetConnection()
+	var project models.Project
+	result := conn.Where("user_id = ? AND inbox = ?", userID, true).First(&project)
+	if result.Error == nil {
+		return &project, nil
+	}
+	if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, result.Error
+	}
+	inbox := models.NewInboxProject(userID)
+	if result := conn.Create(inbox); result.Error != nil {
+		return nil, result.Error
+	}
+	return inbox, nil
+}
+
+// 渡されたプロジェクトを新規作成して保存する
+func (pr *projectRepository) Create(project *models.Project) error {
+	result := pr.handler.GetConnection().Create(project)
+	return result.Error
+}
+
+// 渡されたプロジェクトのデータを更新する
+func (pr *projectRepository) Update(project *models.Project) error {
+	// Saveメソッドだと、存在しないIDの場合はCreate動作になるため、
+	// 存否チェックをする（他のユーザーのプロジェクトは更新できない）
+	_, err := pr.FindById(project.UserID, project.ID)
+	if err != nil {
+		return err
+	}
+	result := pr.handler.GetConnection().Save(project)
+	return result.Error
+}
+
+// 指定されたユーザーの、指定されたIDのプロジェクトを削除する
+// プロジェクトのtodo（削除済みのものを含む）は、moveToのプロジェクトに移す
+func (pr *projectRepository) Delete(userID uint, id uint, moveTo uint) error {
+	return pr.handler.GetConnection().Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Project{}).Where("user_id = ? AND id IN ?", userID, []uint{id, moveTo}).Count(&count).Error; err != nil {
+			return err
+		}
+		if id == moveTo || count != 2 {
+			return errNotFound
+		}
+		result := tx.Unscoped().Model(&models.Todo{}).
+			Where("user_id = ? AND project_id = ?", userID, id).
+			Update("project_id", moveTo)
+		if result.Error != nil {
+			return result.Error
+		}
+		return tx.Delete(&models.Project{}, id).Error
+	})
+}
+
+// リポジトリの終了処理を行う
+func (pr *projectRepository) Close() error {
+	// 依存先をクローズする
+	err := pr.handler.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	return err
+}
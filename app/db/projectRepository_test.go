@@ -0,0 +1,233 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// プロジェクトのテストで使用するデータ
+type projectFixture struct {
+	user  models.User
+	other models.User
+	// 名前をキーにしたプロジェクト（他のユーザーのプロジェクトは"other:"を付ける）
+	projects map[string]*models.Project
+	// タイトルをキーにしたtodo
+	todos map[string]*models.Todo
+}
+
+// プロジェクトのテストで使用するデータを作成する
+func (s *todoRepositoryTestSuite) createProjectFixture(t *testing.T, db *gorm.DB) *projectFixture {
+	f := &projectFixture{
+		user:     models.User{Email: "user@example.com", PasswordHash: "hash"},
+		other:    models.User{Email: "other@example.com", PasswordHash: "hash"},
+		projects: map[string]*models.Project{},
+		todos:    map[string]*models.Todo{},
+	}
+	for _, user := range []*models.User{&f.user, &f.other} {
+		if result := db.Create(user); result.Error != nil {
+			t.Fatalf("Creation is failed. error: %v", result.Error)
+		}
+	}
+	projects := map[string]*models.Project{
+		"Inbox":       models.NewInboxProject(f.user.ID),
+		"work":        {UserID: f.user.ID, Name: "work"},
+		"hobby":       {UserID: f.user.ID, Name: "hobby"},
+		"other:Inbox": models.NewInboxProject(f.other.ID),
+	}
+	for key, project := range projects {
+		if result := db.Create(project); result.Error != nil {
+			t.Fatalf("Creation is failed. error: %v", result.Error)
+		}
+		f.projects[key] = project
+	}
+	todos := map[string]struct {
+		userID  uint
+		project string
+	}{
+		"todo1": {f.user.ID, "Inbox"},
+		"todo2": {f.user.ID, "work"},
+		"todo3": {f.user.ID, "work"},
+		"other": {f.other.ID, "other:Inbox"},
+	}
+	for key, tt := range todos {
+		todo := &models.Todo{UserID: tt.userID, ProjectID: f.projects[tt.project].ID, Title: key, Status: models.NotStarted}
+		if err := createTodo(db, todo); err != nil {
+			t.Fatalf("Creation is failed. error: %v", err)
+		}
+		f.todos[key] = todo
+	}
+	return f
+}
+
+func (s *todoRepositoryTestSuite) TestProject() {
+
+	cases := map[string]struct {
+		// プロジェクトに対する操作
+		run func(r repository.ProjectRepository, f *projectFixture) error
+		// 操作後のプロジェクトごとのtodoの件数
+		want      map[string]int64
+		expectErr bool
+	}{
+		"正常ケース:削除するとtodoは移動先に移る": {
+			run: func(r repository.ProjectRepository, f *projectFixture) error {
+				return r.Delete(f.user.ID, f.projects["work"].ID, f.projects["Inbox"].ID)
+			},
+			want: map[string]int64{"Inbox": 3, "hobby": 0},
+		},
+		"正常ケース:名前を変更": {
+			run: func(r repository.ProjectRepository, f *projectFixture) error {
+				project := f.projects["hobby"]
+				_ = project.Rename("private")
+				return r.Update(project)
+			},
+			want: map[string]int64{"Inbox": 1, "private": 0, "work": 2},
+		},
+		"異常ケース:他のユーザーのプロジェクトは削除できない": {
+			run: func(r repository.ProjectRepository, f *projectFixture) error {
+				return r.Delete(f.user.ID, f.projects["other:Inbox"].ID, f.projects["Inbox"].ID)
+			},
+			want:      map[string]int64{"Inbox": 1, "hobby": 0, "work": 2},
+			expectErr: true,
+		},
+		"異常ケース:他のユーザーのプロジェクトには移せない": {
+			run: func(r repository.ProjectRepository, f *projectFixture) error {
+				return r.Delete(f.user.ID, f.projects["work"].ID, f.projects["other:Inbox"].ID)
+			},
+			want:      map[string]int64{"Inbox": 1, "hobby": 0, "work": 2},
+			expectErr: true,
+		},
+		"異常ケース:他のユーザーのプロジェクトは更新できない": {
+			run: func(r repository.ProjectRepository, f *projectFixture) error {
+				project := *f.projects["other:Inbox"]
+				project.UserID = f.user.ID
+				project.Name = "mine"
+				return r.Update(&project)
+			},
+			want:      map[string]int64{"Inbox": 1, "hobby": 0, "work": 2},
+			expectErr: true,
+		},
+		"異常ケース:同じ名前のプロジェクトは作成できない": {
+			run: func(r repository.ProjectRepository, f *projectFixture) error {
+				project, _ := models.NewProject(f.user.ID, "work")
+				return r.Create(project)
+			},
+			want:      map[string]int64{"Inbox": 1, "hobby": 0, "work": 2},
+			expectErr: true,
+		},
+	}
+	for name, tt := range cases {
+		s.T().Run(name, func(t *testing.T) {
+			// テスト用DBに接続する
+			db, err := s.openDB()
+			if err != nil {
+				s.Failf("database connection is not established", "%v", err)
+			}
+
+			defer s.Close(db)
+
+			// 初期処理
+			sqlHandler := testHandler{conn: db}
+			projectRepository := NewProjectRepository(&sqlHandler)
+			f := s.createProjectFixture(t, db)
+
+			err = tt.run(projectRepository, f)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			projects, err := projectRepository.FindAll(f.user.ID)
+			if assert.NoError(t, err) {
+				got := map[string]int64{}
+				for _, project := range *projects {
+					got[project.Name] = project.TodoCount
+				}
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func (s *todoRepositoryTestSuite) TestProjectFind() {
+
+	// テスト用DBに接続する
+	db, err := s.openDB()
+	if err != nil {
+		s.Failf("database connection is not established", "%v", err)
+	}
+
+	defer s.Close(db)
+
+	// 初期処理
+	sqlHandler := testHandler{conn: db}
+	projectRepository := NewProjectRepository(&sqlHandler)
+	todoRepository := NewTodoRepository(&sqlHandler)
+	f := s.createProjectFixture(s.T(), db)
+
+	s.T().Run("正常ケース:一覧はInboxが先頭で、それ以外は名前順", func(t *testing.T) {
+		projects, err := projectRepository.FindAll(f.user.ID)
+		if assert.NoError(t, err) {
+			names := []string{}
+			for _, project := range *projects {
+				names = append(names, project.Name)
+			}
+			assert.Equal(t, []string{"Inbox", "hobby", "work"}, names)
+		}
+	})
+
+	s.T().Run("正常ケース:Inboxを取得", func(t *testing.T) {
+		inbox, err := projectRepository.FindInbox(f.user.ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, f.projects["Inbox"].ID, inbox.ID)
+		}
+	})
+
+	s.T().Run("正常ケース:Inboxが無い場合は作成", func(t *testing.T) {
+		user := models.User{Email: "new@example.com", PasswordHash: "hash"}
+		if result := db.Create(&user); result.Error != nil {
+			t.Fatalf("Creation is failed. error: %v", result.Error)
+		}
+		inbox, err := projectRepository.FindInbox(user.ID)
+		if assert.NoError(t, err) {
+			assert.True(t, inbox.Inbox)
+			assert.Equal(t, models.InboxProjectName, inbox.Name)
+			again, err := projectRepository.FindInbox(user.ID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, inbox.ID, again.ID)
+			}
+		}
+	})
+
+	s.T().Run("異常ケース:他のユーザーのプロジェクトは取得できない", func(t *testing.T) {
+		_, err := projectRepository.FindById(f.user.ID, f.projects["other:Inbox"].ID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	s.T().Run("正常ケース:プロジェクトで絞り込み", func(t *testing.T) {
+		todos, total, err := todoRepository.Search(models.TodoQuery{UserID: f.user.ID, ProjectID: f.projects["work"].ID, Sort: models.SortByTitle})
+		if assert.NoError(t, err) {
+			titles := []string{}
+			for _, todo := range *todos {
+				titles = append(titles, todo.Title)
+			}
+			assert.Equal(t, []string{"todo2", "todo3"}, titles)
+			assert.Equal(t, int64(2), total)
+		}
+	})
+
+	s.T().Run("正常ケース:削除したtodoは件数に含めない", func(t *testing.T) {
+		if err := todoRepository.Delete(f.user.ID, f.todos["todo2"].ID); err != nil {
+			t.Fatalf("Delete is failed. error: %v", err)
+		}
+		projects, err := projectRepository.FindAll(f.user.ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(1), (*projects)[2].TodoCount)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+)
+
+// sessionモデルのDB処理を担うリポジトリの構造体
+type sessionRepository struct {
+	handler SqlHandler
+}
+
+// SessionRepositoryの新しいインスタンスを作成して返す
+func NewSessionRepository(sqlHandler SqlHandler) repository.SessionRepository {
+	sessionRepository := sessionRepository{handler: sqlHandler}
+	return &sessionRepository
+}
+
+// 指定されたIDのセッションを検索して結果を返す
+func (sr *sessionRepository) FindById(id string) (*models.Session, error) {
+	var session models.Session
+	result := sr.handler.GetConnection().Where("id = ?", id).First(&session)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &session, nil
+}
+
+// 渡されたセッションを新規作成して保存する
+func (sr *sessionRepository) Create(session *models.Session) error {
+	result := sr.handler.GetConnection().Create(session)
+	return result.Error
+}
+
+// 指定されたIDのセッションを削除する
+// ログアウトは何度実行しても良いため、存在しない場合もエラーにしない
+func (sr *sessionRepository) Delete(id string) error {
+	result := sr.handler.GetConnection().Where("id = ?", id).Delete(&models.Session{})
+	return result.Error
+}
+
+// 失効したセッションを削除する
+// SQLiteは日時を文字列として比較するため、保存時と同じくUTCに揃えて検索する
+func (sr *sessionRepository) DeleteExpired(now time.Time) error {
+	result := sr.handler.GetConnection().Where("expires_at <= ?", now.UTC()).Delete(&models.Session{})
+	return result.Error
+}
+
+// sessionRepositoryの終了処理
+func (sr *sessionRepository) Close() error {
+	// 依存先をクローズする
+	err := sr.handler.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *todoRepositoryTestSuite) TestSession() {
+
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		// セッションに対する操作
+		run func(r *sessionRepository, active *models.Session, expired *models.Session) error
+		// 操作後に残っているべきか
+		wantActive  bool
+		wantExpired bool
+	}{
+		"正常ケース:何もしない": {
+			run:         func(r *sessionRepository, active, expired *models.Session) error { return nil },
+			wantActive:  true,
+			wantExpired: true,
+		},
+		"正常ケース:指定したセッションを削除": {
+			run: func(r *sessionRepository, active, expired *models.Session) error {
+				return r.Delete(active.ID)
+			},
+			wantActive:  false,
+			wantExpired: true,
+		},
+		"正常ケース:存在しないセッションの削除はエラーにしない": {
+			run: func(r *sessionRepository, active, expired *models.Session) error {
+				return r.Delete("not_exist")
+			},
+			wantActive:  true,
+			wantExpired: true,
+		},
+		"正常ケース:失効したセッションのみ削除": {
+			run: func(r *sessionRepository, active, expired *models.Session) error {
+				// 別のタイムゾーンで表した日時でも比較できること
+				return r.DeleteExpired(now.In(time.FixedZone("JST", 9*60*60)))
+			},
+			wantActive:  true,
+			wantExpired: false,
+		},
+	}
+	for name, tt := range cases {
+		s.T().Run(name, func(t *testing.T) {
+			// テスト用DBに接続する
+			db, err := s.openDB()
+			if err != nil {
+				s.Failf("database connection is not established", "%v", err)
+			}
+
+			defer s.Close(db)
+
+			// 初期処理
+			sqlHandler := testHandler{conn: db}
+			userRepository := NewUserRepository(&sqlHandler)
+			sessionRepository := &sessionRepository{handler: &sqlHandler}
+
+			user := models.User{Email: "user@example.com", PasswordHash: "hash"}
+			if err := userRepository.Create(&user); err != nil {
+				t.Fatalf("Creation is failed. error: %v", err)
+			}
+			active, _ := models.NewSession(user.ID, now, time.Hour)
+			expired, _ := models.NewSession(user.ID, now.Add(-2*time.Hour), time.Hour)
+			for _, session := range []*models.Session{active, expired} {
+				if err := sessionRepository.Create(session); err != nil {
+					t.Fatalf("Creation is failed. error: %v", err)
+				}
+			}
+
+			err = tt.run(sessionRepository, active, expired)
+
+			// 結果を確認
+			if assert.NoError(t, err) {
+				found, err := sessionRepository.FindById(active.ID)
+				if tt.wantActive {
+					if assert.NoError(t, err) {
+						assert.Equal(t, user.ID, found.UserID)
+						assert.True(t, active.ExpiresAt.Equal(found.ExpiresAt))
+					}
+				} else {
+					assert.True(t, isNotFound(err))
+				}
+				_, err = sessionRepository.FindById(expired.ID)
+				if tt.wantExpired {
+					assert.NoError(t, err)
+				} else {
+					assert.True(t, isNotFound(err))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package db
+
+import (
+	"log/slog"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tagモデルのDB処理を担うリポジトリの構造体
+type tagRepository struct {
+	handler SqlHandler
+}
+
+// todoとタグの中間テーブルの行
+type todoTag struct {
+	TodoID uint
+	TagID  uint
+}
+
+// 中間テーブルの名前
+func (todoTag) TableName() string {
+	return "todo_tags"
+}
+
+// TagRepositoryの新しいインスタンスを作成して返す
+func NewTagRepository(sqlHandler SqlHandler) repository.TagRepository {
+	tagRepository := tagRepository{handler: sqlHandler}
+	return &tagRepository
+}
+
+// 指定されたユーザーのタグの一覧を、付いているtodoの件数と共に名前順で返す
+// 削除済みのtodoは件数に含めない
+func (tr *tagRepository) FindAll(userID uint) (*[]models.Tag, error) {
+	conn := tr.handler.GetConnection()
+	var tags []models.Tag
+	if result := conn.Where("user_id = ?", userID).Order("name").Find(&tags); result.Error != nil {
+		return nil, result.Error
+	}
+
+	var counts []struct {
+		TagID uint
+		Count int64
+	}
+	result := conn.Table("todo_tags").
+		Select("todo_tags.tag_id, COUNT(*) AS count").
+		Joins("JOIN todos ON todos.id = todo_tags.todo_id AND todos.deleted_at IS NULL").
+		Where("todos.user_id = ?", userID).
+		Group("todo_tags.tag_id").
+		Scan(&counts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	byID := make(map[uint]int64, len(counts))
+	for _, c := range counts {
+		byID[c.TagID] = c.Count
+	}
+	for i := range tags {
+		tags[i].TodoCount = byID[tags[i].ID]
+	}
+	return &tags, nil
+}
+
+// 指定されたユーザーの、指定されたIDのタグを検索して結果を返す
+// 他のユーザーのタグは存在しないものとして扱う
+func (tr *tagRepository) FindById(userID uint, id uint) (*models.Tag, error) {
+	var tag models.Tag
+	result := tr.handler.GetConnection().Where("user_id = ? AND id = ?", userID, id).First(&tag)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &tag, nil
+}
+
+// 指定されたユーザーの、指定された名前のタグを名前順で返す
+// 存在しない名前は無視する
+func (tr *tagRepository) FindByNames(userID uint, names []string) (*[]models.Tag, error) {
+	tags := []models.Tag{}
+	if len(names) == 0 {
+		return &tags, nil
+	}
+	result := tr.handler.GetConnection().
+		Where("user_id = ? AND name IN ?", userID, names).
+		Order("name").
+		Find(&tags)
+	return &tags, result.Error
+}
+
+// 指定されたユーザーの、指定されたtodoに付いているタグを名前順で返す
+func (tr *tagRepository) FindByTodo(userID uint, todoID uint) (*[]models.Tag, error) {
+	var tags []models.Tag
+	result := tr.handler.GetConnection().
+		Joins("JOIN todo_tags ON todo_tags.tag_id = tags.id").
+		Where("tags.user_id = ? AND todo_tags.todo_id = ?", userID, todoID).
+		Order("tags.name").
+		Find(&tags)
+	return &tags, result.Error
+}
+
+// 渡されたタグを新規作成して保存する
+func (tr *tagRepository) Create(tag *models.Tag) error {
+	result := tr.handler.GetConnection().Create(tag)
+	return result.Error
+}
+
+// 渡されたタグのデータを更新する
+// todoとの関連はIDで保持しているため、名前を変更すると付いている全てのtodoに反映される
+func (tr *tagRepository) Update(tag *models.Tag) error {
+	// Saveメソッドだと、存在しないIDの場合はCreate動作になるため、
+	// 存否チェックをする（他のユーザーのタグは更新できない）
+	_, err := tr.FindById(tag.UserID, tag.ID)
+	if err != nil {
+		return err
+	}
+	result := tr.handler.GetConnection().Save(tag)
+	return result.Error
+}
+
+// 指定されたユーザーの、指定されたIDのタグを削除する
+// todoとの関連は外部キーの制約で削除される
+func (tr *tagRepository) Delete(userID uint, id uint) error {
+	// 存在しないIDの場合でもエラーは出ないようなので、存否チェックをする
+	_, err := tr.FindById(userID, id)
+	if err != nil {
+		return err
+	}
+	result := tr.handler.GetConnection().Delete(&models.Tag{}, id)
+	return result.Error
+}
+
+// 指定されたtodoにタグを付ける
+// 既に付いているタグは無視する
+func (tr *tagRepository) Attach(userID uint, todoID uint, tagIDs []uint) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	return tr.handler.GetConnection().Transaction(func(tx *gorm.DB) error {
+		if err := checkOwned(tx, userID, todoID, tagIDs); err != nil {
+			return err
+		}
+		rows := make([]todoTag, 0, len(tagIDs))
+		for _, tagID := range tagIDs {
+			rows = append(rows, todoTag{TodoID: todoID, TagID: tagID})
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+	})
+}
+
+// 指定されたtodoからタグを外す
+// 付いていないタグは無視する
+func (tr *tagRepository) Detach(userID uint, todoID uint, tagIDs []uint) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	return tr.handler.GetConnection().Transaction(func(tx *gorm.DB) error {
+		if err := checkOwned(tx, userID, todoID, tagIDs); err != nil {
+			return err
+		}
+		return tx.Where("todo_id = ? AND tag_id IN ?", todoID, tagIDs).Delete(&todoTag{}).Error
+	})
+}
+
+// タグを統合する
+// fromのタグが付いているtodoにintoのタグを付け、fromのタグを削除する
+func (tr *tagRepository) Merge(userID uint, fromID uint, intoID uint) error {
+	return tr.handler.GetConnection().Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Tag{}).Where("user_id = ? AND id IN ?", userID, []uint{fromID, intoID}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count != 2 {
+			return errNotFound
+		}
+		var todoIDs []uint
+		if err := tx.Model(&todoTag{}).Where("tag_id = ?", fromID).Pluck("todo_id", &todoIDs).Error; err != nil {
+			return err
+		}
+		if len(todoIDs) > 0 {
+			rows := make([]todoTag, 0, len(todoIDs))
+			for _, todoID := range todoIDs {
+				rows = append(rows, todoTag{TodoID: todoID, TagID: intoID})
+			}
+			// 既に両方のタグが付いているtodoは、fromの関連の削除のみ行う
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("tag_id = ?", fromID).Delete(&todoTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Tag{}, fromID).Error
+	})
+}
+
+// todoとタグが全て指定されたユーザーのものであることを確認する
+func checkOwned(tx *gorm.DB, userID uint, todoID uint, tagIDs []uint) error {
+	if err := tx.Where("user_id = ? AND id = ?", userID, todoID).First(&models.Todo{}).Error; err != nil {
+		return err
+	}
+	var count int64
+	if err := tx.Model(&models.Tag{}).Where("user_id = ? AND id IN ?", userID, tagIDs).Count(&count).Error; err != nil {
+		return err
+	}
+	if count != int64(len(uniqueIDs(tagIDs))) {
+		return errNotFound
+	}
+	return nil
+}
+
+// 重複を除いたIDの一覧を返す
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// tagRepositoryの終了処理
+func (tr *tagRepository) Close() error {
+	// 依存先をクローズする
+	err := tr.handler.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	return err
+}
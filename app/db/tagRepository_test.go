@@ -0,0 +1,275 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// タグのテストで使用するデータ
+type tagFixture struct {
+	user  models.User
+	other models.User
+	// タイトルをキーにしたtodo
+	todos map[string]*models.Todo
+	// 名前をキーにしたタグ（他のユーザーのタグは"other:"を付ける）
+	tags map[string]*models.Tag
+}
+
+// タグのテストで使用するデータを作成する
+func (s *todoRepositoryTestSuite) createTagFixture(t *testing.T, db *gorm.DB) *tagFixture {
+	f := &tagFixture{
+		user:  models.User{Email: "user@example.com", PasswordHash: "hash"},
+		other: models.User{Email: "other@example.com", PasswordHash: "hash"},
+		todos: map[string]*models.Todo{},
+		tags:  map[string]*models.Tag{},
+	}
+	for _, user := range []*models.User{&f.user, &f.other} {
+		if result := db.Create(user); result.Error != nil {
+			t.Fatalf("Creation is failed. error: %v", result.Error)
+		}
+	}
+	for key, userID := range map[string]uint{"todo1": f.user.ID, "todo2": f.user.ID, "other": f.other.ID} {
+		todo := &models.Todo{UserID: userID, Title: key, Status: models.NotStarted}
+		if err := createTodo(db, todo); err != nil {
+			t.Fatalf("Creation is failed. error: %v", err)
+		}
+		f.todos[key] = todo
+	}
+	tags := map[string]struct {
+		userID uint
+		name   string
+	}{
+		"backend":       {f.user.ID, "backend"},
+		"urgent":        {f.user.ID, "urgent"},
+		"frontend":      {f.user.ID, "frontend"},
+		"other:backend": {f.other.ID, "backend"},
+	}
+	for key, tt := range tags {
+		tag, _ := models.NewTag(tt.userID, tt.name)
+		if result := db.Create(tag); result.Error != nil {
+			t.Fatalf("Creation is failed. error: %v", result.Error)
+		}
+		f.tags[key] = tag
+	}
+	return f
+}
+
+// タグのIDの一覧を返す
+func (f *tagFixture) tagIDs(keys ...string) []uint {
+	ids := make([]uint, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, f.tags[key].ID)
+	}
+	return ids
+}
+
+func (s *todoRepositoryTestSuite) TestTag() {
+
+	cases := map[string]struct {
+		// 事前にtodoに付けておくタグ
+		attached map[string][]string
+		// タグに対する操作
+		run func(r repository.TagRepository, f *tagFixture) error
+		// 操作後にtodoに付いているべきタグの名前
+		want      map[string][]string
+		expectErr bool
+	}{
+		"正常ケース:タグを付ける": {
+			run: func(r repository.TagRepository, f *tagFixture) error {
+				return r.Attach(f.user.ID, f.todos["todo1"].ID, f.tagIDs("urgent", "backend"))
+			},
+			want: map[string][]string{"todo1": {"backend", "urgent"}, "todo2": {}},
+		},
+		"正常ケース:付いているタグを付けても重複しない": {
+			attached: map[string][]string{"todo1": {"backend"}},
+			run: func(r repository.TagRepository, f *tagFixture) error {
+				return r.Attach(f.user.ID, f.todos["todo1"].ID, f.tagIDs("backend", "backend", "urgent"))
+			},
+			want: map[string][]string{"todo1": {"backend", "urgent"}},
+		},
+		"正常ケース:タグを外す": {
+			attached: map[string][]string{"todo1": {"backend", "urgent"}, "todo2": {"backend"}},
+			run: func(r repository.TagRepository, f *tagFixture) error {
+				return r.Detach(f.user.ID, f.todos["todo1"].ID, f.tagIDs("backend", "frontend"))
+			},
+			want: map[string][]string{"todo1": {"urgent"}, "todo2": {"backend"}},
+		},
+		"正常ケース:名前の変更は付いている全てのtodoに反映": {
+			attached: map[string][]string{"todo1": {"backend"}, "todo2": {"backend", "urgent"}},
+			run: func(r repository.TagRepository, f *tagFixture) error {
+				tag := f.tags["backend"]
+				_ = tag.Rename("server")
+				return r.Update(tag)
+			},
+			want: map[string][]string{"todo1": {"server"}, "todo2": {"server", "urgent"}},
+		},
+		"正常ケース:統合すると統合先のタグに置き換わる": {
+			attached: map[string][]string{"todo1": {"backend", "urgent"}, "todo2": {"backend"}},
+			run: func(r repository.TagRepository, f *tagFixture) error {
+				return r.Merge(f.user.ID, f.tags["backend"].ID, f.tags["urgent"].ID)
+			},
+			want: map[string][]string{"todo1": {"urgent"}, "todo2": {"urgent"}},
+		},
+		"正常ケース:タグを削除すると付いているtodoからも外れる": {
+			attached: map[string][]string{"todo1": {"backend", "urgent"}},
+			run: func(r repository.TagRepository, f *tagFixture) error {
+				return r.Delete(f.user.ID, f.tags["backend"].ID)
+			},
+			want: map[string][]string{"todo1": {"urgent"}},
+		},
+		"異常ケース:他のユーザーのtodoにタグを付ける": {
+			run: func(r repository.TagRepository, f *tagFixture) error {
+				return r.Attach(f.user.ID, f.todos["other"].ID, f.tagIDs("backend"))
+			},
+			expectErr: true,
+		},
+		"異常ケース:他のユーザーのタグを付ける": {
+			run: func(r repository.TagRepository, f *tagFixture) error {
+				return r.Attach(f.user.ID, f.todos["todo1"].ID, f.tagIDs("other:backend"))
+			},
+			want:      map[string][]string{"todo1": {}},
+			expectErr: true,
+		},
+		"異常ケース:他のユーザーのタグとは統合できない": {
+			attached: map[string][]string{"todo1": {"backend"}},
+			run: func(r repository.TagRepository, f *tagFixture) error {
+				return r.Merge(f.user.ID, f.tags["backend"].ID, f.tags["other:backend"].ID)
+			},
+			want:      map[string][]string{"todo1": {"backend"}},
+			expectErr: true,
+		},
+		"異常ケース:他のユーザーのタグは削除できない": {
+			run: func(r repository.TagRepository, f *tagFixture) error {
+				return r.Delete(f.user.ID, f.tags["other:backend"].ID)
+			},
+			expectErr: true,
+		},
+		"異常ケース:同じ名前のタグは作成できない": {
+			run: func(r repository.TagRepository, f *tagFixture) error {
+				tag, _ := models.NewTag(f.user.ID, "backend")
+				return r.Create(tag)
+			},
+			expectErr: true,
+		},
+	}
+	for name, tt := range cases {
+		s.T().Run(name, func(t *testing.T) {
+			// テスト用DBに接続する
+			db, err := s.openDB()
+			if err != nil {
+				s.Failf("database connection is not established", "%v", err)
+			}
+
+			defer s.Close(db)
+
+			// 初期処理
+			sqlHandler := testHandler{conn: db}
+			tagRepository := NewTagRepository(&sqlHandler)
+			f := s.createTagFixture(t, db)
+			for key, tags := range tt.attached {
+				if err := tagRepository.Attach(f.user.ID, f.todos[key].ID, f.tagIDs(tags...)); err != nil {
+					t.Fatalf("Attach is failed. error: %v", err)
+				}
+			}
+
+			err = tt.run(tagRepository, f)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			for key, want := range tt.want {
+				tags, err := tagRepository.FindByTodo(f.user.ID, f.todos[key].ID)
+				if assert.NoError(t, err) {
+					names := []string{}
+					for _, tag := range *tags {
+						names = append(names, tag.Name)
+					}
+					assert.Equal(t, want, names, key)
+				}
+			}
+		})
+	}
+}
+
+func (s *todoRepositoryTestSuite) TestTagFind() {
+
+	// テスト用DBに接続する
+	db, err := s.openDB()
+	if err != nil {
+		s.Failf("database connection is not established", "%v", err)
+	}
+
+	defer s.Close(db)
+
+	// 初期処理
+	sqlHandler := testHandler{conn: db}
+	tagRepository := NewTagRepository(&sqlHandler)
+	todoRepository := NewTodoRepository(&sqlHandler)
+	f := s.createTagFixture(s.T(), db)
+	_ = tagRepository.Attach(f.user.ID, f.todos["todo1"].ID, f.tagIDs("backend", "urgent"))
+	_ = tagRepository.Attach(f.user.ID, f.todos["todo2"].ID, f.tagIDs("backend"))
+
+	s.T().Run("正常ケース:一覧は名前順で件数付き", func(t *testing.T) {
+		tags, err := tagRepository.FindAll(f.user.ID)
+		if assert.NoError(t, err) {
+			got := map[string]int64{}
+			names := []string{}
+			for _, tag := range *tags {
+				got[tag.Name] = tag.TodoCount
+				names = append(names, tag.Name)
+			}
+			assert.Equal(t, []string{"backend", "frontend", "urgent"}, names)
+			assert.Equal(t, map[string]int64{"backend": 2, "frontend": 0, "urgent": 1}, got)
+		}
+	})
+
+	s.T().Run("正常ケース:名前で検索", func(t *testing.T) {
+		tags, err := tagRepository.FindByNames(f.user.ID, []string{"urgent", "backend", "unknown"})
+		if assert.NoError(t, err) && assert.Len(t, *tags, 2) {
+			assert.Equal(t, f.tags["backend"].ID, (*tags)[0].ID)
+			assert.Equal(t, f.tags["urgent"].ID, (*tags)[1].ID)
+		}
+	})
+
+	s.T().Run("正常ケース:todoと一緒にタグを読み込む", func(t *testing.T) {
+		todo, err := todoRepository.FindById(f.user.ID, f.todos["todo1"].ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"backend", "urgent"}, todo.TagNames())
+		}
+	})
+
+	s.T().Run("正常ケース:全てのタグが付いているtodoで絞り込み", func(t *testing.T) {
+		for tags, want := range map[string][]string{
+			"backend":        {"todo1", "todo2"},
+			"backend,urgent": {"todo1"},
+			"frontend":       {},
+		} {
+			todos, total, err := todoRepository.Search(models.TodoQuery{UserID: f.user.ID, Tags: models.ParseTagNames(tags), Sort: models.SortByTitle})
+			if assert.NoError(t, err) {
+				titles := []string{}
+				for _, todo := range *todos {
+					titles = append(titles, todo.Title)
+				}
+				assert.Equal(t, want, titles, tags)
+				assert.Equal(t, int64(len(want)), total, tags)
+			}
+		}
+	})
+
+	s.T().Run("正常ケース:削除したtodoは件数に含めない", func(t *testing.T) {
+		if err := todoRepository.Delete(f.user.ID, f.todos["todo2"].ID); err != nil {
+			t.Fatalf("Delete is failed. error: %v", err)
+		}
+		tags, err := tagRepository.FindAll(f.user.ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(1), (*tags)[0].TodoCount)
+		}
+	})
+}
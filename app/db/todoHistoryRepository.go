@@ -0,0 +1,57 @@
+package db
+
+import (
+	"log/slog"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	"gorm.io/gorm"
+)
+
+// todoHistoryモデルのDB処理を担うリポジトリの構造体
+type todoHistoryRepository struct {
+	handler SqlHandler
+}
+
+// TodoHistoryRepositoryの新しいインスタンスを作成して返す
+func NewTodoHistoryRepository(sqlHandler SqlHandler) repository.TodoHistoryRepository {
+	todoHistoryRepository := todoHistoryRepository{handler: sqlHandler}
+	return &todoHistoryRepository
+}
+
+// 指定されたユーザーの、指定されたtodoの変更履歴を新しい順で返す
+func (hr *todoHistoryRepository) FindByTodo(userID uint, todoID uint) (*[]models.TodoHistory, error) {
+	var histories []models.TodoHistory
+	result := hr.handler.GetConnection().
+		Scopes(ownedHistories(userID, todoID), preloadHistoryUser).
+		Order("created_at DESC, id DESC").
+		Find(&histories)
+	return &histories, result.Error
+}
+
+// リポジトリの終了処理を行う
+func (hr *todoHistoryRepository) Close() error {
+	// 依存先をクローズする
+	err := hr.handler.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	return err
+}
+
+// 指定されたユーザーの、指定されたtodoの変更履歴に絞り込むスコープを返す
+// 削除済みのtodoの変更履歴は対象外とする
+func ownedHistories(userID uint, todoID uint) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		owned := tx.Session(&gorm.Session{NewDB: true}).
+			Model(&models.Todo{}).
+			Select("id").
+			Where("user_id = ? AND id = ?", userID, todoID)
+		return tx.Where("todo_id IN (?)", owned)
+	}
+}
+
+// 変更履歴を操作したユーザーを読み込むスコープ
+func preloadHistoryUser(tx *gorm.DB) *gorm.DB {
+	return tx.Preload("User")
+}
@@ -0,0 +1,219 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *todoRepositoryTestSuite) TestTodoHistory() {
+
+	// テスト用DBに接続する
+	db, err := s.openDB()
+	if err != nil {
+		s.Failf("database connection is not established", "%v", err)
+	}
+
+	defer s.Close(db)
+
+	// 初期処理
+	sqlHandler := testHandler{conn: db}
+	historyRepository := NewTodoHistoryRepository(&sqlHandler)
+	todoRepository := NewTodoRepository(&sqlHandler)
+	f := s.createProjectFixture(s.T(), db)
+
+	// 操作順を確定させるため、作成日時をずらす
+	base := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	newHistory := func(key string, minutes int, action models.HistoryAction, field string) models.TodoHistory {
+		history := models.NewTodoHistory(f.todos[key].ID, f.todos[key].UserID, action)
+		history.Field = field
+		history.CreatedAt = base.Add(time.Duration(minutes) * time.Minute)
+		return history
+	}
+	if err := createHistories(db, []models.TodoHistory{
+		newHistory("todo1", 0, models.HistoryCreated, ""),
+		newHistory("todo1", 1, models.HistoryUpdated, models.HistoryFieldTitle),
+		newHistory("todo1", 1, models.HistoryUpdated, models.HistoryFieldStatus),
+		newHistory("todo2", 0, models.HistoryCreated, ""),
+		newHistory("other", 0, models.HistoryCreated, ""),
+	}); err != nil {
+		s.FailNowf("Creation is failed.", "%v", err)
+	}
+
+	fields := func(histories []models.TodoHistory) []string {
+		got := []string{}
+		for _, history := range histories {
+			got = append(got, string(history.Action)+":"+history.Field)
+		}
+		return got
+	}
+
+	s.T().Run("正常ケース:変更履歴を新しい順で取得する", func(t *testing.T) {
+		histories, err := historyRepository.FindByTodo(f.user.ID, f.todos["todo1"].ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"updated:status", "updated:title", "created:"}, fields(*histories))
+			assert.Equal(t, f.user.Email, (*histories)[0].User.Email)
+		}
+	})
+
+	s.T().Run("正常ケース:todoの取得時に変更履歴が新しい順で読み込まれる", func(t *testing.T) {
+		todo, err := todoRepository.FindById(f.user.ID, f.todos["todo1"].ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"updated:status", "updated:title", "created:"}, fields(todo.Histories))
+		}
+	})
+
+	s.T().Run("正常ケース:他のユーザーのtodoの変更履歴は取得できない", func(t *testing.T) {
+		histories, err := historyRepository.FindByTodo(f.user.ID, f.todos["other"].ID)
+		if assert.NoError(t, err) {
+			assert.Empty(t, *histories)
+		}
+	})
+
+	s.T().Run("正常ケース:保存する履歴が無い場合は何もしない", func(t *testing.T) {
+		assert.NoError(t, createHistories(db, nil))
+	})
+
+	s.T().Run("正常ケース:作成したtodoと変更履歴を同じトランザクションで保存する", func(t *testing.T) {
+		todo := &models.Todo{UserID: f.user.ID, ProjectID: f.projects["Inbox"].ID, Title: "created", Status: models.NotStarted}
+		if assert.NoError(t, todoRepository.Create(todo, models.NewTodoHistory(0, f.user.ID, models.HistoryCreated))) {
+			histories, err := historyRepository.FindByTodo(f.user.ID, todo.ID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, []string{"created:"}, fields(*histories))
+			}
+		}
+	})
+
+	s.T().Run("異常ケース:変更履歴を保存できない場合はtodoも作成しない", func(t *testing.T) {
+		// 存在しないユーザーの履歴は、外部キーの制約で保存できない
+		todo := &models.Todo{UserID: f.user.ID, ProjectID: f.projects["Inbox"].ID, Title: "rolled back", Status: models.NotStarted}
+		err := todoRepository.Create(todo, models.NewTodoHistory(0, f.other.ID+100, models.HistoryCreated))
+		assert.Error(t, err)
+		var count int64
+		if result := db.Model(&models.Todo{}).Where("title = ?", "rolled back").Count(&count); assert.NoError(t, result.Error) {
+			assert.Equal(t, int64(0), count)
+		}
+	})
+
+	s.T().Run("正常ケース:更新と次の繰り返しのtodoを、変更履歴とともに同じトランザクションで保存する", func(t *testing.T) {
+		todo, err := todoRepository.FindById(f.user.ID, f.todos["todo3"].ID)
+		if err != nil {
+			t.Fatalf("can't get todo. error: %v", err)
+		}
+		changed := *todo
+		changed.Status = models.Done
+		changes := models.TodoChanges(todo, &changed, f.user.ID)
+		next := &models.Todo{UserID: f.user.ID, ProjectID: todo.ProjectID, Title: "todo3", Status: models.NotStarted}
+
+		if assert.NoError(t, todoRepository.Update(&changed, changes, next)) {
+			histories, err := historyRepository.FindByTodo(f.user.ID, todo.ID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, []string{"updated:status"}, fields(*histories))
+			}
+			histories, err = historyRepository.FindByTodo(f.user.ID, next.ID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, []string{"created:"}, fields(*histories))
+			}
+		}
+	})
+
+	s.T().Run("異常ケース:変更履歴を保存できない場合は更新を取り消す", func(t *testing.T) {
+		todo, err := todoRepository.FindById(f.user.ID, f.todos["todo1"].ID)
+		if err != nil {
+			t.Fatalf("can't get todo. error: %v", err)
+		}
+		changed := *todo
+		changed.Title = "rolled back"
+		history := models.NewTodoHistory(todo.ID, f.other.ID+100, models.HistoryUpdated)
+
+		assert.Error(t, todoRepository.Update(&changed, []models.TodoHistory{history}, nil))
+		after, err := todoRepository.FindById(f.user.ID, todo.ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, todo.Title, after.Title)
+			assert.Equal(t, todo.Version, after.Version)
+		}
+	})
+
+	s.T().Run("正常ケース:タグの作成・付け替えと変更履歴を同じトランザクションで保存する", func(t *testing.T) {
+		todo, err := todoRepository.FindById(f.user.ID, f.todos["todo1"].ID)
+		if err != nil {
+			t.Fatalf("can't get todo. error: %v", err)
+		}
+		tags := []models.Tag{{UserID: f.user.ID, Name: "new tag"}}
+		changes := models.TagChanges(todo.ID, todo.Tags, tags, f.user.ID)
+
+		if assert.NoError(t, todoRepository.ReplaceTags(todo, tags, changes...)) {
+			after, err := todoRepository.FindById(f.user.ID, todo.ID)
+			if assert.NoError(t, err) && assert.Len(t, after.Tags, 1) {
+				assert.Equal(t, "new tag", after.Tags[0].Name)
+				assert.NotZero(t, after.Tags[0].ID)
+			}
+			histories, err := historyRepository.FindByTodo(f.user.ID, todo.ID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, "updated:tags", fields(*histories)[0])
+				assert.Equal(t, "new tag", (*histories)[0].NewValue)
+			}
+		}
+	})
+
+	s.T().Run("異常ケース:変更履歴を保存できない場合はタグの作成・付け替えを取り消す", func(t *testing.T) {
+		todo, err := todoRepository.FindById(f.user.ID, f.todos["todo1"].ID)
+		if err != nil {
+			t.Fatalf("can't get todo. error: %v", err)
+		}
+		tags := []models.Tag{{UserID: f.user.ID, Name: "rolled back"}}
+		history := models.NewTodoHistory(todo.ID, f.other.ID+100, models.HistoryUpdated)
+
+		assert.Error(t, todoRepository.ReplaceTags(todo, tags, history))
+		after, err := todoRepository.FindById(f.user.ID, todo.ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, todo.Tags, after.Tags)
+		}
+		var count int64
+		if result := db.Model(&models.Tag{}).Where("name = ?", "rolled back").Count(&count); assert.NoError(t, result.Error) {
+			assert.Equal(t, int64(0), count)
+		}
+	})
+
+	s.T().Run("正常ケース:ゴミ箱への移動・元に戻す操作を変更履歴とともに保存する", func(t *testing.T) {
+		id := f.todos["todo3"].ID
+		if err := todoRepository.Delete(f.user.ID, id, models.NewTodoHistory(id, f.user.ID, models.HistoryDeleted)); err != nil {
+			t.Fatalf("Deletion is failed. error: %v", err)
+		}
+		if err := todoRepository.Restore(f.user.ID, id, models.NewTodoHistory(id, f.user.ID, models.HistoryRestored)); err != nil {
+			t.Fatalf("Restore is failed. error: %v", err)
+		}
+		histories, err := historyRepository.FindByTodo(f.user.ID, id)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"restored:", "deleted:", "updated:status"}, fields(*histories))
+		}
+	})
+
+	s.T().Run("正常ケース:ゴミ箱のtodoの変更履歴は取得できない", func(t *testing.T) {
+		if err := todoRepository.Delete(f.user.ID, f.todos["todo2"].ID); err != nil {
+			t.Fatalf("Deletion is failed. error: %v", err)
+		}
+		histories, err := historyRepository.FindByTodo(f.user.ID, f.todos["todo2"].ID)
+		if assert.NoError(t, err) {
+			assert.Empty(t, *histories)
+		}
+	})
+
+	s.T().Run("正常ケース:todoを完全に削除しても変更履歴は残り、完全に削除したことも記録する", func(t *testing.T) {
+		id := f.todos["todo2"].ID
+		if err := todoRepository.Purge(f.user.ID, id, models.NewTodoHistory(id, f.user.ID, models.HistoryPurged)); err != nil {
+			t.Fatalf("Purge is failed. error: %v", err)
+		}
+		var actions []string
+		if result := db.Model(&models.TodoHistory{}).Where("todo_id = ?", id).Order("id").Pluck("action", &actions); assert.NoError(t, result.Error) {
+			assert.Equal(t, []string{string(models.HistoryCreated), string(models.HistoryPurged)}, actions)
+		}
+		// 存在しないtodoの変更履歴として、取得はできない
+		histories, err := historyRepository.FindByTodo(f.user.ID, f.todos["todo2"].ID)
+		if assert.NoError(t, err) {
+			assert.Empty(t, *histories)
+		}
+	})
+}
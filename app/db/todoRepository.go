@@ -1,10 +1,16 @@
 package db
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
 	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // todoモデルのDB処理を担うリポジトリの構造体
@@ -18,50 +24,532 @@ func NewTodoRepository(sqlHandler SqlHandler) repository.TodoRepository {
 	return &todoRepository
 }
 
-// todoの一覧を返す
-func (tr *todoRepository) FindAll() (*[]models.Todo, error) {
+// 指定されたユーザーのtodoの一覧を返す
+func (tr *todoRepository) FindAll(userID uint) (*[]models.Todo, error) {
 	var todos []models.Todo
-	result := tr.handler.GetConnection().Find(&todos)
+	result := tr.handler.GetConnection().Scopes(preloadTags, preloadChecklist).Where("user_id = ?", userID).Find(&todos)
 	return &todos, result.Error
 }
 
-// 指定されたIDのtodoを検索して結果を返す
-func (tr *todoRepository) FindById(id uint) (*models.Todo, error) {
+// 指定されたユーザーの、指定されたIDのtodoを検索して結果を返す
+// 他のユーザーのtodoは存在しないものとして扱う
+func (tr *todoRepository) FindById(userID uint, id uint) (*models.Todo, error) {
+	return findTodo(tr.handler.GetConnection().Scopes(preloadTags, preloadChecklist, preloadComments, preloadAttachments, preloadHistories), userID, id)
+}
+
+// 指定されたユーザーの、指定されたIDのtodoを、一覧と同じくタグ・チェックリストのみ読み込んで返す
+// コメント・添付ファイル・変更履歴は読み込まないため、存在の確認やバージョンの比較など、詳細を表示しない処理で使用する
+func (tr *todoRepository) FindSummaryById(userID uint, id uint) (*models.Todo, error) {
+	return findTodo(tr.handler.GetConnection().Scopes(preloadTags, preloadChecklist), userID, id)
+}
+
+// 指定されたユーザーの、指定されたIDのtodoを検索する
+// 関連はconnのスコープで読み込む（存否チェックのみの場合は関連を読み込まない）
+func findTodo(conn *gorm.DB, userID uint, id uint) (*models.Todo, error) {
 	var todo models.Todo
-	result := tr.handler.GetConnection().Where("id = ?", id).First(&todo)
+	result := conn.Where("user_id = ? AND id = ?", userID, id).First(&todo)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return &todo, nil
 }
 
-// 渡されたtodoを新規作成して保存する
-func (tr *todoRepository) Create(todo *models.Todo) error {
-	result := tr.handler.GetConnection().Create(todo)
-	return result.Error
+// 指定されたユーザーの、指定されたIDのtodoの一覧をタグ・チェックリストとともに返す
+// 他のユーザーのtodoと存在しないIDは結果に含めない（並び順は不定）
+func (tr *todoRepository) FindByIds(userID uint, ids []uint) (*[]models.Todo, error) {
+	var todos []models.Todo
+	result := tr.handler.GetConnection().Scopes(preloadTags, preloadChecklist).Where("user_id = ? AND id IN ?", userID, ids).Find(&todos)
+	return &todos, result.Error
+}
+
+// 検索条件に一致するtodoのうち、指定されたページの分と、全体の件数を返す
+// SQLiteは日時を文字列として比較するため、保存時と同じくUTCに揃えて検索する
+func (tr *todoRepository) Search(query models.TodoQuery) (*[]models.Todo, int64, error) {
+	query = query.Normalize()
+	conn := tr.handler.GetConnection()
+
+	var total int64
+	if result := conn.Model(&models.Todo{}).Scopes(todoFilter(query)).Count(&total); result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	var todos []models.Todo
+	result := conn.Scopes(todoFilter(query), preloadTags, preloadChecklist).
+		Order(todoOrder(query)).
+		Limit(query.Limit).
+		Offset(query.Offset()).
+		Find(&todos)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return &todos, total, nil
+}
+
+// 検索条件による絞り込みを行うスコープを返す
+func todoFilter(query models.TodoQuery) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Where("user_id = ?", query.UserID)
+		if query.ProjectID != 0 {
+			tx = tx.Where("project_id = ?", query.ProjectID)
+		}
+		for _, word := range query.Keywords() {
+			// MySQLとSQLiteで既定のエスケープ文字が異なるため、明示的に指定する
+			pattern := "%" + likeEscaper.Replace(word) + "%"
+			tx = tx.Where("(title LIKE ? ESCAPE '!' OR description LIKE ? ESCAPE '!')", pattern, pattern)
+		}
+		if len(query.Statuses) > 0 {
+			tx = tx.Where("status IN ?", query.Statuses)
+		}
+		for _, name := range query.Tags {
+			tagged := tx.Session(&gorm.Session{NewDB: true}).
+				Table("todo_tags").
+				Select("todo_tags.todo_id").
+				Joins("JOIN tags ON tags.id = todo_tags.tag_id").
+				Where("tags.user_id = ? AND tags.name = ?", query.UserID, name)
+			tx = tx.Where("id IN (?)", tagged)
+		}
+		if query.DueFrom != nil {
+			tx = tx.Where("due_at >= ?", query.DueFrom.UTC())
+		}
+		if query.DueTo != nil {
+			tx = tx.Where("due_at < ?", query.DueTo.UTC())
+		}
+		if query.OverdueAt != nil {
+			// 終日の期限はその日の終わり（翌日の0時）までとして判定する
+			// 夏時間の切り替え日はモデルのIsOverdueと最大1時間ずれる
+			at := query.OverdueAt.UTC()
+			tx = tx.Where("(due_has_time = ? AND due_at <= ?) OR (due_has_time = ? AND due_at <= ?)",
+				true, at, false, at.AddDate(0, 0, -1))
+		}
+		return tx
+	}
+}
+
+// 付いているタグを名前順で読み込むスコープ
+func preloadTags(tx *gorm.DB) *gorm.DB {
+	return tx.Preload("Tags", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("tags.name")
+	})
+}
+
+// チェックリストの項目を並び順で読み込むスコープ
+func preloadChecklist(tx *gorm.DB) *gorm.DB {
+	return tx.Preload("ChecklistItems", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("checklist_items.position, checklist_items.id")
+	})
+}
+
+// todoのコメントを、投稿したユーザーとともに投稿順で読み込むスコープ
+// 件数が多くなりうるため、一覧では読み込まない
+func preloadComments(tx *gorm.DB) *gorm.DB {
+	return tx.Preload("Comments", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("comments.created_at, comments.id")
+	}).Preload("Comments.User")
+}
+
+// todoの変更履歴を、操作したユーザーとともに新しい順で読み込むスコープ
+// 件数が多くなりうるため、一覧では読み込まない
+func preloadHistories(tx *gorm.DB) *gorm.DB {
+	return tx.Preload("Histories", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("todo_histories.created_at DESC, todo_histories.id DESC")
+	}).Preload("Histories.User")
+}
+
+// todoの添付ファイルの情報を添付順で読み込むスコープ
+func preloadAttachments(tx *gorm.DB) *gorm.DB {
+	return tx.Preload("Attachments", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("attachments.created_at, attachments.id")
+	})
+}
+
+// 並び替えのキー
+type orderKey struct {
+	// ORDER BYの式（方向を除く）
+	expr string
+	// 並び替えの方向によらず昇順で並べるか
+	fixed bool
+}
+
+// 並び替えの方向によらず昇順で並べるキーを返す
+func (k orderKey) ascending() orderKey {
+	k.fixed = true
+	return k
+}
+
+var (
+	// 状態・優先度の数値は一覧での並び順と一致しないため、定義の順序に変換して並べる
+	statusKey   = orderKey{expr: definitionOrder("status", models.Statuses())}
+	priorityKey = orderKey{expr: definitionOrder("priority", models.Priorities())}
+	// 期限が無いものは、並び替えの方向によらず末尾に並べる
+	dueMissingKey = orderKey{expr: "due_at IS NULL", fixed: true}
+	dueKey        = orderKey{expr: "due_at"}
+	idKey         = orderKey{expr: "id"}
+)
+
+// 並び替えの項目ごとのキーの一覧
+// 先頭のキーから順に比較し、同じ値の場合は次のキーで並べる
+var todoSortKeys = map[models.TodoSort][]orderKey{
+	// 状態ごとに、優先度の高い順・期限の近い順に並べる
+	models.SortByStatus:   {statusKey, priorityKey.ascending(), dueMissingKey, dueKey.ascending(), idKey.ascending()},
+	models.SortByPriority: {priorityKey, dueMissingKey, dueKey.ascending(), idKey.ascending()},
+	models.SortByDue:      {dueMissingKey, dueKey, idKey},
+	models.SortByCreated:  {{expr: "created_at"}, idKey},
+	models.SortByUpdated:  {{expr: "updated_at"}, idKey},
+	models.SortByTitle:    {{expr: "title"}, idKey},
+	models.SortByPosition: {statusKey, {expr: "position"}, idKey},
+}
+
+// 検索条件の並び替えをSQLのORDER BY句に変換する
+// 同じ値の場合の順序が変わらないよう、最後にIDで並べる
+func todoOrder(query models.TodoQuery) string {
+	dir := "ASC"
+	if query.Direction == models.Desc {
+		dir = "DESC"
+	}
+
+	keys := todoSortKeys[query.Sort]
+	if keys == nil {
+		keys = todoSortKeys[models.SortByStatus]
+	}
+	clauses := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k.fixed {
+			clauses = append(clauses, k.expr+" ASC")
+		} else {
+			clauses = append(clauses, k.expr+" "+dir)
+		}
+	}
+	return strings.Join(clauses, ", ")
+}
+
+// 定義の順序で並べるための式を返す
+// valuesは一覧での並び順で渡す
+func definitionOrder[T ~int](column string, values []T) string {
+	var b strings.Builder
+	b.WriteString("CASE " + column)
+	for i, v := range values {
+		fmt.Fprintf(&b, " WHEN %d THEN %d", v, i)
+	}
+	b.WriteString(" END")
+	return b.String()
+}
+
+// LIKE検索で特殊な意味を持つ文字のエスケープ
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// 渡されたtodoを新規作成し、変更履歴とともに1つのトランザクションで保存する
+// 並び順が未設定の場合は、所有者のtodoの末尾に並べる
+// 変更履歴のTodoIDには、作成したtodoのIDを設定する
+// タグ・チェックリストは各リポジトリで保存するため、関連は保存しない
+func (tr *todoRepository) Create(todo *models.Todo, histories ...models.TodoHistory) error {
+	return tr.handler.GetConnection().Transaction(func(tx *gorm.DB) error {
+		if err := createTodo(tx, todo); err != nil {
+			return err
+		}
+		for i := range histories {
+			histories[i].TodoID = todo.ID
+		}
+		return createHistories(tx, histories)
+	})
+}
+
+// 末尾に並べる際に、並び順が他の追加と重複した場合に試行する回数
+const createTodoAttempts = 3
+
+// トランザクションの中で、渡されたtodoを新規作成する（Createを参照）
+// 同時に追加されて末尾の並び順が重複した場合は、末尾を求め直して再試行する
+func createTodo(tx *gorm.DB, todo *models.Todo) error {
+	if todo.Position != "" {
+		return tx.Omit(clause.Associations).Create(todo).Error
+	}
+	var err error
+	for range createTodoAttempts {
+		todo.Position, err = lastPosition(tx, todo.UserID)
+		if err != nil {
+			return err
+		}
+		err = tx.Omit(clause.Associations).Create(todo).Error
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return err
+		}
+	}
+	todo.Position = ""
+	return err
+}
+
+// 指定されたユーザーのtodoの末尾に並ぶ値を返す
+// 削除済みのtodoと並び順が重ならないよう、削除済みのものも含めて末尾を求める
+// MySQLでは最新の値を読むよう、ロックして取得する（SQLiteでは書き込みが直列化されるため無視される）
+func lastPosition(tx *gorm.DB, userID uint) (string, error) {
+	var last string
+	result := tx.Unscoped().Model(&models.Todo{}).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(MAX(position), '')").
+		Scan(&last)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return models.RankBetween(last, "")
+}
+
+// 渡されたtodoのデータを更新し、変更履歴とともに1つのトランザクションで保存する
+// nextを指定した場合は、次の繰り返しのtodoを同じトランザクションで作成し、タグと作成の履歴も保存する
+func (tr *todoRepository) Update(todo *models.Todo, histories []models.TodoHistory, next *models.Todo) error {
+	// 存在しないIDの場合は競合と区別できないため、
+	// 存否チェックをする（他のユーザーのtodoは更新できない）
+	_, err := findTodo(tr.handler.GetConnection(), todo.UserID, todo.ID)
+	if err != nil {
+		return err
+	}
+	// 一括操作の1件と同じ手順で保存する
+	change := &models.TodoBulkChange{Todo: todo, Update: true, Histories: histories, Next: next}
+	return tr.handler.GetConnection().Transaction(func(tx *gorm.DB) error {
+		return applyBulkChange(tx, &models.TodoBulk{UserID: todo.UserID}, change)
+	})
 }
 
-// 渡されたtodoのデータを更新する
-func (tr *todoRepository) Update(todo *models.Todo) error {
-	// Saveメソッドだと、存在しないIDの場合はCreate動作になるため、
-	// 存否チェックをする
-	_, err := tr.FindById(todo.ID)
+// 渡されたtodoの並び順以外の全ての項目を更新する
+// 取得時のバージョンが保存されている値と一致する場合のみ更新し、バージョンを1増やす
+// 一致しない場合はバージョンを戻し、models.ErrTodoConflictを返す
+// 並び順はUpdatePositionでのみ変更するため、取得後に並び替えられていても元に戻さない
+func updateTodo(tx *gorm.DB, todo *models.Todo) error {
+	// Saveは更新件数が0の場合にCreate動作になるため、Updatesで全ての項目を更新する
+	// （更新日時とバージョンは必ず変わるため、MySQLでも更新件数で競合を判定できる）
+	version := todo.Version
+	todo.Version++
+	result := tx.Model(todo).Select("*").Omit(clause.Associations, "position").
+		Where("version = ?", version).
+		Updates(todo)
+	if result.Error != nil {
+		todo.Version = version
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		todo.Version = version
+		return models.ErrTodoConflict
+	}
+	return nil
+}
+
+// 指定されたユーザーの、指定されたIDのtodoの並び順のみを更新する
+// 並び替えは内容の変更ではないため、更新日時は変えない
+func (tr *todoRepository) UpdatePosition(userID uint, id uint, position string) error {
+	// 存在しないIDの場合でもエラーは出ないため、存否チェックをする
+	// （MySQLでは値が変わらない場合に更新件数が0になるため、更新件数では判定しない）
+	_, err := findTodo(tr.handler.GetConnection(), userID, id)
 	if err != nil {
 		return err
 	}
-	result := tr.handler.GetConnection().Save(todo)
+	result := tr.handler.GetConnection().Model(&models.Todo{}).
+		Where("user_id = ? AND id = ?", userID, id).
+		UpdateColumn("position", position)
 	return result.Error
 }
 
-// 指定されたIDのtodoを削除する
-func (tr *todoRepository) Delete(id uint) error {
+// 指定されたユーザーの、指定されたIDのtodoを削除し（ゴミ箱に移す）、変更履歴とともに1つのトランザクションで保存する
+// 元に戻せるよう、コメント・添付ファイルなどは残す（削除済みのtodoのものは取得できない）
+func (tr *todoRepository) Delete(userID uint, id uint, histories ...models.TodoHistory) error {
 	// 存在しないIDの場合でもエラーは出ないようなので、存否チェックをする
-	_, err := tr.FindById(id)
+	_, err := findTodo(tr.handler.GetConnection(), userID, id)
 	if err != nil {
 		return err
 	}
-	result := tr.handler.GetConnection().Delete(&models.Todo{}, id)
-	return result.Error
+	return tr.handler.GetConnection().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.Todo{}, id).Error; err != nil {
+			return err
+		}
+		return createHistories(tx, histories)
+	})
+}
+
+// 指定されたユーザーのゴミ箱のtodoの一覧を、削除した日時の新しい順で返す
+func (tr *todoRepository) FindTrash(userID uint) (*[]models.Todo, error) {
+	var todos []models.Todo
+	result := tr.handler.GetConnection().Unscoped().
+		Scopes(preloadTags).
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC, id DESC").
+		Find(&todos)
+	return &todos, result.Error
+}
+
+// 指定されたユーザーのゴミ箱にある、指定されたIDのtodoを添付ファイルの情報とともに返す
+// 削除されていないtodoは存在しないものとして扱う
+func (tr *todoRepository) FindTrashedById(userID uint, id uint) (*models.Todo, error) {
+	var todo models.Todo
+	result := tr.handler.GetConnection().Unscoped().
+		Scopes(preloadAttachments).
+		Where("user_id = ? AND id = ? AND deleted_at IS NOT NULL", userID, id).
+		First(&todo)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &todo, nil
+}
+
+// 全てのユーザーのゴミ箱のtodoのうち、指定された日時より前に削除されたものを添付ファイルの情報とともに返す
+// ゴミ箱の定期的な削除に使用する
+func (tr *todoRepository) FindTrashedBefore(before time.Time) (*[]models.Todo, error) {
+	var todos []models.Todo
+	result := tr.handler.GetConnection().Unscoped().
+		Scopes(preloadAttachments).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before.UTC()).
+		Order("deleted_at, id").
+		Find(&todos)
+	return &todos, result.Error
+}
+
+// 指定されたユーザーのゴミ箱にある、指定されたIDのtodoを元に戻し、変更履歴とともに1つのトランザクションで保存する
+// 削除中に変更されたわけではないため、更新日時は変えない
+func (tr *todoRepository) Restore(userID uint, id uint, histories ...models.TodoHistory) error {
+	if _, err := tr.FindTrashedById(userID, id); err != nil {
+		return err
+	}
+	return tr.handler.GetConnection().Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&models.Todo{}).
+			Where("user_id = ? AND id = ?", userID, id).
+			UpdateColumn("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		return createHistories(tx, histories)
+	})
+}
+
+// 指定されたユーザーのゴミ箱にある、指定されたIDのtodoを完全に削除し、変更履歴とともに1つのトランザクションで保存する
+// コメント・添付ファイルの情報・チェックリスト・タグの関連も削除する
+// 変更履歴は、完全に削除した後も操作の記録として残す
+// 添付ファイルの内容はユースケースでストレージから削除する
+func (tr *todoRepository) Purge(userID uint, id uint, histories ...models.TodoHistory) error {
+	if _, err := tr.FindTrashedById(userID, id); err != nil {
+		return err
+	}
+	return tr.handler.GetConnection().Transaction(func(tx *gorm.DB) error {
+		for _, model := range []any{&models.Comment{}, &models.Attachment{}, &models.ChecklistItem{}} {
+			if err := tx.Where("todo_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Table("todo_tags").Where("todo_id = ?", id).Delete(nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(&models.Todo{}, id).Error; err != nil {
+			return err
+		}
+		return createHistories(tx, histories)
+	})
+}
+
+// 渡されたtodoに付けるタグを置き換え、変更履歴とともに1つのトランザクションで保存する
+// IDが0のタグは新規作成する（todo・タグの所有者はユースケースで確認する）
+func (tr *todoRepository) ReplaceTags(todo *models.Todo, tags []models.Tag, histories ...models.TodoHistory) error {
+	if tags == nil {
+		// nilの場合は一括操作でタグを変更しないため、空のスライスにして全て外す
+		tags = []models.Tag{}
+	}
+	// 1件のtodoの一括操作と同じ手順で保存する
+	bulk := &models.TodoBulk{
+		UserID:  todo.UserID,
+		Tags:    tags,
+		Changes: []models.TodoBulkChange{{Todo: todo, Histories: histories}},
+		Atomic:  true,
+	}
+	_, err := tr.Bulk(bulk)
+	return err
+}
+
+// 一括操作の変更を1つのトランザクションで保存する
+// todoごとの結果をChangesと同じ順で返す（成功したものはnil）
+// Atomicの場合は1件でも失敗したら全ての変更を取り消し、失敗したtodoのエラーも返す
+// それ以外の場合はtodoごとにセーブポイントを設け、失敗したtodoの変更のみ取り消す
+// タグの作成や確定に失敗した場合は、全ての変更を取り消してエラーを返す
+func (tr *todoRepository) Bulk(bulk *models.TodoBulk) ([]error, error) {
+	errs := make([]error, len(bulk.Changes))
+	err := tr.handler.GetConnection().Transaction(func(tx *gorm.DB) error {
+		for i := range bulk.Tags {
+			if bulk.Tags[i].ID != 0 {
+				continue
+			}
+			if err := tx.Create(&bulk.Tags[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range bulk.Changes {
+			// 入れ子のトランザクションはセーブポイントになり、失敗した場合はそのtodoの変更のみ取り消される
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				return applyBulkChange(tx, bulk, &bulk.Changes[i])
+			})
+			if err == nil {
+				continue
+			}
+			errs[i] = err
+			if bulk.Atomic {
+				return err
+			}
+		}
+		return nil
+	})
+	return errs, err
+}
+
+// トランザクションの中で、一括操作の1件のtodoの変更を保存する
+func applyBulkChange(tx *gorm.DB, bulk *models.TodoBulk, change *models.TodoBulkChange) error {
+	todo := change.Todo
+	if change.Update {
+		if err := updateTodo(tx, todo); err != nil {
+			return err
+		}
+	}
+	if change.Delete {
+		result := tx.Where("user_id = ?", bulk.UserID).Delete(&models.Todo{}, todo.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errNotFound
+		}
+	}
+	if bulk.Tags != nil {
+		if err := tx.Where("todo_id = ?", todo.ID).Delete(&todoTag{}).Error; err != nil {
+			return err
+		}
+		if err := attachTags(tx, todo.ID, bulk.Tags); err != nil {
+			return err
+		}
+	}
+	histories := change.Histories
+	if next := change.Next; next != nil {
+		if err := createTodo(tx, next); err != nil {
+			return err
+		}
+		if err := attachTags(tx, next.ID, next.Tags); err != nil {
+			return err
+		}
+		histories = append(histories, models.NewTodoHistory(next.ID, next.UserID, models.HistoryCreated))
+	}
+	return createHistories(tx, histories)
+}
+
+// トランザクションの中で、変更履歴をまとめて保存する（履歴が無い場合は何もしない）
+// todoの所有者はユースケースで確認する
+func createHistories(tx *gorm.DB, histories []models.TodoHistory) error {
+	if len(histories) == 0 {
+		return nil
+	}
+	return tx.Omit(clause.Associations).Create(&histories).Error
+}
+
+// トランザクションの中で、todoにタグを付ける（既に付いているタグは無視する）
+func attachTags(tx *gorm.DB, todoID uint, tags []models.Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	rows := make([]todoTag, 0, len(tags))
+	for _, tag := range tags {
+		rows = append(rows, todoTag{TodoID: todoID, TagID: tag.ID})
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
 }
 
 // todoRepositoryの終了処理
@@ -2,14 +2,18 @@ package db
 
 import (
 	"bytes"
+	"database/sql"
 	"errors"
-	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-txdb"
+	"github.com/MinadukiSekina/todo-go-app/app/config"
 	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/glebarez/sqlite"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -32,9 +36,21 @@ func (th *testHandler) Close() error {
 	return nil
 }
 
+// テストで使用するtodoの所有者
+const (
+	testUserID  uint = 1
+	otherUserID uint = 2
+)
+
 // テストスイートの構造体
 type todoRepositoryTestSuite struct {
 	suite.Suite
+	// テストに使用するストレージの種類
+	driver string
+	// SQLiteのファイルを保存する一時ディレクトリ
+	tempDir string
+	// メモリ上のDBを破棄しないよう、テストの終了まで開いておく接続
+	memoryDB *sql.DB
 }
 
 // テストスイートを実行する
@@ -44,28 +60,58 @@ func TestTodoRepositoryTestSuite(t *testing.T) {
 
 // テストスイートの実行前に処理される
 func (s *todoRepositoryTestSuite) SetupSuite() {
-	// db.envに定義したDB関係の環境変数を取得
-	dbUser := os.Getenv("MYSQL_USER")
-	dbPassword := os.Getenv("MYSQL_PASSWORD")
-	dbName := os.Getenv("TEST_DATABASE")
-	// tcp（）の中にdocker-composeで定義したDB用コンテナのサービス名を入れれば、
-	// 自動的にホストとポートを読み取ってくれる
-	dsn := fmt.Sprintf(
-		"%s:%s@tcp(db)/%s?charset=utf8mb4&parseTime=true&loc=Local",
-		dbUser,
-		dbPassword,
-		dbName,
-	)
-
-	// txdbに登録する
-	txdb.Register("txdb", "mysql", dsn)
+	// 環境変数TEST_DB_DRIVERでテストに使用するストレージを切り替える
+	// MySQLのコンテナが無い環境でも実行できるよう、未指定の場合はSQLiteを使用する
+	s.driver = os.Getenv("TEST_DB_DRIVER")
+	if s.driver == "" {
+		s.driver = DriverSQLite
+	}
+
+	var dialector gorm.Dialector
+	switch s.driver {
+	case DriverMySQL:
+		// db.envに定義したDB関係の環境変数を取得
+		cfg := config.Default().DB
+		cfg.User = os.Getenv("MYSQL_USER")
+		cfg.Password = os.Getenv("MYSQL_PASSWORD")
+		cfg.Name = os.Getenv("TEST_DATABASE")
+		dsn := MySQLDSN(cfg)
+
+		// txdbに登録する
+		txdb.Register("txdb", "mysql", dsn)
+		dialector = mysql.Open(dsn)
+	case DriverSQLite:
+		dir, err := os.MkdirTemp("", "todo-go-app-test")
+		if err != nil {
+			s.FailNowf("failed to create temp dir", "%v", err)
+		}
+		s.tempDir = dir
+		dsn := SQLiteDSN(filepath.Join(dir, "test.db"))
+
+		// txdbに登録する
+		txdb.Register("txdb", "sqlite", dsn)
+		dialector = sqlite.Open(dsn)
+	case DriverMemory:
+		// 他のテストのDBと共有しないよう、実行ごとに一意の名前を付ける
+		dsn := MemoryDSN(uuid.NewString())
+
+		// txdbに登録する
+		txdb.Register("txdb", "sqlite", dsn)
+		dialector = sqlite.Open(dsn)
+	default:
+		s.FailNowf("unsupported driver", "TEST_DB_DRIVER=%s", s.driver)
+	}
 
 	// マイグレーションは一度だけ実行
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(dialector, NewGormConfig(s.driver))
 	if err != nil {
 		s.Failf("failed to connect to database", "%v", err)
 	}
-	if err := db.AutoMigrate(&models.Todo{}); err != nil {
+	migrator, err := NewMigrator(db, s.driver)
+	if err != nil {
+		s.FailNowf("failed to load migrations", "%v", err)
+	}
+	if _, err := migrator.Up(0); err != nil {
 		s.Failf("failed to migrate database", "%v", err)
 	}
 	sqlDB, err := db.DB()
@@ -73,10 +119,35 @@ func (s *todoRepositoryTestSuite) SetupSuite() {
 		s.Failf("failed to get underlying sql.DB", "%v", err)
 	}
 
+	// メモリ上のDBは全ての接続が閉じられると破棄されるため、テストの終了まで開いておく
+	if s.driver == DriverMemory {
+		s.memoryDB = sqlDB
+		return
+	}
+
 	// マイグレーション用の接続を閉じる
 	defer sqlDB.Close()
 }
 
+// テストスイートの実行後に処理される
+func (s *todoRepositoryTestSuite) TearDownSuite() {
+	if s.memoryDB != nil {
+		s.memoryDB.Close()
+	}
+	if s.tempDir != "" {
+		os.RemoveAll(s.tempDir)
+	}
+}
+
+// テスト用DBに接続する
+// txdbを使用するため、接続ごとにトランザクションが張られ、終了時にロールバックされる
+func (s *todoRepositoryTestSuite) openDB() (*gorm.DB, error) {
+	if s.driver == DriverMySQL {
+		return gorm.Open(mysql.New(mysql.Config{DSN: uuid.NewString(), DriverName: "txdb"}), NewGormConfig(s.driver))
+	}
+	return gorm.Open(&sqlite.Dialector{DSN: uuid.NewString(), DriverName: "txdb"}, NewGormConfig(s.driver))
+}
+
 // 各テスト終了時にDB接続を閉じる
 func (ts *todoRepositoryTestSuite) Close(db *gorm.DB) {
 	conn, err := db.DB()
@@ -89,16 +160,19 @@ func (ts *todoRepositoryTestSuite) Close(db *gorm.DB) {
 
 func (s *todoRepositoryTestSuite) TestFindAll() {
 
-	todo1 := models.Todo{Title: "test1", Status: models.NotStarted}
+	// タグを読み込むため、タグが無い場合は空のスライスになる
+	todo1 := models.Todo{UserID: testUserID, Title: "test1", Status: models.NotStarted, Tags: []models.Tag{}, ChecklistItems: []models.ChecklistItem{}}
 
-	todo2 := models.Todo{Title: "test2", Status: models.NotStarted}
+	todo2 := models.Todo{UserID: testUserID, Title: "test2", Status: models.NotStarted, Tags: []models.Tag{}, ChecklistItems: []models.ChecklistItem{}}
 
 	nothingTodos := []models.Todo{}
 	onlyOneTodos := []models.Todo{todo1}
 	manyHasTodos := []models.Todo{todo1, todo2}
 
 	cases := map[string]struct {
-		want      *[]models.Todo
+		want *[]models.Todo
+		// 他のユーザーが所有するデータ（結果に含まれない）
+		others    []models.Todo
 		expectErr bool
 		err       error
 	}{
@@ -107,6 +181,12 @@ func (s *todoRepositoryTestSuite) TestFindAll() {
 			expectErr: false,
 			err:       nil,
 		},
+		"正常ケース:他のユーザーのデータは含まない": {
+			want:      &onlyOneTodos,
+			others:    []models.Todo{{UserID: otherUserID, Title: "other", Status: models.NotStarted}},
+			expectErr: false,
+			err:       nil,
+		},
 		"正常ケース:1件データあり": {
 			want:      &onlyOneTodos,
 			expectErr: false,
@@ -121,7 +201,7 @@ func (s *todoRepositoryTestSuite) TestFindAll() {
 	for name, tt := range cases {
 		s.T().Run(name, func(t *testing.T) {
 			// テスト用DBに接続する
-			db, err := gorm.Open(mysql.New(mysql.Config{DSN: uuid.NewString(), DriverName: "txdb"}))
+			db, err := s.openDB()
 			if err != nil {
 				s.Failf("database connection is not established", "%v", err)
 			}
@@ -136,14 +216,18 @@ func (s *todoRepositoryTestSuite) TestFindAll() {
 			if len(*tt.want) > 0 {
 				// データが無いはずなので登録する
 				for i := range *tt.want {
-					result := db.Create(&(*tt.want)[i])
-					if result.Error != nil {
-						s.T().Errorf("Creation is failed. error: %v", result.Error)
+					if err := createTodo(db, &(*tt.want)[i]); err != nil {
+						s.T().Errorf("Creation is failed. error: %v", err)
 					}
 				}
 			}
+			for i := range tt.others {
+				if err := createTodo(db, &tt.others[i]); err != nil {
+					t.Errorf("Creation is failed. error: %v", err)
+				}
+			}
 
-			todos, err := todoRepository.FindAll()
+			todos, err := todoRepository.FindAll(testUserID)
 
 			// 結果を確認
 			if tt.expectErr {
@@ -162,9 +246,10 @@ func (s *todoRepositoryTestSuite) TestFindAll() {
 
 func (s *todoRepositoryTestSuite) TestFindById() {
 
-	todo1 := models.Todo{Title: "test1", Status: models.NotStarted}
-	todo2 := models.Todo{Title: "test1", Status: models.NotStarted}
+	todo1 := models.Todo{UserID: testUserID, Title: "test1", Status: models.NotStarted, Tags: []models.Tag{}, ChecklistItems: []models.ChecklistItem{}, Comments: []models.Comment{}, Attachments: []models.Attachment{}, Histories: []models.TodoHistory{}}
+	todo2 := models.Todo{UserID: testUserID, Title: "test1", Status: models.NotStarted}
 	todo2.ID = 1
+	othersTodo := models.Todo{UserID: otherUserID, Title: "other", Status: models.NotStarted}
 
 	cases := map[string]struct {
 		want      *models.Todo
@@ -181,14 +266,20 @@ func (s *todoRepositoryTestSuite) TestFindById() {
 		"異常ケース:指定したIDのデータが無い": {
 			want:      &todo2,
 			expectErr: true,
-			err:       errors.New("record not found"),
+			err:       models.NotFound(gorm.ErrRecordNotFound),
 			setup:     func(d *gorm.DB) {},
 		},
+		"異常ケース:他のユーザーのデータ": {
+			want:      &othersTodo,
+			expectErr: true,
+			err:       models.NotFound(gorm.ErrRecordNotFound),
+			setup:     func(d *gorm.DB) { _ = d.Create(&othersTodo) },
+		},
 	}
 	for name, tt := range cases {
 		s.T().Run(name, func(t *testing.T) {
 			// テスト用DBに接続する
-			db, err := gorm.Open(mysql.New(mysql.Config{DSN: uuid.NewString(), DriverName: "txdb"}))
+			db, err := s.openDB()
 			if err != nil {
 				s.Failf("database connection is not established", "%v", err)
 			}
@@ -202,7 +293,7 @@ func (s *todoRepositoryTestSuite) TestFindById() {
 			sqlHandler := testHandler{conn: db}
 			todoRepository := NewTodoRepository(&sqlHandler)
 
-			todo, err := todoRepository.FindById(tt.want.ID)
+			todo, err := todoRepository.FindById(testUserID, tt.want.ID)
 
 			// 結果を確認
 			if tt.expectErr {
@@ -219,9 +310,50 @@ func (s *todoRepositoryTestSuite) TestFindById() {
 	}
 }
 
+func (s *todoRepositoryTestSuite) TestFindSummaryById() {
+
+	// テスト用DBに接続する
+	db, err := s.openDB()
+	if err != nil {
+		s.Failf("database connection is not established", "%v", err)
+	}
+
+	defer s.Close(db)
+
+	// 初期処理
+	sqlHandler := testHandler{conn: db}
+	todoRepository := NewTodoRepository(&sqlHandler)
+	todo := models.Todo{UserID: testUserID, Title: "test", Status: models.NotStarted}
+	if err := createTodo(db, &todo); err != nil {
+		s.T().Fatalf("Creation is failed. error: %v", err)
+	}
+	if result := db.Create(&models.ChecklistItem{TodoID: todo.ID, Title: "item"}); result.Error != nil {
+		s.T().Fatalf("Creation is failed. error: %v", result.Error)
+	}
+
+	// 詳細を読み込まない場合は、コメント・添付ファイル・変更履歴がnilになる（読み込んだ場合は空のスライス）
+	s.T().Run("正常ケース:タグ・チェックリストのみ読み込む", func(t *testing.T) {
+		got, err := todoRepository.FindSummaryById(testUserID, todo.ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "test", got.Title)
+			assert.Equal(t, []models.Tag{}, got.Tags)
+			assert.Len(t, got.ChecklistItems, 1)
+			assert.Nil(t, got.Comments)
+			assert.Nil(t, got.Attachments)
+			assert.Nil(t, got.Histories)
+		}
+	})
+
+	s.T().Run("異常ケース:他のユーザーのデータ", func(t *testing.T) {
+		got, err := todoRepository.FindSummaryById(otherUserID, todo.ID)
+		assert.Equal(t, models.NotFound(gorm.ErrRecordNotFound), err)
+		assert.Nil(t, got)
+	})
+}
+
 func (s *todoRepositoryTestSuite) TestCreate() {
 
-	todo1 := models.Todo{Title: "test1", Status: models.NotStarted}
+	todo1 := models.Todo{UserID: testUserID, Title: "test1", Status: models.NotStarted, Tags: []models.Tag{}, ChecklistItems: []models.ChecklistItem{}, Comments: []models.Comment{}, Attachments: []models.Attachment{}, Histories: []models.TodoHistory{}}
 
 	cases := map[string]struct {
 		want      *models.Todo
@@ -237,7 +369,7 @@ func (s *todoRepositoryTestSuite) TestCreate() {
 	for name, tt := range cases {
 		s.T().Run(name, func(t *testing.T) {
 			// テスト用DBに接続する
-			db, err := gorm.Open(mysql.New(mysql.Config{DSN: uuid.NewString(), DriverName: "txdb"}))
+			db, err := s.openDB()
 			if err != nil {
 				s.Failf("database connection is not established", "%v", err)
 			}
@@ -257,7 +389,7 @@ func (s *todoRepositoryTestSuite) TestCreate() {
 				}
 			} else {
 				if assert.NoError(t, err) {
-					todo, err := todoRepository.FindById(tt.want.ID)
+					todo, err := todoRepository.FindById(testUserID, tt.want.ID)
 					if err != nil {
 						s.Failf("can't get todo. error: %v", err.Error())
 					}
@@ -270,9 +402,9 @@ func (s *todoRepositoryTestSuite) TestCreate() {
 
 func (s *todoRepositoryTestSuite) TestUpdate() {
 	// 更新前のデータ
-	todo1 := models.Todo{Title: "test1", Status: models.NotStarted}
+	todo1 := models.Todo{UserID: testUserID, Title: "test1", Status: models.NotStarted}
 	// 存在しないデータを更新するケース用
-	notExistTodo := models.Todo{Title: "not_exist_record", Status: models.NotStarted}
+	notExistTodo := models.Todo{UserID: testUserID, Title: "not_exist_record", Status: models.NotStarted}
 
 	cases := map[string]struct {
 		before    *models.Todo
@@ -298,7 +430,7 @@ func (s *todoRepositoryTestSuite) TestUpdate() {
 				t.Status = models.Done
 			},
 			expectErr: true,
-			err:       errors.New("record not found"),
+			err:       models.NotFound(gorm.ErrRecordNotFound),
 			setup:     func(d *gorm.DB) {}, // 何もしない
 		},
 	}
@@ -306,7 +438,7 @@ func (s *todoRepositoryTestSuite) TestUpdate() {
 	for name, tt := range cases {
 		s.T().Run(name, func(t *testing.T) {
 			// テスト用DBに接続する
-			db, err := gorm.Open(mysql.New(mysql.Config{DSN: uuid.NewString(), DriverName: "txdb"}))
+			db, err := s.openDB()
 			if err != nil {
 				s.Failf("database connection is not established", "%v", err)
 			}
@@ -321,7 +453,7 @@ func (s *todoRepositoryTestSuite) TestUpdate() {
 			todoRepository := NewTodoRepository(&sqlHandler)
 
 			// 更新対象のレコードを取得
-			todo, err := todoRepository.FindById(tt.before.ID)
+			todo, err := todoRepository.FindById(testUserID, tt.before.ID)
 
 			// 存在しないIDの場合は、直接Updateを実行する
 			if tt.expectErr {
@@ -330,7 +462,7 @@ func (s *todoRepositoryTestSuite) TestUpdate() {
 				}
 				// 存在しないIDのレコードを直接更新
 				tt.update(tt.before)
-				err = todoRepository.Update(tt.before)
+				err = todoRepository.Update(tt.before, nil, nil)
 				if assert.Error(t, err) {
 					assert.Equal(t, tt.err, err)
 				}
@@ -342,12 +474,12 @@ func (s *todoRepositoryTestSuite) TestUpdate() {
 				tt.update(todo)
 
 				// 更新処理を実行
-				err = todoRepository.Update(todo)
+				err = todoRepository.Update(todo, nil, nil)
 
 				// 結果を確認
 				if assert.NoError(t, err) {
 					// 更新後のデータを取得して確認
-					updated, err := todoRepository.FindById(todo.ID)
+					updated, err := todoRepository.FindById(testUserID, todo.ID)
 					if assert.NoError(t, err) {
 						assert.Equal(t, todo.Title, updated.Title)
 						assert.Equal(t, todo.Status, updated.Status)
@@ -358,11 +490,60 @@ func (s *todoRepositoryTestSuite) TestUpdate() {
 	}
 }
 
+func (s *todoRepositoryTestSuite) TestUpdateVersion() {
+
+	// テスト用DBに接続する
+	db, err := s.openDB()
+	if err != nil {
+		s.Failf("database connection is not established", "%v", err)
+	}
+
+	defer s.Close(db)
+
+	// 初期処理
+	sqlHandler := testHandler{conn: db}
+	todoRepository := NewTodoRepository(&sqlHandler)
+	todo := models.Todo{UserID: testUserID, Title: "test", Status: models.NotStarted}
+	if result := db.Create(&todo); result.Error != nil {
+		s.T().Fatalf("Creation is failed. error: %v", result.Error)
+	}
+
+	// 同じバージョンのtodoを2人で取得したものとする
+	mine, _ := todoRepository.FindById(testUserID, todo.ID)
+	theirs, _ := todoRepository.FindById(testUserID, todo.ID)
+
+	s.T().Run("正常ケース:更新するとバージョンが増える", func(t *testing.T) {
+		theirs.Title = "theirs"
+		if assert.NoError(t, todoRepository.Update(theirs, nil, nil)) {
+			assert.Equal(t, uint(1), theirs.Version)
+			updated, err := todoRepository.FindById(testUserID, todo.ID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, uint(1), updated.Version)
+			}
+		}
+	})
+
+	s.T().Run("異常ケース:取得後に更新されていた場合は更新しない", func(t *testing.T) {
+		mine.Title = "mine"
+		err := todoRepository.Update(mine, nil, nil)
+		assert.ErrorIs(t, err, models.ErrTodoConflict)
+		// 渡したtodoのバージョンは変えないこと
+		assert.Equal(t, uint(0), mine.Version)
+		updated, err := todoRepository.FindById(testUserID, todo.ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "theirs", updated.Title)
+			assert.Equal(t, uint(1), updated.Version)
+		}
+	})
+}
+
 func (s *todoRepositoryTestSuite) TestDelete() {
 	// 更新前のデータ
-	todo1 := models.Todo{Title: "test1", Status: models.NotStarted}
+	todo1 := models.Todo{UserID: testUserID, Title: "test1", Status: models.NotStarted}
 	// 存在しないデータを更新するケース用
-	notExistTodo := models.Todo{Title: "not_exist_record", Status: models.NotStarted}
+	notExistTodo := models.Todo{UserID: testUserID, Title: "not_exist_record", Status: models.NotStarted}
+	// 他のユーザーのデータを削除するケース用
+	othersTodo := models.Todo{UserID: otherUserID, Title: "other", Status: models.NotStarted}
 
 	cases := map[string]struct {
 		todo      *models.Todo
@@ -373,21 +554,27 @@ func (s *todoRepositoryTestSuite) TestDelete() {
 		"正常ケース:削除成功": {
 			todo:      &todo1,
 			expectErr: false,
-			err:       errors.New("record not found"),
+			err:       models.NotFound(gorm.ErrRecordNotFound),
 			setup:     func(d *gorm.DB) { _ = d.Create(&todo1) },
 		},
 		"異常ケース:存在しないIDの削除": {
 			todo:      &notExistTodo,
 			expectErr: true,
-			err:       errors.New("record not found"),
+			err:       models.NotFound(gorm.ErrRecordNotFound),
 			setup:     func(d *gorm.DB) {}, // 何もしない
 		},
+		"異常ケース:他のユーザーのデータの削除": {
+			todo:      &othersTodo,
+			expectErr: true,
+			err:       models.NotFound(gorm.ErrRecordNotFound),
+			setup:     func(d *gorm.DB) { _ = d.Create(&othersTodo) },
+		},
 	}
 
 	for name, tt := range cases {
 		s.T().Run(name, func(t *testing.T) {
 			// テスト用DBに接続する
-			db, err := gorm.Open(mysql.New(mysql.Config{DSN: uuid.NewString(), DriverName: "txdb"}))
+			db, err := s.openDB()
 			if err != nil {
 				s.Failf("database connection is not established", "%v", err)
 			}
@@ -402,7 +589,7 @@ func (s *todoRepositoryTestSuite) TestDelete() {
 			todoRepository := NewTodoRepository(&sqlHandler)
 
 			// 削除処理を実行する
-			err = todoRepository.Delete(tt.todo.ID)
+			err = todoRepository.Delete(testUserID, tt.todo.ID)
 
 			// 結果を確認する（異常系）
 			if tt.expectErr {
@@ -414,16 +601,617 @@ func (s *todoRepositoryTestSuite) TestDelete() {
 
 			// 結果を確認する（正常系）
 			if assert.NoError(t, err) {
-				todo, err := todoRepository.FindById(tt.todo.ID)
+				todo, err := todoRepository.FindById(testUserID, tt.todo.ID)
 				assert.Nil(t, todo)
 				if assert.Error(t, err) {
-					assert.Equal(t, errors.New("record not found"), err)
+					assert.Equal(t, models.NotFound(gorm.ErrRecordNotFound), err)
+				}
+			}
+		})
+	}
+}
+
+func (s *todoRepositoryTestSuite) TestTrash() {
+
+	// テスト用DBに接続する
+	db, err := s.openDB()
+	if err != nil {
+		s.Failf("database connection is not established", "%v", err)
+	}
+
+	defer s.Close(db)
+
+	// 初期処理
+	sqlHandler := testHandler{conn: db}
+	todoRepository := NewTodoRepository(&sqlHandler)
+
+	todos := map[string]*models.Todo{
+		"active":  {UserID: testUserID, Title: "active", Status: models.NotStarted},
+		"old":     {UserID: testUserID, Title: "old", Status: models.NotStarted},
+		"new":     {UserID: testUserID, Title: "new", Status: models.Done},
+		"other":   {UserID: otherUserID, Title: "other", Status: models.NotStarted},
+		"restore": {UserID: testUserID, Title: "restore", Status: models.InProgress},
+	}
+	for _, key := range []string{"active", "old", "new", "other", "restore"} {
+		if err := todoRepository.Create(todos[key]); err != nil {
+			s.FailNowf("Creation is failed.", "%v", err)
+		}
+	}
+	// 削除した日時を固定する
+	deletedAt := map[string]time.Time{
+		"old":     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		"new":     time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		"other":   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		"restore": time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for key, at := range deletedAt {
+		if err := todoRepository.Delete(todos[key].UserID, todos[key].ID); err != nil {
+			s.FailNowf("Deletion is failed.", "%v", err)
+		}
+		if err := db.Unscoped().Model(&models.Todo{}).Where("id = ?", todos[key].ID).UpdateColumn("deleted_at", at).Error; err != nil {
+			s.FailNowf("can't update deleted_at.", "%v", err)
+		}
+	}
+
+	titles := func(todos *[]models.Todo) []string {
+		got := []string{}
+		for _, todo := range *todos {
+			got = append(got, todo.Title)
+		}
+		return got
+	}
+
+	s.T().Run("正常ケース:ゴミ箱のtodoを削除した日時の新しい順で取得する", func(t *testing.T) {
+		trash, err := todoRepository.FindTrash(testUserID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"new", "restore", "old"}, titles(trash))
+		}
+	})
+
+	s.T().Run("正常ケース:ゴミ箱のtodoをIDで取得する", func(t *testing.T) {
+		todo, err := todoRepository.FindTrashedById(testUserID, todos["old"].ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "old", todo.Title)
+		}
+	})
+
+	s.T().Run("異常ケース:削除していないtodoと他のユーザーのtodoはゴミ箱から取得できない", func(t *testing.T) {
+		_, err := todoRepository.FindTrashedById(testUserID, todos["active"].ID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		_, err = todoRepository.FindTrashedById(testUserID, todos["other"].ID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	s.T().Run("正常ケース:指定した日時より前に削除した全てのユーザーのtodoを取得する", func(t *testing.T) {
+		expired, err := todoRepository.FindTrashedBefore(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+		if assert.NoError(t, err) {
+			assert.ElementsMatch(t, []string{"old", "other"}, titles(expired))
+		}
+	})
+
+	s.T().Run("正常ケース:ゴミ箱のtodoを元に戻す", func(t *testing.T) {
+		if assert.NoError(t, todoRepository.Restore(testUserID, todos["restore"].ID)) {
+			todo, err := todoRepository.FindById(testUserID, todos["restore"].ID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, models.InProgress, todo.Status)
+			}
+		}
+	})
+
+	s.T().Run("異常ケース:ゴミ箱に無いtodoは元に戻せない", func(t *testing.T) {
+		assert.ErrorIs(t, todoRepository.Restore(testUserID, todos["active"].ID), gorm.ErrRecordNotFound)
+		assert.ErrorIs(t, todoRepository.Restore(testUserID, todos["other"].ID), gorm.ErrRecordNotFound)
+	})
+
+	s.T().Run("正常ケース:ゴミ箱のtodoを完全に削除する", func(t *testing.T) {
+		if assert.NoError(t, todoRepository.Purge(testUserID, todos["old"].ID)) {
+			var count int64
+			if result := db.Unscoped().Model(&models.Todo{}).Where("id = ?", todos["old"].ID).Count(&count); assert.NoError(t, result.Error) {
+				assert.Equal(t, int64(0), count)
+			}
+		}
+	})
+
+	s.T().Run("異常ケース:ゴミ箱に無いtodoは完全に削除できない", func(t *testing.T) {
+		assert.ErrorIs(t, todoRepository.Purge(testUserID, todos["active"].ID), gorm.ErrRecordNotFound)
+		assert.ErrorIs(t, todoRepository.Purge(testUserID, todos["other"].ID), gorm.ErrRecordNotFound)
+	})
+}
+
+func (s *todoRepositoryTestSuite) TestSearch() {
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	// 期限を設定したtodoを生成する
+	newTodo := func(title string, status models.Status, date string, clock string) models.Todo {
+		todo := models.Todo{UserID: testUserID, Title: title, Status: status}
+		_ = todo.SetDue(date, clock, "Asia/Tokyo")
+		return todo
+	}
+	// タイトルのみのtodoを生成する
+	titled := func(titles ...string) []models.Todo {
+		todos := make([]models.Todo, 0, len(titles))
+		for _, title := range titles {
+			todos = append(todos, models.Todo{UserID: testUserID, Title: title, Status: models.NotStarted})
+		}
+		return todos
+	}
+
+	cases := map[string]struct {
+		todos     []models.Todo
+		query     models.TodoQuery
+		want      []string
+		wantTotal int64
+	}{
+		"正常ケース:期限切れ": {
+			todos: []models.Todo{
+				newTodo("past", models.NotStarted, "2025-03-30", "10:00"),
+				newTodo("yesterday", models.InProgress, "2025-03-31", ""),
+				newTodo("today", models.NotStarted, "2025-04-01", ""),
+				newTodo("later_today", models.NotStarted, "2025-04-01", "09:00"),
+				newTodo("done", models.Done, "2025-03-29", ""),
+				{UserID: otherUserID, Title: "other", Status: models.NotStarted, DueAt: ptrTime(time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC)), DueHasTime: true},
+				{UserID: testUserID, Title: "no_due", Status: models.NotStarted},
+			},
+			// 比較する日時のタイムゾーンが保存時と異なっても検索できること
+			query: models.TodoQuery{
+				UserID:    testUserID,
+				OverdueAt: ptrTime(time.Date(2025, 4, 1, 0, 0, 0, 0, tokyo)),
+				Statuses:  models.OpenStatuses(),
+				Sort:      models.SortByDue,
+			},
+			want:      []string{"past", "yesterday"},
+			wantTotal: 2,
+		},
+		"正常ケース:期限の範囲内を期限順に返す": {
+			todos: []models.Todo{
+				newTodo("evening", models.NotStarted, "2025-03-31", "18:00"),
+				newTodo("all_day", models.NotStarted, "2025-03-31", ""),
+				newTodo("next_day", models.NotStarted, "2025-04-01", ""),
+				newTodo("done", models.Done, "2025-03-31", "12:00"),
+			},
+			query: models.TodoQuery{
+				UserID:   testUserID,
+				DueFrom:  ptrTime(time.Date(2025, 3, 31, 0, 0, 0, 0, tokyo)),
+				DueTo:    ptrTime(time.Date(2025, 4, 1, 0, 0, 0, 0, tokyo)),
+				Statuses: models.OpenStatuses(),
+				Sort:     models.SortByDue,
+			},
+			want:      []string{"all_day", "evening"},
+			wantTotal: 2,
+		},
+		"正常ケース:キーワードを全て含む": {
+			todos:     titled("買い物リスト", "買い物", "リスト作成"),
+			query:     models.TodoQuery{UserID: testUserID, Keyword: "リスト　買い物", Sort: models.SortByTitle},
+			want:      []string{"買い物リスト"},
+			wantTotal: 1,
+		},
+		"正常ケース:キーワードは説明からも検索": {
+			todos: []models.Todo{
+				{UserID: testUserID, Title: "週末の予定", Status: models.NotStarted, Description: "- 牛乳を**買い物**する"},
+				{UserID: testUserID, Title: "買い物", Status: models.NotStarted},
+				{UserID: testUserID, Title: "掃除", Status: models.NotStarted, Description: "部屋の片付け"},
+				{UserID: otherUserID, Title: "other", Status: models.NotStarted, Description: "買い物"},
+			},
+			query:     models.TodoQuery{UserID: testUserID, Keyword: "買い物", Sort: models.SortByTitle},
+			want:      []string{"買い物", "週末の予定"},
+			wantTotal: 2,
+		},
+		"正常ケース:キーワードの記号はそのまま検索": {
+			todos:     titled("100%達成", "100点", "a_b", "ab"),
+			query:     models.TodoQuery{UserID: testUserID, Keyword: "100%", Sort: models.SortByTitle},
+			want:      []string{"100%達成"},
+			wantTotal: 1,
+		},
+		"正常ケース:状態で絞り込み": {
+			todos: []models.Todo{
+				{UserID: testUserID, Title: "not_started", Status: models.NotStarted},
+				{UserID: testUserID, Title: "in_progress", Status: models.InProgress},
+				{UserID: testUserID, Title: "blocked", Status: models.Blocked},
+				{UserID: otherUserID, Title: "other", Status: models.InProgress},
+			},
+			query:     models.TodoQuery{UserID: testUserID, Statuses: []models.Status{models.InProgress, models.Blocked}},
+			want:      []string{"in_progress", "blocked"},
+			wantTotal: 2,
+		},
+		"正常ケース:既定は状態の並び順、同じ状態では期限の近い順": {
+			todos: []models.Todo{
+				{UserID: testUserID, Title: "cancelled", Status: models.Cancelled},
+				{UserID: testUserID, Title: "done", Status: models.Done},
+				{UserID: testUserID, Title: "blocked", Status: models.Blocked},
+				{UserID: testUserID, Title: "no_due", Status: models.NotStarted},
+				newTodo("later", models.NotStarted, "2025-04-02", ""),
+				newTodo("sooner", models.NotStarted, "2025-04-01", ""),
+				{UserID: testUserID, Title: "in_progress", Status: models.InProgress},
+			},
+			query:     models.TodoQuery{UserID: testUserID},
+			want:      []string{"sooner", "later", "no_due", "in_progress", "blocked", "done", "cancelled"},
+			wantTotal: 7,
+		},
+		"正常ケース:既定は同じ状態の中では優先度の高い順、同じ優先度では期限の近い順": {
+			todos: []models.Todo{
+				{UserID: testUserID, Title: "low", Status: models.NotStarted, Priority: models.PriorityLow},
+				newTodo("medium_later", models.NotStarted, "2025-04-02", ""),
+				{UserID: testUserID, Title: "urgent_done", Status: models.Done, Priority: models.PriorityUrgent},
+				newTodo("medium_sooner", models.NotStarted, "2025-04-01", ""),
+				{UserID: testUserID, Title: "urgent", Status: models.NotStarted, Priority: models.PriorityUrgent},
+				{UserID: testUserID, Title: "high", Status: models.NotStarted, Priority: models.PriorityHigh},
+			},
+			query:     models.TodoQuery{UserID: testUserID},
+			want:      []string{"urgent", "high", "medium_sooner", "medium_later", "low", "urgent_done"},
+			wantTotal: 6,
+		},
+		"正常ケース:優先度の低い順": {
+			todos: []models.Todo{
+				{UserID: testUserID, Title: "high", Status: models.NotStarted, Priority: models.PriorityHigh},
+				{UserID: testUserID, Title: "low", Status: models.Done, Priority: models.PriorityLow},
+				{UserID: testUserID, Title: "urgent", Status: models.NotStarted, Priority: models.PriorityUrgent},
+				{UserID: testUserID, Title: "medium", Status: models.InProgress},
+			},
+			query:     models.TodoQuery{UserID: testUserID, Sort: models.SortByPriority, Direction: models.Desc},
+			want:      []string{"low", "medium", "high", "urgent"},
+			wantTotal: 4,
+		},
+		"正常ケース:手動の並び順は状態ごとに並び順で並べる": {
+			todos: []models.Todo{
+				{UserID: testUserID, Title: "done", Status: models.Done, Position: "a0"},
+				{UserID: testUserID, Title: "second", Status: models.NotStarted, Position: "a1V"},
+				{UserID: testUserID, Title: "first", Status: models.NotStarted, Position: "a1"},
+				{UserID: testUserID, Title: "in_progress", Status: models.InProgress, Position: "Zz"},
+				{UserID: testUserID, Title: "third", Status: models.NotStarted, Position: "a2"},
+			},
+			query:     models.TodoQuery{UserID: testUserID, Sort: models.SortByPosition},
+			want:      []string{"first", "second", "third", "in_progress", "done"},
+			wantTotal: 5,
+		},
+		"正常ケース:期限の降順でも期限の無いものは末尾": {
+			todos: []models.Todo{
+				{UserID: testUserID, Title: "no_due", Status: models.NotStarted},
+				newTodo("sooner", models.NotStarted, "2025-04-01", ""),
+				newTodo("later", models.NotStarted, "2025-04-02", ""),
+			},
+			query:     models.TodoQuery{UserID: testUserID, Sort: models.SortByDue, Direction: models.Desc},
+			want:      []string{"later", "sooner", "no_due"},
+			wantTotal: 3,
+		},
+		"正常ケース:ページの指定": {
+			todos:     titled("a", "b", "c", "d", "e"),
+			query:     models.TodoQuery{UserID: testUserID, Sort: models.SortByTitle, Page: 2, Limit: 2},
+			want:      []string{"c", "d"},
+			wantTotal: 5,
+		},
+		"正常ケース:範囲外のページ": {
+			todos:     titled("a", "b"),
+			query:     models.TodoQuery{UserID: testUserID, Page: 3, Limit: 2},
+			want:      []string{},
+			wantTotal: 2,
+		},
+		"正常ケース:データなし": {
+			todos:     []models.Todo{},
+			query:     models.TodoQuery{UserID: testUserID},
+			want:      []string{},
+			wantTotal: 0,
+		},
+	}
+	for name, tt := range cases {
+		s.T().Run(name, func(t *testing.T) {
+			// テスト用DBに接続する
+			db, err := s.openDB()
+			if err != nil {
+				s.Failf("database connection is not established", "%v", err)
+			}
+
+			defer s.Close(db)
+
+			// 初期処理
+			sqlHandler := testHandler{conn: db}
+			todoRepository := NewTodoRepository(&sqlHandler)
+
+			for i := range tt.todos {
+				if err := createTodo(db, &tt.todos[i]); err != nil {
+					t.Errorf("Creation is failed. error: %v", err)
+				}
+			}
+
+			todos, total, err := todoRepository.Search(tt.query)
+
+			// 結果を確認
+			if assert.NoError(t, err) {
+				titles := []string{}
+				for _, todo := range *todos {
+					titles = append(titles, todo.Title)
 				}
+				assert.Equal(t, tt.want, titles)
+				assert.Equal(t, tt.wantTotal, total)
 			}
 		})
 	}
 }
 
+func (s *todoRepositoryTestSuite) TestPosition() {
+
+	// テスト用DBに接続する
+	db, err := s.openDB()
+	if err != nil {
+		s.Failf("database connection is not established", "%v", err)
+	}
+
+	defer s.Close(db)
+
+	// 初期処理
+	sqlHandler := testHandler{conn: db}
+	todoRepository := NewTodoRepository(&sqlHandler)
+
+	todos := []*models.Todo{
+		{UserID: testUserID, Title: "first", Status: models.NotStarted},
+		{UserID: testUserID, Title: "second", Status: models.NotStarted},
+		{UserID: otherUserID, Title: "other", Status: models.NotStarted},
+		{UserID: testUserID, Title: "third", Status: models.NotStarted},
+	}
+	for _, todo := range todos {
+		if err := todoRepository.Create(todo); err != nil {
+			s.FailNowf("Creation is failed.", "%v", err)
+		}
+	}
+
+	s.T().Run("正常ケース:作成時はユーザーごとに末尾に並べる", func(t *testing.T) {
+		assert.Equal(t, "a0", todos[0].Position)
+		assert.Equal(t, "a1", todos[1].Position)
+		assert.Equal(t, "a0", todos[2].Position)
+		assert.Equal(t, "a2", todos[3].Position)
+	})
+
+	s.T().Run("正常ケース:削除済みのtodoより後ろに並べる", func(t *testing.T) {
+		if err := todoRepository.Delete(testUserID, todos[3].ID); err != nil {
+			t.Fatalf("Deletion is failed. error: %v", err)
+		}
+		todo := &models.Todo{UserID: testUserID, Title: "fourth", Status: models.NotStarted}
+		if assert.NoError(t, todoRepository.Create(todo)) {
+			assert.Equal(t, "a3", todo.Position)
+		}
+	})
+
+	s.T().Run("正常ケース:末尾の並び順が他の追加と重複した場合は再試行する", func(t *testing.T) {
+		// 並び順を求めてから保存するまでの間に、同じ並び順のtodoが追加された状態を再現する
+		raced := false
+		err := db.Callback().Create().Before("gorm:create").Register("test:race", func(tx *gorm.DB) {
+			todo, ok := tx.Statement.Dest.(*models.Todo)
+			if !ok || raced {
+				return
+			}
+			raced = true
+			tx.Session(&gorm.Session{NewDB: true}).Exec(
+				"INSERT INTO todos (user_id, title, status, position) VALUES (?, ?, ?, ?)",
+				todo.UserID, "raced", models.NotStarted, todo.Position,
+			)
+		})
+		if err != nil {
+			t.Fatalf("can't register callback. error: %v", err)
+		}
+		defer func() { _ = db.Callback().Create().Remove("test:race") }()
+
+		todo := &models.Todo{UserID: testUserID, Title: "fifth", Status: models.NotStarted}
+		if assert.NoError(t, todoRepository.Create(todo)) {
+			assert.True(t, raced)
+			assert.Equal(t, "a5", todo.Position)
+		}
+	})
+
+	s.T().Run("異常ケース:同じユーザーで並び順が重複する場合は競合", func(t *testing.T) {
+		todo := &models.Todo{UserID: testUserID, Title: "duplicated", Status: models.NotStarted, Position: "a0"}
+		err := todoRepository.Create(todo)
+		assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+		assert.Equal(t, models.KindConflict, models.KindOf(err))
+	})
+
+	s.T().Run("正常ケース:並び順のみを更新", func(t *testing.T) {
+		before, err := todoRepository.FindById(testUserID, todos[1].ID)
+		if err != nil {
+			t.Fatalf("can't get todo. error: %v", err)
+		}
+		if assert.NoError(t, todoRepository.UpdatePosition(testUserID, todos[1].ID, "Zz")) {
+			after, err := todoRepository.FindById(testUserID, todos[1].ID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, "Zz", after.Position)
+				assert.Equal(t, before.UpdatedAt, after.UpdatedAt)
+			}
+		}
+	})
+
+	s.T().Run("正常ケース:並び替える前に取得したtodoを更新しても並び順は戻さない", func(t *testing.T) {
+		stale, err := todoRepository.FindById(testUserID, todos[0].ID)
+		if err != nil {
+			t.Fatalf("can't get todo. error: %v", err)
+		}
+		if err := todoRepository.UpdatePosition(testUserID, todos[0].ID, "Zx"); err != nil {
+			t.Fatalf("can't update position. error: %v", err)
+		}
+		stale.Title = "edited"
+		if assert.NoError(t, todoRepository.Update(stale, nil, nil)) {
+			after, err := todoRepository.FindById(testUserID, todos[0].ID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, "edited", after.Title)
+				assert.Equal(t, "Zx", after.Position)
+			}
+		}
+	})
+
+	s.T().Run("正常ケース:並び順が変わらない場合も更新できる", func(t *testing.T) {
+		assert.NoError(t, todoRepository.UpdatePosition(testUserID, todos[0].ID, "Zx"))
+	})
+
+	s.T().Run("異常ケース:他のユーザーのtodoは更新できない", func(t *testing.T) {
+		assert.ErrorIs(t, todoRepository.UpdatePosition(testUserID, todos[2].ID, "Zy"), gorm.ErrRecordNotFound)
+	})
+}
+
+func (s *todoRepositoryTestSuite) TestBulk() {
+
+	// テスト用DBに接続する
+	db, err := s.openDB()
+	if err != nil {
+		s.Failf("database connection is not established", "%v", err)
+	}
+
+	defer s.Close(db)
+
+	// 初期処理
+	sqlHandler := testHandler{conn: db}
+	todoRepository := NewTodoRepository(&sqlHandler)
+	// 変更履歴・タグの外部キーのため、ユーザーとプロジェクトを作成する
+	f := s.createProjectFixture(s.T(), db)
+	userID, otherUserID := f.user.ID, f.other.ID
+
+	// 指定されたユーザーのInboxに、指定されたタイトルのtodoを作成し、保存されている内容を返す
+	create := func(userID uint, title string) *models.Todo {
+		project := f.projects["Inbox"]
+		if userID == otherUserID {
+			project = f.projects["other:Inbox"]
+		}
+		todo := &models.Todo{UserID: userID, ProjectID: project.ID, Title: title, Status: models.NotStarted}
+		if err := todoRepository.Create(todo); err != nil {
+			s.FailNowf("Creation is failed.", "%v", err)
+		}
+		found, err := todoRepository.FindById(userID, todo.ID)
+		if err != nil {
+			s.FailNowf("can't get todo.", "%v", err)
+		}
+		return found
+	}
+	// 状態を完了にする変更を返す
+	complete := func(todo *models.Todo) models.TodoBulkChange {
+		before := *todo
+		todo.Status = models.Done
+		return models.TodoBulkChange{Todo: todo, Update: true, Histories: models.TodoChanges(&before, todo, todo.UserID)}
+	}
+	// 保存されている変更履歴の件数を返す
+	countHistories := func(todoID uint) int64 {
+		var count int64
+		db.Model(&models.TodoHistory{}).Where("todo_id = ?", todoID).Count(&count)
+		return count
+	}
+
+	s.T().Run("正常ケース:失敗したtodoの変更のみ取り消す", func(t *testing.T) {
+		done, stale, deleted := create(userID, "done"), create(userID, "stale"), create(userID, "deleted")
+		// 取得後に他の操作で更新されたものとする
+		stale.Version = 5
+
+		errs, err := todoRepository.Bulk(&models.TodoBulk{UserID: userID, Changes: []models.TodoBulkChange{
+			complete(done),
+			complete(stale),
+			{Todo: deleted, Delete: true, Histories: []models.TodoHistory{models.NewTodoHistory(deleted.ID, userID, models.HistoryDeleted)}},
+		}})
+
+		// 結果を確認
+		assert.NoError(t, err)
+		if assert.Len(t, errs, 3) {
+			assert.NoError(t, errs[0])
+			assert.ErrorIs(t, errs[1], models.ErrTodoConflict)
+			assert.NoError(t, errs[2])
+		}
+		if got, err := todoRepository.FindById(userID, done.ID); assert.NoError(t, err) {
+			assert.Equal(t, models.Done, got.Status)
+			assert.Equal(t, uint(1), got.Version)
+		}
+		if got, err := todoRepository.FindById(userID, stale.ID); assert.NoError(t, err) {
+			assert.Equal(t, models.NotStarted, got.Status)
+		}
+		_, err = todoRepository.FindById(userID, deleted.ID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.Equal(t, int64(1), countHistories(done.ID))
+		assert.Equal(t, int64(0), countHistories(stale.ID))
+		assert.Equal(t, int64(1), countHistories(deleted.ID))
+	})
+
+	s.T().Run("異常ケース:全て取り消す指定で1件でも失敗したら全て取り消す", func(t *testing.T) {
+		done, stale := create(userID, "atomic done"), create(userID, "atomic stale")
+		stale.Version = 5
+
+		errs, err := todoRepository.Bulk(&models.TodoBulk{UserID: userID, Atomic: true, Changes: []models.TodoBulkChange{
+			complete(done),
+			complete(stale),
+		}})
+
+		// 結果を確認
+		assert.ErrorIs(t, err, models.ErrTodoConflict)
+		if assert.Len(t, errs, 2) {
+			assert.NoError(t, errs[0])
+			assert.ErrorIs(t, errs[1], models.ErrTodoConflict)
+		}
+		if got, err := todoRepository.FindById(userID, done.ID); assert.NoError(t, err) {
+			assert.Equal(t, models.NotStarted, got.Status)
+			assert.Equal(t, uint(0), got.Version)
+		}
+		assert.Equal(t, int64(0), countHistories(done.ID))
+	})
+
+	s.T().Run("正常ケース:タグを付け替え、存在しないタグは作成する", func(t *testing.T) {
+		first, second := create(userID, "first"), create(userID, "second")
+		backend := models.Tag{UserID: userID, Name: "backend"}
+		old := models.Tag{UserID: userID, Name: "old"}
+		if err := db.Create(&[]*models.Tag{&backend, &old}).Error; err != nil {
+			t.Fatalf("Creation is failed. error: %v", err)
+		}
+		if err := db.Create(&[]todoTag{{TodoID: first.ID, TagID: old.ID}, {TodoID: second.ID, TagID: backend.ID}}).Error; err != nil {
+			t.Fatalf("Creation is failed. error: %v", err)
+		}
+
+		bulk := &models.TodoBulk{
+			UserID:  userID,
+			Tags:    []models.Tag{backend, {UserID: userID, Name: "new"}},
+			Changes: []models.TodoBulkChange{{Todo: first}, {Todo: second}},
+		}
+		errs, err := todoRepository.Bulk(bulk)
+
+		// 結果を確認
+		assert.NoError(t, err)
+		assert.Equal(t, []error{nil, nil}, errs)
+		assert.NotZero(t, bulk.Tags[1].ID)
+		for _, id := range []uint{first.ID, second.ID} {
+			if got, err := todoRepository.FindById(userID, id); assert.NoError(t, err) {
+				assert.ElementsMatch(t, []string{"backend", "new"}, got.TagNames())
+			}
+		}
+	})
+
+	s.T().Run("正常ケース:次の繰り返しのtodoを作成し、作成した履歴を記録する", func(t *testing.T) {
+		todo := create(userID, "recurring")
+		next := &models.Todo{UserID: userID, ProjectID: todo.ProjectID, Title: "recurring", Status: models.NotStarted}
+		change := complete(todo)
+		change.Next = next
+
+		errs, err := todoRepository.Bulk(&models.TodoBulk{UserID: userID, Changes: []models.TodoBulkChange{change}})
+
+		// 結果を確認
+		assert.NoError(t, err)
+		assert.Equal(t, []error{nil}, errs)
+		if assert.NotZero(t, next.ID) {
+			_, err := todoRepository.FindById(userID, next.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), countHistories(next.ID))
+		}
+	})
+
+	s.T().Run("異常ケース:他のユーザーのtodoは削除しない", func(t *testing.T) {
+		other := create(otherUserID, "other")
+
+		errs, err := todoRepository.Bulk(&models.TodoBulk{UserID: userID, Changes: []models.TodoBulkChange{{Todo: other, Delete: true}}})
+
+		// 結果を確認
+		assert.NoError(t, err)
+		if assert.Len(t, errs, 1) {
+			assert.ErrorIs(t, errs[0], gorm.ErrRecordNotFound)
+		}
+		_, err = todoRepository.FindById(otherUserID, other.ID)
+		assert.NoError(t, err)
+	})
+}
+
+// 日時のポインタを返す
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
+
 type errorHandler struct {
 }
 
@@ -436,7 +1224,7 @@ func (eh *errorHandler) Close() error {
 }
 func (s *todoRepositoryTestSuite) TestClose() {
 
-	db, err := gorm.Open(mysql.New(mysql.Config{DSN: uuid.NewString(), DriverName: "txdb"}))
+	db, err := s.openDB()
 	if err != nil {
 		s.Failf("database connection is not established", "%v", err)
 	}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"log/slog"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+)
+
+// userモデルのDB処理を担うリポジトリの構造体
+type userRepository struct {
+	handler SqlHandler
+}
+
+// UserRepositoryの新しいインスタンスを作成して返す
+func NewUserRepository(sqlHandler SqlHandler) repository.UserRepository {
+	userRepository := userRepository{handler: sqlHandler}
+	return &userRepository
+}
+
+// 指定されたIDのユーザーを検索して結果を返す
+func (ur *userRepository) FindById(id uint) (*models.User, error) {
+	var user models.User
+	result := ur.handler.GetConnection().Where("id = ?", id).First(&user)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+// 指定されたメールアドレスのユーザーを検索して結果を返す
+func (ur *userRepository) FindByEmail(email string) (*models.User, error) {
+	var user models.User
+	result := ur.handler.GetConnection().Where("email = ?", models.NormalizeEmail(email)).First(&user)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+// 渡されたユーザーを新規作成して保存する
+func (ur *userRepository) Create(user *models.User) error {
+	result := ur.handler.GetConnection().Create(user)
+	return result.Error
+}
+
+// userRepositoryの終了処理
+func (ur *userRepository) Close() error {
+	// 依存先をクローズする
+	err := ur.handler.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	return err
+}
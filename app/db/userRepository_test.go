@@ -0,0 +1,91 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func (s *todoRepositoryTestSuite) TestUserFind() {
+
+	user := models.User{Email: "user@example.com", PasswordHash: "hash"}
+
+	cases := map[string]struct {
+		find      func(r *userRepository) (*models.User, error)
+		expectErr bool
+		err       error
+	}{
+		"正常ケース:IDで検索": {
+			find:      func(r *userRepository) (*models.User, error) { return r.FindById(user.ID) },
+			expectErr: false,
+		},
+		"正常ケース:メールアドレスで検索（大文字小文字を区別しない）": {
+			find:      func(r *userRepository) (*models.User, error) { return r.FindByEmail(" User@Example.com ") },
+			expectErr: false,
+		},
+		"異常ケース:存在しないメールアドレス": {
+			find:      func(r *userRepository) (*models.User, error) { return r.FindByEmail("nobody@example.com") },
+			expectErr: true,
+			err:       models.NotFound(gorm.ErrRecordNotFound),
+		},
+	}
+	for name, tt := range cases {
+		s.T().Run(name, func(t *testing.T) {
+			// テスト用DBに接続する
+			db, err := s.openDB()
+			if err != nil {
+				s.Failf("database connection is not established", "%v", err)
+			}
+
+			defer s.Close(db)
+
+			// 初期処理
+			sqlHandler := testHandler{conn: db}
+			userRepository := &userRepository{handler: &sqlHandler}
+			created := user
+			if err := userRepository.Create(&created); err != nil {
+				t.Fatalf("Creation is failed. error: %v", err)
+			}
+			user.ID = created.ID
+
+			found, err := tt.find(userRepository)
+
+			// 結果を確認
+			if tt.expectErr {
+				if assert.Error(t, err) {
+					assert.Equal(t, tt.err, err)
+				}
+				assert.Nil(t, found)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, created.ID, found.ID)
+				assert.Equal(t, created.Email, found.Email)
+			}
+		})
+	}
+}
+
+func (s *todoRepositoryTestSuite) TestUserCreateDuplicateEmail() {
+
+	db, err := s.openDB()
+	if err != nil {
+		s.Failf("database connection is not established", "%v", err)
+	}
+
+	defer s.Close(db)
+
+	userRepository := NewUserRepository(&testHandler{conn: db})
+
+	// 同じメールアドレスは一意制約で登録できないこと
+	assert.NoError(s.T(), userRepository.Create(&models.User{Email: "user@example.com", PasswordHash: "hash"}))
+	assert.Error(s.T(), userRepository.Create(&models.User{Email: "user@example.com", PasswordHash: "hash"}))
+}
+
+// レコードが存在しないことを示すエラーかを返す
+func isNotFound(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
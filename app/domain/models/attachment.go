@@ -0,0 +1,154 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 添付ファイルの大きさの上限（バイト数）
+const AttachmentMaxSize = 10 << 20
+
+// 添付ファイルの名前の長さの上限（文字数）
+const AttachmentNameMaxLength = 255
+
+// 添付できるファイルの種類
+// 種類はクライアントの申告ではなく、ファイルの先頭の内容から判定する
+// docx・xlsxなどのOfficeの文書はzipとして判定される
+var allowedAttachmentTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+	"text/plain":      true,
+	"application/zip": true,
+}
+
+// 添付ファイルに関わるエラー
+var (
+	// ファイルが空か、名前が不正
+	ErrInvalidAttachment = Validation(errors.New("invalid attachment"))
+	// ファイルが大きすぎる
+	ErrAttachmentTooLarge = Validation(errors.New("attachment is too large"))
+	// 添付できない種類のファイル
+	ErrAttachmentTypeNotAllowed = Validation(errors.New("attachment type is not allowed"))
+)
+
+// todoの添付ファイルの情報
+// ファイルの内容はストレージに保存し、DBにはStorageKeyで参照する情報のみを保存する
+type Attachment struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	// 添付先のtodoのID
+	TodoID uint
+	// 添付したユーザーのID
+	UserID uint
+	// アップロード時のファイル名
+	FileName string
+	// ファイルの先頭の内容から判定した種類（パラメーターを除いたMIMEタイプ）
+	ContentType string
+	// ファイルの大きさ（バイト数）
+	Size int64
+	// ストレージ上の保存先
+	StorageKey string
+}
+
+// 指定されたtodoに、指定されたユーザーが添付するファイルの情報を生成する
+// headにはファイルの先頭（512バイトまで）を渡し、その内容から種類を判定する
+func NewAttachment(todoID uint, userID uint, fileName string, head []byte, size int64) (*Attachment, error) {
+	fileName = cleanFileName(fileName)
+	if fileName == "" || size <= 0 {
+		return nil, fmt.Errorf("%w: name %q, %d bytes", ErrInvalidAttachment, fileName, size)
+	}
+	if size > AttachmentMaxSize {
+		return nil, fmt.Errorf("%w: %d bytes (max %d)", ErrAttachmentTooLarge, size, AttachmentMaxSize)
+	}
+	contentType := DetectContentType(head)
+	if !allowedAttachmentTypes[contentType] {
+		return nil, fmt.Errorf("%w: %s", ErrAttachmentTypeNotAllowed, contentType)
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return &Attachment{
+		TodoID:      todoID,
+		UserID:      userID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  fmt.Sprintf("todos/%d/%s", todoID, hex.EncodeToString(b)),
+	}, nil
+}
+
+// ファイルの先頭の内容から種類を判定し、パラメーターを除いたMIMEタイプを返す
+func DetectContentType(head []byte) string {
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "application/octet-stream"
+	}
+	return mediaType
+}
+
+// 画面に表示できる画像かを返す
+// 画像以外はダウンロードさせる
+func (a Attachment) IsImage() bool {
+	return strings.HasPrefix(a.ContentType, "image/")
+}
+
+// ダウンロード時のContent-Dispositionヘッダーの値を返す
+// 画像はブラウザで表示し、それ以外はファイル名を付けて保存させる
+func (a Attachment) ContentDisposition() string {
+	disposition := "attachment"
+	if a.IsImage() {
+		disposition = "inline"
+	}
+	return mime.FormatMediaType(disposition, map[string]string{"filename": a.FileName})
+}
+
+// ファイルの大きさを読みやすい単位で返す
+func (a Attachment) HumanSize() string {
+	switch {
+	case a.Size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(a.Size)/(1<<20))
+	case a.Size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(a.Size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", a.Size)
+	}
+}
+
+// アップロードされたファイル名から、ディレクトリと制御文字を取り除く
+// 長すぎる場合は拡張子を残して切り詰める
+func cleanFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == ".." || name == "/" {
+		return ""
+	}
+	if utf8.RuneCountInString(name) > AttachmentNameMaxLength {
+		ext := []rune(filepath.Ext(name))
+		if len(ext) > 16 {
+			ext = nil
+		}
+		runes := []rune(name)
+		name = string(runes[:AttachmentNameMaxLength-len(ext)]) + string(ext)
+	}
+	return name
+}
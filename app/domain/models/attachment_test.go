@@ -0,0 +1,140 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAttachment(t *testing.T) {
+
+	// PNGのファイルの先頭
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	type args struct {
+		fileName string
+		head     []byte
+		size     int64
+	}
+
+	cases := map[string]struct {
+		args            args
+		wantName        string
+		wantContentType string
+		expectErr       error
+	}{
+		"正常ケース:画像": {
+			args:            args{fileName: "screenshot.png", head: png, size: 1024},
+			wantName:        "screenshot.png",
+			wantContentType: "image/png",
+		},
+		"正常ケース:テキストは申告された名前に関わらず内容で判定する": {
+			args:            args{fileName: "memo.png", head: []byte("メモ"), size: 6},
+			wantName:        "memo.png",
+			wantContentType: "text/plain",
+		},
+		"正常ケース:ディレクトリと制御文字を取り除く": {
+			args:            args{fileName: `C:\Users\me\..\report` + "\x00.pdf", head: []byte("%PDF-1.7"), size: 8},
+			wantName:        "report.pdf",
+			wantContentType: "application/pdf",
+		},
+		"正常ケース:長すぎる名前は拡張子を残して切り詰める": {
+			args:            args{fileName: strings.Repeat("あ", AttachmentNameMaxLength) + ".txt", head: []byte("a"), size: 1},
+			wantName:        strings.Repeat("あ", AttachmentNameMaxLength-4) + ".txt",
+			wantContentType: "text/plain",
+		},
+		"正常ケース:上限の大きさ": {
+			args:            args{fileName: "a.png", head: png, size: AttachmentMaxSize},
+			wantName:        "a.png",
+			wantContentType: "image/png",
+		},
+		"異常ケース:上限の大きさを超える": {
+			args:      args{fileName: "a.png", head: png, size: AttachmentMaxSize + 1},
+			expectErr: ErrAttachmentTooLarge,
+		},
+		"異常ケース:添付できない種類": {
+			args:      args{fileName: "index.html", head: []byte("<html><script>alert(1)</script>"), size: 31},
+			expectErr: ErrAttachmentTypeNotAllowed,
+		},
+		"異常ケース:空のファイル": {
+			args:      args{fileName: "empty.txt", head: []byte{}, size: 0},
+			expectErr: ErrInvalidAttachment,
+		},
+		"異常ケース:名前が空": {
+			args:      args{fileName: "../", head: png, size: 1024},
+			expectErr: ErrInvalidAttachment,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			attachment, err := NewAttachment(1, 2, tt.args.fileName, tt.args.head, tt.args.size)
+
+			// 結果を確認
+			if tt.expectErr != nil {
+				assert.True(t, errors.Is(err, tt.expectErr), err)
+				assert.Nil(t, attachment)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.wantName, attachment.FileName)
+				assert.Equal(t, tt.wantContentType, attachment.ContentType)
+				assert.Equal(t, tt.args.size, attachment.Size)
+				assert.Equal(t, uint(1), attachment.TodoID)
+				assert.Equal(t, uint(2), attachment.UserID)
+				assert.Regexp(t, `^todos/1/[0-9a-f]{32}$`, attachment.StorageKey)
+			}
+		})
+	}
+}
+
+func TestAttachmentHumanSize(t *testing.T) {
+
+	cases := map[string]struct {
+		size int64
+		want string
+	}{
+		"正常ケース:バイト":   {size: 512, want: "512 B"},
+		"正常ケース:キロバイト": {size: 1536, want: "1.5 KB"},
+		"正常ケース:メガバイト": {size: 3 << 20, want: "3.0 MB"},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Attachment{Size: tt.size}.HumanSize())
+		})
+	}
+}
+
+func TestAttachmentContentDisposition(t *testing.T) {
+
+	cases := map[string]struct {
+		attachment Attachment
+		want       string
+	}{
+		"正常ケース:画像はブラウザで表示する": {
+			attachment: Attachment{FileName: "screenshot.png", ContentType: "image/png"},
+			want:       `inline; filename=screenshot.png`,
+		},
+		"正常ケース:画像以外は保存させる": {
+			attachment: Attachment{FileName: "report.pdf", ContentType: "application/pdf"},
+			want:       `attachment; filename=report.pdf`,
+		},
+		"正常ケース:日本語のファイル名はエンコードする": {
+			attachment: Attachment{FileName: "議事録.txt", ContentType: "text/plain"},
+			want:       `attachment; filename*=utf-8''%E8%AD%B0%E4%BA%8B%E9%8C%B2.txt`,
+		},
+		"正常ケース:引用符を含むファイル名はエスケープする": {
+			attachment: Attachment{FileName: `a"b.txt`, ContentType: "text/plain"},
+			want:       `attachment; filename="a\"b.txt"`,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.attachment.ContentDisposition())
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// 複数のtodoに対する一括操作の種類
+type BulkAction string
+
+const (
+	// 完了にする
+	BulkComplete BulkAction = "complete"
+	// 未着手に戻す
+	BulkReopen BulkAction = "reopen"
+	// ゴミ箱に移す
+	BulkDelete BulkAction = "delete"
+	// タグを付け替える
+	BulkRetag BulkAction = "retag"
+)
+
+// 一度に操作できるtodoの件数の上限
+const BulkMaxSize = 100
+
+// 一括操作の指定が不正な場合のエラー
+var (
+	// 操作の種類が不正
+	ErrInvalidBulkAction = Validation(errors.New("invalid bulk action"))
+	// 対象のtodoが指定されていない
+	ErrBulkEmpty = Validation(errors.New("no todos are specified"))
+	// 対象のtodoが多すぎる
+	ErrBulkTooMany = Validation(errors.New("too many todos are specified"))
+)
+
+// 全て取り消す指定の一括操作で、他のtodoが失敗したために取り消した場合のエラー
+var ErrBulkRolledBack = errors.New("rolled back because another todo failed")
+
+// 操作の種類ごとの、画面に表示する名前
+var bulkActionLabels = map[BulkAction]string{
+	BulkComplete: "完了にする",
+	BulkReopen:   "未着手に戻す",
+	BulkDelete:   "ゴミ箱に移す",
+	BulkRetag:    "タグを付け替える",
+}
+
+// 全ての一括操作の種類を、画面での並び順で返す
+func BulkActions() []BulkAction {
+	return []BulkAction{BulkComplete, BulkReopen, BulkDelete, BulkRetag}
+}
+
+// 画面に表示する操作の名前を返す
+func (a BulkAction) Label() string {
+	return bulkActionLabels[a]
+}
+
+// 文字列から一括操作の種類に変換する
+func ParseBulkAction(s string) (BulkAction, error) {
+	action := BulkAction(s)
+	if _, ok := bulkActionLabels[action]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidBulkAction, s)
+	}
+	return action, nil
+}
+
+// 複数のtodoに対する一括操作の指定
+type BulkRequest struct {
+	// 操作するユーザー（他のユーザーのtodoは存在しないものとして扱う）
+	UserID uint
+	Action BulkAction
+	// 操作するtodoのID（重複は除く）
+	IDs []uint
+	// タグを付け替える場合の、付けるタグの名前（空の場合は全て外す）
+	Tags []string
+	// trueの場合は、1件でも失敗したら全ての変更を取り消す
+	// falseの場合は、成功したものだけを保存し、失敗したものはIDごとにエラーを返す
+	Atomic bool
+}
+
+// 一括操作の指定を生成する
+// 操作の種類・件数・タグの名前を検証し、重複したIDは除く
+func NewBulkRequest(userID uint, action string, ids []uint, tags []string, atomic bool) (*BulkRequest, error) {
+	a, err := ParseBulkAction(action)
+	if err != nil {
+		return nil, err
+	}
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, ErrBulkEmpty
+	}
+	if len(unique) > BulkMaxSize {
+		return nil, fmt.Errorf("%w: %d todos (max %d)", ErrBulkTooMany, len(unique), BulkMaxSize)
+	}
+	names := []string{}
+	if a == BulkRetag {
+		for _, name := range tags {
+			tag, err := NewTag(userID, name)
+			if err != nil {
+				return nil, err
+			}
+			if !slices.Contains(names, tag.Name) {
+				names = append(names, tag.Name)
+			}
+		}
+	}
+	return &BulkRequest{UserID: userID, Action: a, IDs: unique, Tags: names, Atomic: atomic}, nil
+}
+
+// 一括操作の、todoごとの結果
+type BulkResult struct {
+	ID uint
+	// 失敗した場合のエラー（成功した場合はnil）
+	Err error
+}
+
+// 一括操作の結果（指定されたIDの順）
+type BulkResults []BulkResult
+
+// 成功したtodoの件数を返す
+func (r BulkResults) Succeeded() int {
+	n := 0
+	for _, result := range r {
+		if result.Err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// 失敗したtodoの結果のみを返す
+func (r BulkResults) Failed() BulkResults {
+	failed := BulkResults{}
+	for _, result := range r {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// 一括操作で保存する内容（ユースケースで組み立て、リポジトリで1つのトランザクションで保存する）
+type TodoBulk struct {
+	UserID uint
+	// タグを付け替える場合の、付け替え後のタグ（IDが0のものは新規作成する）
+	// nilの場合はタグを変更しない
+	Tags []Tag
+	// todoごとの変更
+	Changes []TodoBulkChange
+	// trueの場合は、1件でも失敗したら全ての変更を取り消す
+	Atomic bool
+}
+
+// 一括操作で、1件のtodoについて保存する内容
+type TodoBulkChange struct {
+	// 変更後のtodo（Versionは取得時のもの）
+	Todo *Todo
+	// 内容を更新するか（状態を変更する場合）
+	Update bool
+	// ゴミ箱に移すか
+	Delete bool
+	// 記録する変更履歴
+	Histories []TodoHistory
+	// 作成する次の繰り返しのtodo（作成した履歴はリポジトリで記録する）
+	Next *Todo
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBulkRequest(t *testing.T) {
+
+	// 上限を超える件数のID
+	tooMany := make([]uint, BulkMaxSize+1)
+	for i := range tooMany {
+		tooMany[i] = uint(i + 1)
+	}
+
+	type args struct {
+		action string
+		ids    []uint
+		tags   []string
+	}
+
+	cases := map[string]struct {
+		args args
+		want *BulkRequest
+		err  error
+	}{
+		"正常ケース:重複したIDを除く": {
+			args: args{action: "complete", ids: []uint{3, 1, 3, 2, 1}},
+			want: &BulkRequest{UserID: 1, Action: BulkComplete, IDs: []uint{3, 1, 2}, Tags: []string{}},
+		},
+		"正常ケース:タグの付け替えではタグの名前を整えて重複を除く": {
+			args: args{action: "retag", ids: []uint{1}, tags: []string{" 仕事 ", "家", "仕事"}},
+			want: &BulkRequest{UserID: 1, Action: BulkRetag, IDs: []uint{1}, Tags: []string{"仕事", "家"}},
+		},
+		"正常ケース:タグの付け替え以外ではタグを無視する": {
+			args: args{action: "delete", ids: []uint{1}, tags: []string{"仕事"}},
+			want: &BulkRequest{UserID: 1, Action: BulkDelete, IDs: []uint{1}, Tags: []string{}},
+		},
+		"正常ケース:上限の件数": {
+			args: args{action: "reopen", ids: tooMany[:BulkMaxSize]},
+			want: &BulkRequest{UserID: 1, Action: BulkReopen, IDs: tooMany[:BulkMaxSize], Tags: []string{}},
+		},
+		"異常ケース:不正な操作の種類": {
+			args: args{action: "archive", ids: []uint{1}},
+			err:  ErrInvalidBulkAction,
+		},
+		"異常ケース:IDが空": {
+			args: args{action: "complete", ids: []uint{}},
+			err:  ErrBulkEmpty,
+		},
+		"異常ケース:上限の件数を超える": {
+			args: args{action: "complete", ids: tooMany},
+			err:  ErrBulkTooMany,
+		},
+		"異常ケース:不正なタグの名前": {
+			args: args{action: "retag", ids: []uint{1}, tags: []string{strings.Repeat("あ", TagNameMaxLength+1)}},
+			err:  ErrInvalidTagName,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewBulkRequest(1, tt.args.action, tt.args.ids, tt.args.tags, false)
+
+			// 結果を確認
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Equal(t, KindValidation, KindOf(err))
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBulkResults(t *testing.T) {
+	failure := errors.New("failed")
+	results := BulkResults{{ID: 1}, {ID: 2, Err: failure}, {ID: 3}, {ID: 4, Err: ErrBulkRolledBack}}
+
+	// 結果を確認
+	assert.Equal(t, 2, results.Succeeded())
+	assert.Equal(t, BulkResults{{ID: 2, Err: failure}, {ID: 4, Err: ErrBulkRolledBack}}, results.Failed())
+	assert.Equal(t, BulkResults{}, BulkResults{{ID: 1}}.Failed())
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// チェックリストの項目のタイトルの長さの上限（文字数）
+const ChecklistItemTitleMaxLength = 200
+
+// チェックリストに関わるエラー
+var (
+	// 項目のタイトルが不正
+	ErrInvalidChecklistItemTitle = Validation(errors.New("invalid checklist item title"))
+	// 未完了の項目が残っているため、todoを完了にできない
+	ErrOpenChecklistItems = Validation(errors.New("todo has open checklist items"))
+)
+
+// todoを細かい作業に分けるためのチェックリストの項目
+// todoを完全に削除すると項目も削除される（論理削除の間は残す）
+type ChecklistItem struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// 項目が属するtodoのID
+	TodoID uint
+	Title  string
+	// 完了したか
+	Done bool
+	// todoの中での並び順（0始まり）
+	Position int
+}
+
+// 指定されたtodoの、指定されたタイトルの項目を生成する
+func NewChecklistItem(todoID uint, title string) (*ChecklistItem, error) {
+	item := ChecklistItem{TodoID: todoID}
+	if err := item.Rename(title); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// 項目のタイトルを変更する
+func (i *ChecklistItem) Rename(title string) error {
+	title = strings.TrimSpace(title)
+	if title == "" || utf8.RuneCountInString(title) > ChecklistItemTitleMaxLength {
+		return fmt.Errorf("%w: %q", ErrInvalidChecklistItemTitle, title)
+	}
+	i.Title = title
+	return nil
+}
+
+// チェックリストの完了した項目の数を返す
+func (t Todo) ChecklistDoneCount() int {
+	count := 0
+	for _, item := range t.ChecklistItems {
+		if item.Done {
+			count++
+		}
+	}
+	return count
+}
+
+// チェックリストに未完了の項目があるかを返す
+func (t Todo) HasOpenChecklistItems() bool {
+	return t.ChecklistDoneCount() < len(t.ChecklistItems)
+}
+
+// チェックリストの進捗を割合（0〜100）で返す
+// 項目が無い場合は0を返す
+func (t Todo) ChecklistPercent() int {
+	if len(t.ChecklistItems) == 0 {
+		return 0
+	}
+	return t.ChecklistDoneCount() * 100 / len(t.ChecklistItems)
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewChecklistItem(t *testing.T) {
+
+	cases := map[string]struct {
+		title     string
+		want      string
+		expectErr bool
+	}{
+		"正常ケース:前後の空白を除く": {
+			title: "  資料を作る ",
+			want:  "資料を作る",
+		},
+		"正常ケース:上限の文字数": {
+			title: strings.Repeat("あ", ChecklistItemTitleMaxLength),
+			want:  strings.Repeat("あ", ChecklistItemTitleMaxLength),
+		},
+		"異常ケース:空白のみ": {
+			title:     " 　",
+			expectErr: true,
+		},
+		"異常ケース:上限を超える文字数": {
+			title:     strings.Repeat("あ", ChecklistItemTitleMaxLength+1),
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			item, err := NewChecklistItem(1, tt.title)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidChecklistItemTitle)
+				assert.Nil(t, item)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, &ChecklistItem{TodoID: 1, Title: tt.want}, item)
+			}
+		})
+	}
+}
+
+func TestChecklistProgress(t *testing.T) {
+
+	cases := map[string]struct {
+		items       []ChecklistItem
+		wantDone    int
+		wantPercent int
+		wantOpen    bool
+	}{
+		"正常ケース:項目なし": {
+			items: nil,
+		},
+		"正常ケース:一部が完了": {
+			items:       []ChecklistItem{{Done: true}, {Done: false}, {Done: true}},
+			wantDone:    2,
+			wantPercent: 66,
+			wantOpen:    true,
+		},
+		"正常ケース:全て完了": {
+			items:       []ChecklistItem{{Done: true}, {Done: true}},
+			wantDone:    2,
+			wantPercent: 100,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			todo := Todo{ChecklistItems: tt.items}
+
+			// 結果を確認
+			assert.Equal(t, tt.wantDone, todo.ChecklistDoneCount())
+			assert.Equal(t, tt.wantPercent, todo.ChecklistPercent())
+			assert.Equal(t, tt.wantOpen, todo.HasOpenChecklistItems())
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// コメントの本文の長さの上限（文字数）
+const CommentBodyMaxLength = 2000
+
+// コメントに関わるエラー
+var (
+	// 本文が不正
+	ErrInvalidCommentBody = Validation(errors.New("invalid comment body"))
+	// 投稿したユーザー以外は、コメントを編集・削除できない
+	ErrNotCommentAuthor = errors.New("not the author of the comment")
+)
+
+// todoについてのコメント
+// todoを削除するとコメントも削除される
+type Comment struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// コメントが属するtodoのID
+	TodoID uint
+	// 投稿したユーザーのID
+	UserID uint
+	// 投稿したユーザー（表示用に読み込む。コメントの保存時には更新しない）
+	User User
+	// 本文（Markdown）
+	Body string
+}
+
+// 指定されたtodoに、指定されたユーザーが投稿するコメントを生成する
+func NewComment(todoID uint, userID uint, body string) (*Comment, error) {
+	comment := Comment{TodoID: todoID, UserID: userID}
+	if err := comment.Edit(body); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// コメントの本文を変更する
+// 改行コードはLFに揃え、前後の空白・改行は取り除く
+func (c *Comment) Edit(body string) error {
+	body = strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n"))
+	if body == "" || utf8.RuneCountInString(body) > CommentBodyMaxLength {
+		return fmt.Errorf("%w: %d characters (max %d)", ErrInvalidCommentBody, utf8.RuneCountInString(body), CommentBodyMaxLength)
+	}
+	c.Body = body
+	return nil
+}
+
+// 指定されたユーザーが投稿したコメントかを返す
+func (c Comment) IsAuthor(userID uint) bool {
+	return c.UserID == userID
+}
+
+// 投稿後に編集されたかを返す
+func (c Comment) IsEdited() bool {
+	return c.UpdatedAt.After(c.CreatedAt)
+}
+
+// 本文をHTMLに変換して返す
+func (c Comment) BodyHTML() template.HTML {
+	return RenderMarkdown(c.Body)
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewComment(t *testing.T) {
+
+	cases := map[string]struct {
+		body      string
+		want      string
+		expectErr bool
+	}{
+		"正常ケース:前後の空白を除き、改行コードを揃える": {
+			body: "\r\n 確認しました。\r\n明日対応します。 \r\n",
+			want: "確認しました。\n明日対応します。",
+		},
+		"正常ケース:上限の文字数": {
+			body: strings.Repeat("あ", CommentBodyMaxLength),
+			want: strings.Repeat("あ", CommentBodyMaxLength),
+		},
+		"異常ケース:空白のみ": {
+			body:      " \n　",
+			expectErr: true,
+		},
+		"異常ケース:上限を超える文字数": {
+			body:      strings.Repeat("あ", CommentBodyMaxLength+1),
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			comment, err := NewComment(1, 2, tt.body)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidCommentBody)
+				assert.Nil(t, comment)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, &Comment{TodoID: 1, UserID: 2, Body: tt.want}, comment)
+			}
+		})
+	}
+}
+
+func TestCommentState(t *testing.T) {
+
+	created := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	comment := Comment{UserID: 2, CreatedAt: created, UpdatedAt: created, Body: "**確認**しました"}
+
+	// 投稿したユーザーのみ作成者として扱うこと
+	assert.True(t, comment.IsAuthor(2))
+	assert.False(t, comment.IsAuthor(3))
+
+	// 更新日時が作成日時より後の場合のみ編集済みとすること
+	assert.False(t, comment.IsEdited())
+	comment.UpdatedAt = created.Add(time.Minute)
+	assert.True(t, comment.IsEdited())
+
+	// 本文はMarkdownとして変換すること
+	assert.Equal(t, "<p><strong>確認</strong>しました</p>\n", string(comment.BodyHTML()))
+}
@@ -0,0 +1,24 @@
+package models
+
+import "errors"
+
+// todoの取得後に、他の操作で内容が更新されていた場合のエラー
+var ErrTodoConflict = Conflict(errors.New("todo has been changed by someone else"))
+
+// todoの更新の競合を表すエラー
+// 保存されている最新のtodoと、更新しようとした内容との差分を保持する
+// errors.Is(err, ErrTodoConflict)で判定できる
+type TodoConflictError struct {
+	// 保存されている最新のtodo
+	Current *Todo
+	// 最新のtodoと異なる項目（OldValueが保存されている値、NewValueが更新しようとした値）
+	Changes []TodoHistory
+}
+
+func (e *TodoConflictError) Error() string {
+	return ErrTodoConflict.Error()
+}
+
+func (e *TodoConflictError) Unwrap() error {
+	return ErrTodoConflict
+}
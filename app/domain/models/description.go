@@ -0,0 +1,46 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+	"unicode/utf8"
+)
+
+// 説明の長さの上限（文字数）
+const DescriptionMaxLength = 10000
+
+// 説明が長すぎる場合のエラー
+var ErrDescriptionTooLong = Validation(errors.New("description is too long"))
+
+// todoの説明（Markdown）を変更する
+// Unicodeの正規化を行い、改行コードはLFに揃え、末尾の空白・改行は取り除く
+// 改行・タブ以外の制御文字は使用できない
+func (t *Todo) SetDescription(description string) error {
+	description = normalizeDescription(description)
+	if err := validateDescription(description); err != nil {
+		return err
+	}
+	t.Description = description
+	return nil
+}
+
+// 説明を保存する形に揃える
+func normalizeDescription(description string) string {
+	description = strings.ReplaceAll(normalizeText(description), "\r\n", "\n")
+	return strings.TrimRight(description, " \t\n")
+}
+
+// 保存する形に揃えた説明を検証する
+func validateDescription(description string) error {
+	if n := utf8.RuneCountInString(description); n > DescriptionMaxLength {
+		return fmt.Errorf("%w: %d characters (max %d)", ErrDescriptionTooLong, n, DescriptionMaxLength)
+	}
+	return checkControlCharacters(description, "\n\t")
+}
+
+// 説明をHTMLに変換して返す
+func (t Todo) DescriptionHTML() template.HTML {
+	return RenderMarkdown(t.Description)
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetDescription(t *testing.T) {
+
+	cases := map[string]struct {
+		arg       string
+		want      string
+		expectErr bool
+		err       error
+	}{
+		"正常ケース:改行コードを揃えて末尾の空白を取り除く": {
+			arg:  "# 見出し\r\n\r\n本文  \r\n\r\n",
+			want: "# 見出し\n\n本文",
+		},
+		"正常ケース:空文字列": {
+			arg:  "",
+			want: "",
+		},
+		"正常ケース:上限の文字数": {
+			arg:  strings.Repeat("あ", DescriptionMaxLength),
+			want: strings.Repeat("あ", DescriptionMaxLength),
+		},
+		"異常ケース:上限の文字数を超える": {
+			arg:       strings.Repeat("あ", DescriptionMaxLength+1),
+			want:      "元の説明",
+			expectErr: true,
+			err:       ErrDescriptionTooLong,
+		},
+		"正常ケース:改行・タブは使用できる": {
+			arg:  "- 牛乳\n\t- 低脂肪",
+			want: "- 牛乳\n\t- 低脂肪",
+		},
+		"異常ケース:制御文字を含む": {
+			arg:       "本文\x00",
+			want:      "元の説明",
+			expectErr: true,
+			err:       ErrControlCharacter,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			todo := Todo{Description: "元の説明"}
+			err := todo.SetDescription(tt.arg)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.True(t, errors.Is(err, tt.err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, todo.Description)
+		})
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+
+	cases := map[string]struct {
+		arg      string
+		contains []string
+		excludes []string
+	}{
+		"正常ケース:見出しと強調": {
+			arg:      "# 見出し\n\n**太字**",
+			contains: []string{"<h1", "見出し</h1>", "<strong>太字</strong>"},
+		},
+		"正常ケース:表とチェックボックス": {
+			arg:      "| a | b |\n| - | - |\n| 1 | 2 |\n\n- [x] 済み",
+			contains: []string{"<table>", "<td>1</td>", `type="checkbox"`, "checked"},
+		},
+		"正常ケース:外部のリンクは別のタブで開く": {
+			arg:      "[リンク](https://example.com)",
+			contains: []string{`href="https://example.com"`, `rel="nofollow noopener"`, `target="_blank"`},
+		},
+		"異常ケース:scriptタグは出力しない": {
+			arg:      "<script>alert(1)</script>\n\n本文",
+			contains: []string{"本文"},
+			excludes: []string{"<script", "alert(1)</script>"},
+		},
+		"異常ケース:javascriptのリンクは出力しない": {
+			arg:      "[click](javascript:alert(1))",
+			excludes: []string{"javascript:"},
+		},
+		"異常ケース:イベント属性は出力しない": {
+			arg:      `<img src="x" onerror="alert(1)">`,
+			excludes: []string{"onerror"},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			html := string(RenderMarkdown(tt.arg))
+
+			// 結果を確認
+			for _, s := range tt.contains {
+				assert.Contains(t, html, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, html, s)
+			}
+		})
+	}
+}
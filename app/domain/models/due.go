@@ -0,0 +1,125 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// 期限の入力形式
+const (
+	DueDateLayout = "2006-01-02"
+	DueTimeLayout = "15:04"
+)
+
+// 期限を設定する
+// date：期限の日付（空文字列の場合は期限を解除する）
+// clock：期限の時刻（空文字列の場合は終日とする）
+// timezone：期限のタイムゾーン（IANAの名前。空文字列の場合はサーバーのタイムゾーン）
+func (t *Todo) SetDue(date string, clock string, timezone string) error {
+	if date == "" {
+		if clock != "" {
+			return errors.New("due time requires due date")
+		}
+		t.DueAt = nil
+		t.DueHasTime = false
+		t.DueTimezone = ""
+		return nil
+	}
+
+	loc, err := loadLocation(timezone)
+	if err != nil {
+		return err
+	}
+
+	var at time.Time
+	if clock == "" {
+		at, err = time.ParseInLocation(DueDateLayout, date, loc)
+	} else {
+		at, err = time.ParseInLocation(DueDateLayout+" "+DueTimeLayout, date+" "+clock, loc)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid due date: %s %s", date, clock)
+	}
+
+	at = at.UTC()
+	t.DueAt = &at
+	t.DueHasTime = clock != ""
+	t.DueTimezone = timezone
+	return nil
+}
+
+// 期限のタイムゾーンを返す
+func (t Todo) DueLocation() *time.Location {
+	loc, err := loadLocation(t.DueTimezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// 期限の日時を返す
+// 終日の期限の場合は、その日の終わり（翌日の0時）を返す
+func (t Todo) Deadline() (time.Time, bool) {
+	if t.DueAt == nil {
+		return time.Time{}, false
+	}
+	at := t.DueAt.In(t.DueLocation())
+	if t.DueHasTime {
+		return at, true
+	}
+	return at.AddDate(0, 0, 1), true
+}
+
+// 指定された日時の時点で期限を過ぎているかを返す
+// 完了・中止したtodoは期限を過ぎていても対象外とする
+func (t Todo) IsOverdue(now time.Time) bool {
+	if t.Status.IsClosed() {
+		return false
+	}
+	deadline, ok := t.Deadline()
+	return ok && !now.Before(deadline)
+}
+
+// 期限の日付を入力形式の文字列で返す
+func (t Todo) DueDateString() string {
+	if t.DueAt == nil {
+		return ""
+	}
+	return t.DueAt.In(t.DueLocation()).Format(DueDateLayout)
+}
+
+// 期限の時刻を入力形式の文字列で返す
+// 終日の期限の場合は空文字列を返す
+func (t Todo) DueTimeString() string {
+	if t.DueAt == nil || !t.DueHasTime {
+		return ""
+	}
+	return t.DueAt.In(t.DueLocation()).Format(DueTimeLayout)
+}
+
+// 指定された日時を含む日の始まり（0時）を返す
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// 指定された日時を含む週の終わり（翌週の月曜日の0時）を返す
+func EndOfWeek(t time.Time) time.Time {
+	// 月曜日を週の始まりとして、日曜日までの残り日数を求める
+	daysLeft := (7 - int(t.Weekday())) % 7
+	return StartOfDay(t).AddDate(0, 0, daysLeft+1)
+}
+
+// タイムゾーンの名前からLocationを取得する
+// 空文字列の場合はサーバーのタイムゾーンを返す
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %s", name)
+	}
+	return loc, nil
+}
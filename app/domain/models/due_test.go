@@ -0,0 +1,166 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetDue(t *testing.T) {
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	type dueArgs struct {
+		date     string
+		clock    string
+		timezone string
+	}
+
+	cases := map[string]struct {
+		args        dueArgs
+		wantAt      *time.Time
+		wantHasTime bool
+		expectErr   bool
+	}{
+		"正常ケース:日付のみ": {
+			args:        dueArgs{date: "2025-03-31", timezone: "Asia/Tokyo"},
+			wantAt:      ptrTime(time.Date(2025, 3, 31, 0, 0, 0, 0, tokyo)),
+			wantHasTime: false,
+		},
+		"正常ケース:日付と時刻": {
+			args:        dueArgs{date: "2025-03-31", clock: "18:30", timezone: "Asia/Tokyo"},
+			wantAt:      ptrTime(time.Date(2025, 3, 31, 18, 30, 0, 0, tokyo)),
+			wantHasTime: true,
+		},
+		"正常ケース:期限の解除": {
+			args:   dueArgs{},
+			wantAt: nil,
+		},
+		"異常ケース:日付の形式が不正": {
+			args:      dueArgs{date: "2025/03/31"},
+			expectErr: true,
+		},
+		"異常ケース:時刻の形式が不正": {
+			args:      dueArgs{date: "2025-03-31", clock: "25:00"},
+			expectErr: true,
+		},
+		"異常ケース:日付が無く時刻のみ": {
+			args:      dueArgs{clock: "10:00"},
+			expectErr: true,
+		},
+		"異常ケース:存在しないタイムゾーン": {
+			args:      dueArgs{date: "2025-03-31", timezone: "Mars/Olympus"},
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			todo := Todo{Title: "test"}
+			err := todo.SetDue(tt.args.date, tt.args.clock, tt.args.timezone)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				if tt.wantAt == nil {
+					assert.Nil(t, todo.DueAt)
+					return
+				}
+				if assert.NotNil(t, todo.DueAt) {
+					assert.True(t, tt.wantAt.Equal(*todo.DueAt))
+				}
+				assert.Equal(t, tt.wantHasTime, todo.DueHasTime)
+				assert.Equal(t, tt.args.date, todo.DueDateString())
+				assert.Equal(t, tt.args.clock, todo.DueTimeString())
+			}
+		})
+	}
+}
+
+func TestIsOverdue(t *testing.T) {
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	// 期限を設定したtodoを生成する
+	newTodo := func(status Status, date string, clock string) Todo {
+		todo := Todo{Title: "test", Status: status}
+		_ = todo.SetDue(date, clock, "Asia/Tokyo")
+		return todo
+	}
+
+	cases := map[string]struct {
+		todo Todo
+		now  time.Time
+		want bool
+	}{
+		"正常ケース:期限なし": {
+			todo: Todo{Title: "test"},
+			now:  time.Date(2025, 4, 1, 0, 0, 0, 0, tokyo),
+			want: false,
+		},
+		"正常ケース:終日の期限の当日中": {
+			todo: newTodo(NotStarted, "2025-03-31", ""),
+			now:  time.Date(2025, 3, 31, 23, 59, 0, 0, tokyo),
+			want: false,
+		},
+		"正常ケース:終日の期限の翌日": {
+			todo: newTodo(NotStarted, "2025-03-31", ""),
+			now:  time.Date(2025, 4, 1, 0, 0, 0, 0, tokyo),
+			want: true,
+		},
+		"正常ケース:時刻付きの期限の直前": {
+			todo: newTodo(NotStarted, "2025-03-31", "18:00"),
+			now:  time.Date(2025, 3, 31, 17, 59, 0, 0, tokyo),
+			want: false,
+		},
+		"正常ケース:時刻付きの期限を過ぎている": {
+			todo: newTodo(NotStarted, "2025-03-31", "18:00"),
+			// 別のタイムゾーンで表した同じ時点でも判定できること
+			now:  time.Date(2025, 3, 31, 9, 30, 0, 0, time.UTC),
+			want: true,
+		},
+		"正常ケース:完了済みは期限を過ぎていても対象外": {
+			todo: newTodo(Done, "2025-03-31", ""),
+			now:  time.Date(2025, 4, 10, 0, 0, 0, 0, tokyo),
+			want: false,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.todo.IsOverdue(tt.now))
+		})
+	}
+}
+
+func TestEndOfWeek(t *testing.T) {
+
+	cases := map[string]struct {
+		now  time.Time
+		want time.Time
+	}{
+		"正常ケース:月曜日": {
+			now:  time.Date(2025, 3, 31, 15, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 4, 7, 0, 0, 0, 0, time.UTC),
+		},
+		"正常ケース:日曜日": {
+			now:  time.Date(2025, 4, 6, 15, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 4, 7, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EndOfWeek(tt.now))
+		})
+	}
+}
+
+// 日時のポインタを返す
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,72 @@
+package models
+
+import "errors"
+
+// ドメインのエラーの種類
+// 各層のエラーは種類を付けて返し、ハンドラーで種類ごとにステータスコードとメッセージに変換する
+type ErrorKind int
+
+const (
+	// 種類が付いていない（想定外のエラー）
+	KindUnknown ErrorKind = iota
+	// 対象が存在しない（他のユーザーのものを含む）
+	KindNotFound
+	// 入力が不正
+	KindValidation
+	// 他のデータや操作と競合した（同じ名前が既にある、先に更新されたなど）
+	KindConflict
+	// DBに接続できないなど、一時的に処理できない
+	KindUnavailable
+	// ログインしていない、または認証情報が誤っている
+	KindUnauthenticated
+)
+
+// 種類を付けたドメインのエラー
+// 元のエラーを包むため、errors.Is・errors.Asで元のエラーも判定できる
+type DomainError struct {
+	Kind ErrorKind
+	Err  error
+}
+
+func (e *DomainError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Err
+}
+
+// 対象が存在しないエラーとして包む
+func NotFound(err error) error {
+	return &DomainError{Kind: KindNotFound, Err: err}
+}
+
+// 入力が不正なエラーとして包む
+func Validation(err error) error {
+	return &DomainError{Kind: KindValidation, Err: err}
+}
+
+// 競合したエラーとして包む
+func Conflict(err error) error {
+	return &DomainError{Kind: KindConflict, Err: err}
+}
+
+// 一時的に処理できないエラーとして包む
+func Unavailable(err error) error {
+	return &DomainError{Kind: KindUnavailable, Err: err}
+}
+
+// 認証されていないエラーとして包む
+func Unauthenticated(err error) error {
+	return &DomainError{Kind: KindUnauthenticated, Err: err}
+}
+
+// エラーの種類を返す
+// 複数の種類で包まれている場合は最も外側のものを返し、種類が付いていない場合はKindUnknownを返す
+func KindOf(err error) ErrorKind {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Kind
+	}
+	return KindUnknown
+}
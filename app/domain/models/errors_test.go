@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindOf(t *testing.T) {
+
+	cause := errors.New("cause")
+
+	cases := map[string]struct {
+		err  error
+		want ErrorKind
+	}{
+		"正常ケース:対象が存在しない":     {err: NotFound(cause), want: KindNotFound},
+		"正常ケース:入力が不正":        {err: Validation(cause), want: KindValidation},
+		"正常ケース:競合した":         {err: Conflict(cause), want: KindConflict},
+		"正常ケース:一時的に処理できない":   {err: Unavailable(cause), want: KindUnavailable},
+		"正常ケース:認証されていない":     {err: Unauthenticated(cause), want: KindUnauthenticated},
+		"正常ケース:包まれたエラー":      {err: fmt.Errorf("failed: %w", NotFound(cause)), want: KindNotFound},
+		"正常ケース:外側の種類を優先する":   {err: Validation(NotFound(cause)), want: KindValidation},
+		"正常ケース:種類が付いた既存のエラー": {err: ErrTodoConflict, want: KindConflict},
+		"正常ケース:種類が付いていないエラー": {err: cause, want: KindUnknown},
+		"正常ケース:エラーが無い":       {err: nil, want: KindUnknown},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, KindOf(tt.err))
+		})
+	}
+}
+
+func TestDomainError(t *testing.T) {
+
+	cause := errors.New("cause")
+
+	t.Run("正常ケース:元のエラーの内容を返し、元のエラーで判定できる", func(t *testing.T) {
+		err := NotFound(cause)
+		assert.Equal(t, "cause", err.Error())
+		assert.ErrorIs(t, err, cause)
+	})
+
+	t.Run("正常ケース:更新の競合は競合の種類になる", func(t *testing.T) {
+		err := &TodoConflictError{Current: &Todo{}}
+		assert.Equal(t, KindConflict, KindOf(err))
+		assert.ErrorIs(t, err, ErrTodoConflict)
+	})
+}
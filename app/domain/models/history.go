@@ -0,0 +1,199 @@
+package models
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 変更履歴の操作の種類
+type HistoryAction string
+
+const (
+	HistoryCreated  HistoryAction = "created"
+	HistoryUpdated  HistoryAction = "updated"
+	HistoryDeleted  HistoryAction = "deleted"
+	HistoryRestored HistoryAction = "restored"
+	HistoryPurged   HistoryAction = "purged"
+)
+
+// 変更履歴に記録するtodoの項目
+const (
+	HistoryFieldTitle       = "title"
+	HistoryFieldStatus      = "status"
+	HistoryFieldPriority    = "priority"
+	HistoryFieldDescription = "description"
+	HistoryFieldDue         = "due"
+	HistoryFieldRecurrence  = "recurrence"
+	HistoryFieldProject     = "project"
+	HistoryFieldTags        = "tags"
+)
+
+// 操作の種類ごとの、画面に表示する名前
+var historyActionLabels = map[HistoryAction]string{
+	HistoryCreated:  "作成",
+	HistoryUpdated:  "変更",
+	HistoryDeleted:  "ゴミ箱に移動",
+	HistoryRestored: "元に戻す",
+	HistoryPurged:   "完全に削除",
+}
+
+// 項目ごとの、画面に表示する名前
+var historyFieldLabels = map[string]string{
+	HistoryFieldTitle:       "タイトル",
+	HistoryFieldStatus:      "状態",
+	HistoryFieldPriority:    "優先度",
+	HistoryFieldDescription: "説明",
+	HistoryFieldDue:         "期限",
+	HistoryFieldRecurrence:  "繰り返し",
+	HistoryFieldProject:     "プロジェクト",
+	HistoryFieldTags:        "タグ",
+}
+
+// todoの変更履歴の1件
+// 追記のみで変更・削除はせず、todoを完全に削除しても残す（操作したユーザーを削除した場合のみ削除する）
+type TodoHistory struct {
+	ID uint `gorm:"primarykey"`
+	// 操作した日時
+	CreatedAt time.Time
+	// 操作したtodoのID
+	TodoID uint
+	// 操作したユーザーのID
+	UserID uint
+	// 操作したユーザー（表示用に読み込む。履歴の保存時には更新しない）
+	User   User
+	Action HistoryAction
+	// 変更した項目と、変更前後の値（変更の場合のみ）
+	// 状態・優先度はフォームやAPIで使用する文字列、プロジェクトは名前、タグは名前の一覧を記録する
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+// 指定されたユーザーによる、指定されたtodoの操作の履歴を生成する
+func NewTodoHistory(todoID uint, userID uint, action HistoryAction) TodoHistory {
+	return TodoHistory{TodoID: todoID, UserID: userID, Action: action}
+}
+
+// todoの更新前後を比較し、変更した項目ごとの履歴を返す
+// 変更した項目が無い場合は空のスライスを返す
+// プロジェクトはIDを記録するため、名前に置き換える場合は呼び出し元で行う
+func TodoChanges(before *Todo, after *Todo, userID uint) []TodoHistory {
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{HistoryFieldTitle, before.Title, after.Title},
+		{HistoryFieldStatus, before.Status.Key(), after.Status.Key()},
+		{HistoryFieldPriority, before.Priority.Key(), after.Priority.Key()},
+		{HistoryFieldDescription, before.Description, after.Description},
+		{HistoryFieldDue, before.dueHistoryValue(), after.dueHistoryValue()},
+		{HistoryFieldRecurrence, before.Recurrence, after.Recurrence},
+		{HistoryFieldProject, uintString(before.ProjectID), uintString(after.ProjectID)},
+	}
+	changes := []TodoHistory{}
+	for _, f := range fields {
+		if f.old == f.new {
+			continue
+		}
+		history := NewTodoHistory(after.ID, userID, HistoryUpdated)
+		history.Field = f.name
+		history.OldValue = f.old
+		history.NewValue = f.new
+		changes = append(changes, history)
+	}
+	return changes
+}
+
+// todoに付けるタグを置き換える前後を比較し、変更した場合はタグの履歴を返す
+// タグの名前は並べ替えて比較するため、順序のみが異なる場合は変更が無いものとして空のスライスを返す
+func TagChanges(todoID uint, before []Tag, after []Tag, userID uint) []TodoHistory {
+	old, new := tagHistoryValue(before), tagHistoryValue(after)
+	if old == new {
+		return []TodoHistory{}
+	}
+	history := NewTodoHistory(todoID, userID, HistoryUpdated)
+	history.Field = HistoryFieldTags
+	history.OldValue = old
+	history.NewValue = new
+	return []TodoHistory{history}
+}
+
+// タグを履歴に記録する文字列で返す（例："backend, urgent"）
+// タグの名前にはカンマを使用できないため、名前の順に「, 」で区切る
+func tagHistoryValue(tags []Tag) string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
+
+// 画面に表示する操作の名前を返す
+func (h TodoHistory) ActionLabel() string {
+	return historyActionLabels[h.Action]
+}
+
+// 画面に表示する項目の名前を返す
+func (h TodoHistory) FieldLabel() string {
+	return historyFieldLabels[h.Field]
+}
+
+// 画面に表示する変更前の値を返す
+func (h TodoHistory) OldValueLabel() string {
+	return h.valueLabel(h.OldValue)
+}
+
+// 画面に表示する変更後の値を返す
+func (h TodoHistory) NewValueLabel() string {
+	return h.valueLabel(h.NewValue)
+}
+
+// 記録した値を画面に表示する文字列に変換する
+// 状態・優先度・繰り返しは名前に変換し、空の値は「なし」とする
+func (h TodoHistory) valueLabel(value string) string {
+	if value == "" {
+		return "なし"
+	}
+	switch h.Field {
+	case HistoryFieldStatus:
+		if s, err := StrToStatus(value, StatusCorrespond()); err == nil {
+			return s.Label()
+		}
+	case HistoryFieldPriority:
+		if p, err := StrToPriority(value, PriorityCorrespond()); err == nil {
+			return p.Label()
+		}
+	case HistoryFieldRecurrence:
+		if rule, err := ParseRecurrence(value); err == nil {
+			return rule.Label()
+		}
+	}
+	return value
+}
+
+// 期限を履歴に記録する文字列で返す（例："2025-06-01 09:00 Asia/Tokyo"）
+// 期限が無い場合は空文字列を返す
+func (t Todo) dueHistoryValue() string {
+	if t.DueAt == nil {
+		return ""
+	}
+	value := t.DueDateString()
+	if t.DueHasTime {
+		value += " " + t.DueTimeString()
+	}
+	if t.DueTimezone != "" {
+		value += " " + t.DueTimezone
+	}
+	return value
+}
+
+// IDを履歴に記録する文字列に変換する（0の場合は空文字列）
+func uintString(id uint) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(id), 10)
+}
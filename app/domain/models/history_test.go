@@ -0,0 +1,174 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTodoChanges(t *testing.T) {
+
+	due := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	dueAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := Todo{Model: gorm.Model{ID: 1}, ProjectID: 1, Title: "test", Status: NotStarted, Priority: PriorityMedium}
+
+	cases := map[string]struct {
+		after func() Todo
+		want  []TodoHistory
+	}{
+		"正常ケース:変更なし": {
+			after: func() Todo { return before },
+			want:  []TodoHistory{},
+		},
+		"正常ケース:複数の項目を変更": {
+			after: func() Todo {
+				after := before
+				after.Title = "changed"
+				after.Status = Done
+				after.ProjectID = 2
+				return after
+			},
+			want: []TodoHistory{
+				{TodoID: 1, UserID: 3, Action: HistoryUpdated, Field: HistoryFieldTitle, OldValue: "test", NewValue: "changed"},
+				{TodoID: 1, UserID: 3, Action: HistoryUpdated, Field: HistoryFieldStatus, OldValue: NotStarted.Key(), NewValue: Done.Key()},
+				{TodoID: 1, UserID: 3, Action: HistoryUpdated, Field: HistoryFieldProject, OldValue: "1", NewValue: "2"},
+			},
+		},
+		"正常ケース:期限を時刻とタイムゾーン付きで設定": {
+			after: func() Todo {
+				after := before
+				after.DueAt = &dueAt
+				after.DueHasTime = true
+				after.DueTimezone = "Asia/Tokyo"
+				return after
+			},
+			want: []TodoHistory{
+				{TodoID: 1, UserID: 3, Action: HistoryUpdated, Field: HistoryFieldDue, OldValue: "", NewValue: "2025-06-01 09:00 Asia/Tokyo"},
+			},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			after := tt.after()
+			assert.Equal(t, tt.want, TodoChanges(&before, &after, 3))
+		})
+	}
+
+	t.Run("正常ケース:終日の期限を削除", func(t *testing.T) {
+		current := before
+		current.DueAt = &due
+		assert.Equal(t, []TodoHistory{
+			{TodoID: 1, UserID: 3, Action: HistoryUpdated, Field: HistoryFieldDue, OldValue: "2025-06-01", NewValue: ""},
+		}, TodoChanges(&current, &before, 3))
+	})
+}
+
+func TestTagChanges(t *testing.T) {
+
+	backend := Tag{ID: 1, Name: "backend"}
+	urgent := Tag{ID: 2, Name: "urgent"}
+	home := Tag{Name: "家"}
+
+	cases := map[string]struct {
+		before []Tag
+		after  []Tag
+		want   []TodoHistory
+	}{
+		"正常ケース:変更なし": {
+			before: []Tag{backend, urgent},
+			after:  []Tag{urgent, backend},
+			want:   []TodoHistory{},
+		},
+		"正常ケース:タグを付け替える": {
+			before: []Tag{urgent, backend},
+			after:  []Tag{backend, home},
+			want: []TodoHistory{
+				{TodoID: 1, UserID: 3, Action: HistoryUpdated, Field: HistoryFieldTags, OldValue: "backend, urgent", NewValue: "backend, 家"},
+			},
+		},
+		"正常ケース:全てのタグを外す": {
+			before: []Tag{backend},
+			after:  []Tag{},
+			want: []TodoHistory{
+				{TodoID: 1, UserID: 3, Action: HistoryUpdated, Field: HistoryFieldTags, OldValue: "backend", NewValue: ""},
+			},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TagChanges(1, tt.before, tt.after, 3))
+		})
+	}
+}
+
+func TestTodoHistoryLabel(t *testing.T) {
+
+	cases := map[string]struct {
+		history  TodoHistory
+		action   string
+		field    string
+		oldValue string
+		newValue string
+	}{
+		"作成": {
+			history:  TodoHistory{Action: HistoryCreated},
+			action:   "作成",
+			oldValue: "なし",
+			newValue: "なし",
+		},
+		"状態の変更": {
+			history:  TodoHistory{Action: HistoryUpdated, Field: HistoryFieldStatus, OldValue: NotStarted.Key(), NewValue: Done.Key()},
+			action:   "変更",
+			field:    "状態",
+			oldValue: NotStarted.Label(),
+			newValue: Done.Label(),
+		},
+		"優先度の変更": {
+			history:  TodoHistory{Action: HistoryUpdated, Field: HistoryFieldPriority, OldValue: PriorityLow.Key(), NewValue: PriorityHigh.Key()},
+			action:   "変更",
+			field:    "優先度",
+			oldValue: PriorityLow.Label(),
+			newValue: PriorityHigh.Label(),
+		},
+		"繰り返しの設定": {
+			history:  TodoHistory{Action: HistoryUpdated, Field: HistoryFieldRecurrence, NewValue: "FREQ=MONTHLY;BYMONTHDAY=-1"},
+			action:   "変更",
+			field:    "繰り返し",
+			oldValue: "なし",
+			newValue: "毎月（月末）",
+		},
+		"タイトルの変更はそのまま表示": {
+			history:  TodoHistory{Action: HistoryUpdated, Field: HistoryFieldTitle, OldValue: "a", NewValue: "b"},
+			action:   "変更",
+			field:    "タイトル",
+			oldValue: "a",
+			newValue: "b",
+		},
+		"タグの付け替え": {
+			history:  TodoHistory{Action: HistoryUpdated, Field: HistoryFieldTags, OldValue: "backend", NewValue: ""},
+			action:   "変更",
+			field:    "タグ",
+			oldValue: "backend",
+			newValue: "なし",
+		},
+		"ゴミ箱に移動": {
+			history:  TodoHistory{Action: HistoryDeleted},
+			action:   "ゴミ箱に移動",
+			oldValue: "なし",
+			newValue: "なし",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.action, tt.history.ActionLabel())
+			assert.Equal(t, tt.field, tt.history.FieldLabel())
+			assert.Equal(t, tt.oldValue, tt.history.OldValueLabel())
+			assert.Equal(t, tt.newValue, tt.history.NewValueLabel())
+		})
+	}
+}
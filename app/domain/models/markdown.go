@@ -0,0 +1,39 @@
+package models
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// MarkdownをHTMLに変換するパーサー
+// 表・取り消し線・チェックボックスなど、GitHubと同じ記法に対応する
+// 生のHTMLは出力しない（goldmarkの既定）が、念のため変換後にもサニタイズする
+var markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// 変換後のHTMLに許可する要素・属性
+// リンクには外部のページを開いても元のページを操作されないよう、rel属性を付ける
+var markdownPolicy = func() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("type", "checked", "disabled").OnElements("input")
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}()
+
+// Markdownの文字列を、安全なHTMLに変換して返す
+// scriptタグやjavascript:のリンクなどは取り除く
+func RenderMarkdown(src string) template.HTML {
+	if src == "" {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(src), &buf); err != nil {
+		// 変換に失敗した場合は、エスケープした元の文字列を表示する
+		return template.HTML("<pre>" + template.HTMLEscapeString(src) + "</pre>")
+	}
+	return template.HTML(markdownPolicy.SanitizeBytes(buf.Bytes()))
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"errors"
+	"sort"
+)
+
+// Enumの代わり
+// PriorityMedium：中（既定値）
+// PriorityLow：低
+// PriorityHigh：高
+// PriorityUrgent：緊急
+// DBには数値で保存しているため、既存の値を変えないよう末尾に追加すること
+// 既存のtodoが「中」になるよう、ゼロ値を「中」にしています
+type Priority int
+
+const (
+	invalidPriority Priority = iota - 1
+	PriorityMedium
+	PriorityLow
+	PriorityHigh
+	PriorityUrgent
+)
+
+// 優先度ごとの定義
+// 画面・APIでの表現や並び順は全てこの定義から導出する
+type priorityDefinition struct {
+	// フォームやAPIで使用する文字列
+	key string
+	// 画面に表示する名前
+	label string
+	// 一覧での並び順（昇順。優先度の高いものから並べる）
+	order int
+	// 画面で使用するCSSのクラス
+	class string
+}
+
+// 優先度の定義の一覧
+var priorityDefinitions = map[Priority]priorityDefinition{
+	PriorityUrgent: {
+		key:   "urgent",
+		label: "緊急",
+		order: 0,
+		class: "priority-urgent",
+	},
+	PriorityHigh: {
+		key:   "high",
+		label: "高",
+		order: 1,
+		class: "priority-high",
+	},
+	PriorityMedium: {
+		key:   "medium",
+		label: "中",
+		order: 2,
+		class: "priority-medium",
+	},
+	PriorityLow: {
+		key:   "low",
+		label: "低",
+		order: 3,
+		class: "priority-low",
+	},
+}
+
+// 定義済みの優先度かを返す
+func (p Priority) IsValid() bool {
+	_, ok := priorityDefinitions[p]
+	return ok
+}
+
+// フォームやAPIで使用する文字列を返す
+func (p Priority) Key() string {
+	return priorityDefinitions[p].key
+}
+
+// 画面に表示する名前を返す
+func (p Priority) Label() string {
+	return priorityDefinitions[p].label
+}
+
+// 画面で使用するCSSのクラスを返す
+func (p Priority) CSSClass() string {
+	return priorityDefinitions[p].class
+}
+
+// 一覧での並び順を返す
+func (p Priority) SortOrder() int {
+	return priorityDefinitions[p].order
+}
+
+// 全ての優先度を一覧での並び順（高いものから）で返す
+func Priorities() []Priority {
+	priorities := make([]Priority, 0, len(priorityDefinitions))
+	for p := range priorityDefinitions {
+		priorities = append(priorities, p)
+	}
+	sort.Slice(priorities, func(i, j int) bool {
+		return priorities[i].SortOrder() < priorities[j].SortOrder()
+	})
+	return priorities
+}
+
+// フォームやAPIで使用する文字列と優先度の対応表を返す
+// StrToPriorityの変換に使用する
+func PriorityCorrespond() map[string]Priority {
+	correspond := make(map[string]Priority, len(priorityDefinitions))
+	for p, def := range priorityDefinitions {
+		correspond[def.key] = p
+	}
+	return correspond
+}
+
+// 文字列を優先度に変換する
+// StrToStatusと同様に、空文字列・対応表に無い文字列・定義されていない値の場合はエラーを返す
+func StrToPriority(target string, correspond map[string]Priority) (Priority, error) {
+	if target == "" {
+		return invalidPriority, errors.New("target string is empty")
+	}
+	if len(correspond) == 0 {
+		return invalidPriority, errors.New("correspond map is empty")
+	}
+
+	for key, value := range correspond {
+		if key == target && value.IsValid() {
+			return value, nil
+		}
+	}
+	return invalidPriority, errors.New("invalid priority value: " + target)
+}
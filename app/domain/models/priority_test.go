@@ -0,0 +1,89 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrToPriority(t *testing.T) {
+
+	type args struct {
+		target     string
+		correspond map[string]Priority
+	}
+
+	cases := map[string]struct {
+		args       args
+		want       Priority
+		expectErr  bool
+		errMessage string
+	}{
+		"正常ケース:緊急": {
+			args: args{target: "urgent", correspond: PriorityCorrespond()},
+			want: PriorityUrgent,
+		},
+		"正常ケース:低": {
+			args: args{target: "low", correspond: PriorityCorrespond()},
+			want: PriorityLow,
+		},
+		"異常ケース:対象の文字列が空文字列": {
+			args:       args{target: "", correspond: PriorityCorrespond()},
+			want:       invalidPriority,
+			expectErr:  true,
+			errMessage: "target string is empty",
+		},
+		"異常ケース:対象の文字列が変換マップに無い": {
+			args:       args{target: "critical", correspond: PriorityCorrespond()},
+			want:       invalidPriority,
+			expectErr:  true,
+			errMessage: "invalid priority value: critical",
+		},
+		"異常ケース:変換マップが空": {
+			args:       args{target: "high", correspond: map[string]Priority{}},
+			want:       invalidPriority,
+			expectErr:  true,
+			errMessage: "correspond map is empty",
+		},
+		"異常ケース:変換後の値が定義されていない": {
+			args:       args{target: "high", correspond: map[string]Priority{"high": 99}},
+			want:       invalidPriority,
+			expectErr:  true,
+			errMessage: "invalid priority value: high",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			result, err := StrToPriority(tt.args.target, tt.args.correspond)
+
+			// エラーを確認
+			if tt.expectErr {
+				if assert.Error(t, err) {
+					assert.Equal(t, tt.errMessage, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestPriorities(t *testing.T) {
+
+	// 優先度の高いものから全ての優先度を返すこと
+	assert.Equal(t, []Priority{PriorityUrgent, PriorityHigh, PriorityMedium, PriorityLow}, Priorities())
+
+	// ゼロ値は「中」であること
+	var zero Priority
+	assert.Equal(t, PriorityMedium, zero)
+
+	// 文字列との変換が往復できること
+	for _, p := range Priorities() {
+		priority, err := StrToPriority(p.Key(), PriorityCorrespond())
+		if assert.NoError(t, err) {
+			assert.Equal(t, p, priority)
+		}
+	}
+}
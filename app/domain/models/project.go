@@ -0,0 +1,66 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// プロジェクトの名前の長さの上限（文字数）
+const ProjectNameMaxLength = 100
+
+// プロジェクトを指定せずに作成したtodoの保存先の名前
+const InboxProjectName = "Inbox"
+
+// プロジェクトに関わるエラー
+var (
+	// プロジェクトの名前が不正
+	ErrInvalidProjectName = Validation(errors.New("invalid project name"))
+	// Inboxは名前の変更・削除ができない
+	ErrInboxProject = Validation(errors.New("inbox project cannot be renamed or deleted"))
+)
+
+// todoをまとめるためのプロジェクト（ボード）
+// ユーザーごとに管理し、同じユーザーの中で名前は重複しない
+type Project struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// 所有者のユーザーID
+	UserID uint
+	Name   string
+	// プロジェクトを指定せずに作成したtodoの保存先か（ユーザーごとに1つ）
+	Inbox bool
+	// プロジェクトのtodoの件数（一覧の取得時のみ設定される）
+	TodoCount int64 `gorm:"-"`
+}
+
+// 指定されたユーザーの、指定された名前のプロジェクトを生成する
+func NewProject(userID uint, name string) (*Project, error) {
+	project := Project{UserID: userID}
+	if err := project.Rename(name); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// 指定されたユーザーのInboxを生成する
+func NewInboxProject(userID uint) *Project {
+	return &Project{UserID: userID, Name: InboxProjectName, Inbox: true}
+}
+
+// プロジェクトの名前を変更する
+// Inboxの名前は変更できない
+func (p *Project) Rename(name string) error {
+	if p.Inbox {
+		return ErrInboxProject
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > ProjectNameMaxLength || name == InboxProjectName {
+		return fmt.Errorf("%w: %q", ErrInvalidProjectName, name)
+	}
+	p.Name = name
+	return nil
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProject(t *testing.T) {
+
+	cases := map[string]struct {
+		name      string
+		want      string
+		expectErr bool
+	}{
+		"正常ケース:前後の空白を除く": {
+			name: "  仕事 ",
+			want: "仕事",
+		},
+		"正常ケース:上限の文字数": {
+			name: strings.Repeat("あ", ProjectNameMaxLength),
+			want: strings.Repeat("あ", ProjectNameMaxLength),
+		},
+		"異常ケース:空白のみ": {
+			name:      " 　",
+			expectErr: true,
+		},
+		"異常ケース:上限を超える文字数": {
+			name:      strings.Repeat("あ", ProjectNameMaxLength+1),
+			expectErr: true,
+		},
+		"異常ケース:Inboxと同じ名前": {
+			name:      " Inbox ",
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			project, err := NewProject(1, tt.name)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidProjectName)
+				assert.Nil(t, project)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, &Project{UserID: 1, Name: tt.want}, project)
+			}
+		})
+	}
+}
+
+func TestRenameInboxProject(t *testing.T) {
+	inbox := NewInboxProject(1)
+
+	err := inbox.Rename("受信箱")
+
+	// 結果を確認
+	assert.ErrorIs(t, err, ErrInboxProject)
+	assert.Equal(t, InboxProjectName, inbox.Name)
+}
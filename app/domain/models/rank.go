@@ -0,0 +1,229 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 並び順（position）の文字列の生成
+// 2つの値の間の値を生成できるため、並び替えでは移動したtodoの値のみを更新すればよい
+//
+// 値は「整数部＋小数部」の文字列で、文字列として比較した順序が並び順になる
+//   - 整数部：先頭の1文字で桁数を表す（a：1桁、b：2桁、…、z：26桁。A〜Zは負の値で、Z：1桁、Y：2桁、…）
+//   - 小数部：整数部の間に挿入する場合に使用する（末尾は0にしない）
+//
+// 末尾への追加では整数部を1つ増やすため、件数が増えても値は短いまま保たれる
+// 同じ位置への挿入を繰り返した場合は、小数部が1文字ずつ長くなる
+
+// 値に使用する文字（ASCIIの順に並べる）
+const rankDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// 最初の値
+const rankZero = "a0"
+
+// 最小の整数部（これより前には小数部で挿入する）
+var rankSmallestInteger = "A" + strings.Repeat("0", 26)
+
+// 並び順の値が不正な場合のエラー
+var ErrInvalidRank = Validation(errors.New("invalid rank"))
+
+// lowerとupperの間に並ぶ値を返す
+// lowerが空文字列の場合は先頭、upperが空文字列の場合は末尾に並ぶ値を返す
+func RankBetween(lower string, upper string) (string, error) {
+	if lower != "" {
+		if err := validateRank(lower); err != nil {
+			return "", err
+		}
+	}
+	if upper != "" {
+		if err := validateRank(upper); err != nil {
+			return "", err
+		}
+	}
+	if lower != "" && upper != "" && lower >= upper {
+		return "", fmt.Errorf("%w: %q is not before %q", ErrInvalidRank, lower, upper)
+	}
+
+	switch {
+	case lower == "" && upper == "":
+		return rankZero, nil
+	case lower == "":
+		intUpper, _ := rankIntegerPart(upper)
+		fracUpper := upper[len(intUpper):]
+		if intUpper == rankSmallestInteger {
+			return intUpper + rankMidpoint("", fracUpper), nil
+		}
+		if intUpper < upper {
+			return intUpper, nil
+		}
+		prev, ok := decrementRankInteger(intUpper)
+		if !ok {
+			return "", fmt.Errorf("%w: no rank before %q", ErrInvalidRank, upper)
+		}
+		return prev, nil
+	case upper == "":
+		intLower, _ := rankIntegerPart(lower)
+		fracLower := lower[len(intLower):]
+		next, ok := incrementRankInteger(intLower)
+		if !ok {
+			return intLower + rankMidpoint(fracLower, ""), nil
+		}
+		return next, nil
+	}
+
+	intLower, _ := rankIntegerPart(lower)
+	fracLower := lower[len(intLower):]
+	intUpper, _ := rankIntegerPart(upper)
+	fracUpper := upper[len(intUpper):]
+	if intLower == intUpper {
+		return intLower + rankMidpoint(fracLower, fracUpper), nil
+	}
+	next, ok := incrementRankInteger(intLower)
+	if ok && next < upper {
+		return next, nil
+	}
+	return intLower + rankMidpoint(fracLower, ""), nil
+}
+
+// 並び順の値として正しい形式かを検証する
+func validateRank(rank string) error {
+	integer, err := rankIntegerPart(rank)
+	if err != nil {
+		return err
+	}
+	if integer == rankSmallestInteger && len(rank) == len(integer) {
+		return fmt.Errorf("%w: %q", ErrInvalidRank, rank)
+	}
+	for i := 1; i < len(rank); i++ {
+		if strings.IndexByte(rankDigits, rank[i]) < 0 {
+			return fmt.Errorf("%w: %q", ErrInvalidRank, rank)
+		}
+	}
+	if len(rank) > len(integer) && rank[len(rank)-1] == rankDigits[0] {
+		return fmt.Errorf("%w: %q", ErrInvalidRank, rank)
+	}
+	return nil
+}
+
+// 値の整数部を返す
+func rankIntegerPart(rank string) (string, error) {
+	if rank == "" {
+		return "", fmt.Errorf("%w: empty", ErrInvalidRank)
+	}
+	n, ok := rankIntegerLength(rank[0])
+	if !ok || len(rank) < n {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRank, rank)
+	}
+	return rank[:n], nil
+}
+
+// 整数部の先頭の文字から、整数部の長さ（先頭の文字を含む）を返す
+func rankIntegerLength(head byte) (int, bool) {
+	switch {
+	case head >= 'a' && head <= 'z':
+		return int(head-'a') + 2, true
+	case head >= 'A' && head <= 'Z':
+		return int('Z'-head) + 2, true
+	}
+	return 0, false
+}
+
+// 整数部を1つ増やした値を返す
+// 最大の値の場合はfalseを返す
+func incrementRankInteger(integer string) (string, bool) {
+	head, digits := integer[0], []byte(integer[1:])
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := strings.IndexByte(rankDigits, digits[i])
+		if d < len(rankDigits)-1 {
+			digits[i] = rankDigits[d+1]
+			return string(head) + string(digits), true
+		}
+		digits[i] = rankDigits[0]
+	}
+	// 桁があふれた場合は、先頭の文字を進めて桁数を変える
+	switch {
+	case head == 'Z':
+		return rankZero, true
+	case head == 'z':
+		return "", false
+	case head >= 'a':
+		return string(head+1) + string(digits) + string(rankDigits[0]), true
+	default:
+		return string(head+1) + string(digits[1:]), true
+	}
+}
+
+// 整数部を1つ減らした値を返す
+// 最小の値の場合はfalseを返す
+func decrementRankInteger(integer string) (string, bool) {
+	head, digits := integer[0], []byte(integer[1:])
+	last := rankDigits[len(rankDigits)-1]
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := strings.IndexByte(rankDigits, digits[i])
+		if d > 0 {
+			digits[i] = rankDigits[d-1]
+			return string(head) + string(digits), true
+		}
+		digits[i] = last
+	}
+	// 桁が足りなくなった場合は、先頭の文字を戻して桁数を変える
+	switch {
+	case head == 'a':
+		return "Z" + string(last), true
+	case head == 'A':
+		return "", false
+	case head <= 'Z':
+		return string(head-1) + string(digits) + string(last), true
+	default:
+		return string(head-1) + string(digits[1:]), true
+	}
+}
+
+// 小数部のlowerとupperの間の値を返す
+// lowerが空文字列の場合は0、upperが空文字列の場合は1として扱う
+func rankMidpoint(lower string, upper string) string {
+	if upper != "" {
+		// 共通する先頭の部分は、そのまま使用する
+		n := 0
+		for n < len(upper) && rankDigitAt(lower, n) == upper[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(lower) {
+				rest = lower[n:]
+			}
+			return upper[:n] + rankMidpoint(rest, upper[n:])
+		}
+	}
+
+	dl := 0
+	if lower != "" {
+		dl = strings.IndexByte(rankDigits, lower[0])
+	}
+	du := len(rankDigits)
+	if upper != "" {
+		du = strings.IndexByte(rankDigits, upper[0])
+	}
+	if du-dl > 1 {
+		return string(rankDigits[(dl+du+1)/2])
+	}
+	// 隣り合う文字の場合は、次の桁で間の値を求める
+	if len(upper) > 1 {
+		return upper[:1]
+	}
+	rest := ""
+	if lower != "" {
+		rest = lower[1:]
+	}
+	return string(rankDigits[dl]) + rankMidpoint(rest, "")
+}
+
+// 指定された位置の文字を返す（範囲外の場合は0）
+func rankDigitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return rankDigits[0]
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankBetween(t *testing.T) {
+
+	cases := map[string]struct {
+		lower     string
+		upper     string
+		want      string
+		expectErr bool
+	}{
+		"正常ケース:最初の値":         {want: "a0"},
+		"正常ケース:末尾に追加":        {lower: "a0", want: "a1"},
+		"正常ケース:末尾に追加で桁が増える":  {lower: "az", want: "b00"},
+		"正常ケース:先頭に追加":        {upper: "a0", want: "Zz"},
+		"正常ケース:小数部のある値の先頭":   {upper: "a0V", want: "a0"},
+		"正常ケース:隣り合う整数の間":     {lower: "a0", upper: "a1", want: "a0V"},
+		"正常ケース:離れた整数の間":      {lower: "a0", upper: "a5", want: "a1"},
+		"正常ケース:小数部の間":        {lower: "a0V", upper: "a0W", want: "a0VV"},
+		"正常ケース:共通する先頭の部分を使う": {lower: "a01", upper: "a0101", want: "a0100V"},
+		"異常ケース:順序が逆":         {lower: "a1", upper: "a0", expectErr: true},
+		"異常ケース:同じ値":          {lower: "a1", upper: "a1", expectErr: true},
+		"異常ケース:末尾が0の小数部":     {lower: "a0V0", expectErr: true},
+		"異常ケース:整数部の桁が足りない":   {lower: "b0", expectErr: true},
+		"異常ケース:使用できない文字":     {lower: "a-", expectErr: true},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := RankBetween(tt.lower, tt.upper)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidRank)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+				assert.Less(t, tt.lower, got)
+				if tt.upper != "" {
+					assert.Less(t, got, tt.upper)
+				}
+			}
+		})
+	}
+}
+
+func TestRankBetweenRepeated(t *testing.T) {
+
+	t.Run("正常ケース:末尾への追加を繰り返しても短いまま", func(t *testing.T) {
+		rank := ""
+		for range 10000 {
+			next, err := RankBetween(rank, "")
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Less(t, rank, next)
+			rank = next
+		}
+		assert.LessOrEqual(t, len(rank), 4)
+	})
+
+	t.Run("正常ケース:先頭への追加を繰り返しても短いまま", func(t *testing.T) {
+		rank := ""
+		for range 10000 {
+			prev, err := RankBetween("", rank)
+			if !assert.NoError(t, err) {
+				return
+			}
+			if rank != "" {
+				assert.Less(t, prev, rank)
+			}
+			rank = prev
+		}
+		assert.LessOrEqual(t, len(rank), 4)
+	})
+
+	t.Run("正常ケース:任意の位置への挿入を繰り返しても順序が保たれる", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		ranks := []string{}
+		for range 2000 {
+			i := r.Intn(len(ranks) + 1)
+			lower, upper := "", ""
+			if i > 0 {
+				lower = ranks[i-1]
+			}
+			if i < len(ranks) {
+				upper = ranks[i]
+			}
+			rank, err := RankBetween(lower, upper)
+			if !assert.NoError(t, err) {
+				return
+			}
+			ranks = slices.Insert(ranks, i, rank)
+		}
+		assert.True(t, slices.IsSorted(ranks))
+		assert.Len(t, slices.Compact(slices.Clone(ranks)), len(ranks))
+	})
+}
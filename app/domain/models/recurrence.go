@@ -0,0 +1,520 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 繰り返しの指定が不正な場合のエラー
+var ErrInvalidRecurrence = Validation(errors.New("invalid recurrence"))
+
+// 繰り返しの頻度
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// 繰り返しの間隔の上限
+const RecurrenceMaxInterval = 999
+
+// 保存する繰り返しの規則（正規化したRRULE）の長さの上限
+const RecurrenceMaxLength = 255
+
+// フォームやAPIで指定できる、よく使う繰り返しの指定
+// それ以外はRRULEで指定する（カスタム）
+const RecurrenceCustom = "custom"
+
+// 繰り返しの種類ごとの頻度
+var recurrencePresets = map[string]Frequency{
+	"daily":   Daily,
+	"weekly":  Weekly,
+	"monthly": Monthly,
+}
+
+// フォームで表示する繰り返しの種類の順序（頻度の短い順）
+var recurrencePresetKeys = []string{"daily", "weekly", "monthly"}
+
+// 繰り返しの頻度ごとの画面に表示する名前
+var frequencyLabels = map[Frequency]string{
+	Daily:   "毎日",
+	Weekly:  "毎週",
+	Monthly: "毎月",
+}
+
+// RRULEの曜日の表記（time.Weekdayの順）
+var weekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// 画面に表示する曜日の名前（time.Weekdayの順）
+var weekdayLabels = []string{"日", "月", "火", "水", "木", "金", "土"}
+
+// 次の繰り返しを探す期間の数の上限
+// 2月30日のように存在しない日しか当てはまらない指定で、探し続けないようにする
+const recurrenceSearchLimit = 1000
+
+// BYDAYの1つの指定
+// Ordinalは毎月の場合のみ使用し、0の場合は月の全ての該当する曜日とする（2MOは第2月曜日、-1FRは最終金曜日）
+type RecurrenceDay struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// 繰り返しの規則
+// iCalendar（RFC 5545）のRRULEのうち、FREQ（DAILY・WEEKLY・MONTHLY）・INTERVAL・BYDAY・BYMONTHDAY・UNTILに対応する
+// 繰り返しの起点（DTSTART）は、そのtodoの期限とする
+type RecurrenceRule struct {
+	Freq     Frequency
+	Interval int
+	// 曜日の指定（毎日の場合は絞り込み、毎週・毎月の場合は該当する日）
+	ByDay []RecurrenceDay
+	// 毎月の日の指定（負の値は月末からの日数で、-1は月末）
+	ByMonthDay []int
+	// 繰り返しの終了日時（nilの場合は無期限）
+	// UntilHasTimeがfalseの場合は、期限のタイムゾーンでのその日の終わりまでとする
+	Until        *time.Time
+	UntilHasTime bool
+}
+
+// 繰り返しの指定を解析する
+// daily・weekly・monthlyのほか、RRULE（先頭の「RRULE:」は省略できる）を指定できる
+// 対応していない項目を含む場合や、値が不正な場合はErrInvalidRecurrenceを返す
+func ParseRecurrence(s string) (RecurrenceRule, error) {
+	s = strings.TrimSpace(s)
+	if freq, ok := recurrencePresets[strings.ToLower(s)]; ok {
+		return RecurrenceRule{Freq: freq, Interval: 1}, nil
+	}
+	s = strings.TrimPrefix(strings.ToUpper(s), "RRULE:")
+	if s == "" {
+		return RecurrenceRule{}, fmt.Errorf("%w: empty rule", ErrInvalidRecurrence)
+	}
+
+	rule := RecurrenceRule{Interval: 1}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return RecurrenceRule{}, fmt.Errorf("%w: %s", ErrInvalidRecurrence, part)
+		}
+		if seen[name] {
+			return RecurrenceRule{}, fmt.Errorf("%w: duplicate %s", ErrInvalidRecurrence, name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Freq = Frequency(value)
+			if _, ok := frequencyLabels[rule.Freq]; !ok {
+				err = fmt.Errorf("unsupported FREQ %s", value)
+			}
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(value)
+			if err == nil && (rule.Interval < 1 || rule.Interval > RecurrenceMaxInterval) {
+				err = fmt.Errorf("INTERVAL must be between 1 and %d", RecurrenceMaxInterval)
+			}
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseByMonthDay(value)
+		case "UNTIL":
+			err = rule.parseUntil(value)
+		default:
+			err = fmt.Errorf("unsupported %s", name)
+		}
+		if err != nil {
+			return RecurrenceRule{}, fmt.Errorf("%w: %w", ErrInvalidRecurrence, err)
+		}
+	}
+
+	if rule.Freq == "" {
+		return RecurrenceRule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRecurrence)
+	}
+	if len(rule.ByMonthDay) > 0 && rule.Freq != Monthly {
+		return RecurrenceRule{}, fmt.Errorf("%w: BYMONTHDAY requires FREQ=MONTHLY", ErrInvalidRecurrence)
+	}
+	for _, day := range rule.ByDay {
+		if day.Ordinal != 0 && rule.Freq != Monthly {
+			return RecurrenceRule{}, fmt.Errorf("%w: ordinal BYDAY requires FREQ=MONTHLY", ErrInvalidRecurrence)
+		}
+	}
+	return rule, nil
+}
+
+// BYDAYの値（例：MO,WE、2MO、-1FR）を解析する
+func parseByDay(value string) ([]RecurrenceDay, error) {
+	var days []RecurrenceDay
+	for _, s := range strings.Split(value, ",") {
+		if len(s) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY %s", s)
+		}
+		code := s[len(s)-2:]
+		weekday := -1
+		for i, c := range weekdayCodes {
+			if c == code {
+				weekday = i
+			}
+		}
+		if weekday < 0 {
+			return nil, fmt.Errorf("invalid BYDAY %s", s)
+		}
+		day := RecurrenceDay{Weekday: time.Weekday(weekday)}
+		if prefix := s[:len(s)-2]; prefix != "" {
+			ordinal, err := strconv.Atoi(prefix)
+			if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+				return nil, fmt.Errorf("invalid BYDAY %s", s)
+			}
+			day.Ordinal = ordinal
+		}
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+// BYMONTHDAYの値（例：1,15、-1）を解析する
+func parseByMonthDay(value string) ([]int, error) {
+	var days []int
+	for _, s := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(s)
+		if err != nil || day == 0 || day < -31 || day > 31 {
+			return nil, fmt.Errorf("invalid BYMONTHDAY %s", s)
+		}
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+// UNTILの値（YYYYMMDD、またはUTCのYYYYMMDDTHHMMSSZ）を解析する
+func (r *RecurrenceRule) parseUntil(value string) error {
+	if at, err := time.Parse("20060102", value); err == nil {
+		r.Until, r.UntilHasTime = &at, false
+		return nil
+	}
+	if at, err := time.Parse("20060102T150405Z", value); err == nil {
+		r.Until, r.UntilHasTime = &at, true
+		return nil
+	}
+	return fmt.Errorf("invalid UNTIL %s", value)
+}
+
+// 規則をRRULEの形式（先頭の「RRULE:」は含まない）で返す
+// 項目の順序を揃え、既定値のINTERVAL=1は省略する
+func (r RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			code := weekdayCodes[day.Weekday]
+			if day.Ordinal != 0 {
+				code = strconv.Itoa(day.Ordinal) + code
+			}
+			days = append(days, code)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Until != nil {
+		if r.UntilHasTime {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+// 画面に表示する説明を返す（例：2週ごと（月・水））
+func (r RecurrenceRule) Label() string {
+	label := frequencyLabels[r.Freq]
+	if r.Interval > 1 {
+		unit := map[Frequency]string{Daily: "日", Weekly: "週", Monthly: "か月"}[r.Freq]
+		label = strconv.Itoa(r.Interval) + unit + "ごと"
+	}
+
+	var details []string
+	for _, day := range r.ByDay {
+		name := weekdayLabels[day.Weekday]
+		switch {
+		case day.Ordinal == -1:
+			name = "最終" + name
+		case day.Ordinal < 0:
+			name = "最後から第" + strconv.Itoa(-day.Ordinal) + name
+		case day.Ordinal > 0:
+			name = "第" + strconv.Itoa(day.Ordinal) + name
+		}
+		details = append(details, name)
+	}
+	for _, day := range r.ByMonthDay {
+		switch {
+		case day == -1:
+			details = append(details, "月末")
+		case day < 0:
+			details = append(details, "月末から"+strconv.Itoa(-day-1)+"日前")
+		default:
+			details = append(details, strconv.Itoa(day)+"日")
+		}
+	}
+	if len(details) > 0 {
+		label += "（" + strings.Join(details, "・") + "）"
+	}
+	if r.Until != nil {
+		label += "、" + r.Until.Format("2006-01-02") + "まで"
+	}
+	return label
+}
+
+// 指定された日時より後の、最初の繰り返しの日時を返す
+// startは繰り返しの起点で、そのタイムゾーンでの時刻を保ったまま日付を進める
+// （夏時間の切り替えがあっても、現地の時刻は変わらない）
+// 起点の日付より後の日が対象となり、存在しない日（2月30日など）は飛ばす
+// 繰り返しが終了している場合はfalseを返す
+func (r RecurrenceRule) Next(start time.Time) (time.Time, bool) {
+	interval := max(r.Interval, 1)
+	for period := 0; period < recurrenceSearchLimit; period++ {
+		for _, date := range r.candidates(start, period*interval) {
+			if !date.After(StartOfDay(start)) {
+				continue
+			}
+			next := time.Date(date.Year(), date.Month(), date.Day(), start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+			if r.isAfterUntil(date, next) {
+				return time.Time{}, false
+			}
+			return next, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// 起点からoffset期間後の期間に含まれる、規則に当てはまる日（0時）を日付順で返す
+func (r RecurrenceRule) candidates(start time.Time, offset int) []time.Time {
+	day := StartOfDay(start)
+	var dates []time.Time
+	switch r.Freq {
+	case Daily:
+		date := day.AddDate(0, 0, offset)
+		if len(r.ByDay) == 0 || r.hasWeekday(date.Weekday()) {
+			dates = append(dates, date)
+		}
+	case Weekly:
+		// 月曜日を週の始まりとする（RRULEのWKSTの既定値）
+		monday := day.AddDate(0, 0, -(int(day.Weekday())+6)%7+offset*7)
+		for i := 0; i < 7; i++ {
+			date := monday.AddDate(0, 0, i)
+			if (len(r.ByDay) == 0 && date.Weekday() == start.Weekday()) || r.hasWeekday(date.Weekday()) {
+				dates = append(dates, date)
+			}
+		}
+	case Monthly:
+		first := time.Date(day.Year(), day.Month()+time.Month(offset), 1, 0, 0, 0, 0, day.Location())
+		dates = r.monthDays(first, start.Day())
+	}
+	return dates
+}
+
+// 指定された月（1日の0時）のうち、規則に当てはまる日を日付順で返す
+// BYMONTHDAYとBYDAYの両方がある場合は、両方に当てはまる日とする
+// どちらも無い場合は、起点と同じ日（その月に無い場合は無し）とする
+func (r RecurrenceRule) monthDays(first time.Time, startDay int) []time.Time {
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+	matched := make([]bool, daysInMonth+1)
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		if startDay <= daysInMonth {
+			matched[startDay] = true
+		}
+	}
+	for _, d := range r.ByMonthDay {
+		if d < 0 {
+			d = daysInMonth + d + 1
+		}
+		if d >= 1 && d <= daysInMonth {
+			matched[d] = true
+		}
+	}
+	if len(r.ByDay) > 0 {
+		byDay := make([]bool, daysInMonth+1)
+		for _, day := range r.ByDay {
+			// その月の最初の該当する曜日
+			firstDay := 1 + (int(day.Weekday)-int(first.Weekday())+7)%7
+			var days []int
+			for d := firstDay; d <= daysInMonth; d += 7 {
+				days = append(days, d)
+			}
+			switch {
+			case day.Ordinal == 0:
+				for _, d := range days {
+					byDay[d] = true
+				}
+			case day.Ordinal > 0 && day.Ordinal <= len(days):
+				byDay[days[day.Ordinal-1]] = true
+			case day.Ordinal < 0 && -day.Ordinal <= len(days):
+				byDay[days[len(days)+day.Ordinal]] = true
+			}
+		}
+		for d := 1; d <= daysInMonth; d++ {
+			if len(r.ByMonthDay) > 0 {
+				matched[d] = matched[d] && byDay[d]
+			} else {
+				matched[d] = byDay[d]
+			}
+		}
+	}
+
+	var dates []time.Time
+	for d := 1; d <= daysInMonth; d++ {
+		if matched[d] {
+			dates = append(dates, first.AddDate(0, 0, d-1))
+		}
+	}
+	return dates
+}
+
+// 繰り返しの終了日時を過ぎているかを返す
+// dateはその日の0時、atは時刻を含めた日時
+func (r RecurrenceRule) isAfterUntil(date time.Time, at time.Time) bool {
+	if r.Until == nil {
+		return false
+	}
+	if r.UntilHasTime {
+		return at.After(*r.Until)
+	}
+	y, m, d := r.Until.Date()
+	return date.After(time.Date(y, m, d, 0, 0, 0, 0, date.Location()))
+}
+
+// 曜日の指定に、指定された曜日が含まれるかを返す
+func (r RecurrenceRule) hasWeekday(weekday time.Weekday) bool {
+	return slices.ContainsFunc(r.ByDay, func(day RecurrenceDay) bool {
+		return day.Weekday == weekday
+	})
+}
+
+// 繰り返しを設定する
+// 空文字列の場合は繰り返しを解除し、それ以外はParseRecurrenceで解析した規則を正規化して保持する
+// 正規化した規則が長すぎる場合はErrInvalidRecurrenceを返す
+func (t *Todo) SetRecurrence(s string) error {
+	if strings.TrimSpace(s) == "" {
+		t.Recurrence = ""
+		return nil
+	}
+	rule, err := ParseRecurrence(s)
+	if err != nil {
+		return err
+	}
+	recurrence := rule.String()
+	if len(recurrence) > RecurrenceMaxLength {
+		return fmt.Errorf("%w: rule must be at most %d characters", ErrInvalidRecurrence, RecurrenceMaxLength)
+	}
+	t.Recurrence = recurrence
+	return nil
+}
+
+// 繰り返しが設定されているかを返す
+func (t Todo) IsRecurring() bool {
+	return t.Recurrence != ""
+}
+
+// 繰り返しの規則を返す
+// 繰り返しが無い場合や、保存されている値が解析できない場合はfalseを返す
+func (t Todo) RecurrenceRule() (RecurrenceRule, bool) {
+	if !t.IsRecurring() {
+		return RecurrenceRule{}, false
+	}
+	rule, err := ParseRecurrence(t.Recurrence)
+	return rule, err == nil
+}
+
+// 画面に表示する繰り返しの説明を返す（繰り返しが無い場合は空文字列）
+func (t Todo) RecurrenceLabel() string {
+	rule, ok := t.RecurrenceRule()
+	if !ok {
+		return ""
+	}
+	return rule.Label()
+}
+
+// フォームで選択する繰り返しの種類を返す
+// daily・weekly・monthlyに当てはまらない場合はcustom、繰り返しが無い場合は空文字列を返す
+func (t Todo) RecurrenceKey() string {
+	if !t.IsRecurring() {
+		return ""
+	}
+	for key, freq := range recurrencePresets {
+		if t.Recurrence == (RecurrenceRule{Freq: freq, Interval: 1}).String() {
+			return key
+		}
+	}
+	return RecurrenceCustom
+}
+
+// フォームで選択できる繰り返しの種類
+type RecurrencePreset struct {
+	// フォームやAPIで使用する文字列
+	Key string
+	// 画面に表示する名前
+	Label string
+}
+
+// フォームで選択できる繰り返しの種類（daily・weekly・monthly）を頻度の短い順で返す
+func RecurrencePresets() []RecurrencePreset {
+	presets := make([]RecurrencePreset, 0, len(recurrencePresetKeys))
+	for _, key := range recurrencePresetKeys {
+		presets = append(presets, RecurrencePreset{Key: key, Label: frequencyLabels[recurrencePresets[key]]})
+	}
+	return presets
+}
+
+// 完了したtodoの、次の繰り返しのtodoを作成して返す
+// 期限は繰り返しの規則で求め、期限が無い場合はnowの日を起点とした終日の期限とする
+// 状態は未着手とし、タイトル・説明・優先度・プロジェクト・タグ・繰り返しを引き継ぐ
+// 繰り返しが無い、または終了している場合はfalseを返す
+func (t Todo) NextOccurrence(now time.Time) (*Todo, bool) {
+	rule, ok := t.RecurrenceRule()
+	if !ok {
+		return nil, false
+	}
+	loc := t.DueLocation()
+	start := StartOfDay(now.In(loc))
+	hasTime := false
+	if t.DueAt != nil {
+		start = t.DueAt.In(loc)
+		hasTime = t.DueHasTime
+	}
+	at, ok := rule.Next(start)
+	if !ok {
+		return nil, false
+	}
+	at = at.UTC()
+
+	next := &Todo{
+		UserID:      t.UserID,
+		ProjectID:   t.ProjectID,
+		Title:       t.Title,
+		Status:      NotStarted,
+		Description: t.Description,
+		Priority:    t.Priority,
+		Recurrence:  t.Recurrence,
+		DueAt:       &at,
+		DueHasTime:  hasTime,
+		DueTimezone: t.DueTimezone,
+		Tags:        append([]Tag(nil), t.Tags...),
+	}
+	return next, true
+}
@@ -0,0 +1,406 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecurrence(t *testing.T) {
+
+	cases := map[string]struct {
+		arg       string
+		want      string
+		expectErr bool
+	}{
+		"正常ケース:毎日": {
+			arg:  "daily",
+			want: "FREQ=DAILY",
+		},
+		"正常ケース:毎週": {
+			arg:  "weekly",
+			want: "FREQ=WEEKLY",
+		},
+		"正常ケース:毎月": {
+			arg:  "monthly",
+			want: "FREQ=MONTHLY",
+		},
+		"正常ケース:RRULEの接頭辞と小文字を正規化": {
+			arg:  "rrule:freq=weekly;interval=2;byday=mo,we",
+			want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE",
+		},
+		"正常ケース:INTERVAL=1は省略": {
+			arg:  "FREQ=DAILY;INTERVAL=1",
+			want: "FREQ=DAILY",
+		},
+		"正常ケース:月末": {
+			arg:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			want: "FREQ=MONTHLY;BYMONTHDAY=-1",
+		},
+		"正常ケース:序数付きの曜日": {
+			arg:  "FREQ=MONTHLY;BYDAY=-1FR",
+			want: "FREQ=MONTHLY;BYDAY=-1FR",
+		},
+		"正常ケース:終了日": {
+			arg:  "FREQ=DAILY;UNTIL=20251231",
+			want: "FREQ=DAILY;UNTIL=20251231",
+		},
+		"正常ケース:終了日時": {
+			arg:  "FREQ=DAILY;UNTIL=20251231T150000Z",
+			want: "FREQ=DAILY;UNTIL=20251231T150000Z",
+		},
+		"異常ケース:空文字列": {
+			arg:       "",
+			expectErr: true,
+		},
+		"異常ケース:FREQが無い": {
+			arg:       "INTERVAL=2",
+			expectErr: true,
+		},
+		"異常ケース:対応していないFREQ": {
+			arg:       "FREQ=HOURLY",
+			expectErr: true,
+		},
+		"異常ケース:対応していない項目": {
+			arg:       "FREQ=DAILY;COUNT=3",
+			expectErr: true,
+		},
+		"異常ケース:INTERVALが0": {
+			arg:       "FREQ=DAILY;INTERVAL=0",
+			expectErr: true,
+		},
+		"異常ケース:曜日が不正": {
+			arg:       "FREQ=WEEKLY;BYDAY=XX",
+			expectErr: true,
+		},
+		"異常ケース:毎週で序数付きの曜日": {
+			arg:       "FREQ=WEEKLY;BYDAY=2MO",
+			expectErr: true,
+		},
+		"異常ケース:毎月以外でBYMONTHDAY": {
+			arg:       "FREQ=WEEKLY;BYMONTHDAY=1",
+			expectErr: true,
+		},
+		"異常ケース:日が範囲外": {
+			arg:       "FREQ=MONTHLY;BYMONTHDAY=32",
+			expectErr: true,
+		},
+		"異常ケース:項目の重複": {
+			arg:       "FREQ=DAILY;FREQ=WEEKLY",
+			expectErr: true,
+		},
+		"異常ケース:終了日の形式が不正": {
+			arg:       "FREQ=DAILY;UNTIL=2025-12-31",
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.arg)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.True(t, errors.Is(err, ErrInvalidRecurrence))
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, rule.String())
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleNext(t *testing.T) {
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	cases := map[string]struct {
+		rule   string
+		start  time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		"毎日": {
+			rule:   "daily",
+			start:  time.Date(2025, 12, 31, 9, 0, 0, 0, tokyo),
+			want:   time.Date(2026, 1, 1, 9, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"3日ごと": {
+			rule:   "FREQ=DAILY;INTERVAL=3",
+			start:  time.Date(2025, 2, 27, 0, 0, 0, 0, tokyo),
+			want:   time.Date(2025, 3, 2, 0, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"平日のみ:金曜日の次は月曜日": {
+			rule:   "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			start:  time.Date(2025, 3, 7, 0, 0, 0, 0, tokyo),
+			want:   time.Date(2025, 3, 10, 0, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"毎週:同じ曜日": {
+			rule:   "weekly",
+			start:  time.Date(2025, 3, 5, 18, 30, 0, 0, tokyo),
+			want:   time.Date(2025, 3, 12, 18, 30, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"毎週:同じ週の次の曜日": {
+			rule:   "FREQ=WEEKLY;BYDAY=MO,WE",
+			start:  time.Date(2025, 3, 3, 0, 0, 0, 0, tokyo),
+			want:   time.Date(2025, 3, 5, 0, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"隔週:週の最後の曜日の次は2週後の最初の曜日": {
+			rule:   "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE",
+			start:  time.Date(2025, 3, 5, 0, 0, 0, 0, tokyo),
+			want:   time.Date(2025, 3, 17, 0, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"毎週:日曜日は週の最後": {
+			rule:   "FREQ=WEEKLY;BYDAY=MO,SU",
+			start:  time.Date(2025, 3, 3, 0, 0, 0, 0, tokyo),
+			want:   time.Date(2025, 3, 9, 0, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"毎月:同じ日": {
+			rule:   "monthly",
+			start:  time.Date(2025, 1, 15, 0, 0, 0, 0, tokyo),
+			want:   time.Date(2025, 2, 15, 0, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"毎月:31日は31日の無い月を飛ばす": {
+			rule:   "monthly",
+			start:  time.Date(2025, 1, 31, 0, 0, 0, 0, tokyo),
+			want:   time.Date(2025, 3, 31, 0, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"毎月:月末は各月の最終日": {
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start:  time.Date(2025, 1, 31, 0, 0, 0, 0, tokyo),
+			want:   time.Date(2025, 2, 28, 0, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"毎月:閏年の月末": {
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start:  time.Date(2024, 1, 31, 0, 0, 0, 0, tokyo),
+			want:   time.Date(2024, 2, 29, 0, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"毎月:月末から年を跨ぐ": {
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start:  time.Date(2025, 12, 31, 0, 0, 0, 0, tokyo),
+			want:   time.Date(2026, 1, 31, 0, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"毎月:同じ月の次の日": {
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=1,15",
+			start:  time.Date(2025, 4, 1, 0, 0, 0, 0, tokyo),
+			want:   time.Date(2025, 4, 15, 0, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"毎月:最終金曜日": {
+			rule:   "FREQ=MONTHLY;BYDAY=-1FR",
+			start:  time.Date(2025, 1, 31, 0, 0, 0, 0, tokyo),
+			want:   time.Date(2025, 2, 28, 0, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"毎月:第2月曜日": {
+			rule:   "FREQ=MONTHLY;BYDAY=2MO",
+			start:  time.Date(2025, 3, 10, 0, 0, 0, 0, tokyo),
+			want:   time.Date(2025, 4, 14, 0, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"3か月ごと": {
+			rule:   "FREQ=MONTHLY;INTERVAL=3",
+			start:  time.Date(2025, 11, 30, 0, 0, 0, 0, tokyo),
+			want:   time.Date(2026, 5, 30, 0, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"夏時間の開始を跨いでも現地の時刻を保つ": {
+			rule:   "daily",
+			start:  time.Date(2025, 3, 8, 9, 0, 0, 0, newYork),
+			want:   time.Date(2025, 3, 9, 9, 0, 0, 0, newYork),
+			wantOK: true,
+		},
+		"夏時間の終了を跨いでも現地の時刻を保つ": {
+			rule:   "weekly",
+			start:  time.Date(2025, 10, 28, 9, 0, 0, 0, newYork),
+			want:   time.Date(2025, 11, 4, 9, 0, 0, 0, newYork),
+			wantOK: true,
+		},
+		"終了日の当日は繰り返す": {
+			rule:   "FREQ=DAILY;UNTIL=20251231",
+			start:  time.Date(2025, 12, 30, 23, 0, 0, 0, tokyo),
+			want:   time.Date(2025, 12, 31, 23, 0, 0, 0, tokyo),
+			wantOK: true,
+		},
+		"終了日を過ぎる場合は終了": {
+			rule:   "FREQ=DAILY;UNTIL=20251231",
+			start:  time.Date(2025, 12, 31, 0, 0, 0, 0, tokyo),
+			wantOK: false,
+		},
+		"終了日時を過ぎる場合は終了": {
+			rule:   "FREQ=DAILY;UNTIL=20251231T000000Z",
+			start:  time.Date(2025, 12, 30, 10, 0, 0, 0, tokyo),
+			wantOK: false,
+		},
+		"当てはまる日が無い場合は終了": {
+			rule:   "FREQ=MONTHLY;INTERVAL=12;BYMONTHDAY=30",
+			start:  time.Date(2025, 2, 1, 0, 0, 0, 0, tokyo),
+			wantOK: false,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.rule)
+			if !assert.NoError(t, err) {
+				return
+			}
+			got, ok := rule.Next(tt.start)
+
+			// 結果を確認
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSetRecurrence(t *testing.T) {
+
+	t.Run("正常ケース:空文字列で解除", func(t *testing.T) {
+		todo := Todo{Title: "test", Recurrence: "FREQ=DAILY"}
+		assert.NoError(t, todo.SetRecurrence(" "))
+		assert.False(t, todo.IsRecurring())
+	})
+
+	t.Run("異常ケース:正規化した規則が長すぎる", func(t *testing.T) {
+		days := make([]string, 0, 62)
+		for d := 1; d <= 31; d++ {
+			days = append(days, strconv.Itoa(d), strconv.Itoa(-d))
+		}
+		todo := Todo{Title: "test", Recurrence: "FREQ=DAILY"}
+		err := todo.SetRecurrence("FREQ=MONTHLY;BYDAY=1MO,2MO,3MO,4MO,5MO,-1MO,-2MO,-3MO;BYMONTHDAY=" + strings.Join(days, ","))
+		assert.ErrorIs(t, err, ErrInvalidRecurrence)
+		assert.Equal(t, "FREQ=DAILY", todo.Recurrence)
+	})
+}
+
+func TestRecurrenceLabel(t *testing.T) {
+
+	cases := map[string]struct {
+		rule string
+		want string
+	}{
+		"毎週":        {rule: "weekly", want: "毎週"},
+		"隔週の曜日指定":   {rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", want: "2週ごと（月・水）"},
+		"月末":        {rule: "FREQ=MONTHLY;BYMONTHDAY=-1", want: "毎月（月末）"},
+		"最終金曜日と終了日": {rule: "FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20251231", want: "毎月（最終金）、2025-12-31まで"},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			todo := Todo{Title: "test"}
+			if assert.NoError(t, todo.SetRecurrence(tt.rule)) {
+				assert.Equal(t, tt.want, todo.RecurrenceLabel())
+			}
+		})
+	}
+}
+
+func TestRecurrenceKey(t *testing.T) {
+
+	cases := map[string]struct {
+		rule string
+		want string
+	}{
+		"繰り返し無し": {rule: "", want: ""},
+		"毎日":     {rule: "FREQ=DAILY", want: "daily"},
+		"毎月":     {rule: "monthly", want: "monthly"},
+		"カスタム":   {rule: "FREQ=WEEKLY;BYDAY=MO", want: RecurrenceCustom},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			todo := Todo{Title: "test"}
+			if assert.NoError(t, todo.SetRecurrence(tt.rule)) {
+				assert.Equal(t, tt.want, todo.RecurrenceKey())
+			}
+		})
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	now := time.Date(2025, 3, 12, 15, 0, 0, 0, tokyo)
+
+	t.Run("正常ケース:期限と内容を引き継ぐ", func(t *testing.T) {
+		todo := Todo{UserID: 1, ProjectID: 2, Title: "ゴミ出し", Status: Done, Description: "燃えるゴミ", Priority: PriorityHigh, Tags: []Tag{{Name: "家事"}}}
+		todo.ID = 10
+		assert.NoError(t, todo.SetDue("2025-01-31", "", "Asia/Tokyo"))
+		assert.NoError(t, todo.SetRecurrence("FREQ=MONTHLY;BYMONTHDAY=-1"))
+
+		next, ok := todo.NextOccurrence(now)
+		if assert.True(t, ok) {
+			assert.Zero(t, next.ID)
+			assert.Equal(t, NotStarted, next.Status)
+			assert.Equal(t, todo.UserID, next.UserID)
+			assert.Equal(t, todo.ProjectID, next.ProjectID)
+			assert.Equal(t, todo.Title, next.Title)
+			assert.Equal(t, todo.Description, next.Description)
+			assert.Equal(t, todo.Priority, next.Priority)
+			assert.Equal(t, todo.Recurrence, next.Recurrence)
+			assert.Equal(t, todo.Tags, next.Tags)
+			assert.Equal(t, "2025-02-28", next.DueDateString())
+			assert.False(t, next.DueHasTime)
+			assert.Equal(t, "Asia/Tokyo", next.DueTimezone)
+		}
+	})
+
+	t.Run("正常ケース:時刻付きの期限は時刻を保つ", func(t *testing.T) {
+		todo := Todo{Title: "test", Status: Done}
+		assert.NoError(t, todo.SetDue("2025-03-08", "09:00", "America/New_York"))
+		assert.NoError(t, todo.SetRecurrence("daily"))
+
+		next, ok := todo.NextOccurrence(now)
+		if assert.True(t, ok) {
+			assert.Equal(t, "2025-03-09", next.DueDateString())
+			assert.Equal(t, "09:00", next.DueTimeString())
+			assert.True(t, next.DueHasTime)
+		}
+	})
+
+	t.Run("正常ケース:期限が無い場合は現在の日を起点とする", func(t *testing.T) {
+		todo := Todo{Title: "test", Status: Done, DueTimezone: "Asia/Tokyo"}
+		assert.NoError(t, todo.SetRecurrence("weekly"))
+
+		next, ok := todo.NextOccurrence(now)
+		if assert.True(t, ok) {
+			assert.Equal(t, "2025-03-19", next.DueDateString())
+			assert.False(t, next.DueHasTime)
+		}
+	})
+
+	t.Run("正常ケース:繰り返しが無い", func(t *testing.T) {
+		todo := Todo{Title: "test", Status: Done}
+		_, ok := todo.NextOccurrence(now)
+		assert.False(t, ok)
+	})
+
+	t.Run("正常ケース:繰り返しが終了している", func(t *testing.T) {
+		todo := Todo{Title: "test", Status: Done}
+		assert.NoError(t, todo.SetDue("2025-03-12", "", "Asia/Tokyo"))
+		assert.NoError(t, todo.SetRecurrence("FREQ=DAILY;UNTIL=20250312"))
+		_, ok := todo.NextOccurrence(now)
+		assert.False(t, ok)
+	})
+}
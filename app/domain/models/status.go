@@ -0,0 +1,198 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// 状態が不正な場合のエラー
+var (
+	// 遷移が許可されていない
+	ErrInvalidStatusTransition = Validation(errors.New("invalid status transition"))
+	// 新規作成のtodoに、作業が終わった状態（完了・中止）や未定義の状態を指定した
+	ErrInvalidInitialStatus = Validation(errors.New("invalid initial status"))
+)
+
+// 状態ごとの定義
+// 画面・APIでの表現や並び順、遷移先は全てこの定義から導出する
+type statusDefinition struct {
+	// フォームやAPIで使用する文字列
+	key string
+	// 画面に表示する名前
+	label string
+	// 一覧での並び順（昇順）
+	order int
+	// 画面で使用するCSSのクラス
+	class string
+	// 完了・中止など、作業が終わった状態か
+	closed bool
+	// 遷移できる状態
+	next []Status
+}
+
+// 状態の定義の一覧
+// 完了・中止から作業中の状態に戻す場合は、一度未着手に戻す（再開する）必要がある
+var statusDefinitions = map[Status]statusDefinition{
+	NotStarted: {
+		key:   "notStarted",
+		label: "未着手",
+		order: 0,
+		class: "status-pending",
+		next:  []Status{InProgress, Blocked, Done, Cancelled},
+	},
+	InProgress: {
+		key:   "inProgress",
+		label: "進行中",
+		order: 1,
+		class: "status-in-progress",
+		next:  []Status{NotStarted, Blocked, Done, Cancelled},
+	},
+	Blocked: {
+		key:   "blocked",
+		label: "ブロック中",
+		order: 2,
+		class: "status-blocked",
+		next:  []Status{NotStarted, InProgress, Cancelled},
+	},
+	Done: {
+		key:    "completed",
+		label:  "完了",
+		order:  3,
+		class:  "status-completed",
+		closed: true,
+		next:   []Status{NotStarted},
+	},
+	Cancelled: {
+		key:    "cancelled",
+		label:  "中止",
+		order:  4,
+		class:  "status-cancelled",
+		closed: true,
+		next:   []Status{NotStarted},
+	},
+}
+
+// 定義済みの状態かを返す
+func (s Status) IsValid() bool {
+	_, ok := statusDefinitions[s]
+	return ok
+}
+
+// フォームやAPIで使用する文字列を返す
+func (s Status) Key() string {
+	return statusDefinitions[s].key
+}
+
+// 画面に表示する名前を返す
+func (s Status) Label() string {
+	return statusDefinitions[s].label
+}
+
+// 画面で使用するCSSのクラスを返す
+func (s Status) CSSClass() string {
+	return statusDefinitions[s].class
+}
+
+// 一覧での並び順を返す
+func (s Status) SortOrder() int {
+	return statusDefinitions[s].order
+}
+
+// 完了・中止など、作業が終わった状態かを返す
+func (s Status) IsClosed() bool {
+	return statusDefinitions[s].closed
+}
+
+// 指定された状態へ遷移できるかを返す
+// 同じ状態への遷移は変更なしとして許可する
+func (s Status) CanTransitionTo(next Status) bool {
+	if !s.IsValid() || !next.IsValid() {
+		return false
+	}
+	if s == next {
+		return true
+	}
+	for _, to := range statusDefinitions[s].next {
+		if to == next {
+			return true
+		}
+	}
+	return false
+}
+
+// 全ての状態を一覧での並び順で返す
+func Statuses() []Status {
+	statuses := make([]Status, 0, len(statusDefinitions))
+	for s := range statusDefinitions {
+		statuses = append(statuses, s)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].SortOrder() < statuses[j].SortOrder()
+	})
+	return statuses
+}
+
+// 作業が終わった状態の一覧を返す
+func ClosedStatuses() []Status {
+	var statuses []Status
+	for _, s := range Statuses() {
+		if s.IsClosed() {
+			statuses = append(statuses, s)
+		}
+	}
+	return statuses
+}
+
+// 作業中（完了・中止していない）の状態の一覧を返す
+func OpenStatuses() []Status {
+	var statuses []Status
+	for _, s := range Statuses() {
+		if !s.IsClosed() {
+			statuses = append(statuses, s)
+		}
+	}
+	return statuses
+}
+
+// フォームやAPIで使用する文字列と状態の対応表を返す
+// StrToStatusの変換に使用する
+func StatusCorrespond() map[string]Status {
+	correspond := make(map[string]Status, len(statusDefinitions))
+	for s, def := range statusDefinitions {
+		correspond[def.key] = s
+	}
+	return correspond
+}
+
+// todoの状態を変更する
+// 遷移が許可されていない場合はErrInvalidStatusTransitionを返す
+// チェックリストに未完了の項目が残っている場合は、完了にできずErrOpenChecklistItemsを返す（中止はできる）
+func (t *Todo) ChangeStatus(next Status) error {
+	if err := t.validateStatusChange(next); err != nil {
+		return err
+	}
+	t.Status = next
+	return nil
+}
+
+// 保存されている状態から、指定された状態へ変更できるかを検証する
+// 完了にする場合は、チェックリストの項目が全て完了しているかも検証する
+func (t Todo) validateStatusChange(next Status) error {
+	if !t.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, t.Status.Key(), next.Key())
+	}
+	if next == Done && t.Status != Done && t.HasOpenChecklistItems() {
+		return fmt.Errorf("%w: %d of %d done", ErrOpenChecklistItems, t.ChecklistDoneCount(), len(t.ChecklistItems))
+	}
+	return nil
+}
+
+// 新規作成のtodoの状態を検証する
+// 作業が終わった状態（完了・中止）では作成できない
+func validateInitialStatus(s Status) error {
+	if !s.IsValid() || s.IsClosed() {
+		return fmt.Errorf("%w: %s", ErrInvalidInitialStatus, s.Key())
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransitionTo(t *testing.T) {
+
+	type args struct {
+		from Status
+		to   Status
+	}
+
+	cases := map[string]struct {
+		args args
+		want bool
+	}{
+		"正常ケース:未着手から進行中": {
+			args: args{from: NotStarted, to: InProgress},
+			want: true,
+		},
+		"正常ケース:進行中からブロック中": {
+			args: args{from: InProgress, to: Blocked},
+			want: true,
+		},
+		"正常ケース:ブロック中から進行中": {
+			args: args{from: Blocked, to: InProgress},
+			want: true,
+		},
+		"正常ケース:中止から未着手に再開": {
+			args: args{from: Cancelled, to: NotStarted},
+			want: true,
+		},
+		"正常ケース:同じ状態": {
+			args: args{from: Done, to: Done},
+			want: true,
+		},
+		"異常ケース:中止から進行中": {
+			args: args{from: Cancelled, to: InProgress},
+			want: false,
+		},
+		"異常ケース:完了からブロック中": {
+			args: args{from: Done, to: Blocked},
+			want: false,
+		},
+		"異常ケース:ブロック中から完了": {
+			args: args{from: Blocked, to: Done},
+			want: false,
+		},
+		"異常ケース:定義されていない状態": {
+			args: args{from: NotStarted, to: Status(99)},
+			want: false,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.args.from.CanTransitionTo(tt.args.to))
+		})
+	}
+}
+
+func TestChangeStatus(t *testing.T) {
+
+	open := ChecklistItem{Title: "open"}
+	done := ChecklistItem{Title: "done", Done: true}
+
+	cases := map[string]struct {
+		from      Status
+		to        Status
+		items     []ChecklistItem
+		want      Status
+		expectErr bool
+		err       error
+	}{
+		"正常ケース:変更できる": {
+			from: NotStarted,
+			to:   Done,
+			want: Done,
+		},
+		"異常ケース:変更できない場合は元の状態のまま": {
+			from:      Cancelled,
+			to:        InProgress,
+			want:      Cancelled,
+			expectErr: true,
+			err:       ErrInvalidStatusTransition,
+		},
+		"正常ケース:チェックリストが全て完了していれば完了にできる": {
+			from:  InProgress,
+			to:    Done,
+			items: []ChecklistItem{done, done},
+			want:  Done,
+		},
+		"異常ケース:チェックリストに未完了の項目があると完了にできない": {
+			from:      InProgress,
+			to:        Done,
+			items:     []ChecklistItem{done, open},
+			want:      InProgress,
+			expectErr: true,
+			err:       ErrOpenChecklistItems,
+		},
+		"正常ケース:チェックリストに未完了の項目があっても中止にできる": {
+			from:  InProgress,
+			to:    Cancelled,
+			items: []ChecklistItem{open},
+			want:  Cancelled,
+		},
+		"正常ケース:完了済みのまま変更しない場合は未完了の項目があってもよい": {
+			from:  Done,
+			to:    Done,
+			items: []ChecklistItem{open},
+			want:  Done,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			todo := Todo{Title: "test", Status: tt.from, ChecklistItems: tt.items}
+			err := todo.ChangeStatus(tt.to)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.True(t, errors.Is(err, tt.err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, todo.Status)
+		})
+	}
+}
+
+func TestStatuses(t *testing.T) {
+
+	// 一覧での並び順で全ての状態を返すこと
+	assert.Equal(t, []Status{NotStarted, InProgress, Blocked, Done, Cancelled}, Statuses())
+	assert.Equal(t, []Status{Done, Cancelled}, ClosedStatuses())
+
+	// 文字列との変換が往復できること
+	for _, s := range Statuses() {
+		status, err := StrToStatus(s.Key(), StatusCorrespond())
+		if assert.NoError(t, err) {
+			assert.Equal(t, s, status)
+		}
+	}
+}
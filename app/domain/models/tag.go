@@ -0,0 +1,91 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// タグの名前の長さの上限（文字数）
+const TagNameMaxLength = 50
+
+// タグの名前が不正な場合のエラー
+var ErrInvalidTagName = Validation(errors.New("invalid tag name"))
+
+// todoを分類するためのタグ
+// ユーザーごとに管理し、同じユーザーの中で名前は重複しない
+type Tag struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// 所有者のユーザーID
+	UserID uint
+	Name   string
+	// タグが付いているtodoの件数（一覧の取得時のみ設定される）
+	TodoCount int64 `gorm:"-"`
+}
+
+// タグの名前の前後の空白を除き、連続する空白を1つにまとめる
+func NormalizeTagName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// 指定されたユーザーの、指定された名前のタグを生成する
+func NewTag(userID uint, name string) (*Tag, error) {
+	tag := Tag{UserID: userID}
+	if err := tag.Rename(name); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// タグの名前の一覧が全て使用できるものかを確認する
+func ValidateTagNames(names []string) error {
+	for _, name := range names {
+		if _, err := NewTag(0, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// タグの名前を変更する
+// 複数の名前の区切りに使用するため、カンマは使用できない
+func (t *Tag) Rename(name string) error {
+	name = NormalizeTagName(name)
+	if name == "" || utf8.RuneCountInString(name) > TagNameMaxLength || strings.ContainsAny(name, ",、") {
+		return fmt.Errorf("%w: %q", ErrInvalidTagName, name)
+	}
+	t.Name = name
+	return nil
+}
+
+// カンマ区切りの文字列からタグの名前の一覧を返す
+// 空の名前と重複は除く
+func ParseTagNames(s string) []string {
+	names := []string{}
+	for _, name := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '、' }) {
+		name = NormalizeTagName(name)
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// todoに付いているタグの名前の一覧を返す
+func (t Todo) TagNames() []string {
+	names := make([]string, 0, len(t.Tags))
+	for _, tag := range t.Tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// todoに付いているタグの名前をカンマ区切りで返す（フォームの入力値に使用する）
+func (t Todo) TagNamesString() string {
+	return strings.Join(t.TagNames(), ", ")
+}
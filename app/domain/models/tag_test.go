@@ -0,0 +1,92 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTag(t *testing.T) {
+
+	cases := map[string]struct {
+		name      string
+		want      string
+		expectErr bool
+	}{
+		"正常ケース:前後と連続する空白を除く": {
+			name: "  買い 　物 ",
+			want: "買い 物",
+		},
+		"正常ケース:上限の文字数": {
+			name: strings.Repeat("あ", TagNameMaxLength),
+			want: strings.Repeat("あ", TagNameMaxLength),
+		},
+		"異常ケース:空白のみ": {
+			name:      " 　",
+			expectErr: true,
+		},
+		"異常ケース:上限を超える文字数": {
+			name:      strings.Repeat("あ", TagNameMaxLength+1),
+			expectErr: true,
+		},
+		"異常ケース:カンマを含む": {
+			name:      "a,b",
+			expectErr: true,
+		},
+		"異常ケース:読点を含む": {
+			name:      "a、b",
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			tag, err := NewTag(1, tt.name)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidTagName)
+				assert.Nil(t, tag)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, &Tag{UserID: 1, Name: tt.want}, tag)
+			}
+		})
+	}
+}
+
+func TestParseTagNames(t *testing.T) {
+
+	cases := map[string]struct {
+		s    string
+		want []string
+	}{
+		"正常ケース:空文字列": {
+			s:    "",
+			want: []string{},
+		},
+		"正常ケース:カンマと読点で区切る": {
+			s:    "仕事, 買い物、 家事 ",
+			want: []string{"仕事", "買い物", "家事"},
+		},
+		"正常ケース:空の名前と重複を除く": {
+			s:    "仕事,, 仕事 ,、",
+			want: []string{"仕事"},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseTagNames(tt.s))
+		})
+	}
+}
+
+func TestTagNamesString(t *testing.T) {
+	todo := Todo{Tags: []Tag{{Name: "backend"}, {Name: "urgent"}}}
+	assert.Equal(t, []string{"backend", "urgent"}, todo.TagNames())
+	assert.Equal(t, "backend, urgent", todo.TagNamesString())
+	assert.Equal(t, "", Todo{}.TagNamesString())
+}
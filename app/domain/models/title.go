@@ -0,0 +1,47 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// タイトルの長さの上限（文字数）
+const TitleMaxLength = 200
+
+// タイトルが不正な場合のエラー
+var (
+	// 空か、空白のみ
+	ErrTitleRequired = Validation(errors.New("title is required"))
+	// 長すぎる
+	ErrTitleTooLong = Validation(errors.New("title is too long"))
+)
+
+// todoのタイトルを変更する
+// Unicodeの正規化を行い、前後の空白を取り除く
+// 改行・タブなどの制御文字は使用できない
+func (t *Todo) SetTitle(title string) error {
+	title = normalizeTitle(title)
+	if err := validateTitle(title); err != nil {
+		return err
+	}
+	t.Title = title
+	return nil
+}
+
+// タイトルを保存する形に揃える
+func normalizeTitle(title string) string {
+	return strings.TrimSpace(normalizeText(title))
+}
+
+// 保存する形に揃えたタイトルを検証する
+func validateTitle(title string) error {
+	if title == "" {
+		return ErrTitleRequired
+	}
+	if n := utf8.RuneCountInString(title); n > TitleMaxLength {
+		return fmt.Errorf("%w: %d characters (max %d)", ErrTitleTooLong, n, TitleMaxLength)
+	}
+	return checkControlCharacters(title, "")
+}
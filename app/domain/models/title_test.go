@@ -0,0 +1,77 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetTitle(t *testing.T) {
+
+	cases := map[string]struct {
+		arg  string
+		want string
+		err  error
+	}{
+		"正常ケース:前後の空白を取り除く": {
+			arg:  "　 買い物 \t",
+			want: "買い物",
+		},
+		"正常ケース:Unicodeの正規化を行う": {
+			// 「が」を「か」と濁点の結合文字で入力した場合
+			arg:  "\u304b\u3099いもの",
+			want: "がいもの",
+		},
+		"正常ケース:上限の文字数": {
+			arg:  strings.Repeat("あ", TitleMaxLength),
+			want: strings.Repeat("あ", TitleMaxLength),
+		},
+		"異常ケース:空文字列": {
+			arg:  "",
+			want: "元のタイトル",
+			err:  ErrTitleRequired,
+		},
+		"異常ケース:空白のみ": {
+			arg:  " 　\t",
+			want: "元のタイトル",
+			err:  ErrTitleRequired,
+		},
+		"異常ケース:上限の文字数を超える": {
+			arg:  strings.Repeat("あ", TitleMaxLength+1),
+			want: "元のタイトル",
+			err:  ErrTitleTooLong,
+		},
+		"異常ケース:改行を含む": {
+			arg:  "買い物\n掃除",
+			want: "元のタイトル",
+			err:  ErrControlCharacter,
+		},
+		"異常ケース:制御文字を含む": {
+			arg:  "買い物\x07",
+			want: "元のタイトル",
+			err:  ErrControlCharacter,
+		},
+		"異常ケース:UTF-8として不正なバイト列を含む": {
+			arg:  "買い物\xff",
+			want: "元のタイトル",
+			err:  ErrControlCharacter,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			todo := Todo{Title: "元のタイトル"}
+			err := todo.SetTitle(tt.arg)
+
+			// 結果を確認
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Equal(t, KindValidation, KindOf(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, todo.Title)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -9,20 +10,66 @@ import (
 // Enumの代わり
 // NotStarted：未着手
 // Done：完了
+// InProgress：進行中
+// Blocked：ブロック中
+// Cancelled：中止
 // iotaで自動連番にしています
+// DBには数値で保存しているため、既存の値を変えないよう末尾に追加すること
 type Status int
 
 const (
 	invalid Status = iota - 1
 	NotStarted
 	Done
+	InProgress
+	Blocked
+	Cancelled
 )
 
 // todoのデータを保持する構造体
 type Todo struct {
 	gorm.Model
-	Title  string
-	Status Status
+	// 所有者のユーザーID
+	UserID uint
+	// 所属するプロジェクトのID
+	ProjectID uint
+	Title     string
+	Status    Status
+	// 説明（Markdown。表示時にRenderMarkdownでHTMLに変換する）
+	Description string
+	// 優先度（未指定の場合は「中」）
+	Priority Priority
+	// 一覧での手動の並び順（RankBetweenで生成した値を文字列として比較する）
+	// 新規作成時はリポジトリで末尾の値を設定する
+	Position string
+	// 期限（期限が無い場合はnil）
+	// 終日の期限の場合は、DueTimezoneでのその日の0時を保持する
+	DueAt *time.Time
+	// 期限に時刻まで指定されているか
+	DueHasTime bool
+	// 期限のタイムゾーン（IANAの名前。空文字列の場合はサーバーのタイムゾーン）
+	DueTimezone string
+	// 繰り返しの規則（RRULEの形式。繰り返さない場合は空文字列）
+	// 完了にすると、TodoUsecase.Editで次の繰り返しのtodoを作成する
+	Recurrence string
+	// 楽観的排他制御のバージョン
+	// 取得時の値と保存されている値が異なる場合は更新せず、TodoRepository.Updateで更新するたびに1増やす
+	Version uint
+	// 付いているタグ（名前順）
+	// 保存はTagRepositoryで行い、todoの保存時には更新しない
+	Tags []Tag `gorm:"many2many:todo_tags"`
+	// チェックリストの項目（並び順）
+	// 保存はChecklistRepositoryで行い、todoの保存時には更新しない
+	ChecklistItems []ChecklistItem
+	// コメント（投稿順）。詳細の取得時のみ読み込む
+	// 保存はCommentRepositoryで行い、todoの保存時には更新しない
+	Comments []Comment
+	// 添付ファイル（添付順）。詳細の取得時のみ読み込む
+	// 保存はTodoUsecaseの添付ファイルの操作で行い、todoの保存時には更新しない
+	Attachments []Attachment
+	// 変更履歴（新しい順）。詳細の取得時のみ読み込む
+	// 保存はTodoUsecaseの各操作で行い、todoの保存時には更新しない
+	Histories []TodoHistory
 }
 
 // StrToStatus converts a string to Status enum type.
@@ -48,7 +95,7 @@ func StrToStatus(target string, correspond map[string]Status) (status Status, er
 	}
 
 	for key, value := range correspond {
-		if key == target && NotStarted <= value && value <= Done {
+		if key == target && value.IsValid() {
 			return value, nil
 		}
 	}
@@ -0,0 +1,343 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 一覧の並び替えの項目
+type TodoSort string
+
+const (
+	SortByStatus TodoSort = "status"
+	// 優先度の高い順に並べる
+	SortByPriority TodoSort = "priority"
+	SortByDue      TodoSort = "due"
+	SortByCreated  TodoSort = "created"
+	SortByUpdated  TodoSort = "updated"
+	SortByTitle    TodoSort = "title"
+	// 状態ごとに、手動の並び順で並べる
+	SortByPosition TodoSort = "position"
+)
+
+// 並び替えの方向
+type SortDirection string
+
+const (
+	Asc  SortDirection = "asc"
+	Desc SortDirection = "desc"
+)
+
+// 期限による絞り込みの条件
+type DueFilter string
+
+const (
+	DueAny      DueFilter = ""
+	DueOverdue  DueFilter = "overdue"
+	DueToday    DueFilter = "today"
+	DueThisWeek DueFilter = "week"
+)
+
+// 1ページあたりの件数
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// ページ番号の上限
+// 読み飛ばす件数（Offset）が桁あふれしないよう、これを超えるページは指定できない
+const MaxPage = 100000
+
+// todoの一覧の検索条件
+// 画面・APIのクエリパラメータから生成し、SQLでの検索に使用する
+type TodoQuery struct {
+	// 所有者のユーザーID
+	UserID uint
+	// 所属するプロジェクトのID（0の場合は全てのプロジェクト）
+	// パス（/projects/:pid/todos）から設定するため、クエリパラメータでは指定しない
+	ProjectID uint
+	// タイトルか説明に含まれる語句（空白区切りで全ての語句を含むものに絞り込む）
+	Keyword string
+	// 状態（空の場合は全ての状態）
+	Statuses []Status
+	// タグの名前（全てのタグが付いているものに絞り込む）
+	Tags []string
+	// 期限による絞り込み（期限切れ・今日まで・今週まで）
+	// 完了・中止したtodoは、状態を指定しない限り対象外とする
+	Due DueFilter
+	// 期限の日付を解釈するタイムゾーン（IANAの名前。空文字列の場合はサーバーのタイムゾーン）
+	// 今日・今週の範囲や、期限の範囲の日付の境界はこのタイムゾーンで求める
+	Timezone string
+	// 期限の範囲（DueFromを含み、DueToを含まない）
+	DueFrom *time.Time
+	DueTo   *time.Time
+	// 指定された日時の時点で期限を過ぎているものに絞り込む
+	// Dueから求めるため、クエリパラメータでは指定しない
+	OverdueAt *time.Time
+	// 並び替え
+	Sort      TodoSort
+	Direction SortDirection
+	// ページ番号（1始まり）と1ページあたりの件数
+	Page  int
+	Limit int
+}
+
+// 並び替えの項目と既定の方向
+var todoSortDefaults = map[TodoSort]SortDirection{
+	SortByStatus:   Asc,
+	SortByPriority: Asc,
+	SortByDue:      Asc,
+	SortByCreated:  Desc,
+	SortByUpdated:  Desc,
+	SortByTitle:    Asc,
+	SortByPosition: Asc,
+}
+
+// クエリパラメータから検索条件を生成する
+// q：タイトル・説明の語句、status：状態（複数指定可）、tag：タグの名前（複数指定可）、due：overdue・today・week
+// due_from・due_to：期限の範囲（YYYY-MM-DD、両端の日を含む）、timezone：期限の日付を解釈するタイムゾーン
+// sort・order：並び替え、page・limit：ページ
+func ParseTodoQuery(values url.Values) (TodoQuery, error) {
+	q := TodoQuery{Keyword: strings.TrimSpace(values.Get("q")), Timezone: values.Get("timezone")}
+	loc, err := loadLocation(q.Timezone)
+	if err != nil {
+		return TodoQuery{}, err
+	}
+
+	for _, s := range values["status"] {
+		if s == "" {
+			continue
+		}
+		status, err := StrToStatus(s, StatusCorrespond())
+		if err != nil {
+			return TodoQuery{}, err
+		}
+		if !slices.Contains(q.Statuses, status) {
+			q.Statuses = append(q.Statuses, status)
+		}
+	}
+
+	for _, name := range values["tag"] {
+		if name = NormalizeTagName(name); name != "" && !slices.Contains(q.Tags, name) {
+			q.Tags = append(q.Tags, name)
+		}
+	}
+
+	switch due := DueFilter(values.Get("due")); due {
+	case DueAny, DueOverdue, DueToday, DueThisWeek:
+		q.Due = due
+	default:
+		return TodoQuery{}, fmt.Errorf("invalid due filter: %s", due)
+	}
+
+	if s := values.Get("due_from"); s != "" {
+		from, err := time.ParseInLocation(DueDateLayout, s, loc)
+		if err != nil {
+			return TodoQuery{}, fmt.Errorf("invalid due_from: %s", s)
+		}
+		q.DueFrom = &from
+	}
+	if s := values.Get("due_to"); s != "" {
+		to, err := time.ParseInLocation(DueDateLayout, s, loc)
+		if err != nil {
+			return TodoQuery{}, fmt.Errorf("invalid due_to: %s", s)
+		}
+		// 指定された日を含めるため、翌日の0時より前を対象とする
+		to = to.AddDate(0, 0, 1)
+		q.DueTo = &to
+	}
+
+	if s := values.Get("sort"); s != "" {
+		if _, ok := todoSortDefaults[TodoSort(s)]; !ok {
+			return TodoQuery{}, fmt.Errorf("invalid sort: %s", s)
+		}
+		q.Sort = TodoSort(s)
+	}
+	switch d := SortDirection(values.Get("order")); d {
+	case "", Asc, Desc:
+		q.Direction = d
+	default:
+		return TodoQuery{}, fmt.Errorf("invalid order: %s", d)
+	}
+
+	if q.Page, err = parsePositive(values, "page"); err != nil {
+		return TodoQuery{}, err
+	}
+	if q.Page > MaxPage {
+		return TodoQuery{}, fmt.Errorf("invalid page: %d (max %d)", q.Page, MaxPage)
+	}
+	if q.Limit, err = parsePositive(values, "limit"); err != nil {
+		return TodoQuery{}, err
+	}
+
+	return q.Normalize(), nil
+}
+
+// 省略された項目を既定値で補い、範囲外の値を丸めた検索条件を返す
+func (q TodoQuery) Normalize() TodoQuery {
+	if q.Sort == "" {
+		q.Sort = SortByStatus
+	}
+	if q.Direction == "" {
+		q.Direction = todoSortDefaults[q.Sort]
+	}
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Page > MaxPage {
+		q.Page = MaxPage
+	}
+	if q.Limit < 1 {
+		q.Limit = DefaultPageSize
+	}
+	if q.Limit > MaxPageSize {
+		q.Limit = MaxPageSize
+	}
+	return q
+}
+
+// 検索で読み飛ばす件数を返す
+func (q TodoQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+// 期限の日付を解釈するタイムゾーンを返す
+// 未指定か不正な場合はサーバーのタイムゾーンを返す
+func (q TodoQuery) Location() *time.Location {
+	loc, err := loadLocation(q.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// 一覧の表示順のまま並び替え（ドラッグ＆ドロップ）ができるかを返す
+func (q TodoQuery) Reorderable() bool {
+	n := q.Normalize()
+	return n.Sort == SortByPosition && n.Direction == Asc
+}
+
+// タイトルの語句を空白で区切って返す
+func (q TodoQuery) Keywords() []string {
+	return strings.Fields(q.Keyword)
+}
+
+// 指定された状態で絞り込んでいるかを返す
+func (q TodoQuery) HasStatus(s Status) bool {
+	return slices.Contains(q.Statuses, s)
+}
+
+// 指定されたタグで絞り込んでいるかを返す
+func (q TodoQuery) HasTag(name string) bool {
+	return slices.Contains(q.Tags, name)
+}
+
+// 期限の範囲の開始日を入力形式の文字列で返す
+func (q TodoQuery) DueFromString() string {
+	if q.DueFrom == nil {
+		return ""
+	}
+	return q.DueFrom.In(q.Location()).Format(DueDateLayout)
+}
+
+// 期限の範囲の終了日を入力形式の文字列で返す
+func (q TodoQuery) DueToString() string {
+	if q.DueTo == nil {
+		return ""
+	}
+	return q.DueTo.In(q.Location()).AddDate(0, 0, -1).Format(DueDateLayout)
+}
+
+// 検索条件を、指定されたページのクエリパラメータの文字列に変換する
+// 既定値の項目は省略する
+func (q TodoQuery) Encode(page int) string {
+	values := url.Values{}
+	if q.Keyword != "" {
+		values.Set("q", q.Keyword)
+	}
+	for _, s := range q.Statuses {
+		values.Add("status", s.Key())
+	}
+	for _, name := range q.Tags {
+		values.Add("tag", name)
+	}
+	if q.Due != DueAny {
+		values.Set("due", string(q.Due))
+	}
+	if s := q.DueFromString(); s != "" {
+		values.Set("due_from", s)
+	}
+	if s := q.DueToString(); s != "" {
+		values.Set("due_to", s)
+	}
+	if q.Timezone != "" {
+		values.Set("timezone", q.Timezone)
+	}
+	n := q.Normalize()
+	if n.Sort != SortByStatus || n.Direction != todoSortDefaults[n.Sort] {
+		values.Set("sort", string(n.Sort))
+		values.Set("order", string(n.Direction))
+	}
+	if n.Limit != DefaultPageSize {
+		values.Set("limit", strconv.Itoa(n.Limit))
+	}
+	if page > 1 {
+		values.Set("page", strconv.Itoa(page))
+	}
+	return values.Encode()
+}
+
+// 検索結果の1ページ分のtodo
+type TodoPage struct {
+	Todos []Todo
+	// 条件に一致する全体の件数
+	Total int64
+	Page  int
+	Limit int
+}
+
+// 全体のページ数を返す（該当が無い場合も1ページとする）
+func (p TodoPage) TotalPages() int {
+	if p.Limit < 1 || p.Total == 0 {
+		return 1
+	}
+	return int((p.Total + int64(p.Limit) - 1) / int64(p.Limit))
+}
+
+// 前のページがあるかを返す
+func (p TodoPage) HasPrev() bool {
+	return p.Page > 1
+}
+
+// 次のページがあるかを返す
+func (p TodoPage) HasNext() bool {
+	return p.Page < p.TotalPages()
+}
+
+// 前のページの番号を返す
+func (p TodoPage) PrevPage() int {
+	return p.Page - 1
+}
+
+// 次のページの番号を返す
+func (p TodoPage) NextPage() int {
+	return p.Page + 1
+}
+
+// クエリパラメータの正の整数を返す
+// 省略された場合は0を返す
+func parsePositive(values url.Values, key string) (int, error) {
+	s := values.Get(key)
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid %s: %s", key, s)
+	}
+	return n, nil
+}
@@ -0,0 +1,287 @@
+package models
+
+import (
+	"math"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTodoQuery(t *testing.T) {
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local)
+	// 終了日を含めるため、翌日の0時になること
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.Local)
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	tokyoFrom := time.Date(2025, 3, 1, 0, 0, 0, 0, tokyo)
+	tokyoTo := time.Date(2025, 4, 1, 0, 0, 0, 0, tokyo)
+
+	cases := map[string]struct {
+		query     string
+		want      TodoQuery
+		expectErr bool
+	}{
+		"正常ケース:指定なしは既定値": {
+			query: "",
+			want:  TodoQuery{Sort: SortByStatus, Direction: Asc, Page: 1, Limit: DefaultPageSize},
+		},
+		"正常ケース:全て指定": {
+			query: "q=+買い物+&status=inProgress&status=blocked&status=inProgress&due=week&due_from=2025-03-01&due_to=2025-03-31&sort=due&order=desc&page=3&limit=50",
+			want: TodoQuery{
+				Keyword:   "買い物",
+				Statuses:  []Status{InProgress, Blocked},
+				Due:       DueThisWeek,
+				DueFrom:   &from,
+				DueTo:     &to,
+				Sort:      SortByDue,
+				Direction: Desc,
+				Page:      3,
+				Limit:     50,
+			},
+		},
+		"正常ケース:期限の範囲はタイムゾーンの日付で解釈する": {
+			query: "due_from=2025-03-01&due_to=2025-03-31&timezone=Asia/Tokyo",
+			want: TodoQuery{
+				Timezone:  "Asia/Tokyo",
+				DueFrom:   &tokyoFrom,
+				DueTo:     &tokyoTo,
+				Sort:      SortByStatus,
+				Direction: Asc,
+				Page:      1,
+				Limit:     DefaultPageSize,
+			},
+		},
+		"正常ケース:並び替えの方向の既定値は項目ごと": {
+			query: "sort=created",
+			want:  TodoQuery{Sort: SortByCreated, Direction: Desc, Page: 1, Limit: DefaultPageSize},
+		},
+		"正常ケース:件数の上限を超える場合は上限": {
+			query: "limit=1000",
+			want:  TodoQuery{Sort: SortByStatus, Direction: Asc, Page: 1, Limit: MaxPageSize},
+		},
+		"異常ケース:状態が不正": {
+			query:     "status=unknown",
+			expectErr: true,
+		},
+		"異常ケース:期限の絞り込みが不正": {
+			query:     "due=tomorrow",
+			expectErr: true,
+		},
+		"異常ケース:期限の範囲の形式が不正": {
+			query:     "due_from=2025/03/01",
+			expectErr: true,
+		},
+		"異常ケース:タイムゾーンが不正": {
+			query:     "due=today&timezone=Mars/Olympus",
+			expectErr: true,
+		},
+		"異常ケース:並び替えの項目が不正": {
+			query:     "sort=user_id",
+			expectErr: true,
+		},
+		"異常ケース:並び替えの方向が不正": {
+			query:     "order=random",
+			expectErr: true,
+		},
+		"異常ケース:ページが0": {
+			query:     "page=0",
+			expectErr: true,
+		},
+		"異常ケース:ページが上限を超える": {
+			query:     "page=100001",
+			expectErr: true,
+		},
+		"異常ケース:ページが整数の範囲を超える": {
+			query:     "page=9223372036854775807",
+			expectErr: true,
+		},
+		"異常ケース:件数が数値でない": {
+			query:     "limit=all",
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			got, err := ParseTodoQuery(values)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTodoQueryEncode(t *testing.T) {
+
+	cases := map[string]struct {
+		query string
+		page  int
+		want  string
+	}{
+		"正常ケース:既定値の項目は省略": {
+			query: "sort=status&order=asc&limit=20",
+			page:  1,
+			want:  "",
+		},
+		"正常ケース:ページの指定": {
+			query: "q=買い物&status=blocked&due=overdue",
+			page:  2,
+			want:  "due=overdue&page=2&q=%E8%B2%B7%E3%81%84%E7%89%A9&status=blocked",
+		},
+		"正常ケース:期限の範囲と並び替え": {
+			query: "due_from=2025-03-01&due_to=2025-03-31&sort=title&limit=10",
+			page:  3,
+			want:  "due_from=2025-03-01&due_to=2025-03-31&limit=10&order=asc&page=3&sort=title",
+		},
+		"正常ケース:タイムゾーン": {
+			query: "due_from=2025-03-01&due_to=2025-03-31&timezone=Asia/Tokyo",
+			page:  1,
+			want:  "due_from=2025-03-01&due_to=2025-03-31&timezone=Asia%2FTokyo",
+		},
+		"正常ケース:タグ": {
+			query: "tag=backend&tag=ui",
+			page:  1,
+			want:  "tag=backend&tag=ui",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			q, err := ParseTodoQuery(values)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, q.Encode(tt.page))
+			}
+		})
+	}
+}
+
+func TestTodoPage(t *testing.T) {
+
+	cases := map[string]struct {
+		page         TodoPage
+		wantPages    int
+		wantPrev     bool
+		wantNext     bool
+		wantPrevPage int
+		wantNextPage int
+	}{
+		"正常ケース:データなし": {
+			page:      TodoPage{Total: 0, Page: 1, Limit: 20},
+			wantPages: 1,
+			wantPrev:  false,
+			wantNext:  false,
+		},
+		"正常ケース:最初のページ": {
+			page:         TodoPage{Total: 41, Page: 1, Limit: 20},
+			wantPages:    3,
+			wantPrev:     false,
+			wantNext:     true,
+			wantNextPage: 2,
+		},
+		"正常ケース:途中のページ": {
+			page:         TodoPage{Total: 41, Page: 2, Limit: 20},
+			wantPages:    3,
+			wantPrev:     true,
+			wantNext:     true,
+			wantPrevPage: 1,
+			wantNextPage: 3,
+		},
+		"正常ケース:最後のページ": {
+			page:         TodoPage{Total: 40, Page: 2, Limit: 20},
+			wantPages:    2,
+			wantPrev:     true,
+			wantNext:     false,
+			wantPrevPage: 1,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.wantPages, tt.page.TotalPages())
+			assert.Equal(t, tt.wantPrev, tt.page.HasPrev())
+			assert.Equal(t, tt.wantNext, tt.page.HasNext())
+			if tt.wantPrev {
+				assert.Equal(t, tt.wantPrevPage, tt.page.PrevPage())
+			}
+			if tt.wantNext {
+				assert.Equal(t, tt.wantNextPage, tt.page.NextPage())
+			}
+		})
+	}
+}
+
+func TestTodoQueryNormalize(t *testing.T) {
+
+	cases := map[string]struct {
+		query      TodoQuery
+		wantPage   int
+		wantLimit  int
+		wantOffset int
+	}{
+		"正常ケース:省略時は既定値": {
+			query:      TodoQuery{},
+			wantPage:   1,
+			wantLimit:  DefaultPageSize,
+			wantOffset: 0,
+		},
+		"正常ケース:範囲内の値はそのまま": {
+			query:      TodoQuery{Page: 3, Limit: 10},
+			wantPage:   3,
+			wantLimit:  10,
+			wantOffset: 20,
+		},
+		"正常ケース:上限を超える値は丸める": {
+			query:      TodoQuery{Page: math.MaxInt, Limit: math.MaxInt},
+			wantPage:   MaxPage,
+			wantLimit:  MaxPageSize,
+			wantOffset: (MaxPage - 1) * MaxPageSize,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := tt.query.Normalize()
+			assert.Equal(t, tt.wantPage, q.Page)
+			assert.Equal(t, tt.wantLimit, q.Limit)
+			assert.Equal(t, tt.wantOffset, q.Offset())
+		})
+	}
+}
+
+func TestTodoQueryReorderable(t *testing.T) {
+
+	cases := map[string]struct {
+		query TodoQuery
+		want  bool
+	}{
+		"正常ケース:手動の並び順": {
+			query: TodoQuery{Sort: SortByPosition},
+			want:  true,
+		},
+		"正常ケース:手動の並び順の降順": {
+			query: TodoQuery{Sort: SortByPosition, Direction: Desc},
+			want:  false,
+		},
+		"正常ケース:既定の並び順": {
+			query: TodoQuery{},
+			want:  false,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.query.Reorderable())
+		})
+	}
+}
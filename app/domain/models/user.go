@@ -0,0 +1,121 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// パスワードの長さの制限
+// bcryptは72バイトを超える部分を無視するため、上限を設ける
+const (
+	PasswordMinLength = 8
+	PasswordMaxBytes  = 72
+)
+
+// ユーザーのデータを保持する構造体
+type User struct {
+	gorm.Model
+	// ログインに使用するメールアドレス（小文字に正規化して保持する）
+	Email string
+	// bcryptでハッシュ化したパスワード
+	PasswordHash string `json:"-"`
+}
+
+// ログインセッションのデータを保持する構造体
+type Session struct {
+	// トークンのハッシュ値
+	// DBが漏洩してもセッションを乗っ取られないよう、トークンそのものは保存しない
+	ID        string `gorm:"primaryKey"`
+	UserID    uint
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	// 発行したトークン（発行直後のみ設定され、保存はされない）
+	Token string `gorm:"-"`
+}
+
+// メールアドレスを比較用に正規化する
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// メールアドレスを検証して設定する
+func (u *User) SetEmail(email string) error {
+	email = NormalizeEmail(email)
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return Validation(errors.New("invalid email address"))
+	}
+	u.Email = email
+	return nil
+}
+
+// パスワードを検証し、ハッシュ化して設定する
+func (u *User) SetPassword(password string) error {
+	if len([]rune(password)) < PasswordMinLength {
+		return Validation(errors.New("password is too short"))
+	}
+	if PasswordMaxBytes < len(password) {
+		return Validation(errors.New("password is too long"))
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	u.PasswordHash = string(hash)
+	return nil
+}
+
+// パスワードが一致するかを返す
+func (u User) CheckPassword(password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+// 新しいセッションを発行する
+// トークンは推測されないよう、暗号論的に安全な乱数から生成する
+func NewSession(userID uint, now time.Time, ttl time.Duration) (*Session, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return &Session{
+		ID:        HashSessionToken(token),
+		UserID:    userID,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+		Token:     token,
+	}, nil
+}
+
+// セッションのトークンから保存用のIDを求める
+func HashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// セッションのトークンからCSRF対策用のトークンを求める
+// セッションのトークンを鍵にしたHMACのため、cookieを読めない他サイトからは求められない
+// トークンが空の場合は空文字列を返す
+func CSRFToken(sessionToken string) string {
+	if sessionToken == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(sessionToken))
+	mac.Write([]byte("csrf"))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// 指定された日時の時点でセッションが失効しているかを返す
+func (s Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
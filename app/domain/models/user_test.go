@@ -0,0 +1,128 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetEmail(t *testing.T) {
+
+	cases := map[string]struct {
+		email     string
+		want      string
+		expectErr bool
+	}{
+		"正常ケース:小文字に正規化される": {
+			email: " User@Example.COM ",
+			want:  "user@example.com",
+		},
+		"異常ケース:@が無い": {
+			email:     "user.example.com",
+			expectErr: true,
+		},
+		"異常ケース:名前付きの形式": {
+			email:     "User <user@example.com>",
+			expectErr: true,
+		},
+		"異常ケース:空文字列": {
+			email:     "",
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var user User
+			err := user.SetEmail(tt.email)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+				assert.Empty(t, user.Email)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, user.Email)
+			}
+		})
+	}
+}
+
+func TestSetPassword(t *testing.T) {
+
+	cases := map[string]struct {
+		password  string
+		expectErr bool
+	}{
+		"正常ケース:8文字": {
+			password: "12345678",
+		},
+		"正常ケース:マルチバイト文字は文字数で数える": {
+			password: "パスワードです。",
+		},
+		"異常ケース:7文字": {
+			password:  "1234567",
+			expectErr: true,
+		},
+		"異常ケース:72バイトを超える": {
+			password:  strings.Repeat("a", 73),
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var user User
+			err := user.SetPassword(tt.password)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+				assert.Empty(t, user.PasswordHash)
+				return
+			}
+			if assert.NoError(t, err) {
+				// 平文のまま保持しないこと
+				assert.NotEqual(t, tt.password, user.PasswordHash)
+				assert.True(t, user.CheckPassword(tt.password))
+				assert.False(t, user.CheckPassword(tt.password+"x"))
+			}
+		})
+	}
+}
+
+func TestNewSession(t *testing.T) {
+
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	session, err := NewSession(1, now, time.Hour)
+	if assert.NoError(t, err) {
+		// 保存するIDはトークンそのものではなくハッシュ値であること
+		assert.NotEqual(t, session.Token, session.ID)
+		assert.Equal(t, HashSessionToken(session.Token), session.ID)
+		assert.False(t, session.IsExpired(now.Add(59*time.Minute)))
+		assert.True(t, session.IsExpired(now.Add(time.Hour)))
+	}
+
+	// 発行するたびに異なるトークンになること
+	other, err := NewSession(1, now, time.Hour)
+	if assert.NoError(t, err) {
+		assert.NotEqual(t, session.Token, other.Token)
+	}
+}
+
+func TestCSRFToken(t *testing.T) {
+
+	// 同じセッションでは同じトークンになること
+	assert.Equal(t, CSRFToken("session-a"), CSRFToken("session-a"))
+	// セッションごとに異なるトークンになること
+	assert.NotEqual(t, CSRFToken("session-a"), CSRFToken("session-b"))
+	// セッションのトークン・保存用のIDとは異なること
+	assert.NotEqual(t, "session-a", CSRFToken("session-a"))
+	assert.NotEqual(t, HashSessionToken("session-a"), CSRFToken("session-a"))
+	// セッションが無い場合は空文字列になること
+	assert.Empty(t, CSRFToken(""))
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// 入力された文字列に、使用できない制御文字が含まれている場合のエラー
+var ErrControlCharacter = Validation(errors.New("contains control characters"))
+
+// 検証エラーの項目の名前（フォーム・APIの項目名と同じ）
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldStatus      = "status"
+	FieldPriority    = "priority"
+	FieldDue         = "due_date"
+	FieldRecurrence  = "recurrence"
+	FieldTags        = "tags"
+	FieldProject     = "project_id"
+)
+
+// todoのタイトル・説明をSetTitle・SetDescriptionと同じ形に揃えてから検証し、不正な項目ごとのエラーをまとめて返す
+// beforeが指定された場合は変更した項目のみ揃えて検証し、以前から保存されている値はそのまま保存できるようにする
+// 状態は、新規作成の場合は作業中の状態か、更新の場合はbeforeの状態から遷移できるかを検証する
+// 完了にする場合は、beforeのチェックリストに未完了の項目が残っていないかも検証する
+func (t *Todo) Validate(before *Todo) error {
+	var errs FieldErrors
+	if before == nil || t.Title != before.Title {
+		t.Title = normalizeTitle(t.Title)
+		errs.Add(FieldTitle, validateTitle(t.Title))
+	}
+	if before == nil || t.Description != before.Description {
+		t.Description = normalizeDescription(t.Description)
+		errs.Add(FieldDescription, validateDescription(t.Description))
+	}
+	if before == nil {
+		errs.Add(FieldStatus, validateInitialStatus(t.Status))
+	} else if t.Status != before.Status {
+		errs.Add(FieldStatus, before.validateStatusChange(t.Status))
+	}
+	return errs.Err()
+}
+
+// 入力の項目ごとの検証エラー
+type FieldError struct {
+	// 項目の名前（FieldTitleなど）
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// 複数の項目の検証エラー
+// 全ての項目を検証してからまとめて返し、画面では項目ごとにエラーを表示する
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e FieldErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// 項目の検証結果を追加する（エラーが無い場合は何もしない）
+func (e *FieldErrors) Add(field string, err error) {
+	if err != nil {
+		*e = append(*e, &FieldError{Field: field, Err: err})
+	}
+}
+
+// 指定された項目のエラーを返す（エラーが無い場合はnil）
+func (e FieldErrors) Get(field string) error {
+	for _, err := range e {
+		if err.Field == field {
+			return err.Err
+		}
+	}
+	return nil
+}
+
+// 検証エラーがある場合は入力が不正なエラーとして返し、無い場合はnilを返す
+func (e FieldErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return Validation(e)
+}
+
+// 入力された文字列を、保存する形に揃える
+// 見た目が同じ文字を同じ値として扱えるよう、Unicodeの正規化（NFC）を行う
+func normalizeText(s string) string {
+	return norm.NFC.String(strings.ToValidUTF8(s, string(utf8.RuneError)))
+}
+
+// 使用できない制御文字が含まれている場合はErrControlCharacterを返す
+// allowed（改行・タブなど）に含まれる文字は使用できる
+func checkControlCharacters(s string, allowed string) error {
+	for _, r := range s {
+		if r == utf8.RuneError || (unicode.IsControl(r) && !strings.ContainsRune(allowed, r)) {
+			return fmt.Errorf("%w: %U", ErrControlCharacter, r)
+		}
+	}
+	return nil
+}
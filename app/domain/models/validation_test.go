@@ -0,0 +1,94 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+
+	cases := map[string]struct {
+		todo   Todo
+		before *Todo
+		// 検証エラーになる項目と、そのエラー
+		want map[string]error
+	}{
+		"正常ケース:全ての項目が正しい": {
+			todo: Todo{Title: "買い物", Description: "牛乳\n\t卵"},
+		},
+		"異常ケース:不正な項目をまとめて返す": {
+			todo: Todo{Title: "", Description: "牛乳\x00"},
+			want: map[string]error{FieldTitle: ErrTitleRequired, FieldDescription: ErrControlCharacter},
+		},
+		"異常ケース:空白のみのタイトル": {
+			todo: Todo{Title: " \u3000 "},
+			want: map[string]error{FieldTitle: ErrTitleRequired},
+		},
+		"異常ケース:完了の状態で新規作成": {
+			todo: Todo{Title: "買い物", Status: Done},
+			want: map[string]error{FieldStatus: ErrInvalidInitialStatus},
+		},
+		"異常ケース:許可されていない状態の遷移": {
+			todo:   Todo{Title: "買い物", Status: InProgress},
+			before: &Todo{Title: "買い物", Status: Done},
+			want:   map[string]error{FieldStatus: ErrInvalidStatusTransition},
+		},
+		"異常ケース:チェックリストに未完了の項目が残っている状態で完了": {
+			todo:   Todo{Title: "買い物", Status: Done},
+			before: &Todo{Title: "買い物", Status: InProgress, ChecklistItems: []ChecklistItem{{Done: true}, {Done: false}}},
+			want:   map[string]error{FieldStatus: ErrOpenChecklistItems},
+		},
+		"正常ケース:許可された状態の遷移": {
+			todo:   Todo{Title: "買い物", Status: Done},
+			before: &Todo{Title: "買い物", Status: InProgress},
+		},
+		"正常ケース:変更していない項目は検証しない": {
+			todo:   Todo{Title: "", Description: "説明"},
+			before: &Todo{Title: "", Description: "元の説明"},
+		},
+		"異常ケース:変更した項目のみ検証する": {
+			todo:   Todo{Title: "", Description: "説明\x1b"},
+			before: &Todo{Title: "", Description: "元の説明"},
+			want:   map[string]error{FieldDescription: ErrControlCharacter},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tt.todo.Validate(tt.before)
+
+			// 結果を確認
+			if len(tt.want) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, KindValidation, KindOf(err))
+			var fieldErrs FieldErrors
+			if assert.True(t, errors.As(err, &fieldErrs)) {
+				assert.Len(t, fieldErrs, len(tt.want))
+				for field, want := range tt.want {
+					assert.ErrorIs(t, fieldErrs.Get(field), want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateNormalize(t *testing.T) {
+
+	t.Run("正常ケース:タイトル・説明を保存する形に揃える", func(t *testing.T) {
+		todo := Todo{Title: "  Cafe\u0301  ", Description: "牛乳\r\n卵 \n"}
+		assert.NoError(t, todo.Validate(nil))
+		assert.Equal(t, "Caf\u00e9", todo.Title)
+		assert.Equal(t, "牛乳\n卵", todo.Description)
+	})
+
+	t.Run("正常ケース:変更していない項目は揃えない", func(t *testing.T) {
+		todo := Todo{Title: " 元のタイトル ", Description: "説明 "}
+		before := todo
+		assert.NoError(t, todo.Validate(&before))
+		assert.Equal(t, before, todo)
+	})
+}
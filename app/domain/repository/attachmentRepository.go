@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+)
+
+// AttachmentRepository is interface for infrastructure
+// 添付ファイルの情報のみを扱い、ファイルの内容はBlobStorageで扱う
+// 検索・削除は全て、添付先のtodoの所有者のユーザーIDで絞り込む
+type AttachmentRepository interface {
+	interfaces.Closer
+	FindByTodo(userID uint, todoID uint) (*[]models.Attachment, error)
+	FindById(userID uint, todoID uint, id uint) (*models.Attachment, error)
+	Create(attachment *models.Attachment) error
+	Delete(userID uint, todoID uint, id uint) error
+}
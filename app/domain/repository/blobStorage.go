@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"errors"
+	"io"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+)
+
+// 指定されたキーのファイルがストレージに存在しない
+var ErrBlobNotFound = models.NotFound(errors.New("blob not found"))
+
+// BlobStorage is interface for infrastructure
+// 添付ファイルの内容を、キーを指定して保存・取得・削除する
+// キーは"todos/1/abcdef"のようにスラッシュで区切った文字列とする
+type BlobStorage interface {
+	interfaces.Closer
+	// rからsizeバイトを読み込んで保存する。同じキーのファイルは上書きする
+	Put(key string, r io.Reader, size int64, contentType string) error
+	// 保存したファイルを読み込むReaderを返す。呼び出し側で閉じること
+	// 存在しない場合はErrBlobNotFoundを返す
+	Get(key string) (io.ReadCloser, error)
+	// 保存したファイルを削除する。存在しない場合も成功とする
+	Delete(key string) error
+}
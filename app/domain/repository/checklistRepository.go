@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+)
+
+// ChecklistRepository is interface for infrastructure
+// 検索・更新・削除は全て、項目が属するtodoの所有者のユーザーIDで絞り込む
+type ChecklistRepository interface {
+	interfaces.Closer
+	FindByTodo(userID uint, todoID uint) (*[]models.ChecklistItem, error)
+	FindById(userID uint, todoID uint, id uint) (*models.ChecklistItem, error)
+	Create(item *models.ChecklistItem) error
+	Update(userID uint, item *models.ChecklistItem) error
+	Delete(userID uint, todoID uint, id uint) error
+	Reorder(userID uint, todoID uint, ids []uint) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+)
+
+// CommentRepository is interface for infrastructure
+// 検索・更新・削除は全て、コメントが属するtodoの所有者のユーザーIDで絞り込む
+type CommentRepository interface {
+	interfaces.Closer
+	FindByTodo(userID uint, todoID uint) (*[]models.Comment, error)
+	FindById(userID uint, todoID uint, id uint) (*models.Comment, error)
+	Create(comment *models.Comment) error
+	Update(userID uint, comment *models.Comment) error
+	Delete(userID uint, todoID uint, id uint) error
+}
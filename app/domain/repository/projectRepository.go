@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+)
+
+// ProjectRepository is interface for infrastructure
+// 検索・更新・削除は全て所有者のユーザーIDで絞り込む
+type ProjectRepository interface {
+	interfaces.Closer
+	FindAll(userID uint) (*[]models.Project, error)
+	FindById(userID uint, id uint) (*models.Project, error)
+	FindInbox(userID uint) (*models.Project, error)
+	Create(project *models.Project) error
+	Update(project *models.Project) error
+	Delete(userID uint, id uint, moveTo uint) error
+}
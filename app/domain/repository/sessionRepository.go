@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+)
+
+// SessionRepository is interface for infrastructure
+type SessionRepository interface {
+	interfaces.Closer
+	FindById(id string) (*models.Session, error)
+	Create(session *models.Session) error
+	Delete(id string) error
+	DeleteExpired(now time.Time) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+)
+
+// TagRepository is interface for infrastructure
+// 検索・更新・削除は全て所有者のユーザーIDで絞り込む
+type TagRepository interface {
+	interfaces.Closer
+	FindAll(userID uint) (*[]models.Tag, error)
+	FindById(userID uint, id uint) (*models.Tag, error)
+	FindByNames(userID uint, names []string) (*[]models.Tag, error)
+	FindByTodo(userID uint, todoID uint) (*[]models.Tag, error)
+	Create(tag *models.Tag) error
+	Update(tag *models.Tag) error
+	Delete(userID uint, id uint) error
+	Attach(userID uint, todoID uint, tagIDs []uint) error
+	Detach(userID uint, todoID uint, tagIDs []uint) error
+	Merge(userID uint, fromID uint, intoID uint) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+)
+
+// TodoHistoryRepository is interface for infrastructure
+// 履歴は追記のみで、変更・削除はしない
+// 保存はtodoの変更と同じトランザクションで行うため、TodoRepositoryで行う
+// 検索は、履歴が属するtodoの所有者のユーザーIDで絞り込む
+type TodoHistoryRepository interface {
+	interfaces.Closer
+	FindByTodo(userID uint, todoID uint) (*[]models.TodoHistory, error)
+}
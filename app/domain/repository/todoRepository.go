@@ -1,16 +1,34 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
 	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
 )
 
 // TodoRepository is interface for infrastructure
+// 検索・更新・削除は全て所有者のユーザーIDで絞り込む
+// FindByIdは詳細の表示用にコメント・添付ファイル・変更履歴も読み込み、FindSummaryByIdはタグ・チェックリストのみ読み込む
+// 削除はゴミ箱に移す（論理削除）のみで、完全に削除するにはPurgeを使用する
+// Create・Update・Delete・Restore・Purge・ReplaceTagsは、todoの変更と渡された変更履歴を1つのトランザクションで保存する
+// Bulkは複数のtodoの変更を、変更履歴・タグとともに1つのトランザクションで保存する
 type TodoRepository interface {
 	interfaces.Closer
-	FindAll() (*[]models.Todo, error)
-	FindById(id uint) (*models.Todo, error)
-	Create(todo *models.Todo) error
-	Update(todo *models.Todo) error
-	Delete(id uint) error
+	FindAll(userID uint) (*[]models.Todo, error)
+	FindById(userID uint, id uint) (*models.Todo, error)
+	FindSummaryById(userID uint, id uint) (*models.Todo, error)
+	FindByIds(userID uint, ids []uint) (*[]models.Todo, error)
+	Search(query models.TodoQuery) (todos *[]models.Todo, total int64, err error)
+	Create(todo *models.Todo, histories ...models.TodoHistory) error
+	Update(todo *models.Todo, histories []models.TodoHistory, next *models.Todo) error
+	UpdatePosition(userID uint, id uint, position string) error
+	Delete(userID uint, id uint, histories ...models.TodoHistory) error
+	FindTrash(userID uint) (*[]models.Todo, error)
+	FindTrashedById(userID uint, id uint) (*models.Todo, error)
+	FindTrashedBefore(before time.Time) (*[]models.Todo, error)
+	Restore(userID uint, id uint, histories ...models.TodoHistory) error
+	Purge(userID uint, id uint, histories ...models.TodoHistory) error
+	ReplaceTags(todo *models.Todo, tags []models.Tag, histories ...models.TodoHistory) error
+	Bulk(bulk *models.TodoBulk) ([]error, error)
 }
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+)
+
+// UserRepository is interface for infrastructure
+type UserRepository interface {
+	interfaces.Closer
+	FindById(id uint) (*models.User, error)
+	FindByEmail(email string) (*models.User, error)
+	Create(user *models.User) error
+}
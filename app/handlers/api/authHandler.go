@@ -0,0 +1,148 @@
+package api
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+// Web画面のログインで発行されるセッションのcookieの名前
+const sessionCookieName = "session"
+
+// cookieで認証する場合に、CSRF対策用のトークンを送信するヘッダーの名前
+const csrfHeaderName = "X-CSRF-Token"
+
+// ログイン中のユーザーをgin.Contextに保存する際のキー
+const currentUserKey = "currentUser"
+
+// /api/v1/sessionsへのリクエストに対するハンドラーの構造体
+type AuthHandler struct {
+	authUsecase usecases.AuthUsecase
+}
+
+// APIで受け付けるログイン情報の構造体
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// APIで返却するセッションの構造体
+type sessionResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// AuthHandlerの新しいインスタンスを作成して返す
+func NewAuthHandler(uc usecases.AuthUsecase) AuthHandler {
+	authHandler := AuthHandler{authUsecase: uc}
+	return authHandler
+}
+
+// ログインしてトークンを発行する
+func (ah *AuthHandler) Login(c *gin.Context) {
+	var req loginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "request body is invalid")
+		return
+	}
+	session, err := ah.authUsecase.Login(req.Email, req.Password)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidCredentials) {
+			abortWithError(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+		respondError(c, err, "user not found", "failed to login")
+		return
+	}
+	c.JSON(http.StatusCreated, sessionResponse{Token: session.Token, ExpiresAt: session.ExpiresAt})
+}
+
+// リクエストに使用したトークンを破棄する
+func (ah *AuthHandler) Logout(c *gin.Context) {
+	token, _ := requestToken(c)
+	if err := ah.authUsecase.Logout(token); err != nil {
+		respondError(c, err, "session not found", "failed to logout")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// 認証されていないリクエストを401で拒否するミドルウェア
+// Authorizationヘッダーのトークンか、Web画面のセッションのcookieで認証する
+// cookieは他サイトからのリクエストでも送信されるため、状態を変更する場合はCSRF対策用のトークンも検証する
+func (ah *AuthHandler) RequireToken(c *gin.Context) {
+	token, fromCookie := requestToken(c)
+	if fromCookie && !isSafeMethod(c.Request.Method) {
+		want := models.CSRFToken(token)
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader(csrfHeaderName)), []byte(want)) != 1 {
+			abortWithError(c, http.StatusForbidden, "csrf token is invalid")
+			return
+		}
+	}
+	user, err := ah.authUsecase.Authenticate(token)
+	if err != nil {
+		if errors.Is(err, usecases.ErrUnauthenticated) {
+			c.Header("WWW-Authenticate", `Bearer realm="todo"`)
+			abortWithError(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+		respondError(c, err, "session not found", "failed to authenticate")
+		return
+	}
+	c.Set(currentUserKey, user)
+	c.Next()
+}
+
+// 終了処理を行う
+func (ah *AuthHandler) Close() {
+	err := ah.authUsecase.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// リクエストからセッションのトークンを取り出し、cookieから取り出したかどうかと共に返す
+// Authorizationヘッダーを優先し、無い場合はcookieを使用する
+func requestToken(c *gin.Context) (string, bool) {
+	if auth := c.GetHeader("Authorization"); auth != "" {
+		scheme, token, ok := strings.Cut(auth, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token), false
+		}
+		return "", false
+	}
+	token, err := c.Cookie(sessionCookieName)
+	if err != nil || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// 状態を変更しないHTTPメソッドかを返す
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// ログイン中のユーザーのIDを返す
+// RequireTokenを通過していない場合は0を返す
+func currentUserID(c *gin.Context) uint {
+	v, ok := c.Get(currentUserKey)
+	if !ok {
+		return 0
+	}
+	if user, ok := v.(*models.User); ok {
+		return user.ID
+	}
+	return 0
+}
@@ -0,0 +1,267 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_usecases "github.com/MinadukiSekina/todo-go-app/app/mock/usecase"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLogin(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	expiresAt := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	session := &models.Session{UserID: 1, Token: "token", ExpiresAt: expiresAt}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockAuthUsecase)
+		body          string
+		want          int
+		wantToken     string
+	}{
+		"正常ケース:ログインに成功": {
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Login("user@example.com", "password").Return(session, nil)
+			},
+			body:      `{"email":"user@example.com","password":"password"}`,
+			want:      http.StatusCreated,
+			wantToken: "token",
+		},
+		"異常ケース:JSONが不正": {
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {},
+			body:          `{"email":`,
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:パスワードが誤っている": {
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Login("user@example.com", "password").Return(nil, usecases.ErrInvalidCredentials)
+			},
+			body: `{"email":"user@example.com","password":"password"}`,
+			want: http.StatusUnauthorized,
+		},
+		"異常ケース:ログインに失敗": {
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Login("user@example.com", "password").Return(nil, errors.New("something is wrong"))
+			},
+			body: `{"email":"user@example.com","password":"password"}`,
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockAuthUsecase(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("POST", "/api/v1/sessions", tt.body, nil)
+
+			// mockを利用してテストする
+			handler := NewAuthHandler(mock)
+			handler.Login(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.wantToken != "" {
+				var res sessionResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res)) {
+					assert.Equal(t, tt.wantToken, res.Token)
+					assert.True(t, expiresAt.Equal(res.ExpiresAt))
+				}
+			}
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockAuthUsecase)
+		want          int
+	}{
+		"正常ケース:ログアウトに成功": {
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Logout("token").Return(nil)
+			},
+			want: http.StatusNoContent,
+		},
+		"異常ケース:ログアウトに失敗": {
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Logout("token").Return(errors.New("something is wrong"))
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockAuthUsecase(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("DELETE", "/api/v1/sessions", "", nil)
+			c.Request.Header.Set("Authorization", "Bearer token")
+
+			// mockを利用してテストする
+			handler := NewAuthHandler(mock)
+			handler.Logout(c)
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestRequireToken(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		method           string
+		prepareRequestFn func(req *http.Request)
+		prepareMockFn    func(m *mock_usecases.MockAuthUsecase)
+		want             int
+		wantUser         *models.User
+	}{
+		"正常ケース:Authorizationヘッダーのトークンで認証": {
+			prepareRequestFn: func(req *http.Request) { req.Header.Set("Authorization", "Bearer token") },
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Authenticate("token").Return(testUser, nil)
+			},
+			want:     http.StatusOK,
+			wantUser: testUser,
+		},
+		"正常ケース:セッションのcookieで認証": {
+			prepareRequestFn: func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: "cookie-token"})
+			},
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Authenticate("cookie-token").Return(testUser, nil)
+			},
+			want:     http.StatusOK,
+			wantUser: testUser,
+		},
+		"正常ケース:cookieで認証してCSRF対策用のトークン付きで作成": {
+			method: "POST",
+			prepareRequestFn: func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: "cookie-token"})
+				req.Header.Set(csrfHeaderName, models.CSRFToken("cookie-token"))
+			},
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Authenticate("cookie-token").Return(testUser, nil)
+			},
+			want:     http.StatusOK,
+			wantUser: testUser,
+		},
+		"正常ケース:Authorizationヘッダーの場合はCSRF対策用のトークンが不要": {
+			method:           "POST",
+			prepareRequestFn: func(req *http.Request) { req.Header.Set("Authorization", "Bearer token") },
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Authenticate("token").Return(testUser, nil)
+			},
+			want:     http.StatusOK,
+			wantUser: testUser,
+		},
+		"異常ケース:cookieで認証してCSRF対策用のトークンが無い": {
+			method: "POST",
+			prepareRequestFn: func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: "cookie-token"})
+			},
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {},
+			want:          http.StatusForbidden,
+		},
+		"異常ケース:cookieで認証してCSRF対策用のトークンが別のセッションのもの": {
+			method: "DELETE",
+			prepareRequestFn: func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: "cookie-token"})
+				req.Header.Set(csrfHeaderName, models.CSRFToken("other-token"))
+			},
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {},
+			want:          http.StatusForbidden,
+		},
+		"異常ケース:Bearer以外の認証方式": {
+			prepareRequestFn: func(req *http.Request) { req.Header.Set("Authorization", "Basic dXNlcjpwYXNz") },
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Authenticate("").Return(nil, usecases.ErrUnauthenticated)
+			},
+			want: http.StatusUnauthorized,
+		},
+		"異常ケース:トークンが無い": {
+			prepareRequestFn: func(req *http.Request) {},
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Authenticate("").Return(nil, usecases.ErrUnauthenticated)
+			},
+			want: http.StatusUnauthorized,
+		},
+		"異常ケース:認証処理に失敗": {
+			prepareRequestFn: func(req *http.Request) { req.Header.Set("Authorization", "Bearer token") },
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Authenticate("token").Return(nil, errors.New("something is wrong"))
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockAuthUsecase(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			// ミドルウェアの後続のハンドラーで認証されたユーザーを確認する
+			handler := NewAuthHandler(mock)
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+			var gotUser *models.User
+			r.Any("/api/v1/todos", handler.RequireToken, func(c *gin.Context) {
+				if v, ok := c.Get(currentUserKey); ok {
+					gotUser = v.(*models.User)
+				}
+				c.Status(http.StatusOK)
+			})
+
+			method := tt.method
+			if method == "" {
+				method = "GET"
+			}
+			req, _ := http.NewRequest(method, "/api/v1/todos", nil)
+			tt.prepareRequestFn(req)
+			r.ServeHTTP(w, req)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantUser, gotUser)
+			if tt.want == http.StatusUnauthorized {
+				assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
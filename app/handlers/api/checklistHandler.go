@@ -0,0 +1,141 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+// /api/v1/todos/:id/checklistへのリクエストに対するハンドラーの構造体
+type ChecklistHandler struct {
+	checklistUsecase usecases.ChecklistUsecase
+}
+
+// ChecklistHandlerの新しいインスタンスを作成して返す
+func NewChecklistHandler(uc usecases.ChecklistUsecase) ChecklistHandler {
+	checklistHandler := ChecklistHandler{checklistUsecase: uc}
+	return checklistHandler
+}
+
+// todoのチェックリストの項目を並び順で返す
+func (ch *ChecklistHandler) Index(c *gin.Context) {
+	todoID, ok := parseID(c)
+	if !ok {
+		return
+	}
+	items, err := ch.checklistUsecase.List(currentUserID(c), todoID)
+	if err != nil {
+		respondChecklistError(c, err, "failed to get checklist")
+		return
+	}
+	c.JSON(http.StatusOK, newChecklistResponse(*items))
+}
+
+// todoのチェックリストの末尾に項目を追加する
+func (ch *ChecklistHandler) Create(c *gin.Context) {
+	todoID, ok := parseID(c)
+	if !ok {
+		return
+	}
+	var req checklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "request body is invalid")
+		return
+	}
+	item, err := ch.checklistUsecase.Add(currentUserID(c), todoID, deref(req.Title))
+	if err != nil {
+		respondChecklistError(c, err, "failed to create checklist item")
+		return
+	}
+	// 完了済みとして追加する場合
+	if req.Done != nil && *req.Done {
+		if item, err = ch.checklistUsecase.Update(currentUserID(c), todoID, item.ID, nil, req.Done); err != nil {
+			respondChecklistError(c, err, "failed to create checklist item")
+			return
+		}
+	}
+	c.Header("Location", fmt.Sprintf("/api/v1/todos/%d/checklist/%d", todoID, item.ID))
+	c.JSON(http.StatusCreated, newChecklistItemResponse(item))
+}
+
+// 項目のタイトル・完了したか・位置を部分的に更新する
+func (ch *ChecklistHandler) Update(c *gin.Context) {
+	todoID, id, ok := parseChecklistIDs(c)
+	if !ok {
+		return
+	}
+	var req checklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "request body is invalid")
+		return
+	}
+	userID := currentUserID(c)
+	item, err := ch.checklistUsecase.Update(userID, todoID, id, req.Title, req.Done)
+	if err != nil {
+		respondChecklistError(c, err, "failed to update checklist item")
+		return
+	}
+	if req.Position != nil {
+		if err := ch.checklistUsecase.Move(userID, todoID, id, *req.Position); err != nil {
+			respondChecklistError(c, err, "failed to move checklist item")
+			return
+		}
+		// 移動後の位置を返すため、取得し直す
+		items, err := ch.checklistUsecase.List(userID, todoID)
+		if err != nil {
+			respondChecklistError(c, err, "failed to get checklist")
+			return
+		}
+		for i := range *items {
+			if (*items)[i].ID == id {
+				item = &(*items)[i]
+			}
+		}
+	}
+	c.JSON(http.StatusOK, newChecklistItemResponse(item))
+}
+
+// 項目を削除する
+func (ch *ChecklistHandler) Delete(c *gin.Context) {
+	todoID, id, ok := parseChecklistIDs(c)
+	if !ok {
+		return
+	}
+	if err := ch.checklistUsecase.Delete(currentUserID(c), todoID, id); err != nil {
+		respondChecklistError(c, err, "failed to delete checklist item")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// 終了処理を行う
+func (ch *ChecklistHandler) Close() {
+	err := ch.checklistUsecase.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// パスパラメータのtodoと項目のIDを数値に変換する
+// 変換できない場合はエラーを返却し、falseを返す
+func parseChecklistIDs(c *gin.Context) (uint, uint, bool) {
+	todoID, ok := parseID(c)
+	if !ok {
+		return 0, 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("item_id"), 10, 64)
+	if err != nil {
+		abortWithError(c, http.StatusBadRequest, "item id must be a positive integer")
+		return 0, 0, false
+	}
+	return todoID, uint(id), true
+}
+
+// チェックリストの操作時のエラーを返却する
+func respondChecklistError(c *gin.Context, err error, msg string) {
+	respondError(c, err, "todo or checklist item not found", msg)
+}
@@ -0,0 +1,305 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_usecases "github.com/MinadukiSekina/todo-go-app/app/mock/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestChecklistIndex(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	items := []models.ChecklistItem{
+		{ID: 1, TodoID: 1, Title: "a", Position: 0, Done: true},
+		{ID: 2, TodoID: 1, Title: "b", Position: 1},
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockChecklistUsecase)
+		id            any
+		want          int
+		wantItems     []checklistItemResponse
+	}{
+		"正常ケース:データあり": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().List(testUser.ID, uint(1)).Return(&items, nil)
+			},
+			id:   1,
+			want: http.StatusOK,
+			wantItems: []checklistItemResponse{
+				{ID: 1, Title: "a", Done: true, Position: 0},
+				{ID: 2, Title: "b", Position: 1},
+			},
+		},
+		"正常ケース:データなし": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().List(testUser.ID, uint(1)).Return(&[]models.ChecklistItem{}, nil)
+			},
+			id:        1,
+			want:      http.StatusOK,
+			wantItems: []checklistItemResponse{},
+		},
+		"異常ケース:todoが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().List(testUser.ID, uint(1)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			id:   1,
+			want: http.StatusNotFound,
+		},
+		"異常ケース:IDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {},
+			id:            "string",
+			want:          http.StatusBadRequest,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockChecklistUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("GET", fmt.Sprintf("/api/v1/todos/%v/checklist", tt.id), "", tt.id)
+
+			// mockを利用してテストする
+			handler := NewChecklistHandler(mock)
+			handler.Index(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusOK {
+				var body []checklistItemResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body)) {
+					assert.Equal(t, tt.wantItems, body)
+				}
+			}
+		})
+	}
+}
+
+func TestChecklistCreate(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	done := true
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockChecklistUsecase)
+		body          string
+		want          int
+		wantLocation  string
+		wantItem      checklistItemResponse
+	}{
+		"正常ケース:作成に成功": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Add(testUser.ID, uint(1), "c").Return(&models.ChecklistItem{ID: 3, TodoID: 1, Title: "c", Position: 2}, nil)
+			},
+			body:         `{"title":"c"}`,
+			want:         http.StatusCreated,
+			wantLocation: "/api/v1/todos/1/checklist/3",
+			wantItem:     checklistItemResponse{ID: 3, Title: "c", Position: 2},
+		},
+		"正常ケース:完了済みとして作成": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Add(testUser.ID, uint(1), "c").Return(&models.ChecklistItem{ID: 3, TodoID: 1, Title: "c", Position: 2}, nil)
+				m.EXPECT().Update(testUser.ID, uint(1), uint(3), nil, &done).Return(&models.ChecklistItem{ID: 3, TodoID: 1, Title: "c", Position: 2, Done: true}, nil)
+			},
+			body:         `{"title":"c","done":true}`,
+			want:         http.StatusCreated,
+			wantLocation: "/api/v1/todos/1/checklist/3",
+			wantItem:     checklistItemResponse{ID: 3, Title: "c", Position: 2, Done: true},
+		},
+		"異常ケース:JSONが不正": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {},
+			body:          `{"title":`,
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:タイトルが不正": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Add(testUser.ID, uint(1), "").Return(nil, models.ErrInvalidChecklistItemTitle)
+			},
+			body: `{"title":""}`,
+			want: http.StatusUnprocessableEntity,
+		},
+		"異常ケース:todoが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Add(testUser.ID, uint(1), "c").Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			body: `{"title":"c"}`,
+			want: http.StatusNotFound,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockChecklistUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("POST", "/api/v1/todos/1/checklist", tt.body, 1)
+
+			// mockを利用してテストする
+			handler := NewChecklistHandler(mock)
+			handler.Create(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+			if tt.want == http.StatusCreated {
+				var body checklistItemResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body)) {
+					assert.Equal(t, tt.wantItem, body)
+				}
+			}
+		})
+	}
+}
+
+func TestChecklistActions(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	title := "B"
+	done := true
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockChecklistUsecase)
+		callFn        func(handler *ChecklistHandler, c *gin.Context)
+		itemID        any
+		body          string
+		want          int
+		wantItem      *checklistItemResponse
+	}{
+		"正常ケース:タイトルと完了したかを更新": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Update(testUser.ID, uint(1), uint(2), &title, &done).Return(&models.ChecklistItem{ID: 2, TodoID: 1, Title: "B", Position: 1, Done: true}, nil)
+			},
+			callFn:   func(handler *ChecklistHandler, c *gin.Context) { handler.Update(c) },
+			itemID:   2,
+			body:     `{"title":"B","done":true}`,
+			want:     http.StatusOK,
+			wantItem: &checklistItemResponse{ID: 2, Title: "B", Position: 1, Done: true},
+		},
+		"正常ケース:位置を変更": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Update(testUser.ID, uint(1), uint(2), nil, nil).Return(&models.ChecklistItem{ID: 2, TodoID: 1, Title: "b", Position: 1}, nil)
+				m.EXPECT().Move(testUser.ID, uint(1), uint(2), 0).Return(nil)
+				m.EXPECT().List(testUser.ID, uint(1)).Return(&[]models.ChecklistItem{
+					{ID: 2, TodoID: 1, Title: "b", Position: 0},
+					{ID: 1, TodoID: 1, Title: "a", Position: 1},
+				}, nil)
+			},
+			callFn:   func(handler *ChecklistHandler, c *gin.Context) { handler.Update(c) },
+			itemID:   2,
+			body:     `{"position":0}`,
+			want:     http.StatusOK,
+			wantItem: &checklistItemResponse{ID: 2, Title: "b", Position: 0},
+		},
+		"異常ケース:タイトルが不正": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Update(testUser.ID, uint(1), uint(2), gomock.Any(), nil).Return(nil, models.ErrInvalidChecklistItemTitle)
+			},
+			callFn: func(handler *ChecklistHandler, c *gin.Context) { handler.Update(c) },
+			itemID: 2,
+			body:   `{"title":""}`,
+			want:   http.StatusUnprocessableEntity,
+		},
+		"異常ケース:更新する項目が存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Update(testUser.ID, uint(1), uint(9), nil, &done).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			callFn: func(handler *ChecklistHandler, c *gin.Context) { handler.Update(c) },
+			itemID: 9,
+			body:   `{"done":true}`,
+			want:   http.StatusNotFound,
+		},
+		"異常ケース:項目のIDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {},
+			callFn:        func(handler *ChecklistHandler, c *gin.Context) { handler.Update(c) },
+			itemID:        "string",
+			body:          `{"done":true}`,
+			want:          http.StatusBadRequest,
+		},
+		"正常ケース:削除に成功": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1), uint(2)).Return(nil)
+			},
+			callFn: func(handler *ChecklistHandler, c *gin.Context) { handler.Delete(c) },
+			itemID: 2,
+			want:   http.StatusNoContent,
+		},
+		"異常ケース:削除する項目が存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1), uint(9)).Return(models.NotFound(gorm.ErrRecordNotFound))
+			},
+			callFn: func(handler *ChecklistHandler, c *gin.Context) { handler.Delete(c) },
+			itemID: 9,
+			want:   http.StatusNotFound,
+		},
+		"異常ケース:削除に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1), uint(2)).Return(errors.New("something is wrong"))
+			},
+			callFn: func(handler *ChecklistHandler, c *gin.Context) { handler.Delete(c) },
+			itemID: 2,
+			want:   http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockChecklistUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("PATCH", fmt.Sprintf("/api/v1/todos/1/checklist/%v", tt.itemID), tt.body, 1)
+			c.Params = append(c.Params, gin.Param{Key: "item_id", Value: fmt.Sprint(tt.itemID)})
+
+			// mockを利用してテストする
+			handler := NewChecklistHandler(mock)
+			tt.callFn(&handler, c)
+			// c.Statusだけではステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.wantItem != nil {
+				var body checklistItemResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body)) {
+					assert.Equal(t, *tt.wantItem, body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+// /api/v1/todos/:id/commentsへのリクエストに対するハンドラーの構造体
+type CommentHandler struct {
+	commentUsecase usecases.CommentUsecase
+}
+
+// CommentHandlerの新しいインスタンスを作成して返す
+func NewCommentHandler(uc usecases.CommentUsecase) CommentHandler {
+	commentHandler := CommentHandler{commentUsecase: uc}
+	return commentHandler
+}
+
+// todoのコメントを投稿順で返す
+func (ch *CommentHandler) Index(c *gin.Context) {
+	todoID, ok := parseID(c)
+	if !ok {
+		return
+	}
+	comments, err := ch.commentUsecase.List(currentUserID(c), todoID)
+	if err != nil {
+		respondCommentError(c, err, "failed to get comments")
+		return
+	}
+	c.JSON(http.StatusOK, newCommentListResponse(*comments))
+}
+
+// todoにコメントを投稿する
+func (ch *CommentHandler) Create(c *gin.Context) {
+	todoID, ok := parseID(c)
+	if !ok {
+		return
+	}
+	var req commentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "request body is invalid")
+		return
+	}
+	comment, err := ch.commentUsecase.Add(currentUserID(c), todoID, deref(req.Body))
+	if err != nil {
+		respondCommentError(c, err, "failed to create comment")
+		return
+	}
+	c.Header("Location", fmt.Sprintf("/api/v1/todos/%d/comments/%d", todoID, comment.ID))
+	c.JSON(http.StatusCreated, newCommentResponse(comment))
+}
+
+// コメントの本文を変更する
+func (ch *CommentHandler) Update(c *gin.Context) {
+	todoID, id, ok := parseCommentIDs(c)
+	if !ok {
+		return
+	}
+	var req commentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "request body is invalid")
+		return
+	}
+	comment, err := ch.commentUsecase.Edit(currentUserID(c), todoID, id, deref(req.Body))
+	if err != nil {
+		respondCommentError(c, err, "failed to update comment")
+		return
+	}
+	c.JSON(http.StatusOK, newCommentResponse(comment))
+}
+
+// コメントを削除する
+func (ch *CommentHandler) Delete(c *gin.Context) {
+	todoID, id, ok := parseCommentIDs(c)
+	if !ok {
+		return
+	}
+	if err := ch.commentUsecase.Delete(currentUserID(c), todoID, id); err != nil {
+		respondCommentError(c, err, "failed to delete comment")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// 終了処理を行う
+func (ch *CommentHandler) Close() {
+	err := ch.commentUsecase.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// パスパラメータのtodoとコメントのIDを数値に変換する
+// 変換できない場合はエラーを返却し、falseを返す
+func parseCommentIDs(c *gin.Context) (uint, uint, bool) {
+	todoID, ok := parseID(c)
+	if !ok {
+		return 0, 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("comment_id"), 10, 64)
+	if err != nil {
+		abortWithError(c, http.StatusBadRequest, "comment id must be a positive integer")
+		return 0, 0, false
+	}
+	return todoID, uint(id), true
+}
+
+// コメントの操作時のエラーを返却する
+func respondCommentError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, models.ErrNotCommentAuthor):
+		abortWithError(c, http.StatusForbidden, err.Error())
+	default:
+		respondError(c, err, "todo or comment not found", msg)
+	}
+}
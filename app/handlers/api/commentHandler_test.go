@@ -0,0 +1,284 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_usecases "github.com/MinadukiSekina/todo-go-app/app/mock/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestCommentIndex(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	comments := []models.Comment{
+		{ID: 1, TodoID: 1, UserID: testUser.ID, User: *testUser, Body: "**確認**"},
+		{ID: 2, TodoID: 1, UserID: testUser.ID, User: *testUser, Body: "対応済み"},
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockCommentUsecase)
+		id            any
+		want          int
+		wantComments  []commentResponse
+	}{
+		"正常ケース:データあり": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().List(testUser.ID, uint(1)).Return(&comments, nil)
+			},
+			id:   1,
+			want: http.StatusOK,
+			wantComments: []commentResponse{
+				{ID: 1, UserID: testUser.ID, AuthorEmail: testUser.Email, Body: "**確認**", BodyHTML: "<p><strong>確認</strong></p>\n"},
+				{ID: 2, UserID: testUser.ID, AuthorEmail: testUser.Email, Body: "対応済み", BodyHTML: "<p>対応済み</p>\n"},
+			},
+		},
+		"正常ケース:データなし": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().List(testUser.ID, uint(1)).Return(&[]models.Comment{}, nil)
+			},
+			id:           1,
+			want:         http.StatusOK,
+			wantComments: []commentResponse{},
+		},
+		"異常ケース:todoが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().List(testUser.ID, uint(1)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			id:   1,
+			want: http.StatusNotFound,
+		},
+		"異常ケース:IDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {},
+			id:            "string",
+			want:          http.StatusBadRequest,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockCommentUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("GET", fmt.Sprintf("/api/v1/todos/%v/comments", tt.id), "", tt.id)
+
+			// mockを利用してテストする
+			handler := NewCommentHandler(mock)
+			handler.Index(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusOK {
+				var body []commentResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body)) {
+					assert.Equal(t, tt.wantComments, body)
+				}
+			}
+		})
+	}
+}
+
+func TestCommentCreate(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockCommentUsecase)
+		body          string
+		want          int
+		wantLocation  string
+		wantComment   commentResponse
+	}{
+		"正常ケース:投稿に成功": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Add(testUser.ID, uint(1), "確認しました").Return(&models.Comment{ID: 3, TodoID: 1, UserID: testUser.ID, User: *testUser, Body: "確認しました"}, nil)
+			},
+			body:         `{"body":"確認しました"}`,
+			want:         http.StatusCreated,
+			wantLocation: "/api/v1/todos/1/comments/3",
+			wantComment:  commentResponse{ID: 3, UserID: testUser.ID, AuthorEmail: testUser.Email, Body: "確認しました", BodyHTML: "<p>確認しました</p>\n"},
+		},
+		"異常ケース:JSONが不正": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {},
+			body:          `{"body":`,
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:本文が不正": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Add(testUser.ID, uint(1), "").Return(nil, models.ErrInvalidCommentBody)
+			},
+			body: `{"body":""}`,
+			want: http.StatusUnprocessableEntity,
+		},
+		"異常ケース:todoが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Add(testUser.ID, uint(1), "確認しました").Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			body: `{"body":"確認しました"}`,
+			want: http.StatusNotFound,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockCommentUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("POST", "/api/v1/todos/1/comments", tt.body, 1)
+
+			// mockを利用してテストする
+			handler := NewCommentHandler(mock)
+			handler.Create(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+			if tt.want == http.StatusCreated {
+				var body commentResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body)) {
+					assert.Equal(t, tt.wantComment, body)
+				}
+			}
+		})
+	}
+}
+
+func TestCommentActions(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockCommentUsecase)
+		callFn        func(handler *CommentHandler, c *gin.Context)
+		commentID     any
+		body          string
+		want          int
+		wantComment   *commentResponse
+	}{
+		"正常ケース:本文を更新": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Edit(testUser.ID, uint(1), uint(2), "修正").Return(&models.Comment{ID: 2, TodoID: 1, UserID: testUser.ID, User: *testUser, Body: "修正"}, nil)
+			},
+			callFn:      func(handler *CommentHandler, c *gin.Context) { handler.Update(c) },
+			commentID:   2,
+			body:        `{"body":"修正"}`,
+			want:        http.StatusOK,
+			wantComment: &commentResponse{ID: 2, UserID: testUser.ID, AuthorEmail: testUser.Email, Body: "修正", BodyHTML: "<p>修正</p>\n"},
+		},
+		"異常ケース:本文が不正": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Edit(testUser.ID, uint(1), uint(2), "").Return(nil, models.ErrInvalidCommentBody)
+			},
+			callFn:    func(handler *CommentHandler, c *gin.Context) { handler.Update(c) },
+			commentID: 2,
+			body:      `{"body":""}`,
+			want:      http.StatusUnprocessableEntity,
+		},
+		"異常ケース:他のユーザーのコメントを更新": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Edit(testUser.ID, uint(1), uint(2), "修正").Return(nil, models.ErrNotCommentAuthor)
+			},
+			callFn:    func(handler *CommentHandler, c *gin.Context) { handler.Update(c) },
+			commentID: 2,
+			body:      `{"body":"修正"}`,
+			want:      http.StatusForbidden,
+		},
+		"異常ケース:更新するコメントが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Edit(testUser.ID, uint(1), uint(9), "修正").Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			callFn:    func(handler *CommentHandler, c *gin.Context) { handler.Update(c) },
+			commentID: 9,
+			body:      `{"body":"修正"}`,
+			want:      http.StatusNotFound,
+		},
+		"異常ケース:コメントのIDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {},
+			callFn:        func(handler *CommentHandler, c *gin.Context) { handler.Update(c) },
+			commentID:     "string",
+			body:          `{"body":"修正"}`,
+			want:          http.StatusBadRequest,
+		},
+		"正常ケース:削除に成功": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1), uint(2)).Return(nil)
+			},
+			callFn:    func(handler *CommentHandler, c *gin.Context) { handler.Delete(c) },
+			commentID: 2,
+			want:      http.StatusNoContent,
+		},
+		"異常ケース:他のユーザーのコメントを削除": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1), uint(2)).Return(models.ErrNotCommentAuthor)
+			},
+			callFn:    func(handler *CommentHandler, c *gin.Context) { handler.Delete(c) },
+			commentID: 2,
+			want:      http.StatusForbidden,
+		},
+		"異常ケース:削除に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1), uint(2)).Return(errors.New("something is wrong"))
+			},
+			callFn:    func(handler *CommentHandler, c *gin.Context) { handler.Delete(c) },
+			commentID: 2,
+			want:      http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockCommentUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("PATCH", fmt.Sprintf("/api/v1/todos/1/comments/%v", tt.commentID), tt.body, 1)
+			c.Params = append(c.Params, gin.Param{Key: "comment_id", Value: fmt.Sprint(tt.commentID)})
+
+			// mockを利用してテストする
+			handler := NewCommentHandler(mock)
+			tt.callFn(&handler, c)
+			// c.Statusだけではステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.wantComment != nil {
+				var body commentResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body)) {
+					assert.Equal(t, *tt.wantComment, body)
+				}
+			}
+		})
+	}
+}
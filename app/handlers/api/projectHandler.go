@@ -0,0 +1,123 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+// /api/v1/projectsへのリクエストに対するハンドラーの構造体
+type ProjectHandler struct {
+	projectUsecase usecases.ProjectUsecase
+}
+
+// ProjectHandlerの新しいインスタンスを作成して返す
+func NewProjectHandler(uc usecases.ProjectUsecase) ProjectHandler {
+	projectHandler := ProjectHandler{projectUsecase: uc}
+	return projectHandler
+}
+
+// プロジェクトの一覧を、todoの件数と共に返す（Inboxが先頭）
+func (ph *ProjectHandler) Index(c *gin.Context) {
+	projects, err := ph.projectUsecase.List(currentUserID(c))
+	if err != nil {
+		respondError(c, err, "project not found", "failed to get projects")
+		return
+	}
+	res := make([]projectResponse, 0, len(*projects))
+	for i := range *projects {
+		res = append(res, newProjectResponse(&(*projects)[i]))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// 指定されたIDのプロジェクトを返す
+func (ph *ProjectHandler) Show(c *gin.Context) {
+	id, ok := parseProjectID(c)
+	if !ok {
+		return
+	}
+	project, err := ph.projectUsecase.SearchByID(currentUserID(c), id)
+	if err != nil {
+		respondProjectError(c, err, "failed to get project")
+		return
+	}
+	c.JSON(http.StatusOK, newProjectResponse(project))
+}
+
+// プロジェクトを新規作成する
+func (ph *ProjectHandler) Create(c *gin.Context) {
+	var req projectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "request body is invalid")
+		return
+	}
+	project, err := ph.projectUsecase.Add(currentUserID(c), req.Name)
+	if err != nil {
+		respondProjectError(c, err, "failed to create project")
+		return
+	}
+	c.Header("Location", fmt.Sprintf("/api/v1/projects/%d", project.ID))
+	c.JSON(http.StatusCreated, newProjectResponse(project))
+}
+
+// 指定されたIDのプロジェクトの名前を変更する
+func (ph *ProjectHandler) Update(c *gin.Context) {
+	id, ok := parseProjectID(c)
+	if !ok {
+		return
+	}
+	var req projectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "request body is invalid")
+		return
+	}
+	project, err := ph.projectUsecase.Rename(currentUserID(c), id, req.Name)
+	if err != nil {
+		respondProjectError(c, err, "failed to update project")
+		return
+	}
+	c.JSON(http.StatusOK, newProjectResponse(project))
+}
+
+// 指定されたIDのプロジェクトを削除する
+// プロジェクトのtodoはInboxに移す
+func (ph *ProjectHandler) Delete(c *gin.Context) {
+	id, ok := parseProjectID(c)
+	if !ok {
+		return
+	}
+	if err := ph.projectUsecase.Delete(currentUserID(c), id); err != nil {
+		respondProjectError(c, err, "failed to delete project")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// 終了処理を行う
+func (ph *ProjectHandler) Close() {
+	err := ph.projectUsecase.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// パスパラメータのプロジェクトのIDを数値に変換する
+// 変換できない場合はエラーを返却し、falseを返す
+func parseProjectID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("pid"), 10, 64)
+	if err != nil {
+		abortWithError(c, http.StatusBadRequest, "project id must be a positive integer")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// プロジェクトの操作時のエラーを返却する
+func respondProjectError(c *gin.Context, err error, msg string) {
+	respondError(c, err, "project not found", msg)
+}
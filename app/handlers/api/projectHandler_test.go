@@ -0,0 +1,251 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_usecases "github.com/MinadukiSekina/todo-go-app/app/mock/usecase"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestProjectIndex(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	projects := []models.Project{
+		{ID: 1, UserID: testUser.ID, Name: models.InboxProjectName, Inbox: true, TodoCount: 2},
+		{ID: 2, UserID: testUser.ID, Name: "仕事", TodoCount: 0},
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockProjectUsecase)
+		want          int
+		wantProjects  []projectResponse
+	}{
+		"正常ケース:データあり": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().List(testUser.ID).Return(&projects, nil)
+			},
+			want: http.StatusOK,
+			wantProjects: []projectResponse{
+				{ID: 1, Name: models.InboxProjectName, Inbox: true, TodoCount: 2},
+				{ID: 2, Name: "仕事", TodoCount: 0},
+			},
+		},
+		"異常ケース:エラーあり": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().List(testUser.ID).Return(nil, errors.New("something is wrong"))
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockProjectUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("GET", "/api/v1/projects", "", nil)
+
+			// mockを利用してテストする
+			handler := NewProjectHandler(mock)
+			handler.Index(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusOK {
+				var body []projectResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body)) {
+					assert.Equal(t, tt.wantProjects, body)
+				}
+			}
+		})
+	}
+}
+
+func TestProjectCreate(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockProjectUsecase)
+		body          string
+		want          int
+		wantLocation  string
+	}{
+		"正常ケース:作成に成功": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().Add(testUser.ID, "趣味").Return(&models.Project{ID: 3, UserID: testUser.ID, Name: "趣味"}, nil)
+			},
+			body:         `{"name":"趣味"}`,
+			want:         http.StatusCreated,
+			wantLocation: "/api/v1/projects/3",
+		},
+		"異常ケース:JSONが不正": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {},
+			body:          `{"name":`,
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:名前が不正": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().Add(testUser.ID, "Inbox").Return(nil, models.ErrInvalidProjectName)
+			},
+			body: `{"name":"Inbox"}`,
+			want: http.StatusUnprocessableEntity,
+		},
+		"異常ケース:同じ名前のプロジェクトが存在する": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().Add(testUser.ID, "仕事").Return(nil, usecases.ErrProjectAlreadyExists)
+			},
+			body: `{"name":"仕事"}`,
+			want: http.StatusConflict,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockProjectUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("POST", "/api/v1/projects", tt.body, nil)
+
+			// mockを利用してテストする
+			handler := NewProjectHandler(mock)
+			handler.Create(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+		})
+	}
+}
+
+func TestProjectActions(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockProjectUsecase)
+		callFn        func(handler *ProjectHandler, c *gin.Context)
+		pid           any
+		body          string
+		want          int
+	}{
+		"正常ケース:取得に成功": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(2)).Return(&models.Project{ID: 2, UserID: testUser.ID, Name: "仕事"}, nil)
+			},
+			callFn: func(handler *ProjectHandler, c *gin.Context) { handler.Show(c) },
+			pid:    2,
+			want:   http.StatusOK,
+		},
+		"異常ケース:取得するプロジェクトが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(2)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			callFn: func(handler *ProjectHandler, c *gin.Context) { handler.Show(c) },
+			pid:    2,
+			want:   http.StatusNotFound,
+		},
+		"正常ケース:名前を変更": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().Rename(testUser.ID, uint(2), "趣味").Return(&models.Project{ID: 2, UserID: testUser.ID, Name: "趣味"}, nil)
+			},
+			callFn: func(handler *ProjectHandler, c *gin.Context) { handler.Update(c) },
+			pid:    2,
+			body:   `{"name":"趣味"}`,
+			want:   http.StatusOK,
+		},
+		"異常ケース:Inboxの名前を変更": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().Rename(testUser.ID, uint(1), "受信箱").Return(nil, models.ErrInboxProject)
+			},
+			callFn: func(handler *ProjectHandler, c *gin.Context) { handler.Update(c) },
+			pid:    1,
+			body:   `{"name":"受信箱"}`,
+			want:   http.StatusUnprocessableEntity,
+		},
+		"異常ケース:IDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {},
+			callFn:        func(handler *ProjectHandler, c *gin.Context) { handler.Update(c) },
+			pid:           "string",
+			body:          `{"name":"趣味"}`,
+			want:          http.StatusBadRequest,
+		},
+		"正常ケース:削除に成功": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(2)).Return(nil)
+			},
+			callFn: func(handler *ProjectHandler, c *gin.Context) { handler.Delete(c) },
+			pid:    2,
+			want:   http.StatusNoContent,
+		},
+		"異常ケース:Inboxを削除": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1)).Return(models.ErrInboxProject)
+			},
+			callFn: func(handler *ProjectHandler, c *gin.Context) { handler.Delete(c) },
+			pid:    1,
+			want:   http.StatusUnprocessableEntity,
+		},
+		"異常ケース:削除に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(2)).Return(errors.New("something is wrong"))
+			},
+			callFn: func(handler *ProjectHandler, c *gin.Context) { handler.Delete(c) },
+			pid:    2,
+			want:   http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockProjectUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("PATCH", fmt.Sprintf("/api/v1/projects/%v", tt.pid), tt.body, nil)
+			c.Params = []gin.Param{{Key: "pid", Value: fmt.Sprint(tt.pid)}}
+
+			// mockを利用してテストする
+			handler := NewProjectHandler(mock)
+			tt.callFn(&handler, c)
+			// c.Statusだけではステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,488 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/handlers/httperr"
+	"github.com/gin-gonic/gin"
+)
+
+// APIで返却するtodoの構造体
+type todoResponse struct {
+	ID        uint   `json:"id"`
+	ProjectID uint   `json:"project_id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	Priority  string `json:"priority"`
+	// 説明（Markdown）と、サニタイズ済みのHTMLに変換したもの
+	Description     string `json:"description"`
+	DescriptionHTML string `json:"description_html"`
+	// 期限の日時（UTC）。期限が無い場合はnull
+	DueAt       *time.Time `json:"due_at"`
+	DueDate     string     `json:"due_date,omitempty"`
+	DueTime     string     `json:"due_time,omitempty"`
+	DueTimezone string     `json:"due_timezone,omitempty"`
+	Overdue     bool       `json:"overdue"`
+	// 繰り返しの規則（RRULEの形式）。繰り返さない場合は空文字列
+	Recurrence string   `json:"recurrence"`
+	Tags       []string `json:"tags"`
+	// チェックリストの項目（並び順）
+	Checklist []checklistItemResponse `json:"checklist"`
+	// 添付ファイル（添付順。詳細の取得時のみ）
+	Attachments []attachmentResponse `json:"attachments"`
+	// 楽観的排他制御のバージョン（ETagヘッダーと同じ値）
+	Version   uint      `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// APIで返却する更新の競合のエラーの構造体
+type conflictResponse struct {
+	Error string `json:"error"`
+	// 保存されている最新のtodo
+	Current todoResponse `json:"current"`
+}
+
+// APIで返却するゴミ箱のtodoの構造体
+type trashedTodoResponse struct {
+	todoResponse
+	// ゴミ箱に移した日時
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// APIで返却するtodoの一覧の構造体
+type todoListResponse struct {
+	Todos []todoResponse `json:"todos"`
+	// 条件に一致する全体の件数
+	Total int64 `json:"total"`
+	Page  int   `json:"page"`
+	Limit int   `json:"limit"`
+}
+
+// APIで受け付けるtodoの構造体
+// PATCHで省略された項目を判別するため、ポインタで保持する
+type todoRequest struct {
+	Title  *string `json:"title"`
+	Status *string `json:"status"`
+	// 優先度（urgent・high・medium・low）。作成時・PUTで省略した場合はmedium、PATCHで省略した場合は変更しない
+	Priority *string `json:"priority"`
+	// 説明（Markdown）。PUTで省略した場合は空にし、PATCHで省略した場合は変更しない
+	Description *string `json:"description"`
+	// 所属するプロジェクトのID。作成時に省略した場合はInbox、更新時に省略した場合は変更しない
+	ProjectID *uint `json:"project_id"`
+	// 期限の日付（YYYY-MM-DD）。空文字列の場合は期限を解除する
+	DueDate *string `json:"due_date"`
+	// 期限の時刻（HH:MM）。省略した場合は終日とする
+	DueTime *string `json:"due_time"`
+	// 期限のタイムゾーン（IANAの名前）。省略した場合はサーバーのタイムゾーン
+	DueTimezone *string `json:"due_timezone"`
+	// 繰り返し（daily・weekly・monthly、またはRRULE）。空文字列の場合は繰り返さない
+	// PUTで省略した場合は解除し、PATCHで省略した場合は変更しない
+	Recurrence *string `json:"recurrence"`
+	// タグの名前の一覧。存在しない名前のタグは新規作成する
+	Tags *[]string `json:"tags"`
+	// 取得時のバージョン。更新時に指定した場合は、保存されているバージョンと異なれば409を返す
+	// If-Matchヘッダーを指定した場合は、そちらを優先する
+	Version *uint `json:"version"`
+}
+
+// リクエストの期限をtodoに設定する
+func (req todoRequest) applyDue(todo *models.Todo) error {
+	return todo.SetDue(deref(req.DueDate), deref(req.DueTime), deref(req.DueTimezone))
+}
+
+// リクエストの優先度を返す
+// 省略された場合はdefaultPriorityを返す
+func (req todoRequest) priorityOr(defaultPriority models.Priority) (models.Priority, error) {
+	if req.Priority == nil {
+		return defaultPriority, nil
+	}
+	return models.StrToPriority(*req.Priority, models.PriorityCorrespond())
+}
+
+// リクエストのタグの名前の一覧を、前後の空白と重複を除いて返す
+func (req todoRequest) tagNames() ([]string, error) {
+	names := []string{}
+	if req.Tags == nil {
+		return names, nil
+	}
+	for _, name := range *req.Tags {
+		tag, err := models.NewTag(0, name)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(names, tag.Name) {
+			names = append(names, tag.Name)
+		}
+	}
+	return names, nil
+}
+
+// 文字列のポインタの値を返す
+// nilの場合は空文字列を返す
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// APIで返却するチェックリストの項目の構造体
+type checklistItemResponse struct {
+	ID       uint   `json:"id"`
+	Title    string `json:"title"`
+	Done     bool   `json:"done"`
+	Position int    `json:"position"`
+}
+
+// APIで受け付けるチェックリストの項目の構造体
+// PATCHで省略された項目を判別するため、ポインタで保持する
+type checklistItemRequest struct {
+	Title *string `json:"title"`
+	Done  *bool   `json:"done"`
+	// チェックリストの中の位置（0始まり）。指定された場合は項目を移動する
+	Position *int `json:"position"`
+}
+
+// APIで返却する添付ファイルの構造体
+type attachmentResponse struct {
+	ID          uint   `json:"id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	// ファイルの内容をダウンロードするURL
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// APIで返却するコメントの構造体
+type commentResponse struct {
+	ID uint `json:"id"`
+	// 投稿したユーザー
+	UserID      uint   `json:"user_id"`
+	AuthorEmail string `json:"author_email"`
+	// 本文（Markdown）と、サニタイズ済みのHTMLに変換したもの
+	Body     string `json:"body"`
+	BodyHTML string `json:"body_html"`
+	// 投稿後に編集されたか
+	Edited    bool      `json:"edited"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// APIで受け付けるコメントの構造体
+type commentRequest struct {
+	Body *string `json:"body"`
+}
+
+// APIで返却する変更履歴の構造体
+type historyResponse struct {
+	ID uint `json:"id"`
+	// 操作の種類（created・updated・deleted・restoredのいずれか）
+	Action string `json:"action"`
+	// 変更した項目と、変更前後の値（変更の場合のみ）
+	Field    string `json:"field,omitempty"`
+	OldValue string `json:"old_value,omitempty"`
+	NewValue string `json:"new_value,omitempty"`
+	// 操作したユーザー
+	UserID     uint      `json:"user_id"`
+	ActorEmail string    `json:"actor_email"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// APIで返却するプロジェクトの構造体
+type projectResponse struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Inbox bool   `json:"inbox"`
+	// プロジェクトのtodoの件数（一覧の取得時のみ）
+	TodoCount int64     `json:"todo_count"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// APIで受け付けるプロジェクトの構造体
+type projectRequest struct {
+	Name string `json:"name"`
+}
+
+// APIで返却するタグの構造体
+type tagResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	// タグが付いているtodoの件数
+	TodoCount int64 `json:"todo_count"`
+}
+
+// APIで受け付けるタグの名前の変更の構造体
+type tagRequest struct {
+	Name string `json:"name"`
+}
+
+// APIで受け付けるタグの統合の構造体
+type tagMergeRequest struct {
+	// 統合先のタグのID
+	Into uint `json:"into"`
+}
+
+// APIで受け付ける一括操作の構造体
+type bulkRequest struct {
+	// 操作の種類（complete・reopen・delete・retagのいずれか）
+	Action string `json:"action"`
+	// 操作するtodoのID
+	IDs []uint `json:"ids"`
+	// タグを付け替える場合の、付けるタグの名前の一覧。空の場合は全て外す
+	Tags []string `json:"tags"`
+	// trueの場合は、1件でも失敗したら全ての変更を取り消す
+	Atomic bool `json:"atomic"`
+}
+
+// APIで返却する一括操作の結果の構造体
+type bulkResponse struct {
+	Action    string `json:"action"`
+	Atomic    bool   `json:"atomic"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+	// todoごとの結果（指定されたIDの順。重複したIDは除く）
+	Results []bulkResultResponse `json:"results"`
+}
+
+// APIで返却する一括操作の、todoごとの結果の構造体
+type bulkResultResponse struct {
+	ID uint `json:"id"`
+	OK bool `json:"ok"`
+	// 失敗した場合のエラー内容と、単独で操作した場合のステータスコード
+	// 他のtodoが失敗したために取り消した場合は424とする
+	Error  string `json:"error,omitempty"`
+	Status int    `json:"status,omitempty"`
+}
+
+// エラー時に返却する構造体
+type errorResponse struct {
+	Error string `json:"error"`
+	// 入力が不正な場合の、項目の名前（titleなど）ごとのエラーの内容
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// todoをレスポンス用の構造体に変換する
+func newTodoResponse(todo *models.Todo) todoResponse {
+	return todoResponse{
+		ID:              todo.ID,
+		ProjectID:       todo.ProjectID,
+		Title:           todo.Title,
+		Status:          todo.Status.Key(),
+		Priority:        todo.Priority.Key(),
+		Description:     todo.Description,
+		DescriptionHTML: string(todo.DescriptionHTML()),
+		DueAt:           todo.DueAt,
+		DueDate:         todo.DueDateString(),
+		DueTime:         todo.DueTimeString(),
+		DueTimezone:     todo.DueTimezone,
+		Overdue:         todo.IsOverdue(time.Now()),
+		Recurrence:      todo.Recurrence,
+		Tags:            todo.TagNames(),
+		Checklist:       newChecklistResponse(todo.ChecklistItems),
+		Attachments:     newAttachmentListResponse(todo.Attachments),
+		Version:         todo.Version,
+		CreatedAt:       todo.CreatedAt,
+		UpdatedAt:       todo.UpdatedAt,
+	}
+}
+
+// 検索結果をレスポンス用の構造体に変換する
+func newTodoListResponse(page *models.TodoPage) todoListResponse {
+	todos := make([]todoResponse, 0, len(page.Todos))
+	for i := range page.Todos {
+		todos = append(todos, newTodoResponse(&page.Todos[i]))
+	}
+	return todoListResponse{Todos: todos, Total: page.Total, Page: page.Page, Limit: page.Limit}
+}
+
+// ゴミ箱のtodoの一覧をレスポンス用の構造体に変換する
+func newTrashResponse(todos []models.Todo) []trashedTodoResponse {
+	res := make([]trashedTodoResponse, 0, len(todos))
+	for i := range todos {
+		res = append(res, trashedTodoResponse{todoResponse: newTodoResponse(&todos[i]), DeletedAt: todos[i].DeletedAt.Time})
+	}
+	return res
+}
+
+// チェックリストの項目の一覧をレスポンス用の構造体に変換する
+func newChecklistResponse(items []models.ChecklistItem) []checklistItemResponse {
+	res := make([]checklistItemResponse, 0, len(items))
+	for i := range items {
+		res = append(res, newChecklistItemResponse(&items[i]))
+	}
+	return res
+}
+
+// チェックリストの項目をレスポンス用の構造体に変換する
+func newChecklistItemResponse(item *models.ChecklistItem) checklistItemResponse {
+	return checklistItemResponse{ID: item.ID, Title: item.Title, Done: item.Done, Position: item.Position}
+}
+
+// 添付ファイルの一覧をレスポンス用の構造体に変換する
+func newAttachmentListResponse(attachments []models.Attachment) []attachmentResponse {
+	res := make([]attachmentResponse, 0, len(attachments))
+	for i := range attachments {
+		res = append(res, newAttachmentResponse(&attachments[i]))
+	}
+	return res
+}
+
+// 添付ファイルをレスポンス用の構造体に変換する
+func newAttachmentResponse(attachment *models.Attachment) attachmentResponse {
+	return attachmentResponse{
+		ID:          attachment.ID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		URL:         fmt.Sprintf("/api/v1/todos/%d/attachments/%d", attachment.TodoID, attachment.ID),
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+// 一括操作の結果をレスポンス用の構造体に変換する
+func newBulkResponse(req *models.BulkRequest, results models.BulkResults) bulkResponse {
+	res := bulkResponse{
+		Action:    string(req.Action),
+		Atomic:    req.Atomic,
+		Succeeded: results.Succeeded(),
+		Failed:    len(results.Failed()),
+		Results:   make([]bulkResultResponse, 0, len(results)),
+	}
+	for _, result := range results {
+		res.Results = append(res.Results, newBulkResultResponse(result))
+	}
+	return res
+}
+
+// 一括操作の、todoごとの結果をレスポンス用の構造体に変換する
+// 想定外のエラーは内容を返さず、ログに出力する
+func newBulkResultResponse(result models.BulkResult) bulkResultResponse {
+	if result.Err == nil {
+		return bulkResultResponse{ID: result.ID, OK: true}
+	}
+	res := bulkResultResponse{ID: result.ID, Error: result.Err.Error(), Status: httperr.Status(result.Err)}
+	switch {
+	case errors.Is(result.Err, models.ErrBulkRolledBack):
+		res.Status = http.StatusFailedDependency
+	case models.KindOf(result.Err) == models.KindUnavailable:
+		slog.Error(result.Err.Error())
+		res.Error = unavailableMessage
+	case models.KindOf(result.Err) == models.KindUnknown:
+		slog.Error(result.Err.Error())
+		res.Error = "failed to update todo"
+	}
+	return res
+}
+
+// 変更履歴の一覧をレスポンス用の構造体に変換する
+func newHistoryListResponse(histories []models.TodoHistory) []historyResponse {
+	res := make([]historyResponse, 0, len(histories))
+	for _, history := range histories {
+		res = append(res, historyResponse{
+			ID:         history.ID,
+			Action:     string(history.Action),
+			Field:      history.Field,
+			OldValue:   history.OldValue,
+			NewValue:   history.NewValue,
+			UserID:     history.UserID,
+			ActorEmail: history.User.Email,
+			CreatedAt:  history.CreatedAt,
+		})
+	}
+	return res
+}
+
+// コメントの一覧をレスポンス用の構造体に変換する
+func newCommentListResponse(comments []models.Comment) []commentResponse {
+	res := make([]commentResponse, 0, len(comments))
+	for i := range comments {
+		res = append(res, newCommentResponse(&comments[i]))
+	}
+	return res
+}
+
+// コメントをレスポンス用の構造体に変換する
+func newCommentResponse(comment *models.Comment) commentResponse {
+	return commentResponse{
+		ID:          comment.ID,
+		UserID:      comment.UserID,
+		AuthorEmail: comment.User.Email,
+		Body:        comment.Body,
+		BodyHTML:    string(comment.BodyHTML()),
+		Edited:      comment.IsEdited(),
+		CreatedAt:   comment.CreatedAt,
+		UpdatedAt:   comment.UpdatedAt,
+	}
+}
+
+// プロジェクトをレスポンス用の構造体に変換する
+func newProjectResponse(project *models.Project) projectResponse {
+	return projectResponse{
+		ID:        project.ID,
+		Name:      project.Name,
+		Inbox:     project.Inbox,
+		TodoCount: project.TodoCount,
+		CreatedAt: project.CreatedAt,
+		UpdatedAt: project.UpdatedAt,
+	}
+}
+
+// タグをレスポンス用の構造体に変換する
+func newTagResponse(tag *models.Tag) tagResponse {
+	return tagResponse{ID: tag.ID, Name: tag.Name, TodoCount: tag.TodoCount}
+}
+
+// エラー内容をJSONで返却する
+func abortWithError(c *gin.Context, code int, msg string) {
+	c.AbortWithStatusJSON(code, errorResponse{Error: msg})
+}
+
+// 入力の項目が不正な場合に、422と項目ごとのエラー内容をJSONで返却する
+func abortWithFieldError(c *gin.Context, field string, err error) {
+	c.AbortWithStatusJSON(http.StatusUnprocessableEntity, errorResponse{
+		Error:  err.Error(),
+		Fields: map[string]string{field: err.Error()},
+	})
+}
+
+// DBに接続できないなど、一時的に処理できない場合のメッセージ
+const unavailableMessage = "service is temporarily unavailable"
+
+// エラーの種類に応じたステータスコード（httperr.Status）で、エラー内容をJSONで返却する
+// 対象が存在しない場合は404とnotFound、入力の不正は422、競合は409、認証されていない場合は401とエラーの内容を返す
+// 項目ごとの検証エラー（models.FieldErrors）の場合は、項目ごとのエラー内容も返す
+// 一時的に処理できない場合は503と共通のメッセージ、想定外のエラーは500とmsgを返し、ログに出力する
+func respondError(c *gin.Context, err error, notFound string, msg string) {
+	code := httperr.Status(err)
+	switch models.KindOf(err) {
+	case models.KindNotFound:
+		abortWithError(c, code, notFound)
+	case models.KindValidation:
+		res := errorResponse{Error: err.Error()}
+		var fieldErrs models.FieldErrors
+		if errors.As(err, &fieldErrs) {
+			res.Fields = map[string]string{}
+			for _, e := range fieldErrs {
+				res.Fields[e.Field] = e.Err.Error()
+			}
+		}
+		c.AbortWithStatusJSON(code, res)
+	case models.KindConflict, models.KindUnauthenticated:
+		abortWithError(c, code, err.Error())
+	case models.KindUnavailable:
+		slog.Error(err.Error())
+		abortWithError(c, code, unavailableMessage)
+	default:
+		slog.Error(err.Error())
+		abortWithError(c, code, msg)
+	}
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+// /api/v1/tagsへのリクエストに対するハンドラーの構造体
+type TagHandler struct {
+	tagUsecase usecases.TagUsecase
+}
+
+// TagHandlerの新しいインスタンスを作成して返す
+func NewTagHandler(uc usecases.TagUsecase) TagHandler {
+	tagHandler := TagHandler{tagUsecase: uc}
+	return tagHandler
+}
+
+// タグの一覧を、付いているtodoの件数と共に返す
+func (th *TagHandler) Index(c *gin.Context) {
+	tags, err := th.tagUsecase.List(currentUserID(c))
+	if err != nil {
+		respondError(c, err, "tag not found", "failed to get tags")
+		return
+	}
+	res := make([]tagResponse, 0, len(*tags))
+	for i := range *tags {
+		res = append(res, newTagResponse(&(*tags)[i]))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// 指定されたIDのタグの名前を変更する
+func (th *TagHandler) Rename(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+	var req tagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "request body is invalid")
+		return
+	}
+
+	tag, err := th.tagUsecase.Rename(currentUserID(c), id, req.Name)
+	if err != nil {
+		respondTagError(c, err, "failed to rename tag")
+		return
+	}
+	c.JSON(http.StatusOK, newTagResponse(tag))
+}
+
+// 指定されたIDのタグを、別のタグに統合する
+func (th *TagHandler) Merge(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+	var req tagMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "request body is invalid")
+		return
+	}
+	if req.Into == 0 {
+		abortWithError(c, http.StatusUnprocessableEntity, "into is required")
+		return
+	}
+
+	if err := th.tagUsecase.Merge(currentUserID(c), id, req.Into); err != nil {
+		respondTagError(c, err, "failed to merge tag")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// 指定されたIDのタグを削除する
+func (th *TagHandler) Delete(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+	if err := th.tagUsecase.Delete(currentUserID(c), id); err != nil {
+		respondTagError(c, err, "failed to delete tag")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// 終了処理を行う
+func (th *TagHandler) Close() {
+	err := th.tagUsecase.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// タグの操作時のエラーを返却する
+func respondTagError(c *gin.Context, err error, msg string) {
+	respondError(c, err, "tag not found", msg)
+}
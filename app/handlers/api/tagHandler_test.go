@@ -0,0 +1,289 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_usecases "github.com/MinadukiSekina/todo-go-app/app/mock/usecase"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestTagIndex(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	tags := []models.Tag{
+		{ID: 1, UserID: testUser.ID, Name: "backend", TodoCount: 2},
+		{ID: 2, UserID: testUser.ID, Name: "urgent", TodoCount: 0},
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTagUsecase)
+		want          int
+		wantTags      []tagResponse
+	}{
+		"正常ケース:データあり": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().List(testUser.ID).Return(&tags, nil)
+			},
+			want: http.StatusOK,
+			wantTags: []tagResponse{
+				{ID: 1, Name: "backend", TodoCount: 2},
+				{ID: 2, Name: "urgent", TodoCount: 0},
+			},
+		},
+		"正常ケース:データなし": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().List(testUser.ID).Return(&[]models.Tag{}, nil)
+			},
+			want:     http.StatusOK,
+			wantTags: []tagResponse{},
+		},
+		"異常ケース:エラーあり": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().List(testUser.ID).Return(nil, errors.New("something is wrong"))
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTagUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("GET", "/api/v1/tags", "", nil)
+
+			// mockを利用してテストする
+			handler := NewTagHandler(mock)
+			handler.Index(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusOK {
+				var body []tagResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body)) {
+					assert.Equal(t, tt.wantTags, body)
+				}
+			}
+		})
+	}
+}
+
+func TestTagRename(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTagUsecase)
+		id            any
+		body          string
+		want          int
+	}{
+		"正常ケース:名前を変更": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Rename(testUser.ID, uint(1), "frontend").Return(&models.Tag{ID: 1, UserID: testUser.ID, Name: "frontend"}, nil)
+			},
+			id:   1,
+			body: `{"name":"frontend"}`,
+			want: http.StatusOK,
+		},
+		"異常ケース:IDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {},
+			id:            "string",
+			body:          `{"name":"frontend"}`,
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:JSONが不正": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {},
+			id:            1,
+			body:          `{"name":`,
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:名前が不正": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Rename(testUser.ID, uint(1), "").Return(nil, fmt.Errorf("%w: %q", models.ErrInvalidTagName, ""))
+			},
+			id:   1,
+			body: `{"name":""}`,
+			want: http.StatusUnprocessableEntity,
+		},
+		"異常ケース:同じ名前のタグが存在する": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Rename(testUser.ID, uint(1), "urgent").Return(nil, usecases.ErrTagAlreadyExists)
+			},
+			id:   1,
+			body: `{"name":"urgent"}`,
+			want: http.StatusConflict,
+		},
+		"異常ケース:対象のタグが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Rename(testUser.ID, uint(1), "frontend").Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			id:   1,
+			body: `{"name":"frontend"}`,
+			want: http.StatusNotFound,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTagUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("PATCH", fmt.Sprintf("/api/v1/tags/%v", tt.id), tt.body, tt.id)
+
+			// mockを利用してテストする
+			handler := NewTagHandler(mock)
+			handler.Rename(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestTagMerge(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTagUsecase)
+		body          string
+		want          int
+	}{
+		"正常ケース:統合に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Merge(testUser.ID, uint(1), uint(2)).Return(nil)
+			},
+			body: `{"into":2}`,
+			want: http.StatusNoContent,
+		},
+		"異常ケース:統合先が無い": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {},
+			body:          `{}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"異常ケース:同じタグに統合": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Merge(testUser.ID, uint(1), uint(1)).Return(usecases.ErrMergeIntoSameTag)
+			},
+			body: `{"into":1}`,
+			want: http.StatusUnprocessableEntity,
+		},
+		"異常ケース:対象のタグが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Merge(testUser.ID, uint(1), uint(3)).Return(models.NotFound(gorm.ErrRecordNotFound))
+			},
+			body: `{"into":3}`,
+			want: http.StatusNotFound,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTagUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("POST", "/api/v1/tags/1/merge", tt.body, 1)
+
+			// mockを利用してテストする
+			handler := NewTagHandler(mock)
+			handler.Merge(c)
+
+			// c.Statusだけではステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestTagDelete(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTagUsecase)
+		want          int
+	}{
+		"正常ケース:削除に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1)).Return(nil)
+			},
+			want: http.StatusNoContent,
+		},
+		"異常ケース:対象のタグが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1)).Return(models.NotFound(gorm.ErrRecordNotFound))
+			},
+			want: http.StatusNotFound,
+		},
+		"異常ケース:削除に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1)).Return(errors.New("something is wrong"))
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTagUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("DELETE", "/api/v1/tags/1", "", 1)
+
+			// mockを利用してテストする
+			handler := NewTagHandler(mock)
+			handler.Delete(c)
+
+			// c.Statusだけではステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
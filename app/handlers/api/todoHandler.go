@@ -0,0 +1,584 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/handlers/httperr"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+// 添付ファイルのアップロードで受け付けるリクエストの大きさの上限
+// ファイルの上限に、フォームの区切りなどの分の余裕を加える
+const maxUploadRequestSize = models.AttachmentMaxSize + 1<<20
+
+// /api/v1/todosへのリクエストに対するハンドラーの構造体
+type TodoHandler struct {
+	todoUsecase    usecases.TodoUsecase
+	projectUsecase usecases.ProjectUsecase
+}
+
+// TodoHandlerの新しいインスタンスを作成して返す
+func NewTodoHandler(uc usecases.TodoUsecase, projectUc usecases.ProjectUsecase) TodoHandler {
+	todoHandler := TodoHandler{todoUsecase: uc, projectUsecase: projectUc}
+	return todoHandler
+}
+
+// todoの一覧を返す
+// /api/v1/projects/:pid/todosの場合は、指定されたプロジェクトのtodoのみを返す
+// クエリパラメータで検索・絞り込み・並び替え・ページの指定ができる（models.ParseTodoQueryを参照）
+func (th *TodoHandler) Index(c *gin.Context) {
+	projectID, ok := th.pathProjectID(c)
+	if !ok {
+		return
+	}
+	query, err := models.ParseTodoQuery(c.Request.URL.Query())
+	if err != nil {
+		abortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	query.UserID = currentUserID(c)
+	query.ProjectID = projectID
+
+	page, err := th.todoUsecase.Search(query)
+	if err != nil {
+		respondError(c, err, "todo not found", "failed to get todos")
+		return
+	}
+	c.JSON(http.StatusOK, newTodoListResponse(page))
+}
+
+// 指定されたIDのtodoを返す
+func (th *TodoHandler) Show(c *gin.Context) {
+	todo, ok := th.findTodo(c)
+	if !ok {
+		return
+	}
+	c.Header("ETag", todoETag(todo))
+	c.JSON(http.StatusOK, newTodoResponse(todo))
+}
+
+// todoを新規作成する
+// /api/v1/projects/:pid/todosの場合は、指定されたプロジェクトに作成する
+func (th *TodoHandler) Create(c *gin.Context) {
+	projectID, ok := th.pathProjectID(c)
+	if !ok {
+		return
+	}
+	var req todoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "request body is invalid")
+		return
+	}
+
+	todo := models.Todo{UserID: currentUserID(c), ProjectID: projectID, Status: models.NotStarted}
+	if err := todo.SetTitle(deref(req.Title)); err != nil {
+		abortWithFieldError(c, models.FieldTitle, err)
+		return
+	}
+	if projectID == 0 && req.ProjectID != nil {
+		todo.ProjectID = *req.ProjectID
+	}
+	if req.Status != nil {
+		status, err := models.StrToStatus(*req.Status, models.StatusCorrespond())
+		if err != nil {
+			abortWithFieldError(c, models.FieldStatus, err)
+			return
+		}
+		todo.Status = status
+	}
+	priority, err := req.priorityOr(models.PriorityMedium)
+	if err != nil {
+		abortWithFieldError(c, models.FieldPriority, err)
+		return
+	}
+	todo.Priority = priority
+	if err := todo.SetDescription(deref(req.Description)); err != nil {
+		abortWithFieldError(c, models.FieldDescription, err)
+		return
+	}
+	if err := req.applyDue(&todo); err != nil {
+		abortWithFieldError(c, models.FieldDue, err)
+		return
+	}
+	if err := todo.SetRecurrence(deref(req.Recurrence)); err != nil {
+		abortWithFieldError(c, models.FieldRecurrence, err)
+		return
+	}
+	tags, err := req.tagNames()
+	if err != nil {
+		abortWithFieldError(c, models.FieldTags, err)
+		return
+	}
+
+	if err := th.todoUsecase.Add(&todo); err != nil {
+		respondError(c, err, "project not found", "failed to create todo")
+		return
+	}
+	created := &todo
+	if len(tags) > 0 {
+		if created, err = th.saveTags(todo.UserID, todo.ID, tags); err != nil {
+			respondError(c, err, "todo not found", "failed to set tags")
+			return
+		}
+	}
+	c.Header("Location", fmt.Sprintf("/api/v1/todos/%d", created.ID))
+	c.Header("ETag", todoETag(created))
+	c.JSON(http.StatusCreated, newTodoResponse(created))
+}
+
+// 指定されたIDのtodoの内容を置き換える
+func (th *TodoHandler) Update(c *gin.Context) {
+	th.save(c, true)
+}
+
+// 指定されたIDのtodoの内容を部分的に更新する
+func (th *TodoHandler) Patch(c *gin.Context) {
+	th.save(c, false)
+}
+
+// 指定されたIDのtodoを削除する（ゴミ箱に移す）
+func (th *TodoHandler) Delete(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+	if err := th.todoUsecase.Delete(currentUserID(c), id); err != nil {
+		respondError(c, err, "todo not found", "failed to delete todo")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// 指定された複数のtodoに、まとめて同じ操作を行い、todoごとの結果を返す
+// atomicがfalseの場合は成功したものだけを保存し、一部が失敗しても200を返す
+// atomicがtrueで1件でも失敗した場合は全て取り消し、最初に失敗したtodoのエラーに応じたステータスコードで結果を返す
+func (th *TodoHandler) Bulk(c *gin.Context) {
+	var body bulkRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		abortWithError(c, http.StatusBadRequest, "request body is invalid")
+		return
+	}
+	req, err := models.NewBulkRequest(currentUserID(c), body.Action, body.IDs, body.Tags, body.Atomic)
+	if err != nil {
+		respondError(c, err, "todo not found", "failed to update todos")
+		return
+	}
+
+	results, err := th.todoUsecase.Bulk(req)
+	if err != nil && results == nil {
+		respondError(c, err, "todo not found", "failed to update todos")
+		return
+	}
+	code := http.StatusOK
+	if err != nil {
+		code = httperr.Status(err)
+	}
+	c.JSON(code, newBulkResponse(req, results))
+}
+
+// ゴミ箱のtodoの一覧を、削除した日時の新しい順で返す
+func (th *TodoHandler) Trash(c *gin.Context) {
+	todos, err := th.todoUsecase.Trash(currentUserID(c))
+	if err != nil {
+		respondError(c, err, "todo not found in trash", "failed to get trash")
+		return
+	}
+	c.JSON(http.StatusOK, newTrashResponse(*todos))
+}
+
+// ゴミ箱の指定されたIDのtodoを元に戻し、元に戻したtodoを返す
+func (th *TodoHandler) Restore(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+	if err := th.todoUsecase.Restore(currentUserID(c), id); err != nil {
+		respondTrashError(c, err, "failed to restore todo")
+		return
+	}
+	todo, ok := th.findTodo(c)
+	if !ok {
+		return
+	}
+	c.Header("ETag", todoETag(todo))
+	c.JSON(http.StatusOK, newTodoResponse(todo))
+}
+
+// ゴミ箱の指定されたIDのtodoを完全に削除する
+func (th *TodoHandler) Purge(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+	if err := th.todoUsecase.Purge(currentUserID(c), id); err != nil {
+		respondTrashError(c, err, "failed to purge todo")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// 指定されたIDのtodoの変更履歴を、新しい順で返す
+func (th *TodoHandler) History(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+	histories, err := th.todoUsecase.History(currentUserID(c), id)
+	if err != nil {
+		respondFindError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newHistoryListResponse(*histories))
+}
+
+// todoにファイルを添付する
+// ファイルはmultipart/form-dataのfileで受け取る
+func (th *TodoHandler) Upload(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+	if c.Request.ContentLength > maxUploadRequestSize {
+		abortWithError(c, http.StatusRequestEntityTooLarge, models.ErrAttachmentTooLarge.Error())
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadRequestSize)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			abortWithError(c, http.StatusRequestEntityTooLarge, models.ErrAttachmentTooLarge.Error())
+			return
+		}
+		abortWithError(c, http.StatusBadRequest, "file is required")
+		return
+	}
+	f, err := file.Open()
+	if err != nil {
+		slog.Error(err.Error())
+		abortWithError(c, http.StatusInternalServerError, "failed to create attachment")
+		return
+	}
+	defer f.Close()
+
+	attachment, err := th.todoUsecase.Attach(currentUserID(c), id, file.Filename, file.Size, f)
+	if err != nil {
+		respondAttachmentError(c, err, "failed to create attachment")
+		return
+	}
+	res := newAttachmentResponse(attachment)
+	c.Header("Location", res.URL)
+	c.JSON(http.StatusCreated, res)
+}
+
+// 添付ファイルの内容を返す
+func (th *TodoHandler) Download(c *gin.Context) {
+	todoID, id, ok := parseAttachmentIDs(c)
+	if !ok {
+		return
+	}
+	attachment, r, err := th.todoUsecase.OpenAttachment(currentUserID(c), todoID, id)
+	if err != nil {
+		respondAttachmentError(c, err, "failed to get attachment")
+		return
+	}
+	defer r.Close()
+
+	// 判定した種類以外として解釈されないようにする
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, r, map[string]string{
+		"Content-Disposition": attachment.ContentDisposition(),
+	})
+}
+
+// 添付ファイルを削除する
+func (th *TodoHandler) DeleteAttachment(c *gin.Context) {
+	todoID, id, ok := parseAttachmentIDs(c)
+	if !ok {
+		return
+	}
+	if err := th.todoUsecase.Detach(currentUserID(c), todoID, id); err != nil {
+		respondAttachmentError(c, err, "failed to delete attachment")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// 終了処理を行う
+func (th *TodoHandler) Close() {
+	err := th.todoUsecase.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	err = th.projectUsecase.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// パスで指定されたプロジェクトのIDを返す
+// パスにプロジェクトが無い場合は0を返す
+// 該当するプロジェクトが無い場合はエラーを返却し、falseを返す
+func (th *TodoHandler) pathProjectID(c *gin.Context) (uint, bool) {
+	if c.Param("pid") == "" {
+		return 0, true
+	}
+	id, ok := parseProjectID(c)
+	if !ok {
+		return 0, false
+	}
+	project, err := th.projectUsecase.SearchByID(currentUserID(c), id)
+	if err != nil {
+		respondProjectError(c, err, "failed to get project")
+		return 0, false
+	}
+	return project.ID, true
+}
+
+// リクエストの内容でtodoを更新する
+// requireAllがtrueの場合は、全ての項目の指定を必須とする（PUT）
+func (th *TodoHandler) save(c *gin.Context, requireAll bool) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req todoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "request body is invalid")
+		return
+	}
+	if requireAll && (req.Title == nil || req.Status == nil) {
+		abortWithError(c, http.StatusUnprocessableEntity, "title and status are required")
+		return
+	}
+	// タイトルは指定された場合のみ更新する（前後の空白を取り除き、空の場合は422を返す）
+	var title models.Todo
+	if req.Title != nil {
+		if err := title.SetTitle(*req.Title); err != nil {
+			abortWithFieldError(c, models.FieldTitle, err)
+			return
+		}
+	}
+
+	var status models.Status
+	if req.Status != nil {
+		var err error
+		status, err = models.StrToStatus(*req.Status, models.StatusCorrespond())
+		if err != nil {
+			abortWithFieldError(c, models.FieldStatus, err)
+			return
+		}
+	}
+
+	// 優先度はPUTでは常に置き換え（省略時は中）、PATCHでは指定された場合のみ更新する
+	updatePriority := requireAll || req.Priority != nil
+	priority, err := req.priorityOr(models.PriorityMedium)
+	if err != nil {
+		abortWithFieldError(c, models.FieldPriority, err)
+		return
+	}
+
+	// 期限はPUTでは常に置き換え（省略時は解除）、PATCHでは日付が指定された場合のみ更新する
+	updateDue := requireAll || req.DueDate != nil
+	var due models.Todo
+	if updateDue {
+		if err := req.applyDue(&due); err != nil {
+			abortWithFieldError(c, models.FieldDue, err)
+			return
+		}
+	}
+
+	// 繰り返しはPUTでは常に置き換え（省略時は解除）、PATCHでは指定された場合のみ更新する
+	updateRecurrence := requireAll || req.Recurrence != nil
+	var recurrence models.Todo
+	if updateRecurrence {
+		if err := recurrence.SetRecurrence(deref(req.Recurrence)); err != nil {
+			abortWithFieldError(c, models.FieldRecurrence, err)
+			return
+		}
+	}
+
+	// タグはPUTでは常に置き換え（省略時は全て外す）、PATCHでは指定された場合のみ更新する
+	updateTags := requireAll || req.Tags != nil
+	tags, err := req.tagNames()
+	if err != nil {
+		abortWithFieldError(c, models.FieldTags, err)
+		return
+	}
+
+	todo, err := th.todoUsecase.SearchByID(currentUserID(c), id)
+	if err != nil {
+		respondFindError(c, err)
+		return
+	}
+	// If-Matchヘッダー、またはリクエストのversionで取得時のバージョンが指定された場合は、
+	// 保存されているバージョンと一致する場合のみ更新する
+	precondition, matched := ifMatch(c, todo)
+	if precondition && !matched {
+		respondConflict(c, todo, true)
+		return
+	}
+	if !precondition && req.Version != nil && *req.Version != todo.Version {
+		respondConflict(c, todo, false)
+		return
+	}
+	if req.Title != nil {
+		todo.Title = title.Title
+	}
+	if req.Status != nil {
+		if err := todo.ChangeStatus(status); err != nil {
+			abortWithFieldError(c, models.FieldStatus, err)
+			return
+		}
+	}
+	if updatePriority {
+		todo.Priority = priority
+	}
+	if requireAll || req.Description != nil {
+		if err := todo.SetDescription(deref(req.Description)); err != nil {
+			abortWithFieldError(c, models.FieldDescription, err)
+			return
+		}
+	}
+	if updateDue {
+		todo.DueAt, todo.DueHasTime, todo.DueTimezone = due.DueAt, due.DueHasTime, due.DueTimezone
+	}
+	if updateRecurrence {
+		todo.Recurrence = recurrence.Recurrence
+	}
+	// プロジェクトは指定された場合のみ移す（所有者が同じプロジェクトかはusecaseで確認する）
+	if req.ProjectID != nil {
+		todo.ProjectID = *req.ProjectID
+	}
+
+	if err := th.todoUsecase.Edit(todo); err != nil {
+		// 確認してから保存するまでの間に、他の操作で更新された場合
+		var conflict *models.TodoConflictError
+		if errors.As(err, &conflict) {
+			respondConflict(c, conflict.Current, precondition)
+			return
+		}
+		respondError(c, err, "todo not found", "failed to update todo")
+		return
+	}
+	if updateTags {
+		if todo, err = th.saveTags(currentUserID(c), id, tags); err != nil {
+			respondError(c, err, "todo not found", "failed to set tags")
+			return
+		}
+	}
+	c.Header("ETag", todoETag(todo))
+	c.JSON(http.StatusOK, newTodoResponse(todo))
+}
+
+// todoに付けるタグを置き換え、タグを含めたtodoを取得し直して返す
+func (th *TodoHandler) saveTags(userID uint, todoID uint, names []string) (*models.Todo, error) {
+	if err := th.todoUsecase.SetTags(userID, todoID, names); err != nil {
+		return nil, err
+	}
+	return th.todoUsecase.SearchByID(userID, todoID)
+}
+
+// パスパラメータのIDに該当するtodoを取得する
+// 取得できなかった場合はエラーを返却し、falseを返す
+func (th *TodoHandler) findTodo(c *gin.Context) (*models.Todo, bool) {
+	id, ok := parseID(c)
+	if !ok {
+		return nil, false
+	}
+	todo, err := th.todoUsecase.SearchByID(currentUserID(c), id)
+	if err != nil {
+		respondFindError(c, err)
+		return nil, false
+	}
+	return todo, true
+}
+
+// todoのバージョンを表すETagを返す
+func todoETag(todo *models.Todo) string {
+	return fmt.Sprintf(`"%d"`, todo.Version)
+}
+
+// If-Matchヘッダーが、todoのETagと一致するかを返す
+// ヘッダーが無い場合はcheckedをfalseで返す
+// カンマ区切りの複数指定と"*"に対応し、強い比較のため弱いETag（W/）は一致しない
+func ifMatch(c *gin.Context, todo *models.Todo) (checked bool, matched bool) {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return false, false
+	}
+	etag := todoETag(todo)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == etag {
+			return true, true
+		}
+	}
+	return true, false
+}
+
+// 更新の競合時のエラーを、保存されている最新のtodoとともに返却する
+// If-Matchヘッダーで指定された場合は412、リクエストのversionで指定された場合は409とする
+func respondConflict(c *gin.Context, current *models.Todo, precondition bool) {
+	code := http.StatusConflict
+	if precondition {
+		code = http.StatusPreconditionFailed
+	}
+	c.Header("ETag", todoETag(current))
+	c.AbortWithStatusJSON(code, conflictResponse{Error: models.ErrTodoConflict.Error(), Current: newTodoResponse(current)})
+}
+
+// パスパラメータのIDを数値に変換する
+// 変換できない場合はエラーを返却し、falseを返す
+func parseID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		abortWithError(c, http.StatusBadRequest, "id must be a positive integer")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// パスパラメータのtodoと添付ファイルのIDを数値に変換する
+// 変換できない場合はエラーを返却し、falseを返す
+func parseAttachmentIDs(c *gin.Context) (uint, uint, bool) {
+	todoID, ok := parseID(c)
+	if !ok {
+		return 0, 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("attachment_id"), 10, 64)
+	if err != nil {
+		abortWithError(c, http.StatusBadRequest, "attachment id must be a positive integer")
+		return 0, 0, false
+	}
+	return todoID, uint(id), true
+}
+
+// 添付ファイルの操作時のエラーを返却する
+func respondAttachmentError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, models.ErrAttachmentTooLarge):
+		abortWithError(c, http.StatusRequestEntityTooLarge, err.Error())
+	default:
+		respondError(c, err, "todo or attachment not found", msg)
+	}
+}
+
+// ゴミ箱の操作時のエラーを返却する
+func respondTrashError(c *gin.Context, err error, msg string) {
+	respondError(c, err, "todo not found in trash", msg)
+}
+
+// todoの検索時のエラーを返却する
+func respondFindError(c *gin.Context, err error) {
+	respondError(c, err, "todo not found", "failed to get todo")
+}
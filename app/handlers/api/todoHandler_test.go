@@ -0,0 +1,1842 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	mock_usecases "github.com/MinadukiSekina/todo-go-app/app/mock/usecase"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// テスト用のログイン中のユーザー
+var testUser = &models.User{Model: gorm.Model{ID: 1}, Email: "user@example.com"}
+
+// ログイン済みのテスト用のgin contextを生成する
+func newTestContext(method string, target string, body string, id any) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	req, _ := http.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	c.Request = req
+	c.Set(currentUserKey, testUser)
+
+	if id != nil {
+		c.Params = []gin.Param{{Key: "id", Value: fmt.Sprint(id)}}
+	}
+	return c, w
+}
+
+// テストで使用するプロジェクト
+var testProjects = []models.Project{
+	{ID: 1, UserID: 1, Name: models.InboxProjectName, Inbox: true},
+	{ID: 2, UserID: 1, Name: "仕事"},
+}
+
+// todoのハンドラーのテストで使用する、プロジェクトのユースケースのモックを生成する
+func newProjectUsecaseMock(ctrl *gomock.Controller) *mock_usecases.MockProjectUsecase {
+	m := mock_usecases.NewMockProjectUsecase(ctrl)
+	m.EXPECT().SearchByID(testUser.ID, gomock.Any()).DoAndReturn(func(userID uint, id uint) (*models.Project, error) {
+		for i := range testProjects {
+			if testProjects[i].ID == id {
+				return &testProjects[i], nil
+			}
+		}
+		return nil, models.NotFound(gorm.ErrRecordNotFound)
+	}).AnyTimes()
+	m.EXPECT().Close().Return(nil).AnyTimes()
+	return m
+}
+
+func TestIndex(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	todo1 := models.Todo{Title: "test1", Status: models.NotStarted}
+	todo1.ID = 1
+
+	todo2 := models.Todo{Title: "test2", Status: models.Done}
+	todo2.ID = 2
+
+	// 検索結果のページを生成する
+	newPage := func(todos ...models.Todo) *models.TodoPage {
+		return &models.TodoPage{Todos: todos, Total: int64(len(todos)), Page: 1, Limit: models.DefaultPageSize}
+	}
+	// 既定値を補った検索条件を生成する
+	newQuery := func(q models.TodoQuery) models.TodoQuery {
+		q.UserID = testUser.ID
+		return q.Normalize()
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		pid           string
+		query         string
+		want          int
+		wantLen       int
+		wantTotal     int64
+	}{
+		"正常ケース:データなし": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{})).Return(newPage(), nil)
+			},
+			want:    http.StatusOK,
+			wantLen: 0,
+		},
+		"正常ケース:2件データあり": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{})).Return(newPage(todo1, todo2), nil)
+			},
+			want:      http.StatusOK,
+			wantLen:   2,
+			wantTotal: 2,
+		},
+		"正常ケース:期限切れで絞り込み": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{Due: models.DueOverdue})).Return(newPage(todo1, todo2), nil)
+			},
+			query:     "?due=overdue",
+			want:      http.StatusOK,
+			wantLen:   2,
+			wantTotal: 2,
+		},
+		"正常ケース:今週までで絞り込み": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{Due: models.DueThisWeek})).Return(newPage(), nil)
+			},
+			query:   "?due=week",
+			want:    http.StatusOK,
+			wantLen: 0,
+		},
+		"正常ケース:検索・絞り込み・並び替え・ページの指定": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{
+					Keyword:   "test",
+					Statuses:  []models.Status{models.Done},
+					Sort:      models.SortByTitle,
+					Direction: models.Desc,
+					Page:      2,
+					Limit:     1,
+				})).Return(&models.TodoPage{Todos: []models.Todo{todo2}, Total: 5, Page: 2, Limit: 1}, nil)
+			},
+			query:     "?q=test&status=completed&sort=title&order=desc&page=2&limit=1",
+			want:      http.StatusOK,
+			wantLen:   1,
+			wantTotal: 5,
+		},
+		"正常ケース:プロジェクトのtodoの一覧": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{ProjectID: 2})).Return(newPage(todo1), nil)
+			},
+			pid:       "2",
+			want:      http.StatusOK,
+			wantLen:   1,
+			wantTotal: 1,
+		},
+		"異常ケース:プロジェクトが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			pid:           "99",
+			want:          http.StatusNotFound,
+		},
+		"異常ケース:プロジェクトのIDが数値でない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			pid:           "string",
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:絞り込み条件が不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			query:         "?due=unknown",
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:状態が不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			query:         "?status=unknown",
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:ページが不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			query:         "?page=0",
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:エラーあり": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{})).Return(nil, errors.New("something is wrong"))
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("GET", "/api/v1/todos"+tt.query, "", nil)
+			if tt.pid != "" {
+				c.Params = []gin.Param{{Key: "pid", Value: tt.pid}}
+			}
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.Index(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusOK {
+				var body todoListResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body)) {
+					assert.Len(t, body.Todos, tt.wantLen)
+					assert.Equal(t, tt.wantTotal, body.Total)
+				}
+			}
+		})
+	}
+}
+
+func TestShow(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	todo1 := models.Todo{Title: "test1", Status: models.Done, Version: 3}
+	todo1.ID = 1
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		id            any
+		want          int
+	}{
+		"正常ケース:データあり": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
+			},
+			id:   1,
+			want: http.StatusOK,
+		},
+		"異常ケース:IDを数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				// IDが数値に変換できない場合はSearchByIDは呼ばれない
+			},
+			id:   "string",
+			want: http.StatusBadRequest,
+		},
+		"異常ケース:データなし": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			id:   1,
+			want: http.StatusNotFound,
+		},
+		"異常ケース:検索に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(nil, errors.New("something is wrong"))
+			},
+			id:   1,
+			want: http.StatusInternalServerError,
+		},
+		"異常ケース:DBに接続できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(nil, models.Unavailable(errors.New("connection refused")))
+			},
+			id:   1,
+			want: http.StatusServiceUnavailable,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("GET", fmt.Sprintf("/api/v1/todos/%v", tt.id), "", tt.id)
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.Show(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusOK {
+				var body todoResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body)) {
+					assert.Equal(t, "completed", body.Status)
+					assert.Equal(t, uint(3), body.Version)
+				}
+				assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		pid           string
+		body          string
+		want          int
+		wantLocation  string
+		// 入力が不正な場合に、エラーを返すべき項目の名前
+		wantField string
+	}{
+		"正常ケース:作成に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Any()).DoAndReturn(func(todo *models.Todo) error {
+					todo.ID = 10
+					return nil
+				})
+			},
+			body:         `{"title":"test1"}`,
+			want:         http.StatusCreated,
+			wantLocation: "/api/v1/todos/10",
+		},
+		"正常ケース:期限付きで作成に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.UserID == testUser.ID && todo.DueAt != nil && !todo.DueHasTime && todo.DueTimezone == "Asia/Tokyo"
+				})).DoAndReturn(func(todo *models.Todo) error {
+					todo.ID = 11
+					return nil
+				})
+			},
+			body:         `{"title":"test1","due_date":"2025-03-31","due_timezone":"Asia/Tokyo"}`,
+			want:         http.StatusCreated,
+			wantLocation: "/api/v1/todos/11",
+		},
+		"正常ケース:優先度付きで作成に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Priority == models.PriorityHigh
+				})).DoAndReturn(func(todo *models.Todo) error {
+					todo.ID = 13
+					return nil
+				})
+			},
+			body:         `{"title":"test1","priority":"high"}`,
+			want:         http.StatusCreated,
+			wantLocation: "/api/v1/todos/13",
+		},
+		"異常ケース:優先度が不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"title":"test1","priority":"critical"}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"正常ケース:繰り返し付きで作成に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Recurrence == "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO"
+				})).DoAndReturn(func(todo *models.Todo) error {
+					todo.ID = 15
+					return nil
+				})
+			},
+			body:         `{"title":"test1","recurrence":"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO"}`,
+			want:         http.StatusCreated,
+			wantLocation: "/api/v1/todos/15",
+		},
+		"異常ケース:繰り返しが不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"title":"test1","recurrence":"FREQ=HOURLY"}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"異常ケース:説明が長すぎる": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"title":"test1","description":"` + strings.Repeat("a", models.DescriptionMaxLength+1) + `"}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"正常ケース:タグ付きで作成に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Any()).DoAndReturn(func(todo *models.Todo) error {
+					todo.ID = 12
+					return nil
+				})
+				m.EXPECT().SetTags(testUser.ID, uint(12), []string{"backend", "urgent"}).Return(nil)
+				tagged := models.Todo{Title: "test1", Status: models.NotStarted, Tags: []models.Tag{{ID: 1, Name: "backend"}, {ID: 2, Name: "urgent"}}}
+				tagged.ID = 12
+				m.EXPECT().SearchByID(testUser.ID, uint(12)).Return(&tagged, nil)
+			},
+			body:         `{"title":"test1","tags":[" backend ","urgent","backend"]}`,
+			want:         http.StatusCreated,
+			wantLocation: "/api/v1/todos/12",
+		},
+		"正常ケース:指定したプロジェクトに作成": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.ProjectID == 2
+				})).DoAndReturn(func(todo *models.Todo) error {
+					todo.ID = 13
+					return nil
+				})
+			},
+			body:         `{"title":"test1","project_id":2}`,
+			want:         http.StatusCreated,
+			wantLocation: "/api/v1/todos/13",
+		},
+		"正常ケース:パスのプロジェクトに作成": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.ProjectID == 2
+				})).DoAndReturn(func(todo *models.Todo) error {
+					todo.ID = 14
+					return nil
+				})
+			},
+			pid:          "2",
+			body:         `{"title":"test1"}`,
+			want:         http.StatusCreated,
+			wantLocation: "/api/v1/todos/14",
+		},
+		"異常ケース:パスのプロジェクトが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			pid:           "99",
+			body:          `{"title":"test1"}`,
+			want:          http.StatusNotFound,
+		},
+		"異常ケース:他のユーザーのプロジェクトを指定": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Any()).Return(usecases.ErrProjectNotFound)
+			},
+			body: `{"title":"test1","project_id":3}`,
+			want: http.StatusUnprocessableEntity,
+		},
+		"異常ケース:タグの名前が空": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"title":"test1","tags":[" "]}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"異常ケース:JSONが不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"title":`,
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:期限の形式が不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"title":"test1","due_date":"2025/03/31"}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"異常ケース:タイトルが無い": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"status":"completed"}`,
+			want:          http.StatusUnprocessableEntity,
+			wantField:     models.FieldTitle,
+		},
+		"正常ケース:タイトルを正規化して作成": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Cond(func(todo *models.Todo) bool {
+					// 前後の空白を取り除き、濁点を結合した形（NFC）にする
+					return todo.Title == "がいもの"
+				})).Return(nil)
+			},
+			body:         `{"title":"  \u304b\u3099いもの\u3000"}`,
+			want:         http.StatusCreated,
+			wantLocation: "/api/v1/todos/0",
+		},
+		"異常ケース:タイトルが空白のみ": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"title":" \t "}`,
+			want:          http.StatusUnprocessableEntity,
+			wantField:     models.FieldTitle,
+		},
+		"異常ケース:タイトルが長すぎる": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"title":"` + strings.Repeat("a", models.TitleMaxLength+1) + `"}`,
+			want:          http.StatusUnprocessableEntity,
+			wantField:     models.FieldTitle,
+		},
+		"異常ケース:タイトルに制御文字が含まれる": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"title":"test\u001b[31m"}`,
+			want:          http.StatusUnprocessableEntity,
+			wantField:     models.FieldTitle,
+		},
+		"異常ケース:usecaseで項目の検証に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				var errs models.FieldErrors
+				errs.Add(models.FieldDescription, models.ErrDescriptionTooLong)
+				m.EXPECT().Add(gomock.Any()).Return(errs.Err())
+			},
+			body:      `{"title":"test1"}`,
+			want:      http.StatusUnprocessableEntity,
+			wantField: models.FieldDescription,
+		},
+		"異常ケース:ステータスの値が変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"title":"test1","status":"cannotConverted"}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"異常ケース:作成に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Any()).Return(errors.New("create todo is failed"))
+			},
+			body: `{"title":"failed"}`,
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("POST", "/api/v1/todos", tt.body, nil)
+			if tt.pid != "" {
+				c.Params = []gin.Param{{Key: "pid", Value: tt.pid}}
+			}
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.Create(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+			if tt.wantField != "" {
+				var body errorResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body)) {
+					assert.Contains(t, body.Fields, tt.wantField)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		id            any
+		body          string
+		want          int
+	}{
+		"正常ケース:更新に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted}
+				todo.ID = 1
+				// タグの置き換え後に取得し直す
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil).Times(2)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
+			},
+			id:   1,
+			body: `{"title":"updated","status":"completed"}`,
+			want: http.StatusOK,
+		},
+		"正常ケース:優先度を省略した場合は中に戻す": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted, Priority: models.PriorityUrgent}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil).Times(2)
+				m.EXPECT().Edit(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Priority == models.PriorityMedium
+				})).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
+			},
+			id:   1,
+			body: `{"title":"updated","status":"completed"}`,
+			want: http.StatusOK,
+		},
+		"異常ケース:タグの名前が不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			id:            1,
+			body:          `{"title":"updated","status":"completed","tags":["a,b"]}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"異常ケース:タグの更新に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{"backend"}).Return(errors.New("something is wrong"))
+			},
+			id:   1,
+			body: `{"title":"updated","status":"completed","tags":["backend"]}`,
+			want: http.StatusInternalServerError,
+		},
+		"正常ケース:プロジェクトを移動": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted, ProjectID: 1}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil).Times(2)
+				m.EXPECT().Edit(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.ProjectID == 2
+				})).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
+			},
+			id:   1,
+			body: `{"title":"updated","status":"completed","project_id":2}`,
+			want: http.StatusOK,
+		},
+		"異常ケース:他のユーザーのプロジェクトに移動": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted, ProjectID: 1}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(usecases.ErrProjectNotFound)
+			},
+			id:   1,
+			body: `{"title":"updated","status":"completed","project_id":3}`,
+			want: http.StatusUnprocessableEntity,
+		},
+		"異常ケース:IDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			id:            "string",
+			body:          `{"title":"updated","status":"completed"}`,
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:ステータスが省略されている": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			id:            1,
+			body:          `{"title":"updated"}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"異常ケース:ステータスの値が変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			id:            1,
+			body:          `{"title":"updated","status":"cannotConverted"}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"異常ケース:対象のタスクが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			id:   1,
+			body: `{"title":"updated","status":"completed"}`,
+			want: http.StatusNotFound,
+		},
+		"異常ケース:更新に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(errors.New("something is wrong"))
+			},
+			id:   1,
+			body: `{"title":"updated","status":"completed"}`,
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("PUT", fmt.Sprintf("/api/v1/todos/%v", tt.id), tt.body, tt.id)
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.Update(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestUpdateConflict(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	// 保存されている最新のtodo（バージョン2）
+	newTodo := func() *models.Todo {
+		todo := models.Todo{Title: "theirs", Status: models.NotStarted, Version: 2}
+		todo.ID = 1
+		return &todo
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		ifMatch       string
+		body          string
+		want          int
+	}{
+		"正常ケース:If-Matchが一致": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(newTodo(), nil).Times(2)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
+			},
+			ifMatch: `"1", "2"`,
+			body:    `{"title":"mine","status":"completed"}`,
+			want:    http.StatusOK,
+		},
+		"正常ケース:If-Matchが*": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(newTodo(), nil).Times(2)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
+			},
+			ifMatch: "*",
+			body:    `{"title":"mine","status":"completed"}`,
+			want:    http.StatusOK,
+		},
+		"正常ケース:versionが一致": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(newTodo(), nil).Times(2)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
+			},
+			body: `{"title":"mine","status":"completed","version":2}`,
+			want: http.StatusOK,
+		},
+		"異常ケース:If-Matchが一致しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(newTodo(), nil)
+			},
+			ifMatch: `"1"`,
+			body:    `{"title":"mine","status":"completed"}`,
+			want:    http.StatusPreconditionFailed,
+		},
+		"異常ケース:If-Matchが弱いETag": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(newTodo(), nil)
+			},
+			ifMatch: `W/"2"`,
+			body:    `{"title":"mine","status":"completed"}`,
+			want:    http.StatusPreconditionFailed,
+		},
+		"異常ケース:versionが一致しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(newTodo(), nil)
+			},
+			body: `{"title":"mine","status":"completed","version":1}`,
+			want: http.StatusConflict,
+		},
+		"異常ケース:If-Matchを確認した後に更新された": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(newTodo(), nil)
+				m.EXPECT().Edit(gomock.Any()).Return(&models.TodoConflictError{Current: newTodo()})
+			},
+			ifMatch: `"2"`,
+			body:    `{"title":"mine","status":"completed"}`,
+			want:    http.StatusPreconditionFailed,
+		},
+		"異常ケース:versionを確認した後に更新された": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(newTodo(), nil)
+				m.EXPECT().Edit(gomock.Any()).Return(&models.TodoConflictError{Current: newTodo()})
+			},
+			body: `{"title":"mine","status":"completed","version":2}`,
+			want: http.StatusConflict,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("PUT", "/api/v1/todos/1", tt.body, 1)
+			if tt.ifMatch != "" {
+				c.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.Update(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			// 最新のバージョンをETagで返すこと
+			assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+			if tt.want != http.StatusOK {
+				var body conflictResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body)) {
+					assert.Equal(t, "theirs", body.Current.Title)
+					assert.Equal(t, uint(2), body.Current.Version)
+				}
+			}
+		})
+	}
+}
+
+func TestPatch(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn  func(m *mock_usecases.MockTodoUsecase)
+		body           string
+		want           int
+		wantTitle      string
+		wantStatus     string
+		wantPriority   string
+		wantDesc       string
+		wantDescHTML   string
+		wantDueDate    string
+		wantDueTime    string
+		wantRecurrence string
+		wantTags       []string
+	}{
+		"正常ケース:ステータスのみ更新": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+			},
+			body:       `{"status":"completed"}`,
+			want:       http.StatusOK,
+			wantTitle:  "test1",
+			wantStatus: "completed",
+		},
+		"正常ケース:タイトルのみ更新": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+			},
+			body:       `{"title":"updated"}`,
+			want:       http.StatusOK,
+			wantTitle:  "updated",
+			wantStatus: "notStarted",
+		},
+		"正常ケース:タイトルの前後の空白を取り除いて更新": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+			},
+			body:       `{"title":"  updated\n"}`,
+			want:       http.StatusOK,
+			wantTitle:  "updated",
+			wantStatus: "notStarted",
+		},
+		"正常ケース:タグのみ更新": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted}
+				todo.ID = 1
+				tagged := todo
+				tagged.Tags = []models.Tag{{ID: 1, Name: "backend"}}
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{"backend"}).Return(nil)
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&tagged, nil)
+			},
+			body:       `{"tags":["backend"]}`,
+			want:       http.StatusOK,
+			wantTitle:  "test1",
+			wantStatus: "notStarted",
+			wantTags:   []string{"backend"},
+		},
+		"正常ケース:期限のみ更新": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+			},
+			body:        `{"due_date":"2025-03-31","due_time":"18:00","due_timezone":"Asia/Tokyo"}`,
+			want:        http.StatusOK,
+			wantTitle:   "test1",
+			wantStatus:  "notStarted",
+			wantDueDate: "2025-03-31",
+			wantDueTime: "18:00",
+		},
+		"正常ケース:期限を解除": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted}
+				todo.ID = 1
+				_ = todo.SetDue("2025-03-31", "", "Asia/Tokyo")
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+			},
+			body:       `{"due_date":""}`,
+			want:       http.StatusOK,
+			wantTitle:  "test1",
+			wantStatus: "notStarted",
+		},
+		"正常ケース:優先度のみ更新": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+			},
+			body:         `{"priority":"urgent"}`,
+			want:         http.StatusOK,
+			wantTitle:    "test1",
+			wantStatus:   "notStarted",
+			wantPriority: "urgent",
+		},
+		"正常ケース:優先度を省略した場合は変更しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted, Priority: models.PriorityLow}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+			},
+			body:         `{"title":"updated"}`,
+			want:         http.StatusOK,
+			wantTitle:    "updated",
+			wantStatus:   "notStarted",
+			wantPriority: "low",
+		},
+		"正常ケース:説明のみ更新": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Description == "*メモ*<script>x</script>"
+				})).Return(nil)
+			},
+			// 生のHTMLのタグは出力しない
+			body:         `{"description":"*メモ*<script>x</script>"}`,
+			want:         http.StatusOK,
+			wantTitle:    "test1",
+			wantStatus:   "notStarted",
+			wantDesc:     "*メモ*<script>x</script>",
+			wantDescHTML: "<p><em>メモ</em>x</p>\n",
+		},
+		"正常ケース:説明を省略した場合は変更しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted, Description: "メモ"}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+			},
+			body:         `{"title":"updated"}`,
+			want:         http.StatusOK,
+			wantTitle:    "updated",
+			wantStatus:   "notStarted",
+			wantDesc:     "メモ",
+			wantDescHTML: "<p>メモ</p>\n",
+		},
+		"正常ケース:繰り返しのみ更新": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Recurrence == "FREQ=MONTHLY"
+				})).Return(nil)
+			},
+			body:           `{"recurrence":"monthly"}`,
+			want:           http.StatusOK,
+			wantTitle:      "test1",
+			wantStatus:     "notStarted",
+			wantRecurrence: "FREQ=MONTHLY",
+		},
+		"正常ケース:繰り返しを省略した場合は変更しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.NotStarted, Recurrence: "FREQ=DAILY"}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+			},
+			body:           `{"title":"updated"}`,
+			want:           http.StatusOK,
+			wantTitle:      "updated",
+			wantStatus:     "notStarted",
+			wantRecurrence: "FREQ=DAILY",
+		},
+		"異常ケース:繰り返しが不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"recurrence":"FREQ=DAILY;COUNT=3"}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"異常ケース:優先度が不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"priority":"critical"}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"異常ケース:タイトルが空文字列": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"title":""}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"異常ケース:タイトルが空白のみ": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"title":"\u3000"}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"異常ケース:許可されていない状態への変更": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.Cancelled}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+			},
+			body: `{"status":"inProgress"}`,
+			want: http.StatusUnprocessableEntity,
+		},
+		"異常ケース:チェックリストに未完了の項目が残っている": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.InProgress, ChecklistItems: []models.ChecklistItem{{Done: false}}}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+			},
+			body: `{"status":"completed"}`,
+			want: http.StatusUnprocessableEntity,
+		},
+		"異常ケース:期限のタイムゾーンが不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"due_date":"2025-03-31","due_timezone":"Mars/Olympus"}`,
+			want:          http.StatusUnprocessableEntity,
+		},
+		"異常ケース:対象のタスクが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			body: `{"status":"completed"}`,
+			want: http.StatusNotFound,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("PATCH", "/api/v1/todos/1", tt.body, 1)
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.Patch(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusOK {
+				var body todoResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body)) {
+					assert.Equal(t, tt.wantTitle, body.Title)
+					assert.Equal(t, tt.wantStatus, body.Status)
+					if tt.wantPriority == "" {
+						tt.wantPriority = models.PriorityMedium.Key()
+					}
+					assert.Equal(t, tt.wantPriority, body.Priority)
+					assert.Equal(t, tt.wantDesc, body.Description)
+					assert.Equal(t, tt.wantDescHTML, body.DescriptionHTML)
+					assert.Equal(t, tt.wantDueDate, body.DueDate)
+					assert.Equal(t, tt.wantDueTime, body.DueTime)
+					assert.Equal(t, tt.wantRecurrence, body.Recurrence)
+					if tt.wantTags == nil {
+						tt.wantTags = []string{}
+					}
+					assert.Equal(t, tt.wantTags, body.Tags)
+				}
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		id            any
+		want          int
+	}{
+		"正常ケース:削除に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1)).Return(nil)
+			},
+			id:   1,
+			want: http.StatusNoContent,
+		},
+		"異常ケース:IDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			id:            "string",
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:対象のタスクが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1)).Return(models.NotFound(gorm.ErrRecordNotFound))
+			},
+			id:   1,
+			want: http.StatusNotFound,
+		},
+		"異常ケース:削除に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1)).Return(errors.New("something is wrong"))
+			},
+			id:   1,
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("DELETE", fmt.Sprintf("/api/v1/todos/%v", tt.id), "", tt.id)
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.Delete(c)
+
+			// c.Statusだけではステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestBulk(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	// 一括操作の指定を生成する（生成できることはmodelsのテストで確認する）
+	bulkRequest := func(action string, ids []uint, tags []string, atomic bool) *models.BulkRequest {
+		req, _ := models.NewBulkRequest(testUser.ID, action, ids, tags, atomic)
+		return req
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		body          string
+		want          int
+		wantBody      string
+	}{
+		"正常ケース:全て成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Bulk(bulkRequest("complete", []uint{1, 2}, nil, false)).Return(models.BulkResults{{ID: 1}, {ID: 2}}, nil)
+			},
+			body:     `{"action":"complete","ids":[1,2,1]}`,
+			want:     http.StatusOK,
+			wantBody: `{"action":"complete","atomic":false,"succeeded":2,"failed":0,"results":[{"id":1,"ok":true},{"id":2,"ok":true}]}`,
+		},
+		"正常ケース:タグを付け替える": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Bulk(bulkRequest("retag", []uint{1}, []string{"backend"}, false)).Return(models.BulkResults{{ID: 1}}, nil)
+			},
+			body:     `{"action":"retag","ids":[1],"tags":[" backend "]}`,
+			want:     http.StatusOK,
+			wantBody: `{"action":"retag","atomic":false,"succeeded":1,"failed":0,"results":[{"id":1,"ok":true}]}`,
+		},
+		"正常ケース:一部が失敗した場合は、IDごとのエラーとともに200を返す": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Bulk(bulkRequest("complete", []uint{1, 2, 3}, nil, false)).Return(models.BulkResults{
+					{ID: 1},
+					{ID: 2, Err: usecases.ErrTodoNotFound},
+					{ID: 3, Err: models.ErrInvalidStatusTransition},
+				}, nil)
+			},
+			body: `{"action":"complete","ids":[1,2,3]}`,
+			want: http.StatusOK,
+			wantBody: `{"action":"complete","atomic":false,"succeeded":1,"failed":2,"results":[` +
+				`{"id":1,"ok":true},` +
+				`{"id":2,"ok":false,"error":"todo not found","status":404},` +
+				`{"id":3,"ok":false,"error":"invalid status transition","status":422}]}`,
+		},
+		"異常ケース:全て取り消す指定で失敗した場合は、失敗したtodoのステータスコードで結果を返す": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Bulk(bulkRequest("delete", []uint{1, 2}, nil, true)).Return(models.BulkResults{
+					{ID: 1, Err: models.ErrBulkRolledBack},
+					{ID: 2, Err: models.ErrTodoConflict},
+				}, fmt.Errorf("todo 2: %w", models.ErrTodoConflict))
+			},
+			body: `{"action":"delete","ids":[1,2],"atomic":true}`,
+			want: http.StatusConflict,
+			wantBody: `{"action":"delete","atomic":true,"succeeded":0,"failed":2,"results":[` +
+				`{"id":1,"ok":false,"error":"rolled back because another todo failed","status":424},` +
+				`{"id":2,"ok":false,"error":"todo has been changed by someone else","status":409}]}`,
+		},
+		"異常ケース:想定外のエラーは内容を返さない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Bulk(gomock.Any()).Return(models.BulkResults{{ID: 1, Err: errors.New("something is wrong")}}, nil)
+			},
+			body:     `{"action":"reopen","ids":[1]}`,
+			want:     http.StatusOK,
+			wantBody: `{"action":"reopen","atomic":false,"succeeded":0,"failed":1,"results":[{"id":1,"ok":false,"error":"failed to update todo","status":500}]}`,
+		},
+		"異常ケース:不正なJSON": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"action":`,
+			want:          http.StatusBadRequest,
+			wantBody:      `{"error":"request body is invalid"}`,
+		},
+		"異常ケース:IDが空": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"action":"complete","ids":[]}`,
+			want:          http.StatusUnprocessableEntity,
+			wantBody:      `{"error":"no todos are specified"}`,
+		},
+		"異常ケース:操作の種類が不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			body:          `{"action":"archive","ids":[1]}`,
+			want:          http.StatusUnprocessableEntity,
+			wantBody:      `{"error":"invalid bulk action: \"archive\""}`,
+		},
+		"異常ケース:保存に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Bulk(gomock.Any()).Return(nil, errors.New("something is wrong"))
+			},
+			body:     `{"action":"delete","ids":[1]}`,
+			want:     http.StatusInternalServerError,
+			wantBody: `{"error":"failed to update todos"}`,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("POST", "/api/v1/todos/bulk", tt.body, nil)
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.Bulk(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.JSONEq(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func TestTrash(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	deletedAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	trashed := models.Todo{Model: gorm.Model{ID: 1, DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}}, Title: "trashed"}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		want          int
+		wantBody      string
+	}{
+		"正常ケース:ゴミ箱のtodoを削除した日時とともに返す": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Trash(testUser.ID).Return(&[]models.Todo{trashed}, nil)
+			},
+			want:     http.StatusOK,
+			wantBody: `"deleted_at":"2025-06-01T09:00:00Z"`,
+		},
+		"正常ケース:ゴミ箱が空の場合は空の配列を返す": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Trash(testUser.ID).Return(&[]models.Todo{}, nil)
+			},
+			want:     http.StatusOK,
+			wantBody: `[]`,
+		},
+		"異常ケース:取得に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Trash(testUser.ID).Return(nil, errors.New("something is wrong"))
+			},
+			want:     http.StatusInternalServerError,
+			wantBody: `"error"`,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("GET", "/api/v1/trash", "", nil)
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.Trash(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestHistory(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	createdAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	histories := []models.TodoHistory{
+		{ID: 2, CreatedAt: createdAt, TodoID: 1, UserID: testUser.ID, User: *testUser, Action: models.HistoryUpdated, Field: models.HistoryFieldTitle, OldValue: "a", NewValue: "b"},
+		{ID: 1, CreatedAt: createdAt, TodoID: 1, UserID: testUser.ID, User: *testUser, Action: models.HistoryCreated},
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		id            any
+		want          int
+		wantBody      []historyResponse
+	}{
+		"正常ケース:変更履歴を返す": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().History(testUser.ID, uint(1)).Return(&histories, nil)
+			},
+			id:   1,
+			want: http.StatusOK,
+			wantBody: []historyResponse{
+				{ID: 2, Action: "updated", Field: "title", OldValue: "a", NewValue: "b", UserID: testUser.ID, ActorEmail: testUser.Email, CreatedAt: createdAt},
+				{ID: 1, Action: "created", UserID: testUser.ID, ActorEmail: testUser.Email, CreatedAt: createdAt},
+			},
+		},
+		"異常ケース:todoが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().History(testUser.ID, uint(1)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			id:   1,
+			want: http.StatusNotFound,
+		},
+		"異常ケース:取得に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().History(testUser.ID, uint(1)).Return(nil, errors.New("something is wrong"))
+			},
+			id:   1,
+			want: http.StatusInternalServerError,
+		},
+		"異常ケース:IDが不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			id:            "abc",
+			want:          http.StatusBadRequest,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("GET", fmt.Sprintf("/api/v1/todos/%v/history", tt.id), "", tt.id)
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.History(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.wantBody != nil {
+				var body []historyResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body)) {
+					assert.Equal(t, tt.wantBody, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	restored := &models.Todo{Model: gorm.Model{ID: 1}, Title: "restored"}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		id            any
+		want          int
+	}{
+		"正常ケース:元に戻したtodoを返す": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Restore(testUser.ID, uint(1)).Return(nil)
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(restored, nil)
+			},
+			id:   1,
+			want: http.StatusOK,
+		},
+		"異常ケース:IDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			id:            "string",
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:ゴミ箱に無い": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Restore(testUser.ID, uint(1)).Return(models.NotFound(gorm.ErrRecordNotFound))
+			},
+			id:   1,
+			want: http.StatusNotFound,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("POST", fmt.Sprintf("/api/v1/trash/%v/restore", tt.id), "", tt.id)
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.Restore(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestPurge(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		id            any
+		want          int
+	}{
+		"正常ケース:完全に削除": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Purge(testUser.ID, uint(1)).Return(nil)
+			},
+			id:   1,
+			want: http.StatusNoContent,
+		},
+		"異常ケース:ゴミ箱に無い": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Purge(testUser.ID, uint(1)).Return(models.NotFound(gorm.ErrRecordNotFound))
+			},
+			id:   1,
+			want: http.StatusNotFound,
+		},
+		"異常ケース:削除に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Purge(testUser.ID, uint(1)).Return(errors.New("something is wrong"))
+			},
+			id:   1,
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("DELETE", fmt.Sprintf("/api/v1/trash/%v", tt.id), "", tt.id)
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.Purge(c)
+
+			// c.Statusだけではステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		expectLog     bool
+		logString     string
+	}{
+		"正常ケース:エラー無し": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Close().Return(nil)
+			},
+			expectLog: false,
+			logString: "",
+		},
+		"異常ケース:エラーあり": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Close().Return(errors.New("something is wrong"))
+			},
+			expectLog: true,
+			logString: "something is wrong",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// slogの出力をキャプチャするためのバッファを作成
+			var logBuffer bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&logBuffer, &slog.HandlerOptions{}))
+
+			// 元のloggerを保存して、テスト後に復元
+			originalLogger := slog.Default()
+			slog.SetDefault(logger)
+			defer slog.SetDefault(originalLogger)
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.Close()
+
+			// ログの出力を確認
+			if tt.expectLog {
+				assert.Contains(t, logBuffer.String(), tt.logString)
+			} else {
+				assert.Empty(t, logBuffer.String())
+			}
+		})
+	}
+}
+
+func TestUpload(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	attachment := models.Attachment{ID: 2, TodoID: 1, FileName: "memo.txt", ContentType: "text/plain", Size: 5}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		id            any
+		fileName      string
+		want          int
+	}{
+		"正常ケース:添付に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Attach(testUser.ID, uint(1), "memo.txt", int64(5), gomock.Any()).Return(&attachment, nil)
+			},
+			id:       1,
+			fileName: "memo.txt",
+			want:     http.StatusCreated,
+		},
+		"異常ケース:ファイルが無い": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			id:            1,
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:IDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			id:            "string",
+			fileName:      "memo.txt",
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:添付できない種類": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Attach(testUser.ID, uint(1), "memo.txt", int64(5), gomock.Any()).Return(nil, models.ErrAttachmentTypeNotAllowed)
+			},
+			id:       1,
+			fileName: "memo.txt",
+			want:     http.StatusUnprocessableEntity,
+		},
+		"異常ケース:対象のタスクが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Attach(testUser.ID, uint(1), "memo.txt", int64(5), gomock.Any()).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			id:       1,
+			fileName: "memo.txt",
+			want:     http.StatusNotFound,
+		},
+		"異常ケース:保存に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Attach(testUser.ID, uint(1), "memo.txt", int64(5), gomock.Any()).Return(nil, errors.New("something is wrong"))
+			},
+			id:       1,
+			fileName: "memo.txt",
+			want:     http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// ファイルを含むフォームを生成する
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			if tt.fileName != "" {
+				fw, _ := mw.CreateFormFile("file", tt.fileName)
+				fw.Write([]byte("hello"))
+			}
+			mw.Close()
+
+			c, w := newTestContext("POST", fmt.Sprintf("/api/v1/todos/%v/attachments", tt.id), body.String(), tt.id)
+			c.Request.Header.Set("Content-Type", mw.FormDataContentType())
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.Upload(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusCreated {
+				var res attachmentResponse
+				if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res)) {
+					assert.Equal(t, "memo.txt", res.FileName)
+					assert.Equal(t, "/api/v1/todos/1/attachments/2", res.URL)
+				}
+				assert.Equal(t, "/api/v1/todos/1/attachments/2", w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestUploadTooLarge(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	// モックの呼び出しを管理するControllerを生成
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// 大きすぎる場合はusecaseの処理は呼ばれない
+	mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+	c, w := newTestContext("POST", "/api/v1/todos/1/attachments", "", 1)
+	c.Request.ContentLength = maxUploadRequestSize + 1
+
+	handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+	handler.Upload(c)
+
+	// 結果を確認
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestDownload(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		attachmentID  any
+		want          int
+	}{
+		"正常ケース:データあり": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				attachment := &models.Attachment{ID: 2, TodoID: 1, FileName: "a.pdf", ContentType: "application/pdf", Size: 8}
+				m.EXPECT().OpenAttachment(testUser.ID, uint(1), uint(2)).Return(attachment, io.NopCloser(strings.NewReader("%PDF-1.7")), nil)
+			},
+			attachmentID: 2,
+			want:         http.StatusOK,
+		},
+		"異常ケース:添付ファイルのIDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			attachmentID:  "string",
+			want:          http.StatusBadRequest,
+		},
+		"異常ケース:添付ファイルが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().OpenAttachment(testUser.ID, uint(1), uint(2)).Return(nil, nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			attachmentID: 2,
+			want:         http.StatusNotFound,
+		},
+		"異常ケース:ストレージにファイルが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().OpenAttachment(testUser.ID, uint(1), uint(2)).Return(nil, nil, repository.ErrBlobNotFound)
+			},
+			attachmentID: 2,
+			want:         http.StatusNotFound,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("GET", fmt.Sprintf("/api/v1/todos/1/attachments/%v", tt.attachmentID), "", 1)
+			c.Params = append(c.Params, gin.Param{Key: "attachment_id", Value: fmt.Sprint(tt.attachmentID)})
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.Download(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusOK {
+				assert.Equal(t, "%PDF-1.7", w.Body.String())
+				assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+				assert.Equal(t, "attachment; filename=a.pdf", w.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
+
+func TestDeleteAttachment(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		want          int
+	}{
+		"正常ケース:削除に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Detach(testUser.ID, uint(1), uint(2)).Return(nil)
+			},
+			want: http.StatusNoContent,
+		},
+		"異常ケース:添付ファイルが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Detach(testUser.ID, uint(1), uint(2)).Return(models.NotFound(gorm.ErrRecordNotFound))
+			},
+			want: http.StatusNotFound,
+		},
+		"異常ケース:削除に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Detach(testUser.ID, uint(1), uint(2)).Return(errors.New("something is wrong"))
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			c, w := newTestContext("DELETE", "/api/v1/todos/1/attachments/2", "", 1)
+			c.Params = append(c.Params, gin.Param{Key: "attachment_id", Value: "2"})
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newProjectUsecaseMock(mockCtrl))
+			handler.DeleteAttachment(c)
+
+			// c.Statusだけではステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package httperr
+
+import (
+	"net/http"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+)
+
+// エラーの種類に応じたステータスコードを返す
+// 種類が付いていない（想定外の）エラーは500とする
+func Status(err error) int {
+	switch models.KindOf(err) {
+	case models.KindNotFound:
+		return http.StatusNotFound
+	case models.KindValidation:
+		return http.StatusUnprocessableEntity
+	case models.KindConflict:
+		return http.StatusConflict
+	case models.KindUnavailable:
+		return http.StatusServiceUnavailable
+	case models.KindUnauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,35 @@
+package httperr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatus(t *testing.T) {
+
+	cause := errors.New("cause")
+
+	cases := map[string]struct {
+		err  error
+		want int
+	}{
+		"正常ケース:対象が存在しない":     {err: models.NotFound(cause), want: http.StatusNotFound},
+		"正常ケース:入力が不正":        {err: models.Validation(cause), want: http.StatusUnprocessableEntity},
+		"正常ケース:競合した":         {err: models.Conflict(cause), want: http.StatusConflict},
+		"正常ケース:一時的に処理できない":   {err: models.Unavailable(cause), want: http.StatusServiceUnavailable},
+		"正常ケース:認証されていない":     {err: models.Unauthenticated(cause), want: http.StatusUnauthorized},
+		"正常ケース:包まれたエラー":      {err: fmt.Errorf("failed: %w", models.Conflict(cause)), want: http.StatusConflict},
+		"正常ケース:種類が付いていないエラー": {err: cause, want: http.StatusInternalServerError},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Status(tt.err))
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/handlers/httperr"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+// セッションのトークンを保存するcookieの名前
+const SessionCookieName = "session"
+
+// ログイン中のユーザーをgin.Contextに保存する際のキー
+const currentUserKey = "currentUser"
+
+// ログイン・ユーザー登録に対するハンドラーの構造体
+type AuthHandler struct {
+	authUsecase usecases.AuthUsecase
+	cookie      config.CookieConfig
+	session     config.SessionConfig
+}
+
+// AuthHandlerの新しいインスタンスを作成して返す
+func NewAuthHandler(uc usecases.AuthUsecase, cookie config.CookieConfig, session config.SessionConfig) AuthHandler {
+	authHandler := AuthHandler{authUsecase: uc, cookie: cookie, session: session}
+	return authHandler
+}
+
+// ログイン画面を表示する
+func (ah *AuthHandler) LoginForm(c *gin.Context) {
+	fm := GetFlashMessage(c)
+	c.HTML(http.StatusOK, "auth/login.html", gin.H{
+		flashMessage: fm.Message,
+		flashType:    fm.Type,
+		"csrfToken":  CSRFToken(c),
+	})
+}
+
+// ログインする
+func (ah *AuthHandler) Login(c *gin.Context) {
+	session, err := ah.authUsecase.Login(c.PostForm("email"), c.PostForm("password"))
+	if err != nil {
+		msg := "メールアドレスかパスワードが正しくありません。"
+		if !errors.Is(err, usecases.ErrInvalidCredentials) {
+			msg = errorMessage(err, msg, msg)
+		}
+		SetFlashMessage(c, ah.cookie, resultIsError, msg)
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+	ah.setSessionCookie(c, session)
+	c.Redirect(http.StatusFound, "/todo")
+}
+
+// ユーザー登録画面を表示する
+func (ah *AuthHandler) SignupForm(c *gin.Context) {
+	fm := GetFlashMessage(c)
+	c.HTML(http.StatusOK, "auth/signup.html", gin.H{
+		flashMessage: fm.Message,
+		flashType:    fm.Type,
+		"csrfToken":  CSRFToken(c),
+	})
+}
+
+// ユーザーを登録し、ログインした状態にする
+func (ah *AuthHandler) Signup(c *gin.Context) {
+	session, err := ah.authUsecase.SignUp(c.PostForm("email"), c.PostForm("password"))
+	if err != nil {
+		if errors.Is(err, usecases.ErrEmailAlreadyUsed) {
+			SetFlashMessage(c, ah.cookie, resultIsError, "このメールアドレスは既に登録されています。")
+		} else {
+			msg := "ユーザー登録に失敗しました。メールアドレスと、8文字以上のパスワードを入力してください。"
+			SetFlashMessage(c, ah.cookie, resultIsError, errorMessage(err, msg, msg))
+		}
+		c.Redirect(http.StatusSeeOther, "/signup")
+		return
+	}
+	ah.setSessionCookie(c, session)
+	SetFlashMessage(c, ah.cookie, resultIsSuccess, "ユーザー登録が完了しました。")
+	c.Redirect(http.StatusFound, "/todo")
+}
+
+// ログアウトする
+func (ah *AuthHandler) Logout(c *gin.Context) {
+	token, _ := c.Cookie(SessionCookieName)
+	if err := ah.authUsecase.Logout(token); err != nil {
+		slog.Error(err.Error())
+	}
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(SessionCookieName, "", -1, "/", ah.cookie.Domain, ah.cookie.Secure, true)
+	SetFlashMessage(c, ah.cookie, resultIsSuccess, "ログアウトしました。")
+	c.Redirect(http.StatusFound, "/login")
+}
+
+// ログインしていない場合にログイン画面へリダイレクトするミドルウェア
+// ログイン中の場合は、後続のハンドラーで使用できるようユーザーを保存する
+func (ah *AuthHandler) RequireLogin(c *gin.Context) {
+	token, _ := c.Cookie(SessionCookieName)
+	user, err := ah.authUsecase.Authenticate(token)
+	if err != nil {
+		if errors.Is(err, usecases.ErrUnauthenticated) {
+			c.Redirect(http.StatusSeeOther, "/login")
+			c.Abort()
+			return
+		}
+		c.HTML(httperr.Status(err), "error/error.html", gin.H{
+			"message": errorMessage(err, "ログイン状態を確認できませんでした。", "ログイン状態を確認できませんでした。"),
+		})
+		c.Abort()
+		return
+	}
+	c.Set(currentUserKey, user)
+	c.Next()
+}
+
+// 終了処理を行う
+func (ah *AuthHandler) Close() {
+	err := ah.authUsecase.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// セッションのトークンをcookieに保存する
+// JavaScriptから読み取れず、他サイトからのPOSTでは送信されないようにする
+func (ah *AuthHandler) setSessionCookie(c *gin.Context, session *models.Session) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(SessionCookieName, session.Token, int(ah.session.TTL.Seconds()), "/", ah.cookie.Domain, ah.cookie.Secure, true)
+}
+
+// ログイン中のユーザーを返す
+// RequireLoginを通過していない場合はnilを返す
+func CurrentUser(c *gin.Context) *models.User {
+	v, ok := c.Get(currentUserKey)
+	if !ok {
+		return nil
+	}
+	user, _ := v.(*models.User)
+	return user
+}
+
+// ログイン中のユーザーのIDを返す
+func currentUserID(c *gin.Context) uint {
+	if user := CurrentUser(c); user != nil {
+		return user.ID
+	}
+	return 0
+}
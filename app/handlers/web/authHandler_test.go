@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_usecases "github.com/MinadukiSekina/todo-go-app/app/mock/usecase"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// テスト用のセッションの設定
+var testSession = config.SessionConfig{TTL: config.Duration{Duration: time.Hour}}
+
+// フォームを送信するテスト用のgin contextを生成する
+func newFormContext(method string, target string, form url.Values) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, r := gin.CreateTestContext(w)
+
+	// テンプレートの読み込み
+	// route.goと同じ指定だとエラーになったため、appからのパスで指定する
+	r.LoadHTMLGlob("/app/app/templates/*/*.html")
+
+	req, _ := http.NewRequest(method, target, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c.Request = req
+	return c, w
+}
+
+// レスポンスで設定されたセッションのcookieを返す
+func sessionCookie(w *httptest.ResponseRecorder) *http.Cookie {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == SessionCookieName {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestLoginForm(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	for _, page := range []string{"login", "signup"} {
+		t.Run(page, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockAuthUsecase(mockCtrl)
+
+			c, w := newFormContext("GET", "/"+page, nil)
+
+			// mockを利用してテストする
+			handler := NewAuthHandler(mock, testCookie, testSession)
+			if page == "login" {
+				handler.LoginForm(c)
+			} else {
+				handler.SignupForm(c)
+			}
+
+			// 結果を確認
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	session := &models.Session{UserID: 1, Token: "token"}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockAuthUsecase)
+		want          int
+		wantLocation  string
+		wantCookie    bool
+	}{
+		"正常ケース:ログインに成功": {
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Login("user@example.com", "password").Return(session, nil)
+			},
+			want:         http.StatusFound,
+			wantLocation: "/todo",
+			wantCookie:   true,
+		},
+		"異常ケース:パスワードが誤っている": {
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Login("user@example.com", "password").Return(nil, usecases.ErrInvalidCredentials)
+			},
+			want:         http.StatusSeeOther,
+			wantLocation: "/login",
+		},
+		"異常ケース:ログインに失敗": {
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Login("user@example.com", "password").Return(nil, errors.New("something is wrong"))
+			},
+			want:         http.StatusSeeOther,
+			wantLocation: "/login",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockAuthUsecase(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			c, w := newFormContext("POST", "/login", url.Values{"email": {"user@example.com"}, "password": {"password"}})
+
+			// mockを利用してテストする
+			handler := NewAuthHandler(mock, testCookie, testSession)
+			handler.Login(c)
+
+			// POSTの場合はリダイレクトのステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+			cookie := sessionCookie(w)
+			if tt.wantCookie {
+				if assert.NotNil(t, cookie) {
+					assert.Equal(t, session.Token, cookie.Value)
+					assert.True(t, cookie.HttpOnly)
+					assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+					assert.Equal(t, 3600, cookie.MaxAge)
+				}
+			} else {
+				assert.Nil(t, cookie)
+			}
+		})
+	}
+}
+
+func TestSignup(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockAuthUsecase)
+		want          int
+		wantLocation  string
+	}{
+		"正常ケース:登録に成功": {
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().SignUp("user@example.com", "password").Return(&models.Session{UserID: 1, Token: "token"}, nil)
+			},
+			want:         http.StatusFound,
+			wantLocation: "/todo",
+		},
+		"異常ケース:登録済みのメールアドレス": {
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().SignUp("user@example.com", "password").Return(nil, usecases.ErrEmailAlreadyUsed)
+			},
+			want:         http.StatusSeeOther,
+			wantLocation: "/signup",
+		},
+		"異常ケース:入力内容が不正": {
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().SignUp("user@example.com", "password").Return(nil, errors.New("password is too short"))
+			},
+			want:         http.StatusSeeOther,
+			wantLocation: "/signup",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockAuthUsecase(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			c, w := newFormContext("POST", "/signup", url.Values{"email": {"user@example.com"}, "password": {"password"}})
+
+			// mockを利用してテストする
+			handler := NewAuthHandler(mock, testCookie, testSession)
+			handler.Signup(c)
+
+			// POSTの場合はリダイレクトのステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	// モックの呼び出しを管理するControllerを生成
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// モックの生成
+	mock := mock_usecases.NewMockAuthUsecase(mockCtrl)
+	mock.EXPECT().Logout("token").Return(nil)
+
+	c, w := newFormContext("POST", "/logout", nil)
+	c.Request.AddCookie(&http.Cookie{Name: SessionCookieName, Value: "token"})
+
+	// mockを利用してテストする
+	handler := NewAuthHandler(mock, testCookie, testSession)
+	handler.Logout(c)
+	c.Writer.WriteHeaderNow()
+
+	// 結果を確認
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "/login", w.Header().Get("Location"))
+	// cookieが削除されること
+	if cookie := sessionCookie(w); assert.NotNil(t, cookie) {
+		assert.Empty(t, cookie.Value)
+		assert.Less(t, cookie.MaxAge, 0)
+	}
+}
+
+func TestRequireLogin(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		cookie        string
+		prepareMockFn func(m *mock_usecases.MockAuthUsecase)
+		want          int
+		wantUser      *models.User
+	}{
+		"正常ケース:ログイン中": {
+			cookie: "token",
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Authenticate("token").Return(testUser, nil)
+			},
+			want:     http.StatusOK,
+			wantUser: testUser,
+		},
+		"異常ケース:ログインしていない": {
+			cookie: "",
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Authenticate("").Return(nil, usecases.ErrUnauthenticated)
+			},
+			want: http.StatusSeeOther,
+		},
+		"異常ケース:ログイン状態の確認に失敗": {
+			cookie: "token",
+			prepareMockFn: func(m *mock_usecases.MockAuthUsecase) {
+				m.EXPECT().Authenticate("token").Return(nil, errors.New("something is wrong"))
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockAuthUsecase(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			// ミドルウェアの後続のハンドラーでログイン中のユーザーを確認する
+			handler := NewAuthHandler(mock, testCookie, testSession)
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+			r.LoadHTMLGlob("/app/app/templates/*/*.html")
+			var gotUser *models.User
+			r.GET("/todo", handler.RequireLogin, func(c *gin.Context) {
+				gotUser = CurrentUser(c)
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest("GET", "/todo", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: tt.cookie})
+			}
+			r.ServeHTTP(w, req)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantUser, gotUser)
+		})
+	}
+}
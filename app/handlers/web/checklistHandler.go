@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+// チェックリストの項目のタイトルが不正な場合のメッセージ
+var checklistItemTitleErrorMessage = fmt.Sprintf("項目のタイトルは%d文字以内で入力してください。", models.ChecklistItemTitleMaxLength)
+
+// todo/:id/checklistパスへのリクエストに対するハンドラーの構造体
+// 処理後はいずれもtodoの詳細画面に戻す
+type ChecklistHandler struct {
+	checklistUsecase usecases.ChecklistUsecase
+	cookie           config.CookieConfig
+}
+
+// ChecklistHandlerの新しいインスタンスを作成して返す
+func NewChecklistHandler(uc usecases.ChecklistUsecase, cookie config.CookieConfig) ChecklistHandler {
+	checklistHandler := ChecklistHandler{checklistUsecase: uc, cookie: cookie}
+	return checklistHandler
+}
+
+// チェックリストの末尾に項目を追加する
+func (ch *ChecklistHandler) Create(c *gin.Context) {
+	todoID, ok := ch.parseTodoID(c)
+	if !ok {
+		return
+	}
+	_, err := ch.checklistUsecase.Add(currentUserID(c), todoID, c.PostForm("title"))
+	if err != nil {
+		ch.redirectWithError(c, todoID, err, "項目を追加できませんでした。")
+		return
+	}
+	SetFlashMessage(c, ch.cookie, resultIsSuccess, "項目を追加しました。")
+	c.Redirect(http.StatusFound, todoPath(todoID))
+}
+
+// 項目を完了・未完了にする
+func (ch *ChecklistHandler) Toggle(c *gin.Context) {
+	todoID, id, ok := ch.parseIDs(c)
+	if !ok {
+		return
+	}
+	done := c.PostForm("done") == "true"
+	_, err := ch.checklistUsecase.Update(currentUserID(c), todoID, id, nil, &done)
+	if err != nil {
+		ch.redirectWithError(c, todoID, err, "項目を更新できませんでした。")
+		return
+	}
+	c.Redirect(http.StatusFound, todoPath(todoID))
+}
+
+// 項目を1つ上（direction=up）・下（direction=down）に移動する
+func (ch *ChecklistHandler) Move(c *gin.Context) {
+	todoID, id, ok := ch.parseIDs(c)
+	if !ok {
+		return
+	}
+	var offset int
+	switch c.PostForm("direction") {
+	case "up":
+		offset = -1
+	case "down":
+		offset = 1
+	default:
+		SetFlashMessage(c, ch.cookie, resultIsError, "移動する方向が不正な値です。")
+		c.Redirect(http.StatusSeeOther, todoPath(todoID))
+		return
+	}
+	items, err := ch.checklistUsecase.List(currentUserID(c), todoID)
+	if err != nil {
+		ch.redirectWithError(c, todoID, err, "項目を移動できませんでした。")
+		return
+	}
+	position := slices.IndexFunc(*items, func(item models.ChecklistItem) bool { return item.ID == id })
+	if position < 0 {
+		SetFlashMessage(c, ch.cookie, resultIsError, "この項目は変更できません。")
+		c.Redirect(http.StatusSeeOther, todoPath(todoID))
+		return
+	}
+	err = ch.checklistUsecase.Move(currentUserID(c), todoID, id, position+offset)
+	if err != nil {
+		ch.redirectWithError(c, todoID, err, "項目を移動できませんでした。")
+		return
+	}
+	c.Redirect(http.StatusFound, todoPath(todoID))
+}
+
+// 項目を削除する
+func (ch *ChecklistHandler) Delete(c *gin.Context) {
+	todoID, id, ok := ch.parseIDs(c)
+	if !ok {
+		return
+	}
+	err := ch.checklistUsecase.Delete(currentUserID(c), todoID, id)
+	if err != nil {
+		ch.redirectWithError(c, todoID, err, "項目を削除できませんでした。")
+		return
+	}
+	SetFlashMessage(c, ch.cookie, resultIsSuccess, "項目を削除しました。")
+	c.Redirect(http.StatusFound, todoPath(todoID))
+}
+
+// 終了処理を行う
+func (ch *ChecklistHandler) Close() {
+	err := ch.checklistUsecase.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// パスパラメータのtodoのIDを数値に変換する
+// 変換できない場合はtodoの一覧に戻し、falseを返す
+func (ch *ChecklistHandler) parseTodoID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		SetFlashMessage(c, ch.cookie, resultIsError, "このタスクは変更できません。")
+		c.Redirect(http.StatusSeeOther, "/todo")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// パスパラメータのtodoと項目のIDを数値に変換する
+// 変換できない場合はエラーのメッセージを設定して戻し、falseを返す
+func (ch *ChecklistHandler) parseIDs(c *gin.Context) (uint, uint, bool) {
+	todoID, ok := ch.parseTodoID(c)
+	if !ok {
+		return 0, 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("item_id"), 10, 64)
+	if err != nil {
+		SetFlashMessage(c, ch.cookie, resultIsError, "この項目は変更できません。")
+		c.Redirect(http.StatusSeeOther, todoPath(todoID))
+		return 0, 0, false
+	}
+	return todoID, uint(id), true
+}
+
+// エラーの内容に応じたメッセージを設定し、todoの詳細画面に戻す
+func (ch *ChecklistHandler) redirectWithError(c *gin.Context, todoID uint, err error, msg string) {
+	if errors.Is(err, models.ErrInvalidChecklistItemTitle) {
+		msg = checklistItemTitleErrorMessage
+	} else {
+		msg = errorMessage(err, "該当するタスクか項目が見つかりませんでした。", msg)
+	}
+	SetFlashMessage(c, ch.cookie, resultIsError, msg)
+	c.Redirect(http.StatusSeeOther, todoPath(todoID))
+}
+
+// 指定されたtodoの詳細画面のパスを返す
+func todoPath(id uint) string {
+	return fmt.Sprintf("/todo/%d", id)
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_usecases "github.com/MinadukiSekina/todo-go-app/app/mock/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestChecklistActions(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	// テスト用の項目（並び順）
+	items := []models.ChecklistItem{
+		{ID: 1, TodoID: 1, Title: "a", Position: 0},
+		{ID: 2, TodoID: 1, Title: "b", Position: 1},
+	}
+	done := true
+	undone := false
+
+	// テスト用の引数を格納する
+	type args struct {
+		todoID any
+		itemID any
+		form   url.Values
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockChecklistUsecase)
+		callFn        func(handler *ChecklistHandler, c *gin.Context)
+		args          args
+		want          int
+		wantLocation  string
+	}{
+		"正常ケース:追加に成功": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Add(testUser.ID, uint(1), "c").Return(&models.ChecklistItem{ID: 3, TodoID: 1, Title: "c", Position: 2}, nil)
+			},
+			callFn:       func(handler *ChecklistHandler, c *gin.Context) { handler.Create(c) },
+			args:         args{todoID: 1, form: url.Values{"title": {"c"}}},
+			want:         http.StatusFound,
+			wantLocation: "/todo/1",
+		},
+		"異常ケース:タイトルが不正": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Add(testUser.ID, uint(1), "").Return(nil, models.ErrInvalidChecklistItemTitle)
+			},
+			callFn:       func(handler *ChecklistHandler, c *gin.Context) { handler.Create(c) },
+			args:         args{todoID: 1, form: url.Values{"title": {""}}},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/1",
+		},
+		"異常ケース:todoのIDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			callFn:       func(handler *ChecklistHandler, c *gin.Context) { handler.Create(c) },
+			args:         args{todoID: "string", form: url.Values{"title": {"c"}}},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo",
+		},
+		"正常ケース:完了にする": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Update(testUser.ID, uint(1), uint(2), nil, &done).Return(&models.ChecklistItem{ID: 2, Done: true}, nil)
+			},
+			callFn:       func(handler *ChecklistHandler, c *gin.Context) { handler.Toggle(c) },
+			args:         args{todoID: 1, itemID: 2, form: url.Values{"done": {"true"}}},
+			want:         http.StatusFound,
+			wantLocation: "/todo/1",
+		},
+		"正常ケース:未完了に戻す": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Update(testUser.ID, uint(1), uint(2), nil, &undone).Return(&models.ChecklistItem{ID: 2}, nil)
+			},
+			callFn:       func(handler *ChecklistHandler, c *gin.Context) { handler.Toggle(c) },
+			args:         args{todoID: 1, itemID: 2, form: url.Values{"done": {"false"}}},
+			want:         http.StatusFound,
+			wantLocation: "/todo/1",
+		},
+		"異常ケース:項目が存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Update(testUser.ID, uint(1), uint(9), nil, &done).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			callFn:       func(handler *ChecklistHandler, c *gin.Context) { handler.Toggle(c) },
+			args:         args{todoID: 1, itemID: 9, form: url.Values{"done": {"true"}}},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/1",
+		},
+		"異常ケース:項目のIDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			callFn:       func(handler *ChecklistHandler, c *gin.Context) { handler.Toggle(c) },
+			args:         args{todoID: 1, itemID: "string", form: url.Values{"done": {"true"}}},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/1",
+		},
+		"正常ケース:上に移動": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().List(testUser.ID, uint(1)).Return(&items, nil)
+				m.EXPECT().Move(testUser.ID, uint(1), uint(2), 0).Return(nil)
+			},
+			callFn:       func(handler *ChecklistHandler, c *gin.Context) { handler.Move(c) },
+			args:         args{todoID: 1, itemID: 2, form: url.Values{"direction": {"up"}}},
+			want:         http.StatusFound,
+			wantLocation: "/todo/1",
+		},
+		"正常ケース:下に移動": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().List(testUser.ID, uint(1)).Return(&items, nil)
+				m.EXPECT().Move(testUser.ID, uint(1), uint(1), 1).Return(nil)
+			},
+			callFn:       func(handler *ChecklistHandler, c *gin.Context) { handler.Move(c) },
+			args:         args{todoID: 1, itemID: 1, form: url.Values{"direction": {"down"}}},
+			want:         http.StatusFound,
+			wantLocation: "/todo/1",
+		},
+		"異常ケース:移動する方向が不正": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				// 方向が不正な場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			callFn:       func(handler *ChecklistHandler, c *gin.Context) { handler.Move(c) },
+			args:         args{todoID: 1, itemID: 1, form: url.Values{"direction": {"left"}}},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/1",
+		},
+		"異常ケース:移動する項目が存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().List(testUser.ID, uint(1)).Return(&items, nil)
+			},
+			callFn:       func(handler *ChecklistHandler, c *gin.Context) { handler.Move(c) },
+			args:         args{todoID: 1, itemID: 9, form: url.Values{"direction": {"up"}}},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/1",
+		},
+		"正常ケース:削除に成功": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1), uint(2)).Return(nil)
+			},
+			callFn:       func(handler *ChecklistHandler, c *gin.Context) { handler.Delete(c) },
+			args:         args{todoID: 1, itemID: 2},
+			want:         http.StatusFound,
+			wantLocation: "/todo/1",
+		},
+		"異常ケース:削除に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockChecklistUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1), uint(2)).Return(errors.New("something is wrong"))
+			},
+			callFn:       func(handler *ChecklistHandler, c *gin.Context) { handler.Delete(c) },
+			args:         args{todoID: 1, itemID: 2},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/1",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockChecklistUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
+			// リクエストを設定
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/todo/%v/checklist", tt.args.todoID), strings.NewReader(tt.args.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Request = req
+
+			// パラメータを設定
+			c.Params = []gin.Param{{Key: "id", Value: fmt.Sprint(tt.args.todoID)}}
+			if tt.args.itemID != nil {
+				c.Params = append(c.Params, gin.Param{Key: "item_id", Value: fmt.Sprint(tt.args.itemID)})
+			}
+
+			// mockを利用してテストする
+			handler := NewChecklistHandler(mock, testCookie)
+			tt.callFn(&handler, c)
+
+			// POSTの場合はリダイレクトのステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+// コメントの本文が不正な場合のメッセージ
+var commentBodyErrorMessage = fmt.Sprintf("コメントは%d文字以内で入力してください。", models.CommentBodyMaxLength)
+
+// todo/:id/commentsパスへのリクエストに対するハンドラーの構造体
+// 処理後はいずれもtodoの詳細画面に戻す
+type CommentHandler struct {
+	commentUsecase usecases.CommentUsecase
+	cookie         config.CookieConfig
+}
+
+// CommentHandlerの新しいインスタンスを作成して返す
+func NewCommentHandler(uc usecases.CommentUsecase, cookie config.CookieConfig) CommentHandler {
+	commentHandler := CommentHandler{commentUsecase: uc, cookie: cookie}
+	return commentHandler
+}
+
+// コメントを投稿する
+func (ch *CommentHandler) Create(c *gin.Context) {
+	todoID, ok := ch.parseTodoID(c)
+	if !ok {
+		return
+	}
+	_, err := ch.commentUsecase.Add(currentUserID(c), todoID, c.PostForm("body"))
+	if err != nil {
+		ch.redirectWithError(c, todoID, err, "コメントを投稿できませんでした。")
+		return
+	}
+	SetFlashMessage(c, ch.cookie, resultIsSuccess, "コメントを投稿しました。")
+	c.Redirect(http.StatusFound, todoPath(todoID)+"#comments")
+}
+
+// コメントの本文を変更する
+func (ch *CommentHandler) Update(c *gin.Context) {
+	todoID, id, ok := ch.parseIDs(c)
+	if !ok {
+		return
+	}
+	_, err := ch.commentUsecase.Edit(currentUserID(c), todoID, id, c.PostForm("body"))
+	if err != nil {
+		ch.redirectWithError(c, todoID, err, "コメントを更新できませんでした。")
+		return
+	}
+	SetFlashMessage(c, ch.cookie, resultIsSuccess, "コメントを更新しました。")
+	c.Redirect(http.StatusFound, fmt.Sprintf("%s#comment-%d", todoPath(todoID), id))
+}
+
+// コメントを削除する
+func (ch *CommentHandler) Delete(c *gin.Context) {
+	todoID, id, ok := ch.parseIDs(c)
+	if !ok {
+		return
+	}
+	err := ch.commentUsecase.Delete(currentUserID(c), todoID, id)
+	if err != nil {
+		ch.redirectWithError(c, todoID, err, "コメントを削除できませんでした。")
+		return
+	}
+	SetFlashMessage(c, ch.cookie, resultIsSuccess, "コメントを削除しました。")
+	c.Redirect(http.StatusFound, todoPath(todoID)+"#comments")
+}
+
+// 終了処理を行う
+func (ch *CommentHandler) Close() {
+	err := ch.commentUsecase.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// パスパラメータのtodoのIDを数値に変換する
+// 変換できない場合はtodoの一覧に戻し、falseを返す
+func (ch *CommentHandler) parseTodoID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		SetFlashMessage(c, ch.cookie, resultIsError, "このタスクにはコメントできません。")
+		c.Redirect(http.StatusSeeOther, "/todo")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// パスパラメータのtodoとコメントのIDを数値に変換する
+// 変換できない場合はエラーのメッセージを設定して戻し、falseを返す
+func (ch *CommentHandler) parseIDs(c *gin.Context) (uint, uint, bool) {
+	todoID, ok := ch.parseTodoID(c)
+	if !ok {
+		return 0, 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("comment_id"), 10, 64)
+	if err != nil {
+		SetFlashMessage(c, ch.cookie, resultIsError, "このコメントは変更できません。")
+		c.Redirect(http.StatusSeeOther, todoPath(todoID))
+		return 0, 0, false
+	}
+	return todoID, uint(id), true
+}
+
+// エラーの内容に応じたメッセージを設定し、todoの詳細画面に戻す
+func (ch *CommentHandler) redirectWithError(c *gin.Context, todoID uint, err error, msg string) {
+	switch {
+	case errors.Is(err, models.ErrInvalidCommentBody):
+		msg = commentBodyErrorMessage
+	case errors.Is(err, models.ErrNotCommentAuthor):
+		msg = "他のユーザーのコメントは変更できません。"
+	default:
+		msg = errorMessage(err, "該当するタスクかコメントが見つかりませんでした。", msg)
+	}
+	SetFlashMessage(c, ch.cookie, resultIsError, msg)
+	c.Redirect(http.StatusSeeOther, todoPath(todoID))
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_usecases "github.com/MinadukiSekina/todo-go-app/app/mock/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestCommentActions(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	// テスト用の引数を格納する
+	type args struct {
+		todoID    any
+		commentID any
+		form      url.Values
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockCommentUsecase)
+		callFn        func(handler *CommentHandler, c *gin.Context)
+		args          args
+		want          int
+		wantLocation  string
+	}{
+		"正常ケース:投稿に成功": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Add(testUser.ID, uint(1), "確認しました").Return(&models.Comment{ID: 3, TodoID: 1, Body: "確認しました"}, nil)
+			},
+			callFn:       func(handler *CommentHandler, c *gin.Context) { handler.Create(c) },
+			args:         args{todoID: 1, form: url.Values{"body": {"確認しました"}}},
+			want:         http.StatusFound,
+			wantLocation: "/todo/1#comments",
+		},
+		"異常ケース:本文が不正": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Add(testUser.ID, uint(1), "").Return(nil, models.ErrInvalidCommentBody)
+			},
+			callFn:       func(handler *CommentHandler, c *gin.Context) { handler.Create(c) },
+			args:         args{todoID: 1, form: url.Values{"body": {""}}},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/1",
+		},
+		"異常ケース:todoのIDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			callFn:       func(handler *CommentHandler, c *gin.Context) { handler.Create(c) },
+			args:         args{todoID: "string", form: url.Values{"body": {"確認しました"}}},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo",
+		},
+		"正常ケース:更新に成功": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Edit(testUser.ID, uint(1), uint(2), "修正").Return(&models.Comment{ID: 2, TodoID: 1, Body: "修正"}, nil)
+			},
+			callFn:       func(handler *CommentHandler, c *gin.Context) { handler.Update(c) },
+			args:         args{todoID: 1, commentID: 2, form: url.Values{"body": {"修正"}}},
+			want:         http.StatusFound,
+			wantLocation: "/todo/1#comment-2",
+		},
+		"異常ケース:他のユーザーのコメントを更新": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Edit(testUser.ID, uint(1), uint(2), "修正").Return(nil, models.ErrNotCommentAuthor)
+			},
+			callFn:       func(handler *CommentHandler, c *gin.Context) { handler.Update(c) },
+			args:         args{todoID: 1, commentID: 2, form: url.Values{"body": {"修正"}}},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/1",
+		},
+		"異常ケース:コメントが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Edit(testUser.ID, uint(1), uint(9), "修正").Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			callFn:       func(handler *CommentHandler, c *gin.Context) { handler.Update(c) },
+			args:         args{todoID: 1, commentID: 9, form: url.Values{"body": {"修正"}}},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/1",
+		},
+		"異常ケース:コメントのIDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			callFn:       func(handler *CommentHandler, c *gin.Context) { handler.Update(c) },
+			args:         args{todoID: 1, commentID: "string", form: url.Values{"body": {"修正"}}},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/1",
+		},
+		"正常ケース:削除に成功": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1), uint(2)).Return(nil)
+			},
+			callFn:       func(handler *CommentHandler, c *gin.Context) { handler.Delete(c) },
+			args:         args{todoID: 1, commentID: 2},
+			want:         http.StatusFound,
+			wantLocation: "/todo/1#comments",
+		},
+		"異常ケース:削除に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockCommentUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1), uint(2)).Return(errors.New("something is wrong"))
+			},
+			callFn:       func(handler *CommentHandler, c *gin.Context) { handler.Delete(c) },
+			args:         args{todoID: 1, commentID: 2},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/1",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockCommentUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
+			// リクエストを設定
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/todo/%v/comments", tt.args.todoID), strings.NewReader(tt.args.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Request = req
+
+			// パラメータを設定
+			c.Params = []gin.Param{{Key: "id", Value: fmt.Sprint(tt.args.todoID)}}
+			if tt.args.commentID != nil {
+				c.Params = append(c.Params, gin.Param{Key: "comment_id", Value: fmt.Sprint(tt.args.commentID)})
+			}
+
+			// mockを利用してテストする
+			handler := NewCommentHandler(mock, testCookie)
+			tt.callFn(&handler, c)
+
+			// POSTの場合はリダイレクトのステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+		})
+	}
+}
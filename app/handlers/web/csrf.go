@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/gin-gonic/gin"
+)
+
+// CSRF対策用のトークンを送信するフォームの項目名
+const CSRFFieldName = "csrf_token"
+
+// CSRF対策用のトークンを送信するヘッダーの名前（JavaScriptからのリクエスト用）
+const CSRFHeaderName = "X-CSRF-Token"
+
+// CSRF対策用のトークンをgin.Contextに保存する際のキー
+const csrfTokenKey = "csrfToken"
+
+// ログイン前のCSRF対策用のトークンを保存するcookieの名前
+const PreSessionCSRFCookieName = "login_csrf"
+
+// 状態を変更するリクエストで、セッションに対応するCSRF対策用のトークンを検証するミドルウェア
+// 後続のハンドラーが画面のフォームに埋め込めるよう、トークンを保存する
+func (ah *AuthHandler) VerifyCSRF(c *gin.Context) {
+	sessionToken, _ := c.Cookie(SessionCookieName)
+	token := models.CSRFToken(sessionToken)
+	c.Set(csrfTokenKey, token)
+
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		c.Next()
+		return
+	}
+
+	sent := c.PostForm(CSRFFieldName)
+	if sent == "" {
+		sent = c.GetHeader(CSRFHeaderName)
+	}
+	if token == "" || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+		abortCSRF(c)
+		return
+	}
+	c.Next()
+}
+
+// ログイン前の画面で、cookieとフォームに同じトークンを送らせてCSRFを検証するミドルウェア
+// セッションが無いため、画面の表示時に乱数のトークンをcookieへ保存し、フォームにも埋め込む
+// 他サイトからはcookieの値を読めないため、フォームに同じ値を設定できない
+func (ah *AuthHandler) VerifyPreSessionCSRF(c *gin.Context) {
+	token, _ := c.Cookie(PreSessionCSRFCookieName)
+
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		if token == "" {
+			b := make([]byte, 32)
+			if _, err := rand.Read(b); err != nil {
+				c.HTML(http.StatusInternalServerError, "error/error.html", gin.H{
+					"message": "画面を表示できませんでした。時間をおいて再度お試しください。",
+				})
+				c.Abort()
+				return
+			}
+			token = base64.RawURLEncoding.EncodeToString(b)
+			c.SetSameSite(http.SameSiteLaxMode)
+			c.SetCookie(PreSessionCSRFCookieName, token, 0, "/", ah.cookie.Domain, ah.cookie.Secure, true)
+		}
+		c.Set(csrfTokenKey, token)
+		c.Next()
+		return
+	}
+
+	sent := c.PostForm(CSRFFieldName)
+	if token == "" || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+		abortCSRF(c)
+		return
+	}
+	c.Next()
+}
+
+// CSRF対策用のトークンが一致しない場合に、エラー画面を表示して処理を中止する
+func abortCSRF(c *gin.Context) {
+	c.HTML(http.StatusForbidden, "error/error.html", gin.H{
+		"message": "不正なリクエストのため処理を中止しました。画面を再読み込みしてから、もう一度操作してください。",
+	})
+	c.Abort()
+}
+
+// 画面のフォームに埋め込むCSRF対策用のトークンを返す
+// VerifyCSRF・VerifyPreSessionCSRFを通過していない場合は空文字列を返す
+func CSRFToken(c *gin.Context) string {
+	return c.GetString(csrfTokenKey)
+}
@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_usecases "github.com/MinadukiSekina/todo-go-app/app/mock/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerifyCSRF(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	// テスト用のセッションのトークンと、対応するCSRF対策用のトークン
+	sessionToken := "session-token"
+	csrfToken := models.CSRFToken(sessionToken)
+
+	// テスト用の引数を格納する
+	type args struct {
+		method       string
+		sessionToken string
+		form         url.Values
+		header       string
+	}
+
+	cases := map[string]struct {
+		args      args
+		want      int
+		wantToken string
+	}{
+		"正常ケース:GETはトークンが無くても通過": {
+			args:      args{method: "GET", sessionToken: sessionToken},
+			want:      http.StatusOK,
+			wantToken: csrfToken,
+		},
+		"正常ケース:フォームのトークンが一致": {
+			args:      args{method: "POST", sessionToken: sessionToken, form: url.Values{CSRFFieldName: {csrfToken}}},
+			want:      http.StatusOK,
+			wantToken: csrfToken,
+		},
+		"正常ケース:ヘッダーのトークンが一致": {
+			args:      args{method: "POST", sessionToken: sessionToken, header: csrfToken},
+			want:      http.StatusOK,
+			wantToken: csrfToken,
+		},
+		"異常ケース:トークンが無い": {
+			args: args{method: "POST", sessionToken: sessionToken},
+			want: http.StatusForbidden,
+		},
+		"異常ケース:トークンが不正": {
+			args: args{method: "POST", sessionToken: sessionToken, form: url.Values{CSRFFieldName: {"invalid"}}},
+			want: http.StatusForbidden,
+		},
+		"異常ケース:別のセッションのトークン": {
+			args: args{method: "POST", sessionToken: sessionToken, form: url.Values{CSRFFieldName: {models.CSRFToken("other-session")}}},
+			want: http.StatusForbidden,
+		},
+		"異常ケース:セッションが無い": {
+			args: args{method: "POST", form: url.Values{CSRFFieldName: {""}}},
+			want: http.StatusForbidden,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成（トークンの検証ではusecaseを呼び出さない）
+			mock := mock_usecases.NewMockAuthUsecase(mockCtrl)
+
+			// ミドルウェアの後続のハンドラーで、フォームに埋め込むトークンを確認する
+			handler := NewAuthHandler(mock, testCookie, testSession)
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+
+			// テンプレートの読み込み
+			// route.goと同じ指定だとエラーになったため、appからのパスで指定する
+			r.LoadHTMLGlob("/app/app/templates/*/*.html")
+
+			var gotToken string
+			r.Any("/todo", handler.VerifyCSRF, func(c *gin.Context) {
+				gotToken = CSRFToken(c)
+				c.Status(http.StatusOK)
+			})
+
+			// リクエストを設定
+			req, _ := http.NewRequest(tt.args.method, "/todo", strings.NewReader(tt.args.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.args.sessionToken != "" {
+				req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: tt.args.sessionToken})
+			}
+			if tt.args.header != "" {
+				req.Header.Set(CSRFHeaderName, tt.args.header)
+			}
+			r.ServeHTTP(w, req)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantToken, gotToken)
+			if tt.want == http.StatusForbidden {
+				// エラー画面が表示されること
+				assert.Contains(t, w.Body.String(), "エラーが発生しました。")
+			}
+		})
+	}
+}
+
+func TestCSRFTokenInForms(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	todo1 := models.Todo{Title: "test1", Status: models.NotStarted}
+	todo1.ID = 1
+	page := &models.TodoPage{Todos: []models.Todo{todo1}, Total: 1, Page: 1, Limit: models.DefaultPageSize}
+
+	csrfToken := models.CSRFToken("session-token")
+	hidden := `name="csrf_token" value="` + csrfToken + `"`
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		callFn        func(handler *TodoHandler, c *gin.Context)
+		wantForms     int
+	}{
+		"正常ケース:一覧画面の追加・一括操作・ログアウトのフォーム": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) { m.EXPECT().Search(gomock.Any()).Return(page, nil) },
+			callFn:        func(handler *TodoHandler, c *gin.Context) { handler.Index(c) },
+			wantForms:     3,
+		},
+		"正常ケース:詳細画面の更新・削除とチェックリストの追加・添付・コメントの投稿のフォーム": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
+			},
+			callFn: func(handler *TodoHandler, c *gin.Context) {
+				c.Params = []gin.Param{{Key: "id", Value: "1"}}
+				handler.ShowById(c)
+			},
+			wantForms: 4,
+		},
+		"正常ケース:チェックリストの項目ごとの完了・移動・削除のフォーム": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				withItem := todo1
+				withItem.ChecklistItems = []models.ChecklistItem{{ID: 1, TodoID: 1, Title: "item"}}
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&withItem, nil)
+			},
+			callFn: func(handler *TodoHandler, c *gin.Context) {
+				c.Params = []gin.Param{{Key: "id", Value: "1"}}
+				handler.ShowById(c)
+			},
+			wantForms: 7,
+		},
+		"正常ケース:自分のコメントのみ編集・削除のフォーム": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				withComments := todo1
+				withComments.Comments = []models.Comment{
+					{ID: 1, TodoID: 1, UserID: testUser.ID, User: *testUser, Body: "自分"},
+					{ID: 2, TodoID: 1, UserID: testUser.ID + 1, User: models.User{Email: "other@example.com"}, Body: "他人"},
+				}
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&withComments, nil)
+			},
+			callFn: func(handler *TodoHandler, c *gin.Context) {
+				c.Params = []gin.Param{{Key: "id", Value: "1"}}
+				handler.ShowById(c)
+			},
+			wantForms: 5,
+		},
+		"正常ケース:添付ファイルごとの削除のフォーム": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				withAttachment := todo1
+				withAttachment.Attachments = []models.Attachment{{ID: 1, TodoID: 1, FileName: "a.png", ContentType: "image/png", Size: 1}}
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&withAttachment, nil)
+			},
+			callFn: func(handler *TodoHandler, c *gin.Context) {
+				c.Params = []gin.Param{{Key: "id", Value: "1"}}
+				handler.ShowById(c)
+			},
+			wantForms: 5,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーと、VerifyCSRFで保存されるトークンを設定
+			c.Set(currentUserKey, testUser)
+			c.Set(csrfTokenKey, csrfToken)
+
+			// テンプレートの読み込み
+			// route.goと同じ指定だとエラーになったため、appからのパスで指定する
+			r.LoadHTMLGlob("/app/app/templates/*/*.html")
+
+			// リクエストを設定
+			req, _ := http.NewRequest("GET", "/todo", nil)
+			c.Request = req
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
+			tt.callFn(&handler, c)
+
+			// 結果を確認
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantForms, strings.Count(w.Body.String(), hidden))
+		})
+	}
+}
+
+func TestVerifyPreSessionCSRF(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	// テスト用のcookieに保存されたトークン
+	cookieToken := "pre-session-token"
+
+	// テスト用の引数を格納する
+	type args struct {
+		method      string
+		cookieToken string
+		form        url.Values
+	}
+
+	cases := map[string]struct {
+		args       args
+		want       int
+		wantCookie bool
+		wantToken  string
+	}{
+		"正常ケース:GETでcookieが無い場合はトークンを発行する": {
+			args:       args{method: "GET"},
+			want:       http.StatusOK,
+			wantCookie: true,
+		},
+		"正常ケース:GETでcookieがある場合はトークンを使い回す": {
+			args:      args{method: "GET", cookieToken: cookieToken},
+			want:      http.StatusOK,
+			wantToken: cookieToken,
+		},
+		"正常ケース:フォームとcookieのトークンが一致": {
+			args: args{method: "POST", cookieToken: cookieToken, form: url.Values{CSRFFieldName: {cookieToken}}},
+			want: http.StatusOK,
+		},
+		"異常ケース:フォームのトークンが無い": {
+			args: args{method: "POST", cookieToken: cookieToken},
+			want: http.StatusForbidden,
+		},
+		"異常ケース:フォームのトークンが不正": {
+			args: args{method: "POST", cookieToken: cookieToken, form: url.Values{CSRFFieldName: {"invalid"}}},
+			want: http.StatusForbidden,
+		},
+		"異常ケース:cookieが無い": {
+			args: args{method: "POST", form: url.Values{CSRFFieldName: {""}}},
+			want: http.StatusForbidden,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成（トークンの検証ではusecaseを呼び出さない）
+			mock := mock_usecases.NewMockAuthUsecase(mockCtrl)
+
+			handler := NewAuthHandler(mock, testCookie, testSession)
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+
+			// テンプレートの読み込み
+			// route.goと同じ指定だとエラーになったため、appからのパスで指定する
+			r.LoadHTMLGlob("/app/app/templates/*/*.html")
+
+			// GETはログイン画面を表示し、フォームに埋め込まれたトークンを確認する
+			r.GET("/login", handler.VerifyPreSessionCSRF, handler.LoginForm)
+			r.POST("/login", handler.VerifyPreSessionCSRF, func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			// リクエストを設定
+			req, _ := http.NewRequest(tt.args.method, "/login", strings.NewReader(tt.args.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.args.cookieToken != "" {
+				req.AddCookie(&http.Cookie{Name: PreSessionCSRFCookieName, Value: tt.args.cookieToken})
+			}
+			r.ServeHTTP(w, req)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusForbidden {
+				// エラー画面が表示されること
+				assert.Contains(t, w.Body.String(), "エラーが発生しました。")
+				return
+			}
+			if tt.args.method != "GET" {
+				return
+			}
+
+			var issued *http.Cookie
+			for _, cookie := range w.Result().Cookies() {
+				if cookie.Name == PreSessionCSRFCookieName {
+					issued = cookie
+				}
+			}
+			token := tt.wantToken
+			if tt.wantCookie {
+				// JavaScriptから読み取れないcookieでトークンが発行されること
+				assert.NotNil(t, issued)
+				assert.NotEmpty(t, issued.Value)
+				assert.True(t, issued.HttpOnly)
+				assert.Equal(t, http.SameSiteLaxMode, issued.SameSite)
+				token = issued.Value
+			} else {
+				assert.Nil(t, issued)
+			}
+			// cookieと同じトークンがフォームに埋め込まれること
+			assert.Contains(t, w.Body.String(), `name="csrf_token" value="`+token+`"`)
+		})
+	}
+}
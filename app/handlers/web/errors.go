@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/handlers/httperr"
+	"github.com/gin-gonic/gin"
+)
+
+// DBに接続できないなど、一時的に処理できない場合に表示するメッセージ
+const unavailableMessage = "ただいま処理できません。しばらく待ってから、もう一度お試しください。"
+
+// 想定外のエラーの場合に、エラー画面に表示するメッセージ
+const internalErrorMessage = "処理中にエラーが発生しました。時間をおいて、もう一度お試しください。"
+
+// エラーの種類に応じて、画面に表示するメッセージを返す
+// 対象が存在しない場合はnotFoundを、一時的に処理できない場合は共通のメッセージを、それ以外はfailedを返す
+// 想定外のエラーと一時的に処理できない場合は、原因を調べられるようログに出力する
+func errorMessage(err error, notFound string, failed string) string {
+	switch models.KindOf(err) {
+	case models.KindNotFound:
+		return notFound
+	case models.KindUnavailable:
+		slog.Error(err.Error())
+		return unavailableMessage
+	case models.KindUnknown:
+		slog.Error(err.Error())
+	}
+	return failed
+}
+
+// エラーの種類ごとに、エラー画面に表示するメッセージ
+// エラーの内容（DBのエラーなど）は利用者向けではないため表示しない
+var kindMessages = map[models.ErrorKind]string{
+	models.KindNotFound:        "対象のデータが見つかりません。",
+	models.KindValidation:      "入力内容が不正です。入力内容を確認して、もう一度お試しください。",
+	models.KindConflict:        "他の操作と競合したため、処理できませんでした。画面を再読み込みして、もう一度お試しください。",
+	models.KindUnauthenticated: "ログインしてください。",
+	models.KindUnavailable:     unavailableMessage,
+}
+
+// エラーの種類に応じたステータスコード（httperr.Status）で、エラー画面を表示する
+// エラーの内容の代わりに種類ごとのメッセージを表示し、想定外のエラーは共通のメッセージを表示する
+// 想定外のエラーと一時的に処理できない場合は、原因を調べられるようログに出力する
+func renderError(c *gin.Context, err error) {
+	kind := models.KindOf(err)
+	message, ok := kindMessages[kind]
+	if !ok {
+		message = internalErrorMessage
+	}
+	if !ok || kind == models.KindUnavailable {
+		slog.Error(err.Error())
+	}
+	c.HTML(httperr.Status(err), "error/error.html", gin.H{
+		"message": message,
+	})
+}
@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"strconv"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
 	"github.com/gin-gonic/gin"
 )
 
@@ -8,19 +11,27 @@ import (
 type FlashMessage struct {
 	Type    string
 	Message string
+	// 元に戻せる操作の場合の、元に戻すtodoのID（空文字列の場合は元に戻せない）
+	Restore string
 }
 
 // cookieにフラッシュメッセージの内容を保存する
-func SetFlashMessage(c *gin.Context, status string, msg string) {
-	c.SetCookie(flashType, status, 1, "/", "localhost", true, true)
-	c.SetCookie(flashMessage, msg, 1, "/", "localhost", true, true)
+func SetFlashMessage(c *gin.Context, cookie config.CookieConfig, status string, msg string) {
+	c.SetCookie(flashType, status, 1, "/", cookie.Domain, cookie.Secure, true)
+	c.SetCookie(flashMessage, msg, 1, "/", cookie.Domain, cookie.Secure, true)
+}
+
+// cookieにフラッシュメッセージとともに表示する、元に戻すtodoのIDを保存する
+func SetFlashRestore(c *gin.Context, cookie config.CookieConfig, todoID uint) {
+	c.SetCookie(flashRestore, strconv.FormatUint(uint64(todoID), 10), 1, "/", cookie.Domain, cookie.Secure, true)
 }
 
 // cookieからフラッシュメッセージの内容を取り出して構造体に変換する
 func GetFlashMessage(c *gin.Context) FlashMessage {
 	t, _ := c.Cookie(flashType)
 	m, _ := c.Cookie(flashMessage)
-	return FlashMessage{Type: t, Message: m}
+	r, _ := c.Cookie(flashRestore)
+	return FlashMessage{Type: t, Message: m, Restore: r}
 }
 
 // フラッシュメッセージの種別
@@ -33,4 +44,5 @@ const (
 const (
 	flashType    string = "Type"
 	flashMessage string = "Message"
+	flashRestore string = "Restore"
 )
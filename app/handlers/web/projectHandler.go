@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+// プロジェクトの名前が不正な場合のメッセージ
+var projectNameErrorMessage = fmt.Sprintf("プロジェクトの名前は%d文字以内で入力してください（「%s」は使用できません）。", models.ProjectNameMaxLength, models.InboxProjectName)
+
+// projectsパスへのリクエストに対するハンドラーの構造体
+type ProjectHandler struct {
+	projectUsecase usecases.ProjectUsecase
+	cookie         config.CookieConfig
+}
+
+// ProjectHandlerの新しいインスタンスを作成して返す
+func NewProjectHandler(uc usecases.ProjectUsecase, cookie config.CookieConfig) ProjectHandler {
+	projectHandler := ProjectHandler{projectUsecase: uc, cookie: cookie}
+	return projectHandler
+}
+
+// プロジェクトの一覧を表示する
+func (ph *ProjectHandler) Index(c *gin.Context) {
+	projects, err := ph.projectUsecase.List(currentUserID(c))
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+
+	// Get flash message content if it exists
+	fm := GetFlashMessage(c)
+
+	c.HTML(http.StatusOK, "project/index.html", gin.H{
+		"projects":   projects,
+		"user":       CurrentUser(c),
+		"csrfToken":  CSRFToken(c),
+		flashMessage: fm.Message,
+		flashType:    fm.Type,
+	})
+}
+
+// プロジェクトを新規作成し、そのtodoの一覧に移動する
+func (ph *ProjectHandler) Create(c *gin.Context) {
+	project, err := ph.projectUsecase.Add(currentUserID(c), c.PostForm("name"))
+	if err != nil {
+		ph.redirectWithError(c, err, "新しいプロジェクトの作成に失敗しました。")
+		return
+	}
+	SetFlashMessage(c, ph.cookie, resultIsSuccess, "新しいプロジェクトを作成しました。")
+	c.Redirect(http.StatusFound, projectTodosPath(project.ID))
+}
+
+// プロジェクトの名前を変更する
+func (ph *ProjectHandler) Rename(c *gin.Context) {
+	id, ok := ph.parseID(c)
+	if !ok {
+		return
+	}
+	_, err := ph.projectUsecase.Rename(currentUserID(c), id, c.PostForm("name"))
+	if err != nil {
+		ph.redirectWithError(c, err, "プロジェクトの名前を変更できませんでした。")
+		return
+	}
+	SetFlashMessage(c, ph.cookie, resultIsSuccess, "プロジェクトの名前を変更しました。")
+	c.Redirect(http.StatusFound, "/projects")
+}
+
+// プロジェクトを削除する
+// プロジェクトのtodoはInboxに移す
+func (ph *ProjectHandler) Delete(c *gin.Context) {
+	id, ok := ph.parseID(c)
+	if !ok {
+		return
+	}
+	err := ph.projectUsecase.Delete(currentUserID(c), id)
+	if err != nil {
+		ph.redirectWithError(c, err, "プロジェクトを削除できませんでした。")
+		return
+	}
+	SetFlashMessage(c, ph.cookie, resultIsSuccess, "プロジェクトを削除しました。タスクはInboxに移動しました。")
+	c.Redirect(http.StatusFound, "/projects")
+}
+
+// 終了処理を行う
+func (ph *ProjectHandler) Close() {
+	err := ph.projectUsecase.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// パスパラメータのIDを数値に変換する
+// 変換できない場合はプロジェクトの一覧に戻し、falseを返す
+func (ph *ProjectHandler) parseID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("pid"), 10, 64)
+	if err != nil {
+		SetFlashMessage(c, ph.cookie, resultIsError, "このプロジェクトは変更できません。")
+		c.Redirect(http.StatusSeeOther, "/projects")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// エラーの内容に応じたメッセージを設定し、プロジェクトの一覧に戻す
+func (ph *ProjectHandler) redirectWithError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, models.ErrInvalidProjectName):
+		msg = projectNameErrorMessage
+	case errors.Is(err, models.ErrInboxProject):
+		msg = "Inboxは名前の変更・削除ができません。"
+	case errors.Is(err, usecases.ErrProjectAlreadyExists):
+		msg = "同じ名前のプロジェクトが既にあります。"
+	default:
+		msg = errorMessage(err, "該当するプロジェクトが見つかりませんでした。", msg)
+	}
+	SetFlashMessage(c, ph.cookie, resultIsError, msg)
+	c.Redirect(http.StatusSeeOther, "/projects")
+}
+
+// 指定されたプロジェクトのtodoの一覧のパスを返す
+func projectTodosPath(id uint) string {
+	return fmt.Sprintf("/projects/%d/todos", id)
+}
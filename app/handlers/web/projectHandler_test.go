@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_usecases "github.com/MinadukiSekina/todo-go-app/app/mock/usecase"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProjectIndex(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockProjectUsecase)
+		want          int
+		wantBody      []string
+	}{
+		"正常ケース:データあり": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().List(testUser.ID).Return(&testProjects, nil)
+			},
+			want: http.StatusOK,
+			// プロジェクトのtodoの一覧へのリンクと、Inbox以外の変更フォーム
+			wantBody: []string{`href="/projects/1/todos"`, `href="/projects/2/todos"`, `action="/projects/2/delete"`},
+		},
+		"異常ケース:エラーあり": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().List(testUser.ID).Return(nil, errors.New("something is wrong"))
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockProjectUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
+			// テンプレートの読み込み
+			// route.goと同じ指定だとエラーになったため、appからのパスで指定する
+			r.LoadHTMLGlob("/app/app/templates/*/*.html")
+
+			// リクエストを設定
+			req, _ := http.NewRequest("GET", "/projects", nil)
+			c.Request = req
+
+			// mockを利用してテストする
+			handler := NewProjectHandler(mock, testCookie)
+			handler.Index(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			for _, s := range tt.wantBody {
+				assert.Contains(t, w.Body.String(), s)
+			}
+			// Inboxは変更できないため、フォームを表示しない
+			assert.NotContains(t, w.Body.String(), `action="/projects/1/delete"`)
+		})
+	}
+}
+
+func TestProjectActions(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	// テスト用の引数を格納する
+	type args struct {
+		id   any
+		form url.Values
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockProjectUsecase)
+		callFn        func(handler *ProjectHandler, c *gin.Context)
+		args          args
+		want          int
+		wantLocation  string
+	}{
+		"正常ケース:作成に成功": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().Add(testUser.ID, "趣味").Return(&models.Project{ID: 3, UserID: testUser.ID, Name: "趣味"}, nil)
+			},
+			callFn:       func(handler *ProjectHandler, c *gin.Context) { handler.Create(c) },
+			args:         args{form: url.Values{"name": {"趣味"}}},
+			want:         http.StatusFound,
+			wantLocation: "/projects/3/todos",
+		},
+		"異常ケース:名前が不正": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().Add(testUser.ID, "Inbox").Return(nil, models.ErrInvalidProjectName)
+			},
+			callFn:       func(handler *ProjectHandler, c *gin.Context) { handler.Create(c) },
+			args:         args{form: url.Values{"name": {"Inbox"}}},
+			want:         http.StatusSeeOther,
+			wantLocation: "/projects",
+		},
+		"正常ケース:名前の変更に成功": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().Rename(testUser.ID, uint(2), "趣味").Return(&models.Project{ID: 2, Name: "趣味"}, nil)
+			},
+			callFn:       func(handler *ProjectHandler, c *gin.Context) { handler.Rename(c) },
+			args:         args{id: 2, form: url.Values{"name": {"趣味"}}},
+			want:         http.StatusFound,
+			wantLocation: "/projects",
+		},
+		"異常ケース:同じ名前のプロジェクトが存在する": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().Rename(testUser.ID, uint(2), "趣味").Return(nil, usecases.ErrProjectAlreadyExists)
+			},
+			callFn:       func(handler *ProjectHandler, c *gin.Context) { handler.Rename(c) },
+			args:         args{id: 2, form: url.Values{"name": {"趣味"}}},
+			want:         http.StatusSeeOther,
+			wantLocation: "/projects",
+		},
+		"異常ケース:IDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			callFn:       func(handler *ProjectHandler, c *gin.Context) { handler.Rename(c) },
+			args:         args{id: "string", form: url.Values{"name": {"趣味"}}},
+			want:         http.StatusSeeOther,
+			wantLocation: "/projects",
+		},
+		"正常ケース:削除に成功": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(2)).Return(nil)
+			},
+			callFn:       func(handler *ProjectHandler, c *gin.Context) { handler.Delete(c) },
+			args:         args{id: 2},
+			want:         http.StatusFound,
+			wantLocation: "/projects",
+		},
+		"異常ケース:Inboxを削除": {
+			prepareMockFn: func(m *mock_usecases.MockProjectUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1)).Return(models.ErrInboxProject)
+			},
+			callFn:       func(handler *ProjectHandler, c *gin.Context) { handler.Delete(c) },
+			args:         args{id: 1},
+			want:         http.StatusSeeOther,
+			wantLocation: "/projects",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockProjectUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
+			// リクエストを設定
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/projects/%v", tt.args.id), strings.NewReader(tt.args.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Request = req
+
+			// パラメータを設定
+			if tt.args.id != nil {
+				c.Params = []gin.Param{{Key: "pid", Value: fmt.Sprint(tt.args.id)}}
+			}
+
+			// mockを利用してテストする
+			handler := NewProjectHandler(mock, testCookie)
+			tt.callFn(&handler, c)
+
+			// POSTの場合はリダイレクトのステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+		})
+	}
+}
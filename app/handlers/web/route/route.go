@@ -1,36 +1,216 @@
 package route
 
 import (
+	"context"
+	"net"
+	"path/filepath"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
 	"github.com/MinadukiSekina/todo-go-app/app/injector"
 	"github.com/gin-gonic/gin"
 )
 
-// ルーティングやミドルウェアの設定を行う
-func SetRouting() {
+// ルーティングやミドルウェアの設定を行い、ctxが終了するまでリクエストを受け付ける
+// 終了時は処理中のリクエストの完了を待ってから、ハンドラーの終了処理を行う
+func SetRouting(ctx context.Context, cfg *config.Config) error {
 	router := gin.Default()
 
 	// HTML・css・jsファイルの読み込み
-	router.LoadHTMLGlob("app/templates/*/*.html")
-	router.Static("/css", "app/static/css/")
-	router.Static("/js", "app/static/js/")
+	router.LoadHTMLGlob(filepath.Join(cfg.Paths.Templates, "*", "*.html"))
+	router.Static("/css", filepath.Join(cfg.Paths.Static, "css"))
+	router.Static("/js", filepath.Join(cfg.Paths.Static, "js"))
 
 	// 各ハンドラーの初期化
-	th := injector.InjectTodoHandler()
-	mh := injector.InjectMainHandler()
-
+	th, err := injector.InjectTodoHandler(cfg)
+	if err != nil {
+		return err
+	}
 	// ハンドラーの終了処理
+	// handler → usecase → repository → SqlHandlerの順に閉じられる
 	defer th.Close()
 
+	mh := injector.InjectMainHandler()
+	ah, err := injector.InjectTodoAPIHandler(cfg)
+	if err != nil {
+		return err
+	}
+	defer ah.Close()
+
+	tg, err := injector.InjectTagHandler(cfg)
+	if err != nil {
+		return err
+	}
+	defer tg.Close()
+	apiTag, err := injector.InjectTagAPIHandler(cfg)
+	if err != nil {
+		return err
+	}
+	defer apiTag.Close()
+
+	ph, err := injector.InjectProjectHandler(cfg)
+	if err != nil {
+		return err
+	}
+	defer ph.Close()
+	apiProject, err := injector.InjectProjectAPIHandler(cfg)
+	if err != nil {
+		return err
+	}
+	defer apiProject.Close()
+
+	ch, err := injector.InjectChecklistHandler(cfg)
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+	apiChecklist, err := injector.InjectChecklistAPIHandler(cfg)
+	if err != nil {
+		return err
+	}
+	defer apiChecklist.Close()
+
+	cm, err := injector.InjectCommentHandler(cfg)
+	if err != nil {
+		return err
+	}
+	defer cm.Close()
+	apiComment, err := injector.InjectCommentAPIHandler(cfg)
+	if err != nil {
+		return err
+	}
+	defer apiComment.Close()
+
+	auth, err := injector.InjectAuthHandler(cfg)
+	if err != nil {
+		return err
+	}
+	defer auth.Close()
+	apiAuth, err := injector.InjectAuthAPIHandler(cfg)
+	if err != nil {
+		return err
+	}
+	defer apiAuth.Close()
+
+	// ゴミ箱の保存期間を過ぎたtodoを定期的に削除する
+	// 終了時はジョブの完了を待ってから、ジョブの終了処理を行う
+	purger, err := injector.InjectTrashPurger(cfg)
+	if err != nil {
+		return err
+	}
+	defer purger.Close()
+	jobCtx, cancelJob := context.WithCancel(ctx)
+	jobDone := make(chan struct{})
+	go func() {
+		defer close(jobDone)
+		purger.Run(jobCtx)
+	}()
+	defer func() {
+		cancelJob()
+		<-jobDone
+	}()
+
 	// ルーティングの設定
 	router.GET("/", mh.Index)
 
-	router.GET("/todo", th.Index)
-	router.POST("/todo", th.Create)
+	// ログイン・ユーザー登録（ログインしていなくてもアクセスできる）
+	// セッションが無いため、cookieに保存したトークンでCSRFを検証する
+	guest := router.Group("/", auth.VerifyPreSessionCSRF)
+	guest.GET("/login", auth.LoginForm)
+	guest.POST("/login", auth.Login)
+	guest.GET("/signup", auth.SignupForm)
+	guest.POST("/signup", auth.Signup)
+
+	// ログインが必要な画面
+	// 状態を変更するリクエストは、CSRF対策用のトークンを検証する
+	web := router.Group("/", auth.RequireLogin, auth.VerifyCSRF)
+	web.POST("/logout", auth.Logout)
+	web.GET("/todo", th.Index)
+	web.POST("/todo", th.Create)
+	web.POST("/todo/preview", th.Preview)
+	web.POST("/todo/bulk", th.Bulk)
+	web.GET("/todo/trash", th.Trash)
+
+	web.GET("/todo/:id", th.ShowById)
+	web.POST("/todo/:id", th.Update)
+	web.POST("/todo/:id/delete", th.Delete)
+	web.POST("/todo/:id/restore", th.Restore)
+	web.POST("/todo/:id/purge", th.Purge)
+	web.POST("/todo/:id/move", th.Move)
+	web.GET("/todo/:id/attachments/:attachment_id", th.Download)
+	web.POST("/todo/:id/attachments/:attachment_id/delete", th.DeleteAttachment)
+
+	// 添付ファイルのアップロード
+	// 大きすぎるリクエストは、CSRF対策用のトークンの検証で本文を読み込む前に拒否する
+	router.POST("/todo/:id/attachments", auth.RequireLogin, th.LimitUploadSize, auth.VerifyCSRF, th.Upload)
+
+	web.GET("/tags", tg.Index)
+	web.POST("/tags/:id", tg.Rename)
+	web.POST("/tags/:id/merge", tg.Merge)
+	web.POST("/tags/:id/delete", tg.Delete)
+
+	web.POST("/todo/:id/checklist", ch.Create)
+	web.POST("/todo/:id/checklist/:item_id/toggle", ch.Toggle)
+	web.POST("/todo/:id/checklist/:item_id/move", ch.Move)
+	web.POST("/todo/:id/checklist/:item_id/delete", ch.Delete)
+
+	web.POST("/todo/:id/comments", cm.Create)
+	web.POST("/todo/:id/comments/:comment_id", cm.Update)
+	web.POST("/todo/:id/comments/:comment_id/delete", cm.Delete)
+
+	web.GET("/projects", ph.Index)
+	web.POST("/projects", ph.Create)
+	web.POST("/projects/:pid", ph.Rename)
+	web.POST("/projects/:pid/delete", ph.Delete)
+	web.GET("/projects/:pid/todos", th.Index)
+	web.POST("/projects/:pid/todos", th.Create)
+	web.POST("/projects/:pid/todos/bulk", th.Bulk)
+
+	// JSON APIのルーティング
+	v1 := router.Group("/api/v1")
+	v1.POST("/sessions", apiAuth.Login)
+
+	// ログインが必要なAPI
+	authorized := v1.Group("", apiAuth.RequireToken)
+	authorized.DELETE("/sessions", apiAuth.Logout)
+	authorized.GET("/todos", ah.Index)
+	authorized.POST("/todos", ah.Create)
+	authorized.POST("/todos/bulk", ah.Bulk)
+	authorized.GET("/todos/:id", ah.Show)
+	authorized.PUT("/todos/:id", ah.Update)
+	authorized.PATCH("/todos/:id", ah.Patch)
+	authorized.DELETE("/todos/:id", ah.Delete)
+	authorized.POST("/todos/:id/attachments", ah.Upload)
+	authorized.GET("/todos/:id/attachments/:attachment_id", ah.Download)
+	authorized.DELETE("/todos/:id/attachments/:attachment_id", ah.DeleteAttachment)
+	authorized.GET("/todos/:id/history", ah.History)
+	authorized.GET("/trash", ah.Trash)
+	authorized.POST("/trash/:id/restore", ah.Restore)
+	authorized.DELETE("/trash/:id", ah.Purge)
+	authorized.GET("/tags", apiTag.Index)
+	authorized.PATCH("/tags/:id", apiTag.Rename)
+	authorized.POST("/tags/:id/merge", apiTag.Merge)
+	authorized.DELETE("/tags/:id", apiTag.Delete)
+	authorized.GET("/todos/:id/checklist", apiChecklist.Index)
+	authorized.POST("/todos/:id/checklist", apiChecklist.Create)
+	authorized.PATCH("/todos/:id/checklist/:item_id", apiChecklist.Update)
+	authorized.DELETE("/todos/:id/checklist/:item_id", apiChecklist.Delete)
 
-	router.GET("/todo/:id", th.ShowById)
-	router.POST("/todo/:id", th.Update)
-	router.POST("/todo/:id/delete", th.Delete)
+	authorized.GET("/todos/:id/comments", apiComment.Index)
+	authorized.POST("/todos/:id/comments", apiComment.Create)
+	authorized.PATCH("/todos/:id/comments/:comment_id", apiComment.Update)
+	authorized.DELETE("/todos/:id/comments/:comment_id", apiComment.Delete)
+	authorized.GET("/projects", apiProject.Index)
+	authorized.POST("/projects", apiProject.Create)
+	authorized.GET("/projects/:pid", apiProject.Show)
+	authorized.PATCH("/projects/:pid", apiProject.Update)
+	authorized.DELETE("/projects/:pid", apiProject.Delete)
+	authorized.GET("/projects/:pid/todos", ah.Index)
+	authorized.POST("/projects/:pid/todos", ah.Create)
 
 	// 待機開始
-	router.Run(":3000")
+	ln, err := net.Listen("tcp", cfg.Server.Addr)
+	if err != nil {
+		return err
+	}
+	return serve(ctx, newServer(cfg.Server, router), ln, cfg.Server.ShutdownTimeout.Duration)
 }
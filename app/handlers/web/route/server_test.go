@@ -0,0 +1,89 @@
+package route
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServe(t *testing.T) {
+
+	cases := map[string]struct {
+		// ハンドラーの処理にかかる時間
+		handlerDelay    time.Duration
+		shutdownTimeout time.Duration
+		expectErr       bool
+		wantStatus      int
+	}{
+		"正常ケース:処理中のリクエストの完了を待って終了する": {
+			handlerDelay:    200 * time.Millisecond,
+			shutdownTimeout: 5 * time.Second,
+			expectErr:       false,
+			wantStatus:      http.StatusOK,
+		},
+		"異常ケース:制限時間内にリクエストが完了しない": {
+			handlerDelay:    2 * time.Second,
+			shutdownTimeout: 50 * time.Millisecond,
+			expectErr:       true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			// リクエストの処理が始まったことを通知するチャネル
+			started := make(chan struct{})
+			mux := http.NewServeMux()
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				close(started)
+				time.Sleep(tt.handlerDelay)
+				w.WriteHeader(http.StatusOK)
+			})
+
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("failed to listen: %v", err)
+			}
+			srv := &http.Server{Handler: mux}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// サーバーを起動する
+			serveErr := make(chan error, 1)
+			go func() { serveErr <- serve(ctx, srv, ln, tt.shutdownTimeout) }()
+
+			// リクエストを送信する
+			resCh := make(chan *http.Response, 1)
+			go func() {
+				res, err := http.Get("http://" + ln.Addr().String() + "/")
+				if err != nil {
+					resCh <- nil
+					return
+				}
+				res.Body.Close()
+				resCh <- res
+			}()
+
+			// 処理中に終了を指示する
+			<-started
+			cancel()
+
+			err = <-serveErr
+			res := <-resCh
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.ErrorIs(t, err, context.DeadlineExceeded)
+				return
+			}
+			assert.NoError(t, err)
+			if assert.NotNil(t, res) {
+				assert.Equal(t, tt.wantStatus, res.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+// タグの名前が不正な場合のメッセージ
+var tagNameErrorMessage = fmt.Sprintf("タグの名前は%d文字以内で、カンマを含めずに入力してください。", models.TagNameMaxLength)
+
+// tagsパスへのリクエストに対するハンドラーの構造体
+type TagHandler struct {
+	tagUsecase usecases.TagUsecase
+	cookie     config.CookieConfig
+}
+
+// TagHandlerの新しいインスタンスを作成して返す
+func NewTagHandler(uc usecases.TagUsecase, cookie config.CookieConfig) TagHandler {
+	tagHandler := TagHandler{tagUsecase: uc, cookie: cookie}
+	return tagHandler
+}
+
+// タグの一覧を表示する
+func (th *TagHandler) Index(c *gin.Context) {
+	tags, err := th.tagUsecase.List(currentUserID(c))
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+
+	// Get flash message content if it exists
+	fm := GetFlashMessage(c)
+
+	c.HTML(http.StatusOK, "tag/index.html", gin.H{
+		"tags":       tags,
+		"user":       CurrentUser(c),
+		"csrfToken":  CSRFToken(c),
+		flashMessage: fm.Message,
+		flashType:    fm.Type,
+	})
+}
+
+// タグの名前を変更する
+func (th *TagHandler) Rename(c *gin.Context) {
+	id, ok := th.parseID(c)
+	if !ok {
+		return
+	}
+	_, err := th.tagUsecase.Rename(currentUserID(c), id, c.PostForm("name"))
+	switch {
+	case err == nil:
+		SetFlashMessage(c, th.cookie, resultIsSuccess, "タグの名前を変更しました。")
+		c.Redirect(http.StatusFound, "/tags")
+	case errors.Is(err, models.ErrInvalidTagName):
+		th.redirectWithError(c, tagNameErrorMessage)
+	case errors.Is(err, usecases.ErrTagAlreadyExists):
+		th.redirectWithError(c, "同じ名前のタグが既にあります。まとめる場合は統合を使用してください。")
+	default:
+		th.redirectWithError(c, errorMessage(err, tagNotFoundMessage, "タグの名前を変更できませんでした。"))
+	}
+}
+
+// タグを別のタグに統合する
+func (th *TagHandler) Merge(c *gin.Context) {
+	id, ok := th.parseID(c)
+	if !ok {
+		return
+	}
+	into, err := strconv.ParseUint(c.PostForm("into"), 10, 64)
+	if err != nil {
+		th.redirectWithError(c, "統合先のタグを選択してください。")
+		return
+	}
+	err = th.tagUsecase.Merge(currentUserID(c), id, uint(into))
+	if err != nil {
+		th.redirectWithError(c, errorMessage(err, tagNotFoundMessage, "タグを統合できませんでした。"))
+		return
+	}
+	SetFlashMessage(c, th.cookie, resultIsSuccess, "タグを統合しました。")
+	c.Redirect(http.StatusFound, "/tags")
+}
+
+// タグを削除する
+// タグが付いていたtodoは削除せず、タグだけを外す
+func (th *TagHandler) Delete(c *gin.Context) {
+	id, ok := th.parseID(c)
+	if !ok {
+		return
+	}
+	err := th.tagUsecase.Delete(currentUserID(c), id)
+	if err != nil {
+		th.redirectWithError(c, errorMessage(err, tagNotFoundMessage, "タグを削除できませんでした。"))
+		return
+	}
+	SetFlashMessage(c, th.cookie, resultIsSuccess, "タグを削除しました。")
+	c.Redirect(http.StatusFound, "/tags")
+}
+
+// 終了処理を行う
+func (th *TagHandler) Close() {
+	err := th.tagUsecase.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// パスパラメータのIDを数値に変換する
+// 変換できない場合はタグの一覧に戻し、falseを返す
+func (th *TagHandler) parseID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		th.redirectWithError(c, "このタグは変更できません。")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// 該当するタグが無い場合のメッセージ
+const tagNotFoundMessage = "該当するタグが見つかりませんでした。"
+
+// エラーのメッセージを設定し、タグの一覧に戻す
+func (th *TagHandler) redirectWithError(c *gin.Context, msg string) {
+	SetFlashMessage(c, th.cookie, resultIsError, msg)
+	c.Redirect(http.StatusSeeOther, "/tags")
+}
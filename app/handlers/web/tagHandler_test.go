@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_usecases "github.com/MinadukiSekina/todo-go-app/app/mock/usecase"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTagIndex(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	tags := []models.Tag{
+		{ID: 1, UserID: testUser.ID, Name: "backend", TodoCount: 2},
+		{ID: 2, UserID: testUser.ID, Name: "買い物", TodoCount: 0},
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTagUsecase)
+		want          int
+		wantBody      []string
+	}{
+		"正常ケース:データあり": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().List(testUser.ID).Return(&tags, nil)
+			},
+			want: http.StatusOK,
+			// タグで絞り込んだ一覧へのリンクと、統合先の選択肢
+			wantBody: []string{`href="/todo?tag=backend"`, `href="/todo?tag=%e8%b2%b7%e3%81%84%e7%89%a9"`, `<option value="2">`},
+		},
+		"正常ケース:データなし": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().List(testUser.ID).Return(&[]models.Tag{}, nil)
+			},
+			want:     http.StatusOK,
+			wantBody: []string{"タグはありません。"},
+		},
+		"異常ケース:エラーあり": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().List(testUser.ID).Return(nil, errors.New("something is wrong"))
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTagUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
+			// テンプレートの読み込み
+			// route.goと同じ指定だとエラーになったため、appからのパスで指定する
+			r.LoadHTMLGlob("/app/app/templates/*/*.html")
+
+			// リクエストを設定
+			req, _ := http.NewRequest("GET", "/tags", nil)
+			c.Request = req
+
+			// mockを利用してテストする
+			handler := NewTagHandler(mock, testCookie)
+			handler.Index(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			for _, s := range tt.wantBody {
+				assert.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestTagActions(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	// テスト用の引数を格納する
+	type args struct {
+		id   any
+		form url.Values
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTagUsecase)
+		callFn        func(handler *TagHandler, c *gin.Context)
+		args          args
+		want          int
+	}{
+		"正常ケース:名前の変更に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Rename(testUser.ID, uint(1), "frontend").Return(&models.Tag{ID: 1, Name: "frontend"}, nil)
+			},
+			callFn: func(handler *TagHandler, c *gin.Context) { handler.Rename(c) },
+			args:   args{id: 1, form: url.Values{"name": {"frontend"}}},
+			want:   http.StatusFound,
+		},
+		"異常ケース:同じ名前のタグが存在する": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Rename(testUser.ID, uint(1), "urgent").Return(nil, usecases.ErrTagAlreadyExists)
+			},
+			callFn: func(handler *TagHandler, c *gin.Context) { handler.Rename(c) },
+			args:   args{id: 1, form: url.Values{"name": {"urgent"}}},
+			want:   http.StatusSeeOther,
+		},
+		"異常ケース:IDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			callFn: func(handler *TagHandler, c *gin.Context) { handler.Rename(c) },
+			args:   args{id: "string", form: url.Values{"name": {"frontend"}}},
+			want:   http.StatusSeeOther,
+		},
+		"正常ケース:統合に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Merge(testUser.ID, uint(1), uint(2)).Return(nil)
+			},
+			callFn: func(handler *TagHandler, c *gin.Context) { handler.Merge(c) },
+			args:   args{id: 1, form: url.Values{"into": {"2"}}},
+			want:   http.StatusFound,
+		},
+		"異常ケース:統合先が不正": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			callFn: func(handler *TagHandler, c *gin.Context) { handler.Merge(c) },
+			args:   args{id: 1, form: url.Values{"into": {""}}},
+			want:   http.StatusSeeOther,
+		},
+		"正常ケース:削除に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1)).Return(nil)
+			},
+			callFn: func(handler *TagHandler, c *gin.Context) { handler.Delete(c) },
+			args:   args{id: 1},
+			want:   http.StatusFound,
+		},
+		"異常ケース:削除に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTagUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1)).Return(errors.New("something is wrong"))
+			},
+			callFn: func(handler *TagHandler, c *gin.Context) { handler.Delete(c) },
+			args:   args{id: 1},
+			want:   http.StatusSeeOther,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTagUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
+			// リクエストを設定
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/tags/%v", tt.args.id), strings.NewReader(tt.args.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Request = req
+
+			// パラメータを設定
+			c.Params = []gin.Param{{Key: "id", Value: fmt.Sprint(tt.args.id)}}
+
+			// mockを利用してテストする
+			handler := NewTagHandler(mock, testCookie)
+			tt.callFn(&handler, c)
+
+			// POSTの場合はリダイレクトのステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, "/tags", w.Header().Get("Location"))
+		})
+	}
+}
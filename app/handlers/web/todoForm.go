@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/gin-gonic/gin"
+)
+
+// 入力内容に誤りがある場合のメッセージ
+const invalidFormMessage = "入力内容を確認してください。"
+
+// タイトルが長すぎる場合のメッセージ
+var titleTooLongMessage = fmt.Sprintf("タスクのタイトルは%d文字以内で入力してください。", models.TitleMaxLength)
+
+// todoの作成・更新のフォームの入力内容
+// 入力に誤りがある場合は、入力した値のまま項目ごとのエラーのメッセージを添えてフォームを再表示する
+type todoForm struct {
+	Title          string
+	Description    string
+	DueDate        string
+	DueTime        string
+	Recurrence     string
+	RecurrenceRule string
+	Priority       string
+	Tags           string
+	ProjectID      string
+	// 項目の名前（models.FieldTitleなど）ごとのエラーのメッセージ
+	Errors map[string]string
+}
+
+// 新規作成の初期状態のフォームを返す
+func newTodoForm() todoForm {
+	return todoForm{Priority: models.PriorityMedium.Key(), Errors: map[string]string{}}
+}
+
+// リクエストのフォームの入力内容を読み込む
+func todoFormFromRequest(c *gin.Context) todoForm {
+	return todoForm{
+		Title:          c.PostForm("title"),
+		Description:    c.PostForm("description"),
+		DueDate:        c.PostForm("due_date"),
+		DueTime:        c.PostForm("due_time"),
+		Recurrence:     c.PostForm("recurrence"),
+		RecurrenceRule: c.PostForm("recurrence_rule"),
+		Priority:       c.PostForm("priority"),
+		Tags:           c.PostForm("tags"),
+		ProjectID:      c.PostForm("project_id"),
+		Errors:         map[string]string{},
+	}
+}
+
+// 項目のエラーのメッセージを設定する（既に設定されている場合は最初のものを残す）
+func (f *todoForm) addError(field string, msg string) {
+	if _, ok := f.Errors[field]; !ok {
+		f.Errors[field] = msg
+	}
+}
+
+// ドメインの検証エラーを、項目ごとのエラーのメッセージとして設定する
+// 項目ごとのエラー（models.FieldErrors）でない場合はfalseを返す
+func (f *todoForm) addFieldErrors(err error) bool {
+	var errs models.FieldErrors
+	if !errors.As(err, &errs) {
+		return false
+	}
+	for _, e := range errs {
+		f.addError(e.Field, todoFieldMessage(e.Field, e.Err))
+	}
+	return true
+}
+
+// 入力に誤りがあるかどうか
+func (f todoForm) HasErrors() bool {
+	return len(f.Errors) > 0
+}
+
+// タイトル・説明の検証エラーに応じたメッセージを返す
+func todoFieldMessage(field string, err error) string {
+	switch {
+	case errors.Is(err, models.ErrTitleRequired):
+		return "タスクのタイトルを入力してください。"
+	case errors.Is(err, models.ErrTitleTooLong):
+		return titleTooLongMessage
+	case errors.Is(err, models.ErrDescriptionTooLong):
+		return descriptionErrorMessage
+	case errors.Is(err, models.ErrControlCharacter) && field == models.FieldDescription:
+		return "タスクの説明に、改行・タブ以外の制御文字は使用できません。"
+	case errors.Is(err, models.ErrControlCharacter):
+		return "タスクのタイトルに、改行やタブなどの制御文字は使用できません。"
+	case errors.Is(err, models.ErrOpenChecklistItems):
+		return "チェックリストに未完了の項目が残っているため、完了にできません。"
+	case errors.Is(err, models.ErrInvalidStatusTransition):
+		return "現在の状態からは変更できません。"
+	case errors.Is(err, models.ErrInvalidInitialStatus):
+		return "完了・中止の状態では作成できません。"
+	default:
+		return "入力内容が不正です。"
+	}
+}
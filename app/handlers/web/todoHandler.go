@@ -1,51 +1,98 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
-	"sort"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/MinadukiSekina/todo-go-app/app/config"
 	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/handlers/httperr"
 	"github.com/MinadukiSekina/todo-go-app/app/usecases"
 	"github.com/gin-gonic/gin"
 )
 
 // todoパスへのリクエストに対するハンドラーの構造体
 type TodoHandler struct {
-	todoUsecase usecases.TodoUsecase
+	todoUsecase    usecases.TodoUsecase
+	tagUsecase     usecases.TagUsecase
+	projectUsecase usecases.ProjectUsecase
+	cookie         config.CookieConfig
 }
 
 // TodoHandlerの新しいインスタンスを作成して返す
-func NewTodoHandler(uc usecases.TodoUsecase) TodoHandler {
-	todoHandler := TodoHandler{todoUsecase: uc}
+func NewTodoHandler(uc usecases.TodoUsecase, tagUc usecases.TagUsecase, projectUc usecases.ProjectUsecase, cookie config.CookieConfig) TodoHandler {
+	todoHandler := TodoHandler{todoUsecase: uc, tagUsecase: tagUc, projectUsecase: projectUc, cookie: cookie}
 	return todoHandler
 }
 
 // todoの一覧を表示する
+// /projects/:pid/todosの場合は、指定されたプロジェクトのtodoのみを表示する
+// クエリパラメータで検索・絞り込み・並び替え・ページの指定ができる（models.ParseTodoQueryを参照）
 func (th *TodoHandler) Index(c *gin.Context) {
-	todos, err := th.todoUsecase.Show()
+	project, basePath, ok := th.currentProject(c)
+	if !ok {
+		return
+	}
+	query, err := models.ParseTodoQuery(c.Request.URL.Query())
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error/error.html", gin.H{
-			"message": err.Error(),
-		})
+		SetFlashMessage(c, th.cookie, resultIsError, "検索条件が不正な値です。")
+		c.Redirect(http.StatusSeeOther, basePath)
 		return
 	}
+	th.renderIndex(c, http.StatusOK, project, basePath, query, newTodoForm(), GetFlashMessage(c))
+}
 
-	// Sort todos by status in ascending order
-	sort.Slice(*todos, func(i, j int) bool {
-		return (*todos)[i].Status < (*todos)[j].Status
-	})
+// todoの一覧と新規作成のフォームを表示する
+// 新規作成の入力に誤りがある場合は、入力した値とエラーのメッセージをformで渡す
+func (th *TodoHandler) renderIndex(c *gin.Context, code int, project *models.Project, basePath string, query models.TodoQuery, form todoForm, fm FlashMessage) {
+	query.UserID = currentUserID(c)
+	if project != nil {
+		query.ProjectID = project.ID
+	}
 
-	// Get flash message content if it exists
-	fm := GetFlashMessage(c)
+	page, err := th.todoUsecase.Search(query)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	// 絞り込み用のタグの一覧
+	tags, err := th.tagUsecase.List(query.UserID)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	// 切り替え用のプロジェクトの一覧
+	projects, err := th.projectUsecase.List(query.UserID)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
 
-	c.HTML(http.StatusOK, "todo/index.html", gin.H{
-		"todos":      todos,
-		"NotStarted": models.NotStarted,
-		"Done":       models.Done,
-		flashMessage: fm.Message,
-		flashType:    fm.Type,
+	c.HTML(code, "todo/index.html", gin.H{
+		"todos":       page.Todos,
+		"page":        page,
+		"query":       query,
+		"statuses":    models.Statuses(),
+		"priorities":  models.Priorities(),
+		"recurrences": models.RecurrencePresets(),
+		"bulkActions": models.BulkActions(),
+		"tags":        tags,
+		"project":     project,
+		"projects":    projects,
+		"basePath":    basePath,
+		"user":        CurrentUser(c),
+		"due":         string(query.Due),
+		"now":         time.Now(),
+		"form":        form,
+		"csrfToken":   CSRFToken(c),
+		flashMessage:  fm.Message,
+		flashType:     fm.Type,
+		flashRestore:  fm.Restore,
 	})
 }
 
@@ -54,13 +101,13 @@ func (th *TodoHandler) ShowById(c *gin.Context) {
 	id_s := c.Param("id")
 	id, err := strconv.ParseUint(id_s, 10, 64)
 	if err != nil {
-		SetFlashMessage(c, resultIsError, "このタスクは閲覧できません。")
+		SetFlashMessage(c, th.cookie, resultIsError, "このタスクは閲覧できません。")
 		c.Redirect(http.StatusSeeOther, "/todo")
 		return
 	}
-	todo, err := th.todoUsecase.SearchByID(uint(id))
+	todo, err := th.todoUsecase.SearchByID(currentUserID(c), uint(id))
 	if err != nil {
-		SetFlashMessage(c, resultIsError, "該当するタスクが見つかりませんでした。")
+		SetFlashMessage(c, th.cookie, resultIsError, errorMessage(err, "該当するタスクが見つかりませんでした。", "タスクを表示できませんでした。"))
 		c.Redirect(http.StatusSeeOther, "/todo")
 		return
 	}
@@ -68,70 +115,227 @@ func (th *TodoHandler) ShowById(c *gin.Context) {
 	// Get flash message content if it exists
 	fm := GetFlashMessage(c)
 
-	c.HTML(http.StatusOK, "todo/show.html", gin.H{
-		"todo":       todo,
-		"NotStarted": models.NotStarted,
-		"Done":       models.Done,
+	th.renderShow(c, http.StatusOK, todo, gin.H{
 		flashMessage: fm.Message,
 		flashType:    fm.Type,
 	})
 }
 
 // todoを新規作成する
+// /projects/:pid/todosの場合は指定されたプロジェクトに、それ以外はフォームで選択されたプロジェクト（未選択の場合はInbox）に作成する
+// 入力に誤りがある場合は、入力した値と項目ごとのエラーを添えて一覧の画面を再表示する
 func (th *TodoHandler) Create(c *gin.Context) {
-	title := c.PostForm("title")
-	todo := models.Todo{Title: title, Status: models.NotStarted}
+	project, basePath, ok := th.currentProject(c)
+	if !ok {
+		return
+	}
+	form := todoFormFromRequest(c)
+	todo := models.Todo{UserID: currentUserID(c), Status: models.NotStarted}
+	if err := todo.SetTitle(form.Title); err != nil {
+		form.addError(models.FieldTitle, todoFieldMessage(models.FieldTitle, err))
+	}
+	if project != nil {
+		todo.ProjectID = project.ID
+	} else if todo.ProjectID, ok = parseOptionalID(form.ProjectID); !ok {
+		form.addError(models.FieldProject, "プロジェクトが不正な値です。")
+	}
+	if todo.Priority, ok = parseOptionalPriority(form.Priority, models.PriorityMedium); !ok {
+		form.addError(models.FieldPriority, priorityErrorMessage)
+	}
+	if err := todo.SetDue(form.DueDate, form.DueTime, c.PostForm("due_timezone")); err != nil {
+		form.addError(models.FieldDue, dueErrorMessage)
+	}
+	if err := setFormRecurrence(c, &todo); err != nil {
+		form.addError(models.FieldRecurrence, recurrenceErrorMessage)
+	}
+	tags := models.ParseTagNames(form.Tags)
+	if err := models.ValidateTagNames(tags); err != nil {
+		form.addError(models.FieldTags, tagNameErrorMessage)
+	}
+	if form.HasErrors() {
+		th.showCreateErrors(c, project, basePath, form)
+		return
+	}
 	err := th.todoUsecase.Add(&todo)
+	if errors.Is(err, usecases.ErrProjectNotFound) {
+		form.addError(models.FieldProject, "指定されたプロジェクトが見つかりませんでした。")
+		th.showCreateErrors(c, project, basePath, form)
+		return
+	}
+	if form.addFieldErrors(err) {
+		th.showCreateErrors(c, project, basePath, form)
+		return
+	}
 	if err != nil {
-		SetFlashMessage(c, resultIsError, "新しいタスクの作成に失敗しました。")
-		c.Redirect(http.StatusSeeOther, "/todo")
+		SetFlashMessage(c, th.cookie, resultIsError, errorMessage(err, "新しいタスクの作成に失敗しました。", "新しいタスクの作成に失敗しました。"))
+		c.Redirect(http.StatusSeeOther, basePath)
 		return
 	}
-	SetFlashMessage(c, resultIsSuccess, "新しいタスクを作成しました。")
-	c.Redirect(http.StatusFound, "/todo")
+	if len(tags) > 0 {
+		if err := th.todoUsecase.SetTags(todo.UserID, todo.ID, tags); err != nil {
+			slog.Error(err.Error())
+			SetFlashMessage(c, th.cookie, resultIsError, "新しいタスクを作成しましたが、タグを付けられませんでした。")
+			c.Redirect(http.StatusSeeOther, basePath)
+			return
+		}
+	}
+	SetFlashMessage(c, th.cookie, resultIsSuccess, "新しいタスクを作成しました。")
+	c.Redirect(http.StatusFound, basePath)
+}
+
+// 新規作成の入力に誤りがある場合に、入力した値と項目ごとのエラーを添えて一覧の画面を表示する
+// 一覧は検索条件を指定しない状態で表示する
+func (th *TodoHandler) showCreateErrors(c *gin.Context, project *models.Project, basePath string, form todoForm) {
+	query, _ := models.ParseTodoQuery(nil)
+	th.renderIndex(c, http.StatusUnprocessableEntity, project, basePath, query, form, FlashMessage{Message: invalidFormMessage, Type: resultIsError})
 }
 
+// todoを更新する
+// 入力に誤りがある場合は、入力した値と項目ごとのエラーを添えて詳細画面を再表示する
 func (th *TodoHandler) Update(c *gin.Context) {
 	id_s := c.Param("id")
 	id, err := strconv.ParseUint(id_s, 10, 64)
 	if err != nil {
-		SetFlashMessage(c, resultIsError, "このタスクは更新できません。")
+		SetFlashMessage(c, th.cookie, resultIsError, "このタスクは更新できません。")
+		c.Redirect(http.StatusSeeOther, "/todo")
+		return
+	}
+
+	// 既存のTodoを取得
+	existingTodo, err := th.todoUsecase.SearchByID(currentUserID(c), uint(id))
+	if err != nil {
+		SetFlashMessage(c, th.cookie, resultIsError, errorMessage(err, "対象となるタスクが存在しません。", "タスクの内容を更新できませんでした。"))
 		c.Redirect(http.StatusSeeOther, "/todo")
 		return
 	}
-	title := c.PostForm("title")
-	status_s := c.PostForm("status")
 
+	// 画面を表示した時点のバージョン（指定が無い場合は競合を確認しない）
+	if version := c.PostForm("version"); version != "" {
+		v, err := strconv.ParseUint(version, 10, 64)
+		if err != nil {
+			SetFlashMessage(c, th.cookie, resultIsError, "タスクのバージョンが不正な値です。")
+			c.Redirect(http.StatusSeeOther, "/todo/"+id_s)
+			return
+		}
+		existingTodo.Version = uint(v)
+	}
+
+	form := todoFormFromRequest(c)
+	if err := existingTodo.SetTitle(form.Title); err != nil {
+		form.addError(models.FieldTitle, todoFieldMessage(models.FieldTitle, err))
+	}
+	if err := existingTodo.SetDescription(form.Description); err != nil {
+		form.addError(models.FieldDescription, todoFieldMessage(models.FieldDescription, err))
+	}
+	if projectID, ok := parseOptionalID(form.ProjectID); !ok {
+		form.addError(models.FieldProject, "プロジェクトが不正な値です。")
+	} else if projectID != 0 {
+		existingTodo.ProjectID = projectID
+	}
+	// 優先度は未選択の場合は変更しない
+	if priority, ok := parseOptionalPriority(form.Priority, existingTodo.Priority); ok {
+		existingTodo.Priority = priority
+	} else {
+		form.addError(models.FieldPriority, priorityErrorMessage)
+	}
 	// 文字列のstatusをStatus型に変換
-	var correspond = map[string]models.Status{
-		"notStarted": models.NotStarted,
-		"completed":  models.Done,
+	if status, err := models.StrToStatus(c.PostForm("status"), models.StatusCorrespond()); err != nil {
+		form.addError(models.FieldStatus, "タスクの状態が不正な値です。")
+	} else if err := existingTodo.ChangeStatus(status); errors.Is(err, models.ErrOpenChecklistItems) {
+		form.addError(models.FieldStatus, fmt.Sprintf("チェックリストに未完了の項目が残っているため、完了にできません（%d/%d完了）。", existingTodo.ChecklistDoneCount(), len(existingTodo.ChecklistItems)))
+	} else if err != nil {
+		form.addError(models.FieldStatus, "現在の状態からは「"+status.Label()+"」に変更できません。")
+	}
+	if err := existingTodo.SetDue(form.DueDate, form.DueTime, c.PostForm("due_timezone")); err != nil {
+		form.addError(models.FieldDue, dueErrorMessage)
+	}
+	if err := setFormRecurrence(c, existingTodo); err != nil {
+		form.addError(models.FieldRecurrence, recurrenceErrorMessage)
+	}
+	tags := models.ParseTagNames(form.Tags)
+	if err := models.ValidateTagNames(tags); err != nil {
+		form.addError(models.FieldTags, tagNameErrorMessage)
+	}
+	if form.HasErrors() {
+		th.showUpdateErrors(c, existingTodo, form)
+		return
+	}
+	err = th.todoUsecase.Edit(existingTodo)
+	if errors.Is(err, usecases.ErrProjectNotFound) {
+		form.addError(models.FieldProject, "移動先のプロジェクトが見つかりませんでした。")
+		th.showUpdateErrors(c, existingTodo, form)
+		return
+	}
+	if form.addFieldErrors(err) {
+		th.showUpdateErrors(c, existingTodo, form)
+		return
+	}
+	var conflict *models.TodoConflictError
+	if errors.As(err, &conflict) {
+		th.showConflict(c, existingTodo, conflict)
+		return
 	}
-	status, err := models.StrToStatus(status_s, correspond)
 	if err != nil {
-		SetFlashMessage(c, resultIsError, "タスクの状態が不正な値です。")
+		SetFlashMessage(c, th.cookie, resultIsError, errorMessage(err, "対象となるタスクが存在しません。", "タスクの内容を更新できませんでした。"))
 		c.Redirect(http.StatusSeeOther, "/todo/"+id_s)
 		return
 	}
-
-	// 既存のTodoを取得
-	existingTodo, err := th.todoUsecase.SearchByID(uint(id))
+	err = th.todoUsecase.SetTags(currentUserID(c), uint(id), tags)
 	if err != nil {
-		SetFlashMessage(c, resultIsError, "対象となるタスクが存在しません。")
-		c.Redirect(http.StatusSeeOther, "/todo")
+		SetFlashMessage(c, th.cookie, resultIsError, errorMessage(err, "対象となるタスクが存在しません。", "タスクのタグを更新できませんでした。"))
+		c.Redirect(http.StatusSeeOther, "/todo/"+id_s)
 		return
 	}
+	SetFlashMessage(c, th.cookie, resultIsSuccess, "タスクの内容を更新しました。")
+	c.Redirect(http.StatusFound, "/todo/"+id_s)
+}
 
-	existingTodo.Title = title
-	existingTodo.Status = status
-	err = th.todoUsecase.Edit(existingTodo)
+// 更新の入力に誤りがある場合に、入力した値と項目ごとのエラーを添えて詳細画面を表示する
+func (th *TodoHandler) showUpdateErrors(c *gin.Context, todo *models.Todo, form todoForm) {
+	th.renderShow(c, http.StatusUnprocessableEntity, todo, gin.H{
+		"form":       form,
+		"errors":     form.Errors,
+		flashMessage: invalidFormMessage,
+		flashType:    resultIsError,
+	})
+}
+
+// 更新が競合した場合に、保存されている内容と入力した内容を並べて詳細画面を表示する
+// 入力した内容は残し、最新のバージョンで再度更新（上書き）できるようにする
+func (th *TodoHandler) showConflict(c *gin.Context, todo *models.Todo, conflict *models.TodoConflictError) {
+	todo.Version = conflict.Current.Version
+	th.renderShow(c, http.StatusConflict, todo, gin.H{
+		"conflicts":  conflict.Changes,
+		flashMessage: "他のユーザーがこのタスクを変更したため、更新できませんでした。",
+		flashType:    resultIsError,
+	})
+}
+
+// todoの詳細画面を表示する
+// extraで、フラッシュメッセージや競合・入力エラーの内容などを追加で渡す
+// 入力エラーが無い場合も、テンプレートで参照できるように空のerrorsを渡す
+func (th *TodoHandler) renderShow(c *gin.Context, code int, todo *models.Todo, extra gin.H) {
+	// 移動先のプロジェクトの一覧
+	projects, err := th.projectUsecase.List(currentUserID(c))
 	if err != nil {
-		SetFlashMessage(c, resultIsError, "タスクの内容を更新できませんでした。")
-		c.Redirect(http.StatusSeeOther, "/todo/"+id_s)
+		renderError(c, err)
 		return
 	}
-	SetFlashMessage(c, resultIsSuccess, "タスクの内容を更新しました。")
-	c.Redirect(http.StatusFound, "/todo/"+id_s)
+
+	data := gin.H{
+		"todo":        todo,
+		"projects":    projects,
+		"user":        CurrentUser(c),
+		"statuses":    models.Statuses(),
+		"priorities":  models.Priorities(),
+		"recurrences": models.RecurrencePresets(),
+		"csrfToken":   CSRFToken(c),
+		"errors":      map[string]string{},
+	}
+	for k, v := range extra {
+		data[k] = v
+	}
+	c.HTML(code, "todo/show.html", data)
 }
 
 // todoを削除する
@@ -139,26 +343,440 @@ func (th *TodoHandler) Delete(c *gin.Context) {
 	id_s := c.Param("id")
 	id, err := strconv.ParseUint(id_s, 10, 64)
 	if err != nil {
-		SetFlashMessage(c, resultIsError, "このタスクは削除できません。")
+		SetFlashMessage(c, th.cookie, resultIsError, "このタスクは削除できません。")
 		c.Redirect(http.StatusSeeOther, "/todo")
 		return
 	}
 
-	err = th.todoUsecase.Delete(uint(id))
+	err = th.todoUsecase.Delete(currentUserID(c), uint(id))
 
 	if err != nil {
-		SetFlashMessage(c, resultIsError, "削除できませんでした。")
+		SetFlashMessage(c, th.cookie, resultIsError, errorMessage(err, "対象となるタスクが存在しません。", "削除できませんでした。"))
 		c.Redirect(http.StatusSeeOther, "/todo/"+id_s)
 		return
 	}
-	SetFlashMessage(c, resultIsSuccess, "タスクの削除を完了しました。")
+	// ゴミ箱に移しただけなので、すぐに元に戻せるようにする
+	SetFlashMessage(c, th.cookie, resultIsSuccess, "タスクをゴミ箱に移しました。")
+	SetFlashRestore(c, th.cookie, uint(id))
 	c.Redirect(http.StatusFound, "/todo")
 }
 
+// ゴミ箱のtodoの一覧を表示する
+func (th *TodoHandler) Trash(c *gin.Context) {
+	todos, err := th.todoUsecase.Trash(currentUserID(c))
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+
+	// Get flash message content if it exists
+	fm := GetFlashMessage(c)
+
+	c.HTML(http.StatusOK, "todo/trash.html", gin.H{
+		"todos":      todos,
+		"user":       CurrentUser(c),
+		"csrfToken":  CSRFToken(c),
+		flashMessage: fm.Message,
+		flashType:    fm.Type,
+	})
+}
+
+// ゴミ箱のtodoを元に戻し、そのtodoの詳細を表示する
+func (th *TodoHandler) Restore(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		SetFlashMessage(c, th.cookie, resultIsError, "このタスクは元に戻せません。")
+		c.Redirect(http.StatusSeeOther, "/todo/trash")
+		return
+	}
+	if err := th.todoUsecase.Restore(currentUserID(c), uint(id)); err != nil {
+		SetFlashMessage(c, th.cookie, resultIsError, errorMessage(err, "該当するタスクがゴミ箱に見つかりませんでした。", "元に戻せませんでした。"))
+		c.Redirect(http.StatusSeeOther, "/todo/trash")
+		return
+	}
+	SetFlashMessage(c, th.cookie, resultIsSuccess, "タスクを元に戻しました。")
+	c.Redirect(http.StatusFound, todoPath(uint(id)))
+}
+
+// ゴミ箱のtodoを完全に削除する
+func (th *TodoHandler) Purge(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		SetFlashMessage(c, th.cookie, resultIsError, "このタスクは削除できません。")
+		c.Redirect(http.StatusSeeOther, "/todo/trash")
+		return
+	}
+	if err := th.todoUsecase.Purge(currentUserID(c), uint(id)); err != nil {
+		SetFlashMessage(c, th.cookie, resultIsError, errorMessage(err, "該当するタスクがゴミ箱に見つかりませんでした。", "削除できませんでした。"))
+		c.Redirect(http.StatusSeeOther, "/todo/trash")
+		return
+	}
+	SetFlashMessage(c, th.cookie, resultIsSuccess, "タスクを完全に削除しました。")
+	c.Redirect(http.StatusFound, "/todo/trash")
+}
+
+// 一覧で選択した複数のtodoに、まとめて同じ操作を行う
+// idで対象のtodoを（複数）、actionで操作の種類を、タグを付け替える場合はtagsで付けるタグを受け取る
+// atomicが指定された場合は1件でも失敗したら全て取り消し、それ以外の場合は成功したものだけを保存する
+func (th *TodoHandler) Bulk(c *gin.Context) {
+	_, basePath, ok := th.currentProject(c)
+	if !ok {
+		return
+	}
+	ids := make([]uint, 0)
+	for _, id_s := range c.PostFormArray("id") {
+		id, err := strconv.ParseUint(id_s, 10, 64)
+		if err != nil {
+			SetFlashMessage(c, th.cookie, resultIsError, "選択したタスクが不正な値です。")
+			c.Redirect(http.StatusSeeOther, basePath)
+			return
+		}
+		ids = append(ids, uint(id))
+	}
+	req, err := models.NewBulkRequest(currentUserID(c), c.PostForm("action"), ids, models.ParseTagNames(c.PostForm("tags")), c.PostForm("atomic") != "")
+	if err != nil {
+		SetFlashMessage(c, th.cookie, resultIsError, bulkRequestErrorMessage(err))
+		c.Redirect(http.StatusSeeOther, basePath)
+		return
+	}
+
+	results, err := th.todoUsecase.Bulk(req)
+	switch {
+	case err != nil && results == nil:
+		SetFlashMessage(c, th.cookie, resultIsError, errorMessage(err, "対象となるタスクが存在しません。", "まとめて操作できませんでした。"))
+	case err != nil:
+		SetFlashMessage(c, th.cookie, resultIsError, "失敗したタスクがあるため、全ての操作を取り消しました。"+bulkFailureMessage(results))
+	case len(results.Failed()) > 0:
+		SetFlashMessage(c, th.cookie, resultIsError, bulkSuccessMessage(req.Action, results.Succeeded())+fmt.Sprintf("%d件は操作できませんでした。", len(results.Failed()))+bulkFailureMessage(results))
+	default:
+		SetFlashMessage(c, th.cookie, resultIsSuccess, bulkSuccessMessage(req.Action, results.Succeeded()))
+	}
+	c.Redirect(http.StatusSeeOther, basePath)
+}
+
+// 一括操作の指定が不正な場合のメッセージを返す
+func bulkRequestErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, models.ErrBulkEmpty):
+		return "操作するタスクを選択してください。"
+	case errors.Is(err, models.ErrBulkTooMany):
+		return fmt.Sprintf("一度に操作できるタスクは%d件までです。", models.BulkMaxSize)
+	case errors.Is(err, models.ErrInvalidTagName):
+		return tagNameErrorMessage
+	default:
+		return "操作の種類が不正な値です。"
+	}
+}
+
+// 一括操作で成功したtodoの件数を伝えるメッセージを返す
+func bulkSuccessMessage(action models.BulkAction, n int) string {
+	switch action {
+	case models.BulkComplete:
+		return fmt.Sprintf("%d件のタスクを完了にしました。", n)
+	case models.BulkReopen:
+		return fmt.Sprintf("%d件のタスクを未着手に戻しました。", n)
+	case models.BulkDelete:
+		return fmt.Sprintf("%d件のタスクをゴミ箱に移しました。", n)
+	default:
+		return fmt.Sprintf("%d件のタスクのタグを付け替えました。", n)
+	}
+}
+
+// 一括操作のメッセージに表示する、失敗したtodoの件数の上限
+// フラッシュメッセージはCookieに保存するため、多すぎる場合は省略する
+const bulkFailureDisplayLimit = 5
+
+// 一括操作で失敗したtodoのIDと理由を、メッセージに添える形式で返す（例："（#3：見つかりません、#5：…）"）
+// 他のtodoが失敗したために取り消したものは含めない
+func bulkFailureMessage(results models.BulkResults) string {
+	reasons := []string{}
+	for _, result := range results.Failed() {
+		if errors.Is(result.Err, models.ErrBulkRolledBack) {
+			continue
+		}
+		reasons = append(reasons, fmt.Sprintf("#%d：%s", result.ID, bulkErrorMessage(result.Err)))
+	}
+	if len(reasons) == 0 {
+		return ""
+	}
+	if len(reasons) > bulkFailureDisplayLimit {
+		reasons = append(reasons[:bulkFailureDisplayLimit], fmt.Sprintf("ほか%d件", len(reasons)-bulkFailureDisplayLimit))
+	}
+	return "（" + strings.Join(reasons, "、") + "）"
+}
+
+// 一括操作で、1件のtodoが失敗した理由を返す
+func bulkErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, models.ErrOpenChecklistItems):
+		return "チェックリストに未完了の項目が残っています"
+	case errors.Is(err, models.ErrInvalidStatusTransition):
+		return "現在の状態からは変更できません"
+	case errors.Is(err, models.ErrTodoConflict):
+		return "他の操作で更新されました"
+	default:
+		return errorMessage(err, "見つかりません", "操作できませんでした")
+	}
+}
+
 // 終了処理を行う
 func (th *TodoHandler) Close() {
 	err := th.todoUsecase.Close()
 	if err != nil {
 		slog.Error(err.Error())
 	}
+	err = th.tagUsecase.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	err = th.projectUsecase.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// パスで指定されたプロジェクトと、そのtodoの一覧のパスを返す
+// パスにプロジェクトが無い場合は、nilと全てのtodoの一覧のパスを返す
+// 該当するプロジェクトが無い場合はプロジェクトの一覧に戻し、falseを返す
+func (th *TodoHandler) currentProject(c *gin.Context) (*models.Project, string, bool) {
+	pid_s := c.Param("pid")
+	if pid_s == "" {
+		return nil, "/todo", true
+	}
+	pid, err := strconv.ParseUint(pid_s, 10, 64)
+	if err == nil {
+		project, err := th.projectUsecase.SearchByID(currentUserID(c), uint(pid))
+		if err == nil {
+			return project, projectTodosPath(project.ID), true
+		}
+	}
+	SetFlashMessage(c, th.cookie, resultIsError, "該当するプロジェクトが見つかりませんでした。")
+	c.Redirect(http.StatusSeeOther, "/projects")
+	return nil, "", false
+}
+
+// 一覧でドラッグ＆ドロップしたtodoを、after_id（直前のtodo）とbefore_id（直後のtodo）の間に移動する
+// 先頭・末尾に移動する場合は、片方を省略する
+// JavaScriptから呼び出すため、画面は返さずにステータスコードとJSONのメッセージで結果を返す
+func (th *TodoHandler) Move(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "このタスクは移動できません。"})
+		return
+	}
+	afterID, ok := parseOptionalID(c.PostForm("after_id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "移動先が不正な値です。"})
+		return
+	}
+	beforeID, ok := parseOptionalID(c.PostForm("before_id"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "移動先が不正な値です。"})
+		return
+	}
+
+	err = th.todoUsecase.Move(currentUserID(c), uint(id), afterID, beforeID)
+	switch {
+	case err == nil:
+		c.Status(http.StatusNoContent)
+	case errors.Is(err, usecases.ErrInvalidMove):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "移動先が不正な値です。画面を再読み込みしてから、もう一度操作してください。"})
+	default:
+		c.JSON(httperr.Status(err), gin.H{"error": errorMessage(err, "対象となるタスクが存在しません。", "タスクを移動できませんでした。")})
+	}
+}
+
+// 編集中の説明のMarkdownを、表示と同じHTMLに変換して返す（プレビュー用）
+func (th *TodoHandler) Preview(c *gin.Context) {
+	var todo models.Todo
+	if err := todo.SetDescription(c.PostForm("description")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": descriptionErrorMessage})
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(todo.DescriptionHTML()))
+}
+
+// 添付ファイルのアップロードで受け付ける、リクエストの本文の大きさの上限
+// ファイルの上限に、multipartの区切りやCSRF対策用のトークンなどの分を加える
+const maxUploadRequestSize = models.AttachmentMaxSize + 1<<20
+
+// 添付ファイルが大きすぎる場合のメッセージ
+var attachmentTooLargeMessage = fmt.Sprintf("添付するファイルは%dMB以内にしてください。", models.AttachmentMaxSize>>20)
+
+// 添付ファイルのアップロードで、リクエストの本文の大きさを制限するミドルウェア
+// CSRF対策用のトークンの検証でフォームを読み込む前に、大きすぎるリクエストを拒否する
+func (th *TodoHandler) LimitUploadSize(c *gin.Context) {
+	if c.Request.ContentLength > maxUploadRequestSize {
+		SetFlashMessage(c, th.cookie, resultIsError, attachmentTooLargeMessage)
+		c.Redirect(http.StatusSeeOther, "/todo/"+c.Param("id")+"#attachments")
+		c.Abort()
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadRequestSize)
+	c.Next()
+}
+
+// todoにファイルを添付する
+// ファイルはフォームのfileで受け取る
+func (th *TodoHandler) Upload(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		SetFlashMessage(c, th.cookie, resultIsError, "このタスクにはファイルを添付できません。")
+		c.Redirect(http.StatusSeeOther, "/todo")
+		return
+	}
+	back := todoPath(uint(id)) + "#attachments"
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		msg := "添付するファイルを選択してください。"
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			msg = attachmentTooLargeMessage
+		}
+		SetFlashMessage(c, th.cookie, resultIsError, msg)
+		c.Redirect(http.StatusSeeOther, back)
+		return
+	}
+	f, err := file.Open()
+	if err != nil {
+		slog.Error(err.Error())
+		SetFlashMessage(c, th.cookie, resultIsError, "ファイルを添付できませんでした。")
+		c.Redirect(http.StatusSeeOther, back)
+		return
+	}
+	defer f.Close()
+
+	_, err = th.todoUsecase.Attach(currentUserID(c), uint(id), file.Filename, file.Size, f)
+	if err != nil {
+		SetFlashMessage(c, th.cookie, resultIsError, attachmentErrorMessage(err, "ファイルを添付できませんでした。"))
+		c.Redirect(http.StatusSeeOther, back)
+		return
+	}
+	SetFlashMessage(c, th.cookie, resultIsSuccess, "ファイルを添付しました。")
+	c.Redirect(http.StatusFound, back)
+}
+
+// 添付ファイルの内容を返す
+// 画像はブラウザで表示し、それ以外はダウンロードさせる
+func (th *TodoHandler) Download(c *gin.Context) {
+	todoID, id, ok := th.parseAttachmentIDs(c)
+	if !ok {
+		return
+	}
+	attachment, r, err := th.todoUsecase.OpenAttachment(currentUserID(c), todoID, id)
+	if err != nil {
+		SetFlashMessage(c, th.cookie, resultIsError, attachmentErrorMessage(err, "添付ファイルを読み込めませんでした。"))
+		c.Redirect(http.StatusSeeOther, todoPath(todoID)+"#attachments")
+		return
+	}
+	defer r.Close()
+
+	// 判定した種類以外として解釈されないようにする
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, r, map[string]string{
+		"Content-Disposition": attachment.ContentDisposition(),
+	})
+}
+
+// 添付ファイルを削除する
+func (th *TodoHandler) DeleteAttachment(c *gin.Context) {
+	todoID, id, ok := th.parseAttachmentIDs(c)
+	if !ok {
+		return
+	}
+	back := todoPath(todoID) + "#attachments"
+	err := th.todoUsecase.Detach(currentUserID(c), todoID, id)
+	if err != nil {
+		SetFlashMessage(c, th.cookie, resultIsError, attachmentErrorMessage(err, "添付ファイルを削除できませんでした。"))
+		c.Redirect(http.StatusSeeOther, back)
+		return
+	}
+	SetFlashMessage(c, th.cookie, resultIsSuccess, "添付ファイルを削除しました。")
+	c.Redirect(http.StatusFound, back)
+}
+
+// パスパラメータのtodoと添付ファイルのIDを数値に変換する
+// 変換できない場合はエラーのメッセージを設定して戻し、falseを返す
+func (th *TodoHandler) parseAttachmentIDs(c *gin.Context) (uint, uint, bool) {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		SetFlashMessage(c, th.cookie, resultIsError, "該当するタスクが見つかりませんでした。")
+		c.Redirect(http.StatusSeeOther, "/todo")
+		return 0, 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("attachment_id"), 10, 64)
+	if err != nil {
+		SetFlashMessage(c, th.cookie, resultIsError, "該当する添付ファイルが見つかりませんでした。")
+		c.Redirect(http.StatusSeeOther, todoPath(uint(todoID))+"#attachments")
+		return 0, 0, false
+	}
+	return uint(todoID), uint(id), true
+}
+
+// 添付ファイルの操作時のエラーに応じたメッセージを返す
+// 添付ファイルに固有のエラー以外は、errorMessageでエラーの種類に応じたメッセージにする（想定外のエラーはmsg）
+func attachmentErrorMessage(err error, msg string) string {
+	switch {
+	case errors.Is(err, models.ErrAttachmentTooLarge):
+		return attachmentTooLargeMessage
+	case errors.Is(err, models.ErrAttachmentTypeNotAllowed):
+		return "添付できるファイルは、画像（PNG・JPEG・GIF・WebP）・PDF・テキスト・zip形式のファイルのみです。"
+	case errors.Is(err, models.ErrInvalidAttachment):
+		return "空のファイルは添付できません。"
+	default:
+		return errorMessage(err, "該当するタスクか添付ファイルが見つかりませんでした。", msg)
+	}
+}
+
+// フォームで選択されたプロジェクトなどの任意のIDを数値に変換する
+// 未選択の場合は0を返し、変換できない場合はfalseを返す
+func parseOptionalID(s string) (uint, bool) {
+	if s == "" {
+		return 0, true
+	}
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// 優先度が不正な場合のメッセージ
+const priorityErrorMessage = "タスクの優先度が不正な値です。"
+
+// 説明が長すぎる場合のメッセージ
+var descriptionErrorMessage = fmt.Sprintf("タスクの説明は%d文字以内で入力してください。", models.DescriptionMaxLength)
+
+// 期限が不正な場合のメッセージ
+const dueErrorMessage = "タスクの期限が不正な値です。"
+
+// 繰り返しが不正な場合のメッセージ
+const recurrenceErrorMessage = "タスクの繰り返しが不正な値です。"
+
+// フォームで選択された繰り返しをtodoに設定する
+// カスタム（custom）の場合は、recurrence_ruleに入力されたRRULEを設定する
+// 未選択の場合は繰り返しを解除する
+func setFormRecurrence(c *gin.Context, todo *models.Todo) error {
+	recurrence := c.PostForm("recurrence")
+	if recurrence == models.RecurrenceCustom {
+		recurrence = c.PostForm("recurrence_rule")
+		if strings.TrimSpace(recurrence) == "" {
+			return models.ErrInvalidRecurrence
+		}
+	}
+	return todo.SetRecurrence(recurrence)
+}
+
+// フォームで選択された優先度を変換する
+// 未選択の場合はdefaultPriorityを返し、変換できない場合はfalseを返す
+func parseOptionalPriority(s string, defaultPriority models.Priority) (models.Priority, bool) {
+	if s == "" {
+		return defaultPriority, true
+	}
+	priority, err := models.StrToPriority(s, models.PriorityCorrespond())
+	if err != nil {
+		return defaultPriority, false
+	}
+	return priority, true
 }
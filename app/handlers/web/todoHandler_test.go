@@ -4,20 +4,63 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/MinadukiSekina/todo-go-app/app/config"
 	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
 	mock_usecases "github.com/MinadukiSekina/todo-go-app/app/mock/usecase"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
+// テスト用のcookieの設定
+var testCookie = config.CookieConfig{Domain: "localhost", Secure: true}
+
+// テスト用のログイン中のユーザー
+var testUser = &models.User{Model: gorm.Model{ID: 1}, Email: "user@example.com"}
+
+// テスト用のタグの一覧を返すTagUsecaseのモックを生成する
+func newTagUsecaseMock(ctrl *gomock.Controller) *mock_usecases.MockTagUsecase {
+	m := mock_usecases.NewMockTagUsecase(ctrl)
+	tags := []models.Tag{{ID: 1, UserID: testUser.ID, Name: "backend", TodoCount: 1}}
+	m.EXPECT().List(testUser.ID).Return(&tags, nil).AnyTimes()
+	m.EXPECT().Close().Return(nil).AnyTimes()
+	return m
+}
+
+// テストで使用するプロジェクト
+var testProjects = []models.Project{
+	{ID: 1, UserID: 1, Name: models.InboxProjectName, Inbox: true, TodoCount: 1},
+	{ID: 2, UserID: 1, Name: "仕事"},
+}
+
+// todoのハンドラーのテストで使用する、プロジェクトのユースケースのモックを生成する
+func newProjectUsecaseMock(ctrl *gomock.Controller) *mock_usecases.MockProjectUsecase {
+	m := mock_usecases.NewMockProjectUsecase(ctrl)
+	m.EXPECT().List(testUser.ID).Return(&testProjects, nil).AnyTimes()
+	m.EXPECT().SearchByID(testUser.ID, gomock.Any()).DoAndReturn(func(userID uint, id uint) (*models.Project, error) {
+		for i := range testProjects {
+			if testProjects[i].ID == id {
+				return &testProjects[i], nil
+			}
+		}
+		return nil, models.NotFound(gorm.ErrRecordNotFound)
+	}).AnyTimes()
+	m.EXPECT().Close().Return(nil).AnyTimes()
+	return m
+}
+
 func TestIndex(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
@@ -28,31 +71,173 @@ func TestIndex(t *testing.T) {
 	todo2 := models.Todo{Title: "test2", Status: models.NotStarted}
 	todo2.ID = 1
 
-	nothingTodos := []models.Todo{}
-	onlyOneTodos := []models.Todo{todo1}
-	manyHasTodos := []models.Todo{todo1, todo2}
+	// 検索結果のページを生成する
+	newPage := func(todos ...models.Todo) *models.TodoPage {
+		return &models.TodoPage{Todos: todos, Total: int64(len(todos)), Page: 1, Limit: models.DefaultPageSize}
+	}
+	// 既定値を補った検索条件を生成する
+	newQuery := func(q models.TodoQuery) models.TodoQuery {
+		q.UserID = testUser.ID
+		return q.Normalize()
+	}
 
 	cases := map[string]struct {
 		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
-		want          int
+		pid           string
+		query         string
+		// リクエストに付けるcookie（フラッシュメッセージ）
+		cookies      []*http.Cookie
+		want         int
+		wantLocation string
+		// レスポンスの本文に含まれるべき文字列と、含まれてはいけない文字列
+		wantBody   string
+		hiddenBody string
 	}{
 		"正常ケース:データなし": {
-			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) { m.EXPECT().Show().Return(&nothingTodos, nil) },
-			want:          http.StatusOK,
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{})).Return(newPage(), nil)
+			},
+			want: http.StatusOK,
 		},
 		"正常ケース:1件データあり": {
-			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) { m.EXPECT().Show().Return(&onlyOneTodos, nil) },
-			want:          http.StatusOK,
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{})).Return(newPage(todo1), nil)
+			},
+			want: http.StatusOK,
 		},
 		"正常ケース:2件データあり": {
-			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) { m.EXPECT().Show().Return(&manyHasTodos, nil) },
-			want:          http.StatusOK,
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{})).Return(newPage(todo1, todo2), nil)
+			},
+			want: http.StatusOK,
+		},
+		"正常ケース:期限切れで絞り込み": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{Due: models.DueOverdue})).Return(newPage(todo1), nil)
+			},
+			query: "due=overdue",
+			want:  http.StatusOK,
+		},
+		"正常ケース:今日までで絞り込み": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{Due: models.DueToday})).Return(newPage(todo1), nil)
+			},
+			query: "due=today",
+			want:  http.StatusOK,
+		},
+		"正常ケース:今週までで絞り込み": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{Due: models.DueThisWeek})).Return(newPage(todo1, todo2), nil)
+			},
+			query: "due=week",
+			want:  http.StatusOK,
+		},
+		"正常ケース:検索・絞り込み・並び替え・ページの指定": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{
+					Keyword:   "買い物",
+					Statuses:  []models.Status{models.InProgress, models.Blocked},
+					Sort:      models.SortByDue,
+					Direction: models.Desc,
+					Page:      2,
+					Limit:     1,
+				})).Return(&models.TodoPage{Todos: []models.Todo{todo2}, Total: 3, Page: 2, Limit: 1}, nil)
+			},
+			query: "q=買い物&status=inProgress&status=blocked&sort=due&order=desc&page=2&limit=1",
+			want:  http.StatusOK,
+		},
+		"正常ケース:手動の並び順で並び替え": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{Sort: models.SortByPosition})).Return(newPage(todo1, todo2), nil)
+			},
+			query: "sort=position",
+			want:  http.StatusOK,
+		},
+		"正常ケース:タグで絞り込み": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				tagged := todo1
+				tagged.Tags = []models.Tag{{ID: 1, UserID: testUser.ID, Name: "backend"}}
+				m.EXPECT().Search(newQuery(models.TodoQuery{Tags: []string{"backend", "ui"}})).Return(newPage(tagged), nil)
+			},
+			query: "tag=backend&tag=ui",
+			want:  http.StatusOK,
+		},
+		"正常ケース:プロジェクトで絞り込み": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{ProjectID: 2})).Return(newPage(todo1), nil)
+			},
+			pid:  "2",
+			want: http.StatusOK,
+		},
+		"異常ケース:プロジェクトが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			pid:           "99",
+			want:          http.StatusSeeOther,
+			wantLocation:  "/projects",
+		},
+		"異常ケース:プロジェクトのIDが数値でない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			pid:           "string",
+			want:          http.StatusSeeOther,
+			wantLocation:  "/projects",
+		},
+		"異常ケース:プロジェクトのページで不正な検索条件": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			pid:           "2",
+			query:         "due=unknown",
+			want:          http.StatusSeeOther,
+			wantLocation:  "/projects/2/todos",
+		},
+		"異常ケース:不正な検索条件": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			query:         "due=unknown",
+			want:          http.StatusSeeOther,
+			wantLocation:  "/todo",
+		},
+		"正常ケース:削除後は元に戻すフォームを表示する": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{})).Return(newPage(), nil)
+			},
+			cookies: []*http.Cookie{
+				{Name: flashType, Value: resultIsSuccess},
+				{Name: flashMessage, Value: "deleted"},
+				{Name: flashRestore, Value: "5"},
+			},
+			want:     http.StatusOK,
+			wantBody: `action="/todo/5/restore"`,
 		},
 		"異常ケース:エラーあり": {
 			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
-				m.EXPECT().Show().Return(nil, errors.New("something is wrong"))
+				m.EXPECT().Search(newQuery(models.TodoQuery{})).Return(nil, errors.New("something is wrong"))
 			},
-			want: http.StatusInternalServerError,
+			// 想定外のエラーの内容は表示しない
+			want:       http.StatusInternalServerError,
+			wantBody:   internalErrorMessage,
+			hiddenBody: "something is wrong",
+		},
+		"異常ケース:DBに接続できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{})).Return(nil, models.Unavailable(errors.New("connection refused")))
+			},
+			want:       http.StatusServiceUnavailable,
+			wantBody:   unavailableMessage,
+			hiddenBody: "connection refused",
+		},
+		"異常ケース:対象が存在しない場合はエラーの内容の代わりにメッセージを表示": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{})).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			want:       http.StatusNotFound,
+			wantBody:   kindMessages[models.KindNotFound],
+			hiddenBody: "record not found",
+		},
+		"異常ケース:入力が不正な場合はエラーの内容の代わりにメッセージを表示": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Search(newQuery(models.TodoQuery{})).Return(nil, models.Validation(errors.New("invalid query")))
+			},
+			want:       http.StatusUnprocessableEntity,
+			wantBody:   kindMessages[models.KindValidation],
+			hiddenBody: "invalid query",
 		},
 	}
 
@@ -73,20 +258,35 @@ func TestIndex(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, r := gin.CreateTestContext(w)
 
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
 			// テンプレートの読み込み
 			// route.goと同じ指定だとエラーになったため、appからのパスで指定する
 			r.LoadHTMLGlob("/app/app/templates/*/*.html")
 
 			// リクエストを設定
-			req, _ := http.NewRequest("GET", "/todo", nil)
+			req, _ := http.NewRequest("GET", "/todo?"+tt.query, nil)
+			for _, cookie := range tt.cookies {
+				req.AddCookie(cookie)
+			}
 			c.Request = req
+			if tt.pid != "" {
+				c.Params = []gin.Param{{Key: "pid", Value: tt.pid}}
+			}
 
 			// mockを利用してテストする
-			handler := NewTodoHandler(mock)
+			handler := NewTodoHandler(mock, newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
 			handler.Index(c)
+			c.Writer.WriteHeaderNow()
 
 			// 結果を確認
 			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+			if tt.hiddenBody != "" {
+				assert.NotContains(t, w.Body.String(), tt.hiddenBody)
+			}
 		})
 	}
 }
@@ -102,16 +302,25 @@ func TestShowByID(t *testing.T) {
 
 	todo1 := models.Todo{Title: "test1", Status: models.NotStarted}
 	todo1.ID = 1
+	// 変更履歴のタイムラインも表示できること
+	todo1.Histories = []models.TodoHistory{
+		{ID: 2, TodoID: 1, UserID: testUser.ID, User: *testUser, Action: models.HistoryUpdated, Field: models.HistoryFieldStatus, OldValue: models.NotStarted.Key(), NewValue: models.Done.Key()},
+		{ID: 1, TodoID: 1, UserID: testUser.ID, User: *testUser, Action: models.HistoryCreated},
+	}
 
 	cases := map[string]struct {
 		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
 		args          args
 		want          int
+		// 一覧に戻す場合に表示するメッセージ
+		wantMessage string
 	}{
 		"正常ケース:データあり": {
-			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) { m.EXPECT().SearchByID(uint(1)).Return(&todo1, nil) },
-			args:          args{id: 1},
-			want:          http.StatusOK,
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
+			},
+			args: args{id: 1},
+			want: http.StatusOK,
 		},
 		"異常ケース:IDを数値に変換できない": {
 			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
@@ -122,10 +331,27 @@ func TestShowByID(t *testing.T) {
 		},
 		"異常ケース:データなし": {
 			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
-				m.EXPECT().SearchByID(uint(1)).Return(nil, errors.New("record not found"))
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
 			},
-			args: args{id: 1},
-			want: http.StatusSeeOther,
+			args:        args{id: 1},
+			want:        http.StatusSeeOther,
+			wantMessage: "該当するタスクが見つかりませんでした。",
+		},
+		"異常ケース:DBに接続できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(nil, models.Unavailable(errors.New("connection refused")))
+			},
+			args:        args{id: 1},
+			want:        http.StatusSeeOther,
+			wantMessage: unavailableMessage,
+		},
+		"異常ケース:検索に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(nil, errors.New("something is wrong"))
+			},
+			args:        args{id: 1},
+			want:        http.StatusSeeOther,
+			wantMessage: "タスクを表示できませんでした。",
 		},
 	}
 
@@ -146,6 +372,9 @@ func TestShowByID(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, r := gin.CreateTestContext(w)
 
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
 			// テンプレートの読み込み
 			// route.goと同じ指定だとエラーになったため、appからのパスで指定する
 			r.LoadHTMLGlob("/app/app/templates/*/*.html")
@@ -158,28 +387,62 @@ func TestShowByID(t *testing.T) {
 			c.Params = []gin.Param{{Key: "id", Value: fmt.Sprint(tt.args.id)}}
 
 			// mockを利用してテストする
-			handler := NewTodoHandler(mock)
+			handler := NewTodoHandler(mock, newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
 			handler.ShowById(c)
 
 			// 結果を確認
 			assert.Equal(t, tt.want, w.Code)
+			if tt.wantMessage != "" {
+				assert.Equal(t, tt.wantMessage, flashMessageCookie(w))
+			}
 		})
 	}
 }
 
+// レスポンスで設定されたフラッシュメッセージを返す
+func flashMessageCookie(w *httptest.ResponseRecorder) string {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == flashMessage {
+			msg, _ := url.QueryUnescape(cookie.Value)
+			return msg
+		}
+	}
+	return ""
+}
+
 func TestCreate(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 
 	// テスト用の引数を格納する
 	type args struct {
-		title string
+		title   string
+		dueDate string
+		dueTime string
+		tags    string
+		// フォームで選択した優先度
+		priority string
+		// フォームで選択した繰り返しと、カスタムの場合のRRULE
+		recurrence     string
+		recurrenceRule string
+		// フォームで選択したプロジェクト
+		projectID string
+		// パスで指定したプロジェクト
+		pid string
+	}
+
+	// 入力に誤りがある場合は、一覧の画面を再表示するためにtodoを検索する
+	expectIndex := func(m *mock_usecases.MockTodoUsecase) {
+		m.EXPECT().Search(gomock.Any()).Return(&models.TodoPage{Page: 1, Limit: models.DefaultPageSize}, nil)
 	}
 
 	cases := map[string]struct {
 		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
 		args          args
 		want          int
+		wantLocation  string
+		// レスポンスの本文に含まれるべき文字列（入力エラーの表示の確認用）
+		wantBody []string
 	}{
 		"正常ケース:作成に成功": {
 			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) { m.EXPECT().Add(gomock.Any()).Return(nil) },
@@ -193,6 +456,181 @@ func TestCreate(t *testing.T) {
 			args: args{title: "failed"},
 			want: http.StatusSeeOther,
 		},
+		"正常ケース:期限付きで作成に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.UserID == testUser.ID && todo.DueAt != nil && todo.DueHasTime
+				})).Return(nil)
+			},
+			args: args{title: "test1", dueDate: "2025-03-31", dueTime: "18:00"},
+			want: http.StatusFound,
+		},
+		"異常ケース:期限の形式が不正": {
+			prepareMockFn: expectIndex,
+			args:          args{title: "failed", dueDate: "2025/03/31"},
+			want:          http.StatusUnprocessableEntity,
+			wantBody:      []string{dueErrorMessage, `value="failed"`},
+		},
+		"正常ケース:優先度を選択して作成に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Priority == models.PriorityUrgent
+				})).Return(nil)
+			},
+			args: args{title: "test1", priority: "urgent"},
+			want: http.StatusFound,
+		},
+		"正常ケース:優先度を選択しない場合は中で作成": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Priority == models.PriorityMedium
+				})).Return(nil)
+			},
+			args: args{title: "test1"},
+			want: http.StatusFound,
+		},
+		"異常ケース:優先度が不正": {
+			prepareMockFn: expectIndex,
+			args:          args{title: "failed", priority: "critical"},
+			want:          http.StatusUnprocessableEntity,
+			wantBody:      []string{priorityErrorMessage},
+		},
+		"正常ケース:繰り返しを選択して作成に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Recurrence == "FREQ=WEEKLY"
+				})).Return(nil)
+			},
+			args: args{title: "test1", recurrence: "weekly"},
+			want: http.StatusFound,
+		},
+		"正常ケース:カスタムの繰り返しで作成に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Recurrence == "FREQ=MONTHLY;BYMONTHDAY=-1"
+				})).Return(nil)
+			},
+			args: args{title: "test1", recurrence: "custom", recurrenceRule: "FREQ=MONTHLY;BYMONTHDAY=-1"},
+			want: http.StatusFound,
+		},
+		"異常ケース:カスタムの繰り返しが空": {
+			prepareMockFn: expectIndex,
+			args:          args{title: "failed", recurrence: "custom"},
+			want:          http.StatusUnprocessableEntity,
+			wantBody:      []string{recurrenceErrorMessage},
+		},
+		"異常ケース:繰り返しが不正": {
+			prepareMockFn: expectIndex,
+			args:          args{title: "failed", recurrence: "custom", recurrenceRule: "FREQ=YEARLY"},
+			want:          http.StatusUnprocessableEntity,
+			// 入力したRRULEが残ること
+			wantBody: []string{recurrenceErrorMessage, `value="FREQ=YEARLY"`},
+		},
+		"正常ケース:タグ付きで作成に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Any()).DoAndReturn(func(todo *models.Todo) error {
+					todo.ID = 1
+					return nil
+				})
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{"仕事", "買い物"}).Return(nil)
+			},
+			args: args{title: "test1", tags: " 仕事 、買い物, 仕事,"},
+			want: http.StatusFound,
+		},
+		"異常ケース:タグの名前が不正": {
+			prepareMockFn: expectIndex,
+			args:          args{title: "failed", tags: strings.Repeat("a", models.TagNameMaxLength+1)},
+			want:          http.StatusUnprocessableEntity,
+			wantBody:      []string{tagNameErrorMessage},
+		},
+		"正常ケース:選択したプロジェクトに作成": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.ProjectID == 2
+				})).Return(nil)
+			},
+			args:         args{title: "test1", projectID: "2"},
+			want:         http.StatusFound,
+			wantLocation: "/todo",
+		},
+		"正常ケース:プロジェクトのページから作成": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.ProjectID == 2
+				})).Return(nil)
+			},
+			args:         args{title: "test1", pid: "2"},
+			want:         http.StatusFound,
+			wantLocation: "/projects/2/todos",
+		},
+		"異常ケース:選択したプロジェクトが不正": {
+			prepareMockFn: expectIndex,
+			args:          args{title: "failed", projectID: "string"},
+			want:          http.StatusUnprocessableEntity,
+			wantBody:      []string{"プロジェクトが不正な値です。"},
+		},
+		"異常ケース:他のユーザーのプロジェクトを選択": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Any()).Return(usecases.ErrProjectNotFound)
+				expectIndex(m)
+			},
+			args:     args{title: "failed", projectID: "3"},
+			want:     http.StatusUnprocessableEntity,
+			wantBody: []string{"指定されたプロジェクトが見つかりませんでした。"},
+		},
+		"正常ケース:タイトルの前後の空白を取り除いて作成": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Title == "test1"
+				})).Return(nil)
+			},
+			args: args{title: "\u3000 test1 \t"},
+			want: http.StatusFound,
+		},
+		"異常ケース:タイトルが空白のみ": {
+			prepareMockFn: expectIndex,
+			args:          args{title: " \u3000 "},
+			want:          http.StatusUnprocessableEntity,
+			wantBody:      []string{"タスクのタイトルを入力してください。", "is-invalid"},
+		},
+		"異常ケース:タイトルが長すぎる": {
+			prepareMockFn: expectIndex,
+			args:          args{title: strings.Repeat("あ", models.TitleMaxLength+1), dueDate: "2025/03/31"},
+			want:          http.StatusUnprocessableEntity,
+			// 誤りのある項目のエラーをまとめて表示すること
+			wantBody: []string{titleTooLongMessage, dueErrorMessage},
+		},
+		"異常ケース:タイトルに制御文字が含まれる": {
+			prepareMockFn: expectIndex,
+			args:          args{title: "test\x00"},
+			want:          http.StatusUnprocessableEntity,
+			wantBody:      []string{"タスクのタイトルに、改行やタブなどの制御文字は使用できません。"},
+		},
+		"異常ケース:usecaseで項目の検証に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				var errs models.FieldErrors
+				errs.Add(models.FieldTitle, models.ErrTitleTooLong)
+				m.EXPECT().Add(gomock.Any()).Return(errs.Err())
+				expectIndex(m)
+			},
+			args:     args{title: "failed"},
+			want:     http.StatusUnprocessableEntity,
+			wantBody: []string{titleTooLongMessage},
+		},
+		"異常ケース:パスのプロジェクトが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			args:          args{title: "failed", pid: "99"},
+			want:          http.StatusSeeOther,
+			wantLocation:  "/projects",
+		},
+		"異常ケース:タグの設定に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Add(gomock.Any()).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(0), []string{"仕事"}).Return(errors.New("something is wrong"))
+			},
+			args: args{title: "test1", tags: "仕事"},
+			want: http.StatusSeeOther,
+		},
 	}
 
 	for name, tt := range cases {
@@ -212,6 +650,9 @@ func TestCreate(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, r := gin.CreateTestContext(w)
 
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
 			// テンプレートの読み込み
 			// route.goと同じ指定だとエラーになったため、appからのパスで指定する
 			r.LoadHTMLGlob("/app/app/templates/*/*.html")
@@ -219,14 +660,25 @@ func TestCreate(t *testing.T) {
 			// フォームデータの組み立て
 			formData := url.Values{}
 			formData.Add("title", tt.args.title)
+			formData.Add("due_date", tt.args.dueDate)
+			formData.Add("due_time", tt.args.dueTime)
+			formData.Add("due_timezone", "Asia/Tokyo")
+			formData.Add("tags", tt.args.tags)
+			formData.Add("priority", tt.args.priority)
+			formData.Add("recurrence", tt.args.recurrence)
+			formData.Add("recurrence_rule", tt.args.recurrenceRule)
+			formData.Add("project_id", tt.args.projectID)
 
 			// リクエストを設定
 			req, _ := http.NewRequest("POST", "/todo", strings.NewReader(formData.Encode()))
 			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 			c.Request = req
+			if tt.args.pid != "" {
+				c.Params = []gin.Param{{Key: "pid", Value: tt.args.pid}}
+			}
 
 			// mockを利用してテストする
-			handler := NewTodoHandler(mock)
+			handler := NewTodoHandler(mock, newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
 			handler.Create(c)
 
 			// GETの場合と異なり、POSTの場合はリダイレクトのステータスコードが書き込まれないらしい
@@ -236,6 +688,12 @@ func TestCreate(t *testing.T) {
 
 			// 結果を確認
 			assert.Equal(t, tt.want, w.Code)
+			if tt.wantLocation != "" {
+				assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+			}
+			for _, want := range tt.wantBody {
+				assert.Contains(t, w.Body.String(), want)
+			}
 		})
 	}
 }
@@ -249,24 +707,218 @@ func TestUpdate(t *testing.T) {
 
 	// テスト用の引数を格納する
 	type args struct {
-		id     any
-		title  string
-		status string
+		id        any
+		title     string
+		status    string
+		dueDate   string
+		timezone  string
+		tags      string
+		priority  string
+		projectID string
+		// 説明（Markdown）
+		description string
+		// フォームで選択した繰り返し
+		recurrence string
+		// 画面を表示した時点のバージョン
+		version string
 	}
 
 	cases := map[string]struct {
 		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
 		args          args
 		want          int
+		// レスポンスに含まれるべき文字列（競合時・入力エラーの表示の確認用）
+		wantBody []string
 	}{
+		"正常ケース:表示時のバージョンを指定して更新": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := todo1
+				todo.Version = 4
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Version == 3
+				})).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
+			},
+			args: args{id: 1, title: "test1", status: "completed", version: "3"},
+			want: http.StatusFound,
+		},
+		"異常ケース:バージョンが不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
+			},
+			args: args{id: 1, title: "failed", status: "completed", version: "string"},
+			want: http.StatusSeeOther,
+		},
+		"異常ケース:他のユーザーが先に更新していた": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := todo1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				current := todo1
+				current.Title = "changed"
+				current.Version = 4
+				m.EXPECT().Edit(gomock.Any()).Return(&models.TodoConflictError{
+					Current: &current,
+					Changes: []models.TodoHistory{{TodoID: 1, Action: models.HistoryUpdated, Field: models.HistoryFieldTitle, OldValue: "changed", NewValue: "mine"}},
+				})
+			},
+			args: args{id: 1, title: "mine", status: "completed", version: "3"},
+			want: http.StatusConflict,
+			// 保存されている内容と入力した内容を並べ、最新のバージョンで再度更新できること
+			wantBody: []string{"<td>changed</td>", "<td>mine</td>", `name="version" value="4"`},
+		},
 		"正常ケース:更新に成功": {
 			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
-				m.EXPECT().SearchByID(uint(1)).Return(&todo1, nil)
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
+			},
+			args: args{id: 1, title: "test1", status: "completed"},
+			want: http.StatusFound,
+		},
+		"正常ケース:タグを付けて更新に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{"backend", "urgent"}).Return(nil)
+			},
+			args: args{id: 1, title: "test1", status: "completed", tags: "backend, urgent"},
+			want: http.StatusFound,
+		},
+		"異常ケース:タグの名前が不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
+			},
+			args:     args{id: 1, title: "failed", status: "completed", tags: strings.Repeat("a", models.TagNameMaxLength+1)},
+			want:     http.StatusUnprocessableEntity,
+			wantBody: []string{tagNameErrorMessage},
+		},
+		"異常ケース:タグの更新に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
 				m.EXPECT().Edit(gomock.Any()).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{"backend"}).Return(errors.New("something is wrong"))
+			},
+			args: args{id: 1, title: "test1", status: "completed", tags: "backend"},
+			want: http.StatusSeeOther,
+		},
+		"正常ケース:プロジェクトを移動": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := todo1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.ProjectID == 2
+				})).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
+			},
+			args: args{id: 1, title: "test1", status: "completed", projectID: "2"},
+			want: http.StatusFound,
+		},
+		"正常ケース:優先度を変更": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := todo1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Priority == models.PriorityHigh
+				})).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
+			},
+			args: args{id: 1, title: "test1", status: "completed", priority: "high"},
+			want: http.StatusFound,
+		},
+		"正常ケース:優先度を選択しない場合は変更しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := todo1
+				todo.Priority = models.PriorityLow
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Priority == models.PriorityLow
+				})).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
 			},
 			args: args{id: 1, title: "test1", status: "completed"},
 			want: http.StatusFound,
 		},
+		"異常ケース:優先度が不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
+			},
+			args:     args{id: 1, title: "failed", status: "completed", priority: "critical"},
+			want:     http.StatusUnprocessableEntity,
+			wantBody: []string{priorityErrorMessage},
+		},
+		"正常ケース:繰り返しを設定": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := todo1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Recurrence == "FREQ=DAILY"
+				})).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
+			},
+			args: args{id: 1, title: "test1", status: "completed", recurrence: "daily"},
+			want: http.StatusFound,
+		},
+		"正常ケース:繰り返さないを選択すると解除": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := todo1
+				todo.Recurrence = "FREQ=DAILY"
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Recurrence == ""
+				})).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
+			},
+			args: args{id: 1, title: "test1", status: "completed"},
+			want: http.StatusFound,
+		},
+		"異常ケース:繰り返しが不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
+			},
+			args:     args{id: 1, title: "failed", status: "completed", recurrence: "yearly"},
+			want:     http.StatusUnprocessableEntity,
+			wantBody: []string{recurrenceErrorMessage},
+		},
+		"正常ケース:説明を更新": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := todo1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Description == "# メモ\n\n- 項目"
+				})).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
+			},
+			args: args{id: 1, title: "test1", status: "completed", description: "# メモ\r\n\r\n- 項目\r\n"},
+			want: http.StatusFound,
+		},
+		"異常ケース:説明が長すぎる": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := todo1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+			},
+			args:     args{id: 1, title: "failed", status: "completed", description: strings.Repeat("a", models.DescriptionMaxLength+1)},
+			want:     http.StatusUnprocessableEntity,
+			wantBody: []string{descriptionErrorMessage},
+		},
+		"異常ケース:他のユーザーのプロジェクトに移動": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := todo1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Any()).Return(usecases.ErrProjectNotFound)
+			},
+			args:     args{id: 1, title: "failed", status: "completed", projectID: "3"},
+			want:     http.StatusUnprocessableEntity,
+			wantBody: []string{"移動先のプロジェクトが見つかりませんでした。"},
+		},
+		"異常ケース:プロジェクトが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
+			},
+			args:     args{id: 1, title: "failed", status: "completed", projectID: "string"},
+			want:     http.StatusUnprocessableEntity,
+			wantBody: []string{"プロジェクトが不正な値です。"},
+		},
 		"異常ケース:IDが数値に変換できない": {
 			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
 				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
@@ -276,26 +928,100 @@ func TestUpdate(t *testing.T) {
 		},
 		"異常ケース:ステータスの値が変換できない": {
 			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
-				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
 			},
-			args: args{id: 1, title: "failed", status: "cannotConverted"},
-			want: http.StatusSeeOther,
+			args:     args{id: 1, title: "failed", status: "cannotConverted"},
+			want:     http.StatusUnprocessableEntity,
+			wantBody: []string{"タスクの状態が不正な値です。"},
+		},
+		"正常ケース:タイトルの前後の空白を取り除いて更新": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := todo1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+				m.EXPECT().Edit(gomock.Cond(func(todo *models.Todo) bool {
+					return todo.Title == "changed"
+				})).Return(nil)
+				m.EXPECT().SetTags(testUser.ID, uint(1), []string{}).Return(nil)
+			},
+			args: args{id: 1, title: "  changed\u3000", status: "completed"},
+			want: http.StatusFound,
+		},
+		"異常ケース:タイトルが空白のみ": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := todo1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+			},
+			args: args{id: 1, title: "   ", status: "completed", description: "入力した説明", tags: "backend"},
+			want: http.StatusUnprocessableEntity,
+			// 入力した内容が残ること
+			wantBody: []string{"タスクのタイトルを入力してください。", "入力した説明</textarea>", `value="backend"`},
+		},
+		"異常ケース:タイトルと説明に制御文字が含まれる": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := todo1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+			},
+			args: args{id: 1, title: "test\x1b[31m", status: "completed", description: "memo\x07"},
+			want: http.StatusUnprocessableEntity,
+			wantBody: []string{
+				"タスクのタイトルに、改行やタブなどの制御文字は使用できません。",
+				"タスクの説明に、改行・タブ以外の制御文字は使用できません。",
+			},
+		},
+		"異常ケース:usecaseで項目の検証に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
+				var errs models.FieldErrors
+				errs.Add(models.FieldDescription, models.ErrDescriptionTooLong)
+				m.EXPECT().Edit(gomock.Any()).Return(errs.Err())
+			},
+			args:     args{id: 1, title: "test1", status: "completed"},
+			want:     http.StatusUnprocessableEntity,
+			wantBody: []string{descriptionErrorMessage},
 		},
 		"異常ケース:対象のタスクが存在しない": {
 			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
-				m.EXPECT().SearchByID(uint(1)).Return(nil, errors.New("record not found"))
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(nil, errors.New("record not found"))
 			},
 			args: args{id: 1, title: "failed", status: "completed"},
 			want: http.StatusSeeOther,
 		},
 		"異常ケース:更新に失敗": {
 			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
-				m.EXPECT().SearchByID(uint(1)).Return(&todo1, nil)
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
 				m.EXPECT().Edit(gomock.Any()).Return(errors.New("something is wrong"))
 			},
 			args: args{id: 1, title: "failed", status: "completed"},
 			want: http.StatusSeeOther,
 		},
+		"異常ケース:許可されていない状態への変更": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				cancelled := models.Todo{Title: "test1", Status: models.Cancelled}
+				cancelled.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&cancelled, nil)
+			},
+			args:     args{id: 1, title: "failed", status: "inProgress"},
+			want:     http.StatusUnprocessableEntity,
+			wantBody: []string{"現在の状態からは「進行中」に変更できません。"},
+		},
+		"異常ケース:チェックリストに未完了の項目が残っている": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				todo := models.Todo{Title: "test1", Status: models.InProgress, ChecklistItems: []models.ChecklistItem{{Done: true}, {Done: false}}}
+				todo.ID = 1
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo, nil)
+			},
+			args:     args{id: 1, title: "failed", status: "completed"},
+			want:     http.StatusUnprocessableEntity,
+			wantBody: []string{"（1/2完了）"},
+		},
+		"異常ケース:期限のタイムゾーンが不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().SearchByID(testUser.ID, uint(1)).Return(&todo1, nil)
+			},
+			args:     args{id: 1, title: "failed", status: "completed", dueDate: "2025-03-31", timezone: "Mars/Olympus"},
+			want:     http.StatusUnprocessableEntity,
+			wantBody: []string{dueErrorMessage},
+		},
 	}
 
 	for name, tt := range cases {
@@ -315,6 +1041,9 @@ func TestUpdate(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, r := gin.CreateTestContext(w)
 
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
 			// テンプレートの読み込み
 			// route.goと同じ指定だとエラーになったため、appからのパスで指定する
 			r.LoadHTMLGlob("/app/app/templates/*/*.html")
@@ -323,6 +1052,14 @@ func TestUpdate(t *testing.T) {
 			formData := url.Values{}
 			formData.Add("title", tt.args.title)
 			formData.Add("status", tt.args.status)
+			formData.Add("due_date", tt.args.dueDate)
+			formData.Add("due_timezone", tt.args.timezone)
+			formData.Add("tags", tt.args.tags)
+			formData.Add("priority", tt.args.priority)
+			formData.Add("project_id", tt.args.projectID)
+			formData.Add("description", tt.args.description)
+			formData.Add("recurrence", tt.args.recurrence)
+			formData.Add("version", tt.args.version)
 
 			// リクエストを設定
 			req, _ := http.NewRequest("POST", fmt.Sprintf("/todo/%v", tt.args.id), strings.NewReader(formData.Encode()))
@@ -333,7 +1070,7 @@ func TestUpdate(t *testing.T) {
 			c.Params = []gin.Param{{Key: "id", Value: fmt.Sprint(tt.args.id)}}
 
 			// mockを利用してテストする
-			handler := NewTodoHandler(mock)
+			handler := NewTodoHandler(mock, newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
 			handler.Update(c)
 
 			// GETの場合と異なり、POSTの場合はリダイレクトのステータスコードが書き込まれないらしい
@@ -343,47 +1080,557 @@ func TestUpdate(t *testing.T) {
 
 			// 結果を確認
 			assert.Equal(t, tt.want, w.Code)
+			for _, want := range tt.wantBody {
+				assert.Contains(t, w.Body.String(), want)
+			}
 		})
 	}
 }
 
-func TestDelete(t *testing.T) {
+func TestPreview(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 
-	todo1 := models.Todo{Title: "test1", Status: models.NotStarted}
-	todo1.ID = 1
-
-	// テスト用の引数を格納する
-	type args struct {
-		id any
-	}
-
 	cases := map[string]struct {
-		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
-		args          args
-		want          int
+		description string
+		want        int
+		wantBody    string
 	}{
-		"正常ケース:削除に成功": {
-			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
-				m.EXPECT().Delete(uint(1)).Return(nil)
-			},
-			args: args{id: 1},
-			want: http.StatusFound,
+		"正常ケース:MarkdownをHTMLに変換": {
+			description: "**太字**",
+			want:        http.StatusOK,
+			wantBody:    "<p><strong>太字</strong></p>\n",
 		},
-		"異常ケース:IDが数値に変換できない": {
-			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
-				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
-			},
-			args: args{id: "string"},
-			want: http.StatusSeeOther,
+		"正常ケース:scriptタグは取り除く": {
+			description: "<script>alert(1)</script>",
+			want:        http.StatusOK,
+			wantBody:    "\n",
 		},
-		"異常ケース:更新に失敗": {
-			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
-				m.EXPECT().Delete(uint(1)).Return(errors.New("something is wrong"))
+		"異常ケース:説明が長すぎる": {
+			description: strings.Repeat("a", models.DescriptionMaxLength+1),
+			want:        http.StatusBadRequest,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
+			// リクエストを設定
+			formData := url.Values{}
+			formData.Add("description", tt.description)
+			req, _ := http.NewRequest("POST", "/todo/preview", strings.NewReader(formData.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Request = req
+
+			// mockを利用してテストする（usecaseは呼び出さない）
+			handler := NewTodoHandler(mock_usecases.NewMockTodoUsecase(mockCtrl), newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
+			handler.Preview(c)
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusOK {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	todo1 := models.Todo{Title: "test1", Status: models.NotStarted}
+	todo1.ID = 1
+
+	// テスト用の引数を格納する
+	type args struct {
+		id any
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		args          args
+		want          int
+		// 元に戻すtodoのIDとしてcookieに保存される値
+		wantRestore string
+	}{
+		"正常ケース:削除に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1)).Return(nil)
+			},
+			args:        args{id: 1},
+			want:        http.StatusFound,
+			wantRestore: "1",
+		},
+		"異常ケース:IDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			args: args{id: "string"},
+			want: http.StatusSeeOther,
+		},
+		"異常ケース:更新に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Delete(testUser.ID, uint(1)).Return(errors.New("something is wrong"))
+			},
+			args: args{id: 1},
+			want: http.StatusSeeOther,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
+			// テンプレートの読み込み
+			// route.goと同じ指定だとエラーになったため、appからのパスで指定する
+			r.LoadHTMLGlob("/app/app/templates/*/*.html")
+
+			// リクエストを設定
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/todo/%v", tt.args.id), nil)
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Request = req
+
+			// パラメータを設定
+			c.Params = []gin.Param{{Key: "id", Value: fmt.Sprint(tt.args.id)}}
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
+			handler.Delete(c)
+
+			// GETの場合と異なり、POSTの場合はリダイレクトのステータスコードが書き込まれないらしい
+			// 回避策として、c.Redirectの後に明示的に書き込むようにする（Create内部でc.Redirectを呼んでいる）
+			// ref: https://stackoverflow.com/questions/76319196/unit-testing-of-gins-context-redirect-works-for-get-response-code-but-fails-for
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			restore := ""
+			for _, cookie := range w.Result().Cookies() {
+				if cookie.Name == flashRestore {
+					restore = cookie.Value
+				}
+			}
+			assert.Equal(t, tt.wantRestore, restore)
+		})
+	}
+}
+
+func TestTrash(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	trashed := models.Todo{Title: "trashed", Status: models.NotStarted}
+	trashed.ID = 1
+	trashed.DeletedAt = gorm.DeletedAt{Time: time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC), Valid: true}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		want          int
+	}{
+		"正常ケース:データなし": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Trash(testUser.ID).Return(&[]models.Todo{}, nil)
+			},
+			want: http.StatusOK,
+		},
+		"正常ケース:1件データあり": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Trash(testUser.ID).Return(&[]models.Todo{trashed}, nil)
+			},
+			want: http.StatusOK,
+		},
+		"異常ケース:取得に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Trash(testUser.ID).Return(nil, errors.New("something is wrong"))
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
+			// テンプレートの読み込み
+			// route.goと同じ指定だとエラーになったため、appからのパスで指定する
+			r.LoadHTMLGlob("/app/app/templates/*/*.html")
+
+			// リクエストを設定
+			req, _ := http.NewRequest("GET", "/todo/trash", nil)
+			c.Request = req
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
+			handler.Trash(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusOK {
+				assert.Contains(t, w.Body.String(), "ゴミ箱")
+			}
+		})
+	}
+}
+
+func TestRestoreAndPurge(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		// 実行するハンドラー
+		run          func(h *TodoHandler, c *gin.Context)
+		id           string
+		want         int
+		wantLocation string
+	}{
+		"正常ケース:元に戻すとtodoの詳細を表示する": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Restore(testUser.ID, uint(1)).Return(nil)
+			},
+			run:          (*TodoHandler).Restore,
+			id:           "1",
+			want:         http.StatusFound,
+			wantLocation: "/todo/1",
+		},
+		"異常ケース:ゴミ箱に無いtodoを元に戻す": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Restore(testUser.ID, uint(1)).Return(models.NotFound(gorm.ErrRecordNotFound))
+			},
+			run:          (*TodoHandler).Restore,
+			id:           "1",
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/trash",
+		},
+		"異常ケース:元に戻すIDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {},
+			run:           (*TodoHandler).Restore,
+			id:            "string",
+			want:          http.StatusSeeOther,
+			wantLocation:  "/todo/trash",
+		},
+		"正常ケース:完全に削除するとゴミ箱を表示する": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Purge(testUser.ID, uint(1)).Return(nil)
+			},
+			run:          (*TodoHandler).Purge,
+			id:           "1",
+			want:         http.StatusFound,
+			wantLocation: "/todo/trash",
+		},
+		"異常ケース:完全な削除に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Purge(testUser.ID, uint(1)).Return(errors.New("something is wrong"))
+			},
+			run:          (*TodoHandler).Purge,
+			id:           "1",
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/trash",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
+			// リクエストを設定
+			req, _ := http.NewRequest("POST", "/todo/"+tt.id, nil)
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Request = req
+
+			// パラメータを設定
+			c.Params = []gin.Param{{Key: "id", Value: tt.id}}
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
+			tt.run(&handler, c)
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+		})
+	}
+}
+
+func TestMove(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	// テスト用の引数を格納する
+	type args struct {
+		id   any
+		form url.Values
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		args          args
+		want          int
+	}{
+		"正常ケース:間に移動": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Move(testUser.ID, uint(3), uint(1), uint(2)).Return(nil)
+			},
+			args: args{id: 3, form: url.Values{"after_id": {"1"}, "before_id": {"2"}}},
+			want: http.StatusNoContent,
+		},
+		"正常ケース:先頭に移動": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Move(testUser.ID, uint(3), uint(0), uint(1)).Return(nil)
+			},
+			args: args{id: 3, form: url.Values{"after_id": {""}, "before_id": {"1"}}},
+			want: http.StatusNoContent,
+		},
+		"異常ケース:IDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			args: args{id: "string", form: url.Values{"after_id": {"1"}}},
+			want: http.StatusBadRequest,
+		},
+		"異常ケース:隣のtodoのIDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			args: args{id: 3, form: url.Values{"before_id": {"string"}}},
+			want: http.StatusBadRequest,
+		},
+		"異常ケース:移動先が不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Move(testUser.ID, uint(3), uint(2), uint(1)).Return(usecases.ErrInvalidMove)
+			},
+			args: args{id: 3, form: url.Values{"after_id": {"2"}, "before_id": {"1"}}},
+			want: http.StatusBadRequest,
+		},
+		"異常ケース:対象のタスクが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Move(testUser.ID, uint(9), uint(1), uint(0)).Return(models.NotFound(gorm.ErrRecordNotFound))
+			},
+			args: args{id: 9, form: url.Values{"after_id": {"1"}}},
+			want: http.StatusNotFound,
+		},
+		"異常ケース:移動に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Move(testUser.ID, uint(3), uint(1), uint(0)).Return(errors.New("something is wrong"))
+			},
+			args: args{id: 3, form: url.Values{"after_id": {"1"}}},
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
+			// リクエストを設定
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/todo/%v/move", tt.args.id), strings.NewReader(tt.args.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Request = req
+
+			// パラメータを設定
+			c.Params = []gin.Param{{Key: "id", Value: fmt.Sprint(tt.args.id)}}
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
+			handler.Move(c)
+
+			// c.Statusだけではステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestBulk(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	// 一括操作の指定を生成する（生成できることはmodelsのテストで確認する）
+	bulkRequest := func(action string, ids []uint, tags []string, atomic bool) *models.BulkRequest {
+		req, _ := models.NewBulkRequest(testUser.ID, action, ids, tags, atomic)
+		return req
+	}
+
+	// テスト用の引数を格納する
+	type args struct {
+		pid  string
+		form url.Values
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		args          args
+		wantLocation  string
+		wantType      string
+		wantMessage   string
+	}{
+		"正常ケース:全て成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Bulk(bulkRequest("complete", []uint{1, 2}, nil, false)).Return(models.BulkResults{{ID: 1}, {ID: 2}}, nil)
 			},
-			args: args{id: 1},
-			want: http.StatusSeeOther,
+			args:         args{form: url.Values{"id": {"1", "2"}, "action": {"complete"}}},
+			wantLocation: "/todo",
+			wantType:     resultIsSuccess,
+			wantMessage:  "2件のタスクを完了にしました。",
+		},
+		"正常ケース:プロジェクトの一覧でタグを付け替える": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Bulk(bulkRequest("retag", []uint{1}, []string{"仕事", "家"}, false)).Return(models.BulkResults{{ID: 1}}, nil)
+			},
+			args:         args{pid: "2", form: url.Values{"id": {"1"}, "action": {"retag"}, "tags": {"仕事, 家"}}},
+			wantLocation: "/projects/2/todos",
+			wantType:     resultIsSuccess,
+			wantMessage:  "1件のタスクのタグを付け替えました。",
+		},
+		"正常ケース:一部が失敗した場合は、失敗したIDと理由を表示": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Bulk(bulkRequest("complete", []uint{1, 2, 3}, nil, false)).Return(models.BulkResults{
+					{ID: 1},
+					{ID: 2, Err: usecases.ErrTodoNotFound},
+					{ID: 3, Err: models.ErrOpenChecklistItems},
+				}, nil)
+			},
+			args:         args{form: url.Values{"id": {"1", "2", "3"}, "action": {"complete"}}},
+			wantLocation: "/todo",
+			wantType:     resultIsError,
+			wantMessage:  "1件のタスクを完了にしました。2件は操作できませんでした。（#2：見つかりません、#3：チェックリストに未完了の項目が残っています）",
+		},
+		"異常ケース:全て取り消す指定で失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Bulk(bulkRequest("delete", []uint{1, 2}, nil, true)).Return(models.BulkResults{
+					{ID: 1, Err: models.ErrBulkRolledBack},
+					{ID: 2, Err: models.ErrTodoConflict},
+				}, fmt.Errorf("todo 2: %w", models.ErrTodoConflict))
+			},
+			args:         args{form: url.Values{"id": {"1", "2"}, "action": {"delete"}, "atomic": {"true"}}},
+			wantLocation: "/todo",
+			wantType:     resultIsError,
+			wantMessage:  "失敗したタスクがあるため、全ての操作を取り消しました。（#2：他の操作で更新されました）",
+		},
+		"異常ケース:タスクを選択していない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				// 検証に失敗した場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			args:         args{form: url.Values{"action": {"complete"}}},
+			wantLocation: "/todo",
+			wantType:     resultIsError,
+			wantMessage:  "操作するタスクを選択してください。",
+		},
+		"異常ケース:IDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			args:         args{form: url.Values{"id": {"string"}, "action": {"complete"}}},
+			wantLocation: "/todo",
+			wantType:     resultIsError,
+			wantMessage:  "選択したタスクが不正な値です。",
+		},
+		"異常ケース:操作の種類が不正": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				// 検証に失敗した場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			args:         args{form: url.Values{"id": {"1"}, "action": {"archive"}}},
+			wantLocation: "/todo",
+			wantType:     resultIsError,
+			wantMessage:  "操作の種類が不正な値です。",
+		},
+		"異常ケース:保存に失敗": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Bulk(gomock.Any()).Return(nil, errors.New("something is wrong"))
+			},
+			args:         args{form: url.Values{"id": {"1"}, "action": {"reopen"}}},
+			wantLocation: "/todo",
+			wantType:     resultIsError,
+			wantMessage:  "まとめて操作できませんでした。",
+		},
+		"異常ケース:プロジェクトが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				// プロジェクトが見つからない場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			args:         args{pid: "99", form: url.Values{"id": {"1"}, "action": {"complete"}}},
+			wantLocation: "/projects",
+			wantType:     resultIsError,
+			wantMessage:  "該当するプロジェクトが見つかりませんでした。",
 		},
 	}
 
@@ -402,31 +1649,37 @@ func TestDelete(t *testing.T) {
 
 			// gin contextの生成
 			w := httptest.NewRecorder()
-			c, r := gin.CreateTestContext(w)
+			c, _ := gin.CreateTestContext(w)
 
-			// テンプレートの読み込み
-			// route.goと同じ指定だとエラーになったため、appからのパスで指定する
-			r.LoadHTMLGlob("/app/app/templates/*/*.html")
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
 
 			// リクエストを設定
-			req, _ := http.NewRequest("POST", fmt.Sprintf("/todo/%v", tt.args.id), nil)
+			req, _ := http.NewRequest("POST", "/todo/bulk", strings.NewReader(tt.args.form.Encode()))
 			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 			c.Request = req
 
 			// パラメータを設定
-			c.Params = []gin.Param{{Key: "id", Value: fmt.Sprint(tt.args.id)}}
+			if tt.args.pid != "" {
+				c.Params = []gin.Param{{Key: "pid", Value: tt.args.pid}}
+			}
 
 			// mockを利用してテストする
-			handler := NewTodoHandler(mock)
-			handler.Delete(c)
+			handler := NewTodoHandler(mock, newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
+			handler.Bulk(c)
 
-			// GETの場合と異なり、POSTの場合はリダイレクトのステータスコードが書き込まれないらしい
-			// 回避策として、c.Redirectの後に明示的に書き込むようにする（Create内部でc.Redirectを呼んでいる）
-			// ref: https://stackoverflow.com/questions/76319196/unit-testing-of-gins-context-redirect-works-for-get-response-code-but-fails-for
+			// POSTの場合は、c.Redirectの後に明示的に書き込む（TestDeleteを参照）
 			c.Writer.WriteHeaderNow()
 
 			// 結果を確認
-			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, http.StatusSeeOther, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+			flash := map[string]string{}
+			for _, cookie := range w.Result().Cookies() {
+				flash[cookie.Name], _ = url.QueryUnescape(cookie.Value)
+			}
+			assert.Equal(t, tt.wantType, flash[flashType])
+			assert.Equal(t, tt.wantMessage, flash[flashMessage])
 		})
 	}
 }
@@ -480,7 +1733,7 @@ func TestClose(t *testing.T) {
 			defer slog.SetDefault(originalLogger)
 
 			// mockを利用してテストする
-			handler := NewTodoHandler(mock)
+			handler := NewTodoHandler(mock, newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
 			handler.Close()
 
 			// ログの出力を確認
@@ -492,3 +1745,328 @@ func TestClose(t *testing.T) {
 		})
 	}
 }
+
+// 添付ファイルのテストで使用する、ファイルを含むフォームの本文とContent-Typeを返す
+// fileNameが空の場合はファイルを含めない
+func newUploadBody(t *testing.T, fileName string, content string) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if fileName != "" {
+		fw, err := mw.CreateFormFile("file", fileName)
+		if err != nil {
+			t.Fatalf("failed to create form file: %v", err)
+		}
+		io.WriteString(fw, content)
+	}
+	mw.Close()
+	return &body, mw.FormDataContentType()
+}
+
+func TestUpload(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	// テスト用の引数を格納する
+	type args struct {
+		id       any
+		fileName string
+		content  string
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		args          args
+		want          int
+		wantLocation  string
+	}{
+		"正常ケース:添付に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Attach(testUser.ID, uint(1), "memo.txt", int64(5), gomock.Any()).DoAndReturn(func(userID uint, todoID uint, fileName string, size int64, r io.Reader) (*models.Attachment, error) {
+					// アップロードされた内容がそのまま渡されること
+					body, _ := io.ReadAll(r)
+					assert.Equal(t, "hello", string(body))
+					return &models.Attachment{ID: 1, TodoID: 1, FileName: fileName, Size: size}, nil
+				})
+			},
+			args:         args{id: 1, fileName: "memo.txt", content: "hello"},
+			want:         http.StatusFound,
+			wantLocation: "/todo/1#attachments",
+		},
+		"異常ケース:ファイルが選択されていない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				// ファイルが無い場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			args:         args{id: 1},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/1#attachments",
+		},
+		"異常ケース:添付できない種類": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Attach(testUser.ID, uint(1), "index.html", gomock.Any(), gomock.Any()).Return(nil, models.ErrAttachmentTypeNotAllowed)
+			},
+			args:         args{id: 1, fileName: "index.html", content: "<html></html>"},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo/1#attachments",
+		},
+		"異常ケース:IDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			args:         args{id: "string", fileName: "memo.txt", content: "hello"},
+			want:         http.StatusSeeOther,
+			wantLocation: "/todo",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
+			// リクエストを設定
+			body, contentType := newUploadBody(t, tt.args.fileName, tt.args.content)
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/todo/%v/attachments", tt.args.id), body)
+			req.Header.Set("Content-Type", contentType)
+			c.Request = req
+
+			// パラメータを設定
+			c.Params = []gin.Param{{Key: "id", Value: fmt.Sprint(tt.args.id)}}
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
+			handler.Upload(c)
+
+			// POSTの場合はリダイレクトのステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+		})
+	}
+}
+
+func TestLimitUploadSize(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		contentLength int64
+		wantAborted   bool
+	}{
+		"正常ケース:上限以内": {
+			contentLength: maxUploadRequestSize,
+			wantAborted:   false,
+		},
+		"異常ケース:上限を超える": {
+			contentLength: maxUploadRequestSize + 1,
+			wantAborted:   true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			// リクエストを設定
+			req, _ := http.NewRequest("POST", "/todo/1/attachments", strings.NewReader(""))
+			req.ContentLength = tt.contentLength
+			c.Request = req
+			c.Params = []gin.Param{{Key: "id", Value: "1"}}
+
+			handler := NewTodoHandler(mock_usecases.NewMockTodoUsecase(mockCtrl), newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
+			handler.LimitUploadSize(c)
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.wantAborted, c.IsAborted())
+			if tt.wantAborted {
+				assert.Equal(t, http.StatusSeeOther, w.Code)
+				assert.Equal(t, "/todo/1#attachments", w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestDownload(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	// テスト用の引数を格納する
+	type args struct {
+		todoID       any
+		attachmentID any
+	}
+
+	cases := map[string]struct {
+		prepareMockFn   func(m *mock_usecases.MockTodoUsecase)
+		args            args
+		want            int
+		wantBody        string
+		wantType        string
+		wantDisposition string
+	}{
+		"正常ケース:画像はブラウザで表示する": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				attachment := &models.Attachment{ID: 2, TodoID: 1, FileName: "a.png", ContentType: "image/png", Size: 3}
+				m.EXPECT().OpenAttachment(testUser.ID, uint(1), uint(2)).Return(attachment, io.NopCloser(strings.NewReader("png")), nil)
+			},
+			args:            args{todoID: 1, attachmentID: 2},
+			want:            http.StatusOK,
+			wantBody:        "png",
+			wantType:        "image/png",
+			wantDisposition: "inline; filename=a.png",
+		},
+		"正常ケース:画像以外はダウンロードさせる": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				attachment := &models.Attachment{ID: 2, TodoID: 1, FileName: "memo.txt", ContentType: "text/plain", Size: 5}
+				m.EXPECT().OpenAttachment(testUser.ID, uint(1), uint(2)).Return(attachment, io.NopCloser(strings.NewReader("hello")), nil)
+			},
+			args:            args{todoID: 1, attachmentID: 2},
+			want:            http.StatusOK,
+			wantBody:        "hello",
+			wantType:        "text/plain",
+			wantDisposition: "attachment; filename=memo.txt",
+		},
+		"異常ケース:添付ファイルが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().OpenAttachment(testUser.ID, uint(1), uint(9)).Return(nil, nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			args: args{todoID: 1, attachmentID: 9},
+			want: http.StatusSeeOther,
+		},
+		"異常ケース:添付ファイルのIDが数値に変換できない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				// 変換できない場合はusecaseの処理が走る前にReturnするので何もしない
+			},
+			args: args{todoID: 1, attachmentID: "string"},
+			want: http.StatusSeeOther,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
+			// リクエストを設定
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/todo/%v/attachments/%v", tt.args.todoID, tt.args.attachmentID), nil)
+			c.Request = req
+
+			// パラメータを設定
+			c.Params = []gin.Param{{Key: "id", Value: fmt.Sprint(tt.args.todoID)}, {Key: "attachment_id", Value: fmt.Sprint(tt.args.attachmentID)}}
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
+			handler.Download(c)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusOK {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+				assert.Equal(t, tt.wantType, w.Header().Get("Content-Type"))
+				assert.Equal(t, tt.wantDisposition, w.Header().Get("Content-Disposition"))
+				assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+			}
+		})
+	}
+}
+
+func TestDeleteAttachment(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+		want          int
+	}{
+		"正常ケース:削除に成功": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Detach(testUser.ID, uint(1), uint(2)).Return(nil)
+			},
+			want: http.StatusFound,
+		},
+		"異常ケース:添付ファイルが存在しない": {
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().Detach(testUser.ID, uint(1), uint(2)).Return(models.NotFound(gorm.ErrRecordNotFound))
+			},
+			want: http.StatusSeeOther,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_usecases.NewMockTodoUsecase(mockCtrl)
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(mock)
+
+			// gin contextの生成
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			// ログイン中のユーザーを設定
+			c.Set(currentUserKey, testUser)
+
+			// リクエストを設定
+			req, _ := http.NewRequest("POST", "/todo/1/attachments/2/delete", nil)
+			c.Request = req
+			c.Params = []gin.Param{{Key: "id", Value: "1"}, {Key: "attachment_id", Value: "2"}}
+
+			// mockを利用してテストする
+			handler := NewTodoHandler(mock, newTagUsecaseMock(mockCtrl), newProjectUsecaseMock(mockCtrl), testCookie)
+			handler.DeleteAttachment(c)
+
+			// POSTの場合はリダイレクトのステータスコードが書き込まれないため、明示的に書き込む
+			c.Writer.WriteHeaderNow()
+
+			// 結果を確認
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, "/todo/1#attachments", w.Header().Get("Location"))
+		})
+	}
+}
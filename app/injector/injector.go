@@ -1,36 +1,323 @@
 package injector
 
 import (
+	"github.com/MinadukiSekina/todo-go-app/app/config"
 	"github.com/MinadukiSekina/todo-go-app/app/db"
 	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	"github.com/MinadukiSekina/todo-go-app/app/handlers/api"
 	handlers "github.com/MinadukiSekina/todo-go-app/app/handlers/web"
+	"github.com/MinadukiSekina/todo-go-app/app/jobs"
+	"github.com/MinadukiSekina/todo-go-app/app/storage"
 	"github.com/MinadukiSekina/todo-go-app/app/usecases"
 )
 
 // データベース接続を初期化し、SqlHandlerを返す
-func InjectDB() db.SqlHandler {
-	sqlhandler := db.GetSqlHandler()
-	return sqlhandler
+func InjectDB(cfg *config.Config) (db.SqlHandler, error) {
+	return db.GetSqlHandler(cfg.DB)
 }
 
 // sqlHandlerを使用してTodoRepositoryを生成する
-func InjectTodoRepository() repository.TodoRepository {
-	sqlHandler := InjectDB()
-	return db.NewTodoRepository(sqlHandler)
+func InjectTodoRepository(cfg *config.Config) (repository.TodoRepository, error) {
+	sqlHandler, err := InjectDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return db.NewTodoRepository(sqlHandler), nil
 }
 
-// TodoRepositoryを使用してTodoUsecaseを生成する
-func InjectTodoUsecase() usecases.TodoUsecase {
-	TodoRepo := InjectTodoRepository()
-	return usecases.NewTodoUsecase(TodoRepo)
+// sqlHandlerを使用してUserRepositoryを生成する
+func InjectUserRepository(cfg *config.Config) (repository.UserRepository, error) {
+	sqlHandler, err := InjectDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return db.NewUserRepository(sqlHandler), nil
 }
 
-// TodoUsecaseを使用してTodoHandlerを生成する
-func InjectTodoHandler() handlers.TodoHandler {
-	return handlers.NewTodoHandler(InjectTodoUsecase())
+// sqlHandlerを使用してSessionRepositoryを生成する
+func InjectSessionRepository(cfg *config.Config) (repository.SessionRepository, error) {
+	sqlHandler, err := InjectDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return db.NewSessionRepository(sqlHandler), nil
+}
+
+// sqlHandlerを使用してTagRepositoryを生成する
+func InjectTagRepository(cfg *config.Config) (repository.TagRepository, error) {
+	sqlHandler, err := InjectDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return db.NewTagRepository(sqlHandler), nil
+}
+
+// sqlHandlerを使用してProjectRepositoryを生成する
+func InjectProjectRepository(cfg *config.Config) (repository.ProjectRepository, error) {
+	sqlHandler, err := InjectDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return db.NewProjectRepository(sqlHandler), nil
+}
+
+// sqlHandlerを使用してChecklistRepositoryを生成する
+func InjectChecklistRepository(cfg *config.Config) (repository.ChecklistRepository, error) {
+	sqlHandler, err := InjectDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return db.NewChecklistRepository(sqlHandler), nil
+}
+
+// sqlHandlerを使用してCommentRepositoryを生成する
+func InjectCommentRepository(cfg *config.Config) (repository.CommentRepository, error) {
+	sqlHandler, err := InjectDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return db.NewCommentRepository(sqlHandler), nil
+}
+
+// sqlHandlerを使用してAttachmentRepositoryを生成する
+func InjectAttachmentRepository(cfg *config.Config) (repository.AttachmentRepository, error) {
+	sqlHandler, err := InjectDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return db.NewAttachmentRepository(sqlHandler), nil
+}
+
+// SqlHandlerを使用してTodoHistoryRepositoryを生成する
+func InjectTodoHistoryRepository(cfg *config.Config) (repository.TodoHistoryRepository, error) {
+	sqlHandler, err := InjectDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return db.NewTodoHistoryRepository(sqlHandler), nil
+}
+
+// 設定に応じて、添付ファイルの内容の保存先を生成する
+func InjectBlobStorage(cfg *config.Config) (repository.BlobStorage, error) {
+	return storage.New(cfg.Storage)
+}
+
+// TodoRepository・TagRepository・ProjectRepository・AttachmentRepository・BlobStorage・TodoHistoryRepositoryを使用してTodoUsecaseを生成する
+func InjectTodoUsecase(cfg *config.Config) (usecases.TodoUsecase, error) {
+	TodoRepo, err := InjectTodoRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+	tagRepo, err := InjectTagRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+	projectRepo, err := InjectProjectRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+	attachmentRepo, err := InjectAttachmentRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+	blobStorage, err := InjectBlobStorage(cfg)
+	if err != nil {
+		return nil, err
+	}
+	historyRepo, err := InjectTodoHistoryRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return usecases.NewTodoUsecase(TodoRepo, tagRepo, projectRepo, attachmentRepo, blobStorage, historyRepo), nil
+}
+
+// ChecklistRepositoryとTodoRepositoryを使用してChecklistUsecaseを生成する
+func InjectChecklistUsecase(cfg *config.Config) (usecases.ChecklistUsecase, error) {
+	checklistRepo, err := InjectChecklistRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+	todoRepo, err := InjectTodoRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return usecases.NewChecklistUsecase(checklistRepo, todoRepo), nil
+}
+
+// CommentRepositoryとTodoRepositoryを使用してCommentUsecaseを生成する
+func InjectCommentUsecase(cfg *config.Config) (usecases.CommentUsecase, error) {
+	commentRepo, err := InjectCommentRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+	todoRepo, err := InjectTodoRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return usecases.NewCommentUsecase(commentRepo, todoRepo), nil
+}
+
+// ProjectRepositoryを使用してProjectUsecaseを生成する
+func InjectProjectUsecase(cfg *config.Config) (usecases.ProjectUsecase, error) {
+	projectRepo, err := InjectProjectRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return usecases.NewProjectUsecase(projectRepo), nil
+}
+
+// TagRepositoryを使用してTagUsecaseを生成する
+func InjectTagUsecase(cfg *config.Config) (usecases.TagUsecase, error) {
+	tagRepo, err := InjectTagRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return usecases.NewTagUsecase(tagRepo), nil
+}
+
+// TodoUsecase・TagUsecase・ProjectUsecaseを使用してTodoHandlerを生成する
+func InjectTodoHandler(cfg *config.Config) (handlers.TodoHandler, error) {
+	uc, err := InjectTodoUsecase(cfg)
+	if err != nil {
+		return handlers.TodoHandler{}, err
+	}
+	tagUc, err := InjectTagUsecase(cfg)
+	if err != nil {
+		return handlers.TodoHandler{}, err
+	}
+	projectUc, err := InjectProjectUsecase(cfg)
+	if err != nil {
+		return handlers.TodoHandler{}, err
+	}
+	return handlers.NewTodoHandler(uc, tagUc, projectUc, cfg.Cookie), nil
+}
+
+// ChecklistUsecaseを使用してChecklistHandlerを生成する
+func InjectChecklistHandler(cfg *config.Config) (handlers.ChecklistHandler, error) {
+	uc, err := InjectChecklistUsecase(cfg)
+	if err != nil {
+		return handlers.ChecklistHandler{}, err
+	}
+	return handlers.NewChecklistHandler(uc, cfg.Cookie), nil
+}
+
+// CommentUsecaseを使用してCommentHandlerを生成する
+func InjectCommentHandler(cfg *config.Config) (handlers.CommentHandler, error) {
+	uc, err := InjectCommentUsecase(cfg)
+	if err != nil {
+		return handlers.CommentHandler{}, err
+	}
+	return handlers.NewCommentHandler(uc, cfg.Cookie), nil
+}
+
+// ProjectUsecaseを使用してProjectHandlerを生成する
+func InjectProjectHandler(cfg *config.Config) (handlers.ProjectHandler, error) {
+	uc, err := InjectProjectUsecase(cfg)
+	if err != nil {
+		return handlers.ProjectHandler{}, err
+	}
+	return handlers.NewProjectHandler(uc, cfg.Cookie), nil
+}
+
+// TagUsecaseを使用してTagHandlerを生成する
+func InjectTagHandler(cfg *config.Config) (handlers.TagHandler, error) {
+	uc, err := InjectTagUsecase(cfg)
+	if err != nil {
+		return handlers.TagHandler{}, err
+	}
+	return handlers.NewTagHandler(uc, cfg.Cookie), nil
+}
+
+// TodoUsecaseとProjectUsecaseを使用してAPI用のTodoHandlerを生成する
+func InjectTodoAPIHandler(cfg *config.Config) (api.TodoHandler, error) {
+	uc, err := InjectTodoUsecase(cfg)
+	if err != nil {
+		return api.TodoHandler{}, err
+	}
+	projectUc, err := InjectProjectUsecase(cfg)
+	if err != nil {
+		return api.TodoHandler{}, err
+	}
+	return api.NewTodoHandler(uc, projectUc), nil
+}
+
+// ChecklistUsecaseを使用してAPI用のChecklistHandlerを生成する
+func InjectChecklistAPIHandler(cfg *config.Config) (api.ChecklistHandler, error) {
+	uc, err := InjectChecklistUsecase(cfg)
+	if err != nil {
+		return api.ChecklistHandler{}, err
+	}
+	return api.NewChecklistHandler(uc), nil
+}
+
+// CommentUsecaseを使用してAPI用のCommentHandlerを生成する
+func InjectCommentAPIHandler(cfg *config.Config) (api.CommentHandler, error) {
+	uc, err := InjectCommentUsecase(cfg)
+	if err != nil {
+		return api.CommentHandler{}, err
+	}
+	return api.NewCommentHandler(uc), nil
+}
+
+// ProjectUsecaseを使用してAPI用のProjectHandlerを生成する
+func InjectProjectAPIHandler(cfg *config.Config) (api.ProjectHandler, error) {
+	uc, err := InjectProjectUsecase(cfg)
+	if err != nil {
+		return api.ProjectHandler{}, err
+	}
+	return api.NewProjectHandler(uc), nil
+}
+
+// TagUsecaseを使用してAPI用のTagHandlerを生成する
+func InjectTagAPIHandler(cfg *config.Config) (api.TagHandler, error) {
+	uc, err := InjectTagUsecase(cfg)
+	if err != nil {
+		return api.TagHandler{}, err
+	}
+	return api.NewTagHandler(uc), nil
+}
+
+// UserRepositoryとSessionRepositoryを使用してAuthUsecaseを生成する
+func InjectAuthUsecase(cfg *config.Config) (usecases.AuthUsecase, error) {
+	userRepo, err := InjectUserRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+	sessionRepo, err := InjectSessionRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return usecases.NewAuthUsecase(userRepo, sessionRepo, cfg.Session.TTL.Duration), nil
+}
+
+// AuthUsecaseを使用してAuthHandlerを生成する
+func InjectAuthHandler(cfg *config.Config) (handlers.AuthHandler, error) {
+	uc, err := InjectAuthUsecase(cfg)
+	if err != nil {
+		return handlers.AuthHandler{}, err
+	}
+	return handlers.NewAuthHandler(uc, cfg.Cookie, cfg.Session), nil
+}
+
+// AuthUsecaseを使用してAPI用のAuthHandlerを生成する
+func InjectAuthAPIHandler(cfg *config.Config) (api.AuthHandler, error) {
+	uc, err := InjectAuthUsecase(cfg)
+	if err != nil {
+		return api.AuthHandler{}, err
+	}
+	return api.NewAuthHandler(uc), nil
 }
 
 // アプリケーションのメインハンドラー（ルートパス用）を生成する
 func InjectMainHandler() handlers.MainHandler {
 	return handlers.NewMainHandler()
 }
+
+// TodoUsecaseを使用して、ゴミ箱の保存期間を過ぎたtodoを削除するジョブを生成する
+func InjectTrashPurger(cfg *config.Config) (jobs.TrashPurger, error) {
+	uc, err := InjectTodoUsecase(cfg)
+	if err != nil {
+		return jobs.TrashPurger{}, err
+	}
+	return jobs.NewTrashPurger(uc, cfg.Trash), nil
+}
@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
+	"github.com/MinadukiSekina/todo-go-app/app/usecases"
+)
+
+// ゴミ箱のtodoのうち、保存期間を過ぎたものを定期的に完全に削除するジョブ
+type TrashPurger struct {
+	usecase usecases.TodoUsecase
+	// ゴミ箱に移してから完全に削除するまでの期間
+	retention time.Duration
+	// 削除を実行する間隔
+	interval time.Duration
+	// 現在日時を返す関数（テストで差し替えられるようにする）
+	now func() time.Time
+}
+
+// TrashPurgerの新しいインスタンスを作成して返す
+func NewTrashPurger(usecase usecases.TodoUsecase, cfg config.TrashConfig) TrashPurger {
+	return TrashPurger{usecase: usecase, retention: cfg.Retention(), interval: cfg.PurgeInterval.Duration, now: time.Now}
+}
+
+// ctxが終了するまで、起動時と一定の間隔ごとに保存期間を過ぎたtodoを削除する
+// 削除に失敗した場合はログに出力し、次の実行で再び削除を試みる
+func (p TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.PurgeOnce()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// 終了と同時に間隔が経過した場合は、削除を始めずに終了する
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}
+}
+
+// 保存期間を過ぎたtodoを一度だけ削除する
+func (p TrashPurger) PurgeOnce() {
+	before := p.now().Add(-p.retention)
+	purged, err := p.usecase.PurgeExpired(before)
+	if err != nil {
+		slog.Error("failed to purge expired todos from the trash", "purged", purged, "error", err)
+		return
+	}
+	if purged > 0 {
+		slog.Info("purged expired todos from the trash", "purged", purged, "before", before)
+	}
+}
+
+// TrashPurgerの終了処理
+func (p TrashPurger) Close() error {
+	return p.usecase.Close()
+}
@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
+	mock_usecases "github.com/MinadukiSekina/todo-go-app/app/mock/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurgeOnce(t *testing.T) {
+
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		retentionDays int
+		prepareMockFn func(m *mock_usecases.MockTodoUsecase)
+	}{
+		"正常ケース:保存期間より前に削除したtodoを削除する": {
+			retentionDays: 30,
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().PurgeExpired(time.Date(2025, 5, 31, 12, 0, 0, 0, time.UTC)).Return(2, nil)
+			},
+		},
+		"異常ケース:削除に失敗しても終了しない": {
+			retentionDays: 1,
+			prepareMockFn: func(m *mock_usecases.MockTodoUsecase) {
+				m.EXPECT().PurgeExpired(time.Date(2025, 6, 29, 12, 0, 0, 0, time.UTC)).Return(0, errors.New("database is unavailable"))
+			},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			m := mock_usecases.NewMockTodoUsecase(mockCtrl)
+			tt.prepareMockFn(m)
+
+			purger := NewTrashPurger(m, config.TrashConfig{RetentionDays: tt.retentionDays, PurgeInterval: config.Duration{Duration: time.Hour}})
+			purger.now = func() time.Time { return now }
+			purger.PurgeOnce()
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+
+	// モックの呼び出しを管理するControllerを生成
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 起動時と一定の間隔ごとに削除し、3回目の削除でctxを終了する
+	calls := 0
+	m := mock_usecases.NewMockTodoUsecase(mockCtrl)
+	m.EXPECT().PurgeExpired(gomock.Any()).Times(3).DoAndReturn(func(before time.Time) (int, error) {
+		calls++
+		if calls == 3 {
+			cancel()
+		}
+		return 0, nil
+	})
+
+	purger := NewTrashPurger(m, config.TrashConfig{RetentionDays: 30, PurgeInterval: config.Duration{Duration: 10 * time.Millisecond}})
+
+	done := make(chan struct{})
+	go func() {
+		purger.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not stop after the context was cancelled")
+	}
+	assert.Equal(t, 3, calls)
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/domain/repository/checklistRepository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/domain/repository/checklistRepository.go -destination=app/mock/repository/mockChecklistRepository.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	models "github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChecklistRepository is a mock of ChecklistRepository interface.
+type MockChecklistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChecklistRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChecklistRepositoryMockRecorder is the mock recorder for MockChecklistRepository.
+type MockChecklistRepositoryMockRecorder struct {
+	mock *MockChecklistRepository
+}
+
+// NewMockChecklistRepository creates a new mock instance.
+func NewMockChecklistRepository(ctrl *gomock.Controller) *MockChecklistRepository {
+	mock := &MockChecklistRepository{ctrl: ctrl}
+	mock.recorder = &MockChecklistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChecklistRepository) EXPECT() *MockChecklistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockChecklistRepository) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockChecklistRepositoryMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockChecklistRepository)(nil).Close))
+}
+
+// Create mocks base method.
+func (m *MockChecklistRepository) Create(item *models.ChecklistItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChecklistRepositoryMockRecorder) Create(item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChecklistRepository)(nil).Create), item)
+}
+
+// Delete mocks base method.
+func (m *MockChecklistRepository) Delete(userID, todoID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockChecklistRepositoryMockRecorder) Delete(userID, todoID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockChecklistRepository)(nil).Delete), userID, todoID, id)
+}
+
+// FindById mocks base method.
+func (m *MockChecklistRepository) FindById(userID, todoID, id uint) (*models.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", userID, todoID, id)
+	ret0, _ := ret[0].(*models.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockChecklistRepositoryMockRecorder) FindById(userID, todoID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockChecklistRepository)(nil).FindById), userID, todoID, id)
+}
+
+// FindByTodo mocks base method.
+func (m *MockChecklistRepository) FindByTodo(userID, todoID uint) (*[]models.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTodo", userID, todoID)
+	ret0, _ := ret[0].(*[]models.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTodo indicates an expected call of FindByTodo.
+func (mr *MockChecklistRepositoryMockRecorder) FindByTodo(userID, todoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTodo", reflect.TypeOf((*MockChecklistRepository)(nil).FindByTodo), userID, todoID)
+}
+
+// Reorder mocks base method.
+func (m *MockChecklistRepository) Reorder(userID, todoID uint, ids []uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", userID, todoID, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockChecklistRepositoryMockRecorder) Reorder(userID, todoID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockChecklistRepository)(nil).Reorder), userID, todoID, ids)
+}
+
+// Update mocks base method.
+func (m *MockChecklistRepository) Update(userID uint, item *models.ChecklistItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userID, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChecklistRepositoryMockRecorder) Update(userID, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChecklistRepository)(nil).Update), userID, item)
+}
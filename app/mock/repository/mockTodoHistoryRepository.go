@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/domain/repository/todoHistoryRepository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/domain/repository/todoHistoryRepository.go -destination=app/mock/repository/mockTodoHistoryRepository.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	models "github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTodoHistoryRepository is a mock of TodoHistoryRepository interface.
+type MockTodoHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodoHistoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTodoHistoryRepositoryMockRecorder is the mock recorder for MockTodoHistoryRepository.
+type MockTodoHistoryRepositoryMockRecorder struct {
+	mock *MockTodoHistoryRepository
+}
+
+// NewMockTodoHistoryRepository creates a new mock instance.
+func NewMockTodoHistoryRepository(ctrl *gomock.Controller) *MockTodoHistoryRepository {
+	mock := &MockTodoHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockTodoHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodoHistoryRepository) EXPECT() *MockTodoHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockTodoHistoryRepository) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockTodoHistoryRepositoryMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockTodoHistoryRepository)(nil).Close))
+}
+
+// FindByTodo mocks base method.
+func (m *MockTodoHistoryRepository) FindByTodo(userID, todoID uint) (*[]models.TodoHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTodo", userID, todoID)
+	ret0, _ := ret[0].(*[]models.TodoHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTodo indicates an expected call of FindByTodo.
+func (mr *MockTodoHistoryRepositoryMockRecorder) FindByTodo(userID, todoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTodo", reflect.TypeOf((*MockTodoHistoryRepository)(nil).FindByTodo), userID, todoID)
+}
@@ -11,6 +11,7 @@ package mock_repository
 
 import (
 	reflect "reflect"
+	time "time"
 
 	models "github.com/MinadukiSekina/todo-go-app/app/domain/models"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +41,21 @@ func (m *MockTodoRepository) EXPECT() *MockTodoRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Bulk mocks base method.
+func (m *MockTodoRepository) Bulk(bulk *models.TodoBulk) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", bulk)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockTodoRepositoryMockRecorder) Bulk(bulk any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockTodoRepository)(nil).Bulk), bulk)
+}
+
 // Close mocks base method.
 func (m *MockTodoRepository) Close() error {
 	m.ctrl.T.Helper()
@@ -55,73 +71,245 @@ func (mr *MockTodoRepositoryMockRecorder) Close() *gomock.Call {
 }
 
 // Create mocks base method.
-func (m *MockTodoRepository) Create(todo *models.Todo) error {
+func (m *MockTodoRepository) Create(todo *models.Todo, histories ...models.TodoHistory) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", todo)
+	varargs := []any{todo}
+	for _, a := range histories {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockTodoRepositoryMockRecorder) Create(todo any) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) Create(todo any, histories ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoRepository)(nil).Create), todo)
+	varargs := append([]any{todo}, histories...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoRepository)(nil).Create), varargs...)
 }
 
 // Delete mocks base method.
-func (m *MockTodoRepository) Delete(id uint) error {
+func (m *MockTodoRepository) Delete(userID, id uint, histories ...models.TodoHistory) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	varargs := []any{userID, id}
+	for _, a := range histories {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTodoRepositoryMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) Delete(userID, id any, histories ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoRepository)(nil).Delete), id)
+	varargs := append([]any{userID, id}, histories...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoRepository)(nil).Delete), varargs...)
 }
 
 // FindAll mocks base method.
-func (m *MockTodoRepository) FindAll() (*[]models.Todo, error) {
+func (m *MockTodoRepository) FindAll(userID uint) (*[]models.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
+	ret := m.ctrl.Call(m, "FindAll", userID)
 	ret0, _ := ret[0].(*[]models.Todo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockTodoRepositoryMockRecorder) FindAll() *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) FindAll(userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTodoRepository)(nil).FindAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTodoRepository)(nil).FindAll), userID)
 }
 
 // FindById mocks base method.
-func (m *MockTodoRepository) FindById(id uint) (*models.Todo, error) {
+func (m *MockTodoRepository) FindById(userID, id uint) (*models.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindById", id)
+	ret := m.ctrl.Call(m, "FindById", userID, id)
 	ret0, _ := ret[0].(*models.Todo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindById indicates an expected call of FindById.
-func (mr *MockTodoRepositoryMockRecorder) FindById(id any) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) FindById(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockTodoRepository)(nil).FindById), userID, id)
+}
+
+// FindByIds mocks base method.
+func (m *MockTodoRepository) FindByIds(userID uint, ids []uint) (*[]models.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIds", userID, ids)
+	ret0, _ := ret[0].(*[]models.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIds indicates an expected call of FindByIds.
+func (mr *MockTodoRepositoryMockRecorder) FindByIds(userID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIds", reflect.TypeOf((*MockTodoRepository)(nil).FindByIds), userID, ids)
+}
+
+// FindSummaryById mocks base method.
+func (m *MockTodoRepository) FindSummaryById(userID, id uint) (*models.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSummaryById", userID, id)
+	ret0, _ := ret[0].(*models.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSummaryById indicates an expected call of FindSummaryById.
+func (mr *MockTodoRepositoryMockRecorder) FindSummaryById(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSummaryById", reflect.TypeOf((*MockTodoRepository)(nil).FindSummaryById), userID, id)
+}
+
+// FindTrash mocks base method.
+func (m *MockTodoRepository) FindTrash(userID uint) (*[]models.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTrash", userID)
+	ret0, _ := ret[0].(*[]models.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTrash indicates an expected call of FindTrash.
+func (mr *MockTodoRepositoryMockRecorder) FindTrash(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTrash", reflect.TypeOf((*MockTodoRepository)(nil).FindTrash), userID)
+}
+
+// FindTrashedBefore mocks base method.
+func (m *MockTodoRepository) FindTrashedBefore(before time.Time) (*[]models.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTrashedBefore", before)
+	ret0, _ := ret[0].(*[]models.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTrashedBefore indicates an expected call of FindTrashedBefore.
+func (mr *MockTodoRepositoryMockRecorder) FindTrashedBefore(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTrashedBefore", reflect.TypeOf((*MockTodoRepository)(nil).FindTrashedBefore), before)
+}
+
+// FindTrashedById mocks base method.
+func (m *MockTodoRepository) FindTrashedById(userID, id uint) (*models.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTrashedById", userID, id)
+	ret0, _ := ret[0].(*models.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTrashedById indicates an expected call of FindTrashedById.
+func (mr *MockTodoRepositoryMockRecorder) FindTrashedById(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTrashedById", reflect.TypeOf((*MockTodoRepository)(nil).FindTrashedById), userID, id)
+}
+
+// Purge mocks base method.
+func (m *MockTodoRepository) Purge(userID, id uint, histories ...models.TodoHistory) error {
+	m.ctrl.T.Helper()
+	varargs := []any{userID, id}
+	for _, a := range histories {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Purge", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTodoRepositoryMockRecorder) Purge(userID, id any, histories ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{userID, id}, histories...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTodoRepository)(nil).Purge), varargs...)
+}
+
+// ReplaceTags mocks base method.
+func (m *MockTodoRepository) ReplaceTags(todo *models.Todo, tags []models.Tag, histories ...models.TodoHistory) error {
+	m.ctrl.T.Helper()
+	varargs := []any{todo, tags}
+	for _, a := range histories {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReplaceTags", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTags indicates an expected call of ReplaceTags.
+func (mr *MockTodoRepositoryMockRecorder) ReplaceTags(todo, tags any, histories ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockTodoRepository)(nil).FindById), id)
+	varargs := append([]any{todo, tags}, histories...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTags", reflect.TypeOf((*MockTodoRepository)(nil).ReplaceTags), varargs...)
+}
+
+// Restore mocks base method.
+func (m *MockTodoRepository) Restore(userID, id uint, histories ...models.TodoHistory) error {
+	m.ctrl.T.Helper()
+	varargs := []any{userID, id}
+	for _, a := range histories {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Restore", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTodoRepositoryMockRecorder) Restore(userID, id any, histories ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{userID, id}, histories...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTodoRepository)(nil).Restore), varargs...)
+}
+
+// Search mocks base method.
+func (m *MockTodoRepository) Search(query models.TodoQuery) (*[]models.Todo, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", query)
+	ret0, _ := ret[0].(*[]models.Todo)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTodoRepositoryMockRecorder) Search(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTodoRepository)(nil).Search), query)
 }
 
 // Update mocks base method.
-func (m *MockTodoRepository) Update(todo *models.Todo) error {
+func (m *MockTodoRepository) Update(todo *models.Todo, histories []models.TodoHistory, next *models.Todo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", todo)
+	ret := m.ctrl.Call(m, "Update", todo, histories, next)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockTodoRepositoryMockRecorder) Update(todo any) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) Update(todo, histories, next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoRepository)(nil).Update), todo, histories, next)
+}
+
+// UpdatePosition mocks base method.
+func (m *MockTodoRepository) UpdatePosition(userID, id uint, position string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePosition", userID, id, position)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePosition indicates an expected call of UpdatePosition.
+func (mr *MockTodoRepositoryMockRecorder) UpdatePosition(userID, id, position any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoRepository)(nil).Update), todo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePosition", reflect.TypeOf((*MockTodoRepository)(nil).UpdatePosition), userID, id, position)
 }
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/usecases/checklistUsecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/usecases/checklistUsecase.go -destination=app/mock/usecase/mockChecklistUsecase.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	reflect "reflect"
+
+	models "github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChecklistUsecase is a mock of ChecklistUsecase interface.
+type MockChecklistUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockChecklistUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockChecklistUsecaseMockRecorder is the mock recorder for MockChecklistUsecase.
+type MockChecklistUsecaseMockRecorder struct {
+	mock *MockChecklistUsecase
+}
+
+// NewMockChecklistUsecase creates a new mock instance.
+func NewMockChecklistUsecase(ctrl *gomock.Controller) *MockChecklistUsecase {
+	mock := &MockChecklistUsecase{ctrl: ctrl}
+	mock.recorder = &MockChecklistUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChecklistUsecase) EXPECT() *MockChecklistUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockChecklistUsecase) Add(userID, todoID uint, title string) (*models.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", userID, todoID, title)
+	ret0, _ := ret[0].(*models.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockChecklistUsecaseMockRecorder) Add(userID, todoID, title any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockChecklistUsecase)(nil).Add), userID, todoID, title)
+}
+
+// Close mocks base method.
+func (m *MockChecklistUsecase) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockChecklistUsecaseMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockChecklistUsecase)(nil).Close))
+}
+
+// Delete mocks base method.
+func (m *MockChecklistUsecase) Delete(userID, todoID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockChecklistUsecaseMockRecorder) Delete(userID, todoID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockChecklistUsecase)(nil).Delete), userID, todoID, id)
+}
+
+// List mocks base method.
+func (m *MockChecklistUsecase) List(userID, todoID uint) (*[]models.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID, todoID)
+	ret0, _ := ret[0].(*[]models.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockChecklistUsecaseMockRecorder) List(userID, todoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockChecklistUsecase)(nil).List), userID, todoID)
+}
+
+// Move mocks base method.
+func (m *MockChecklistUsecase) Move(userID, todoID, id uint, position int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", userID, todoID, id, position)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockChecklistUsecaseMockRecorder) Move(userID, todoID, id, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockChecklistUsecase)(nil).Move), userID, todoID, id, position)
+}
+
+// Update mocks base method.
+func (m *MockChecklistUsecase) Update(userID, todoID, id uint, title *string, done *bool) (*models.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userID, todoID, id, title, done)
+	ret0, _ := ret[0].(*models.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChecklistUsecaseMockRecorder) Update(userID, todoID, id, title, done any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChecklistUsecase)(nil).Update), userID, todoID, id, title, done)
+}
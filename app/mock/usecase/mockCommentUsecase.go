@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/usecases/commentUsecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/usecases/commentUsecase.go -destination=app/mock/usecase/mockCommentUsecase.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	reflect "reflect"
+
+	models "github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCommentUsecase is a mock of CommentUsecase interface.
+type MockCommentUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockCommentUsecaseMockRecorder is the mock recorder for MockCommentUsecase.
+type MockCommentUsecaseMockRecorder struct {
+	mock *MockCommentUsecase
+}
+
+// NewMockCommentUsecase creates a new mock instance.
+func NewMockCommentUsecase(ctrl *gomock.Controller) *MockCommentUsecase {
+	mock := &MockCommentUsecase{ctrl: ctrl}
+	mock.recorder = &MockCommentUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentUsecase) EXPECT() *MockCommentUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockCommentUsecase) Add(userID, todoID uint, body string) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", userID, todoID, body)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockCommentUsecaseMockRecorder) Add(userID, todoID, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCommentUsecase)(nil).Add), userID, todoID, body)
+}
+
+// Close mocks base method.
+func (m *MockCommentUsecase) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockCommentUsecaseMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockCommentUsecase)(nil).Close))
+}
+
+// Delete mocks base method.
+func (m *MockCommentUsecase) Delete(userID, todoID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentUsecaseMockRecorder) Delete(userID, todoID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentUsecase)(nil).Delete), userID, todoID, id)
+}
+
+// Edit mocks base method.
+func (m *MockCommentUsecase) Edit(userID, todoID, id uint, body string) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Edit", userID, todoID, id, body)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Edit indicates an expected call of Edit.
+func (mr *MockCommentUsecaseMockRecorder) Edit(userID, todoID, id, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Edit", reflect.TypeOf((*MockCommentUsecase)(nil).Edit), userID, todoID, id, body)
+}
+
+// List mocks base method.
+func (m *MockCommentUsecase) List(userID, todoID uint) (*[]models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID, todoID)
+	ret0, _ := ret[0].(*[]models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCommentUsecaseMockRecorder) List(userID, todoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCommentUsecase)(nil).List), userID, todoID)
+}
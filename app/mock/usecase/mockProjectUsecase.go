@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/usecases/projectUsecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/usecases/projectUsecase.go -destination=app/mock/usecase/mockProjectUsecase.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	reflect "reflect"
+
+	models "github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProjectUsecase is a mock of ProjectUsecase interface.
+type MockProjectUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockProjectUsecaseMockRecorder is the mock recorder for MockProjectUsecase.
+type MockProjectUsecaseMockRecorder struct {
+	mock *MockProjectUsecase
+}
+
+// NewMockProjectUsecase creates a new mock instance.
+func NewMockProjectUsecase(ctrl *gomock.Controller) *MockProjectUsecase {
+	mock := &MockProjectUsecase{ctrl: ctrl}
+	mock.recorder = &MockProjectUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectUsecase) EXPECT() *MockProjectUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockProjectUsecase) Add(userID uint, name string) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", userID, name)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockProjectUsecaseMockRecorder) Add(userID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockProjectUsecase)(nil).Add), userID, name)
+}
+
+// Close mocks base method.
+func (m *MockProjectUsecase) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockProjectUsecaseMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockProjectUsecase)(nil).Close))
+}
+
+// Delete mocks base method.
+func (m *MockProjectUsecase) Delete(userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProjectUsecaseMockRecorder) Delete(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProjectUsecase)(nil).Delete), userID, id)
+}
+
+// List mocks base method.
+func (m *MockProjectUsecase) List(userID uint) (*[]models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID)
+	ret0, _ := ret[0].(*[]models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockProjectUsecaseMockRecorder) List(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockProjectUsecase)(nil).List), userID)
+}
+
+// Rename mocks base method.
+func (m *MockProjectUsecase) Rename(userID, id uint, name string) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", userID, id, name)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockProjectUsecaseMockRecorder) Rename(userID, id, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockProjectUsecase)(nil).Rename), userID, id, name)
+}
+
+// SearchByID mocks base method.
+func (m *MockProjectUsecase) SearchByID(userID, id uint) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchByID", userID, id)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchByID indicates an expected call of SearchByID.
+func (mr *MockProjectUsecaseMockRecorder) SearchByID(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByID", reflect.TypeOf((*MockProjectUsecase)(nil).SearchByID), userID, id)
+}
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/usecases/tagUsecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/usecases/tagUsecase.go -destination=app/mock/usecase/mockTagUsecase.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	reflect "reflect"
+
+	models "github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTagUsecase is a mock of TagUsecase interface.
+type MockTagUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockTagUsecaseMockRecorder is the mock recorder for MockTagUsecase.
+type MockTagUsecaseMockRecorder struct {
+	mock *MockTagUsecase
+}
+
+// NewMockTagUsecase creates a new mock instance.
+func NewMockTagUsecase(ctrl *gomock.Controller) *MockTagUsecase {
+	mock := &MockTagUsecase{ctrl: ctrl}
+	mock.recorder = &MockTagUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagUsecase) EXPECT() *MockTagUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockTagUsecase) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockTagUsecaseMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockTagUsecase)(nil).Close))
+}
+
+// Delete mocks base method.
+func (m *MockTagUsecase) Delete(userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTagUsecaseMockRecorder) Delete(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTagUsecase)(nil).Delete), userID, id)
+}
+
+// List mocks base method.
+func (m *MockTagUsecase) List(userID uint) (*[]models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID)
+	ret0, _ := ret[0].(*[]models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTagUsecaseMockRecorder) List(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTagUsecase)(nil).List), userID)
+}
+
+// Merge mocks base method.
+func (m *MockTagUsecase) Merge(userID, fromID, intoID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", userID, fromID, intoID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockTagUsecaseMockRecorder) Merge(userID, fromID, intoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockTagUsecase)(nil).Merge), userID, fromID, intoID)
+}
+
+// Rename mocks base method.
+func (m *MockTagUsecase) Rename(userID, id uint, name string) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", userID, id, name)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockTagUsecaseMockRecorder) Rename(userID, id, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockTagUsecase)(nil).Rename), userID, id, name)
+}
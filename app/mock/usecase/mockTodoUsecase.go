@@ -10,7 +10,9 @@
 package mock_usecases
 
 import (
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/MinadukiSekina/todo-go-app/app/domain/models"
 	gomock "go.uber.org/mock/gomock"
@@ -54,6 +56,36 @@ func (mr *MockTodoUsecaseMockRecorder) Add(todo any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockTodoUsecase)(nil).Add), todo)
 }
 
+// Attach mocks base method.
+func (m *MockTodoUsecase) Attach(userID, todoID uint, fileName string, size int64, r io.Reader) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", userID, todoID, fileName, size, r)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockTodoUsecaseMockRecorder) Attach(userID, todoID, fileName, size, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockTodoUsecase)(nil).Attach), userID, todoID, fileName, size, r)
+}
+
+// Bulk mocks base method.
+func (m *MockTodoUsecase) Bulk(req *models.BulkRequest) (models.BulkResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", req)
+	ret0, _ := ret[0].(models.BulkResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockTodoUsecaseMockRecorder) Bulk(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockTodoUsecase)(nil).Bulk), req)
+}
+
 // Close mocks base method.
 func (m *MockTodoUsecase) Close() error {
 	m.ctrl.T.Helper()
@@ -69,17 +101,31 @@ func (mr *MockTodoUsecaseMockRecorder) Close() *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockTodoUsecase) Delete(id uint) error {
+func (m *MockTodoUsecase) Delete(userID, id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", userID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTodoUsecaseMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockTodoUsecaseMockRecorder) Delete(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoUsecase)(nil).Delete), userID, id)
+}
+
+// Detach mocks base method.
+func (m *MockTodoUsecase) Detach(userID, todoID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detach", userID, todoID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Detach indicates an expected call of Detach.
+func (mr *MockTodoUsecaseMockRecorder) Detach(userID, todoID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoUsecase)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detach", reflect.TypeOf((*MockTodoUsecase)(nil).Detach), userID, todoID, id)
 }
 
 // Edit mocks base method.
@@ -96,32 +142,164 @@ func (mr *MockTodoUsecaseMockRecorder) Edit(todo any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Edit", reflect.TypeOf((*MockTodoUsecase)(nil).Edit), todo)
 }
 
+// History mocks base method.
+func (m *MockTodoUsecase) History(userID, todoID uint) (*[]models.TodoHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", userID, todoID)
+	ret0, _ := ret[0].(*[]models.TodoHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockTodoUsecaseMockRecorder) History(userID, todoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockTodoUsecase)(nil).History), userID, todoID)
+}
+
+// Move mocks base method.
+func (m *MockTodoUsecase) Move(userID, id, afterID, beforeID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", userID, id, afterID, beforeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTodoUsecaseMockRecorder) Move(userID, id, afterID, beforeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTodoUsecase)(nil).Move), userID, id, afterID, beforeID)
+}
+
+// OpenAttachment mocks base method.
+func (m *MockTodoUsecase) OpenAttachment(userID, todoID, id uint) (*models.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenAttachment", userID, todoID, id)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenAttachment indicates an expected call of OpenAttachment.
+func (mr *MockTodoUsecaseMockRecorder) OpenAttachment(userID, todoID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAttachment", reflect.TypeOf((*MockTodoUsecase)(nil).OpenAttachment), userID, todoID, id)
+}
+
+// Purge mocks base method.
+func (m *MockTodoUsecase) Purge(userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTodoUsecaseMockRecorder) Purge(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTodoUsecase)(nil).Purge), userID, id)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockTodoUsecase) PurgeExpired(before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockTodoUsecaseMockRecorder) PurgeExpired(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockTodoUsecase)(nil).PurgeExpired), before)
+}
+
+// Restore mocks base method.
+func (m *MockTodoUsecase) Restore(userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTodoUsecaseMockRecorder) Restore(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTodoUsecase)(nil).Restore), userID, id)
+}
+
+// Search mocks base method.
+func (m *MockTodoUsecase) Search(query models.TodoQuery) (*models.TodoPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", query)
+	ret0, _ := ret[0].(*models.TodoPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTodoUsecaseMockRecorder) Search(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTodoUsecase)(nil).Search), query)
+}
+
 // SearchByID mocks base method.
-func (m *MockTodoUsecase) SearchByID(arg0 uint) (*models.Todo, error) {
+func (m *MockTodoUsecase) SearchByID(userID, id uint) (*models.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchByID", arg0)
+	ret := m.ctrl.Call(m, "SearchByID", userID, id)
 	ret0, _ := ret[0].(*models.Todo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchByID indicates an expected call of SearchByID.
-func (mr *MockTodoUsecaseMockRecorder) SearchByID(arg0 any) *gomock.Call {
+func (mr *MockTodoUsecaseMockRecorder) SearchByID(userID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByID", reflect.TypeOf((*MockTodoUsecase)(nil).SearchByID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByID", reflect.TypeOf((*MockTodoUsecase)(nil).SearchByID), userID, id)
+}
+
+// SetTags mocks base method.
+func (m *MockTodoUsecase) SetTags(userID, todoID uint, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTags", userID, todoID, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTags indicates an expected call of SetTags.
+func (mr *MockTodoUsecaseMockRecorder) SetTags(userID, todoID, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTags", reflect.TypeOf((*MockTodoUsecase)(nil).SetTags), userID, todoID, names)
 }
 
 // Show mocks base method.
-func (m *MockTodoUsecase) Show() (*[]models.Todo, error) {
+func (m *MockTodoUsecase) Show(userID uint) (*[]models.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Show")
+	ret := m.ctrl.Call(m, "Show", userID)
 	ret0, _ := ret[0].(*[]models.Todo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Show indicates an expected call of Show.
-func (mr *MockTodoUsecaseMockRecorder) Show() *gomock.Call {
+func (mr *MockTodoUsecaseMockRecorder) Show(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Show", reflect.TypeOf((*MockTodoUsecase)(nil).Show), userID)
+}
+
+// Trash mocks base method.
+func (m *MockTodoUsecase) Trash(userID uint) (*[]models.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trash", userID)
+	ret0, _ := ret[0].(*[]models.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Trash indicates an expected call of Trash.
+func (mr *MockTodoUsecaseMockRecorder) Trash(userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Show", reflect.TypeOf((*MockTodoUsecase)(nil).Show))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trash", reflect.TypeOf((*MockTodoUsecase)(nil).Trash), userID)
 }
@@ -0,0 +1,45 @@
+// 説明の編集欄の「プレビュー」で、入力中のMarkdownを表示と同じHTMLに変換して表示する
+// 変換はサーバーで行い、サニタイズ済みのHTMLを受け取る
+const descriptionEditor = document.querySelector('.description-editor');
+if (descriptionEditor) {
+    const preview = document.querySelector('.description-preview');
+    const tabs = document.querySelectorAll('.description-tab');
+
+    // 編集欄とプレビューの表示を切り替える
+    const showTab = (name) => {
+        tabs.forEach(tab => tab.classList.toggle('active', tab.dataset.tab === name));
+        descriptionEditor.hidden = name !== 'edit';
+        preview.hidden = name !== 'preview';
+    };
+
+    // 入力中の説明をHTMLに変換して表示する
+    const renderPreview = async () => {
+        const body = new URLSearchParams({ description: descriptionEditor.value });
+        try {
+            const response = await fetch('/todo/preview', {
+                method: 'POST',
+                headers: { 'X-CSRF-Token': descriptionEditor.dataset.csrfToken },
+                body: body,
+            });
+            if (!response.ok) {
+                const data = await response.json().catch(() => ({}));
+                throw new Error(data.error || 'プレビューを表示できませんでした。');
+            }
+            preview.innerHTML = await response.text();
+            if (preview.innerHTML.trim() === '') {
+                preview.textContent = '説明はありません。';
+            }
+        } catch (error) {
+            preview.textContent = error.message;
+        }
+    };
+
+    tabs.forEach(tab => {
+        tab.addEventListener('click', async () => {
+            if (tab.dataset.tab === 'preview') {
+                await renderPreview();
+            }
+            showTab(tab.dataset.tab);
+        });
+    });
+}
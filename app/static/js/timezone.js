@@ -0,0 +1,16 @@
+// 期限のタイムゾーンが未設定の場合は、ブラウザのタイムゾーンを設定する
+const browserTimezone = Intl.DateTimeFormat().resolvedOptions().timeZone;
+document.querySelectorAll('input[name="due_timezone"], input[name="timezone"]').forEach(input => {
+    if (input.value === '') {
+        input.value = browserTimezone;
+    }
+});
+
+// 期限で絞り込むリンクに、今日・今週の境界を求めるためのブラウザのタイムゾーンを付ける
+document.querySelectorAll('a[data-timezone]').forEach(link => {
+    const url = new URL(link.href);
+    if (!url.searchParams.has('timezone')) {
+        url.searchParams.set('timezone', browserTimezone);
+        link.href = url.toString();
+    }
+});
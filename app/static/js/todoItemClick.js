@@ -1,5 +1,10 @@
 document.querySelectorAll('.todo-item').forEach(item => {
-    item.addEventListener('click', () => {
+    item.addEventListener('click', (event) => {
+    // タグなどのリンクをクリックした場合は、リンク先に移動する
+    // 一括操作の選択のチェックボックスをクリックした場合は、選択のみ切り替える
+    if (event.target.closest('a, input')) {
+        return;
+    }
     const id = item.dataset.id;  // data-id属性からIDを取得
     window.location.href = `/todo/${id}`;
     });
@@ -0,0 +1,74 @@
+// 手動の並び順で表示している場合に、一覧のtodoをドラッグ＆ドロップで並び替える
+// 並び替えは同じ状態のtodoの中でのみ行い、移動先の前後のtodoのIDをサーバーに送信して保存する
+const reorderList = document.querySelector('.todo-items[data-reorderable="true"]');
+if (reorderList) {
+    let dragging = null;
+    let originalNext = null;
+
+    // 同じ状態の隣のtodoを返す（無い場合はnull）
+    const sameStatusSibling = (item, sibling) => {
+        if (sibling && sibling.classList.contains('todo-item') && sibling.dataset.status === item.dataset.status) {
+            return sibling;
+        }
+        return null;
+    };
+
+    // 移動先をサーバーに保存する（失敗した場合はメッセージを表示して再読み込みする）
+    const saveMove = async (item) => {
+        const prev = sameStatusSibling(item, item.previousElementSibling);
+        const next = sameStatusSibling(item, item.nextElementSibling);
+        const body = new URLSearchParams({
+            after_id: prev ? prev.dataset.id : '',
+            before_id: next ? next.dataset.id : '',
+        });
+        try {
+            const response = await fetch(`/todo/${item.dataset.id}/move`, {
+                method: 'POST',
+                headers: { 'X-CSRF-Token': reorderList.dataset.csrfToken },
+                body: body,
+            });
+            if (!response.ok) {
+                const data = await response.json().catch(() => ({}));
+                throw new Error(data.error || 'タスクを移動できませんでした。');
+            }
+        } catch (error) {
+            alert(error.message);
+            window.location.reload();
+        }
+    };
+
+    reorderList.querySelectorAll('.todo-item').forEach(item => {
+        item.draggable = true;
+        item.addEventListener('dragstart', (event) => {
+            dragging = item;
+            originalNext = item.nextElementSibling;
+            item.classList.add('todo-dragging');
+            event.dataTransfer.effectAllowed = 'move';
+        });
+        item.addEventListener('dragover', (event) => {
+            if (!dragging || dragging === item || dragging.dataset.status !== item.dataset.status) {
+                return;
+            }
+            event.preventDefault();
+            // 要素の上半分では前に、下半分では後ろに入れる
+            const rect = item.getBoundingClientRect();
+            const after = event.clientY > rect.top + rect.height / 2;
+            reorderList.insertBefore(dragging, after ? item.nextElementSibling : item);
+        });
+        item.addEventListener('drop', (event) => {
+            event.preventDefault();
+        });
+        item.addEventListener('dragend', (event) => {
+            item.classList.remove('todo-dragging');
+            dragging = null;
+            // 一覧の外でドロップした場合は元の位置に戻す
+            if (event.dataTransfer.dropEffect === 'none') {
+                reorderList.insertBefore(item, originalNext);
+                return;
+            }
+            if (item.nextElementSibling !== originalNext) {
+                saveMove(item);
+            }
+        });
+    });
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+)
+
+// ローカルのファイルシステムにファイルを保存するストレージの構造体
+// キーのスラッシュ区切りを、保存先のディレクトリの下の階層に対応させる
+type localStorage struct {
+	dir string
+}
+
+// 指定されたディレクトリに保存するBlobStorageを生成して返す
+// ディレクトリが無い場合は作成する
+func NewLocalStorage(dir string) (repository.BlobStorage, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage dir: %w", err)
+	}
+	storage := localStorage{dir: dir}
+	return &storage, nil
+}
+
+// ファイルを保存する
+// 書き込み途中のファイルを読まれないよう、一時ファイルに書き込んでから置き換える
+func (s *localStorage) Put(key string, r io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	// 置き換えに成功した場合は一時ファイルが無いため、削除のエラーは無視する
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, io.LimitReader(r, size+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if n != size {
+		return fmt.Errorf("size mismatch: got %d bytes, want %d", n, size)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// 保存したファイルを読み込むReaderを返す
+func (s *localStorage) Get(key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", repository.ErrBlobNotFound, key)
+	}
+	return f, err
+}
+
+// 保存したファイルを削除する
+func (s *localStorage) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// ストレージの終了処理を行う
+// 閉じるものは無いため、何もしない
+func (s *localStorage) Close() error {
+	return nil
+}
+
+// キーに対応するファイルのパスを返す
+// 保存先のディレクトリの外を指さないよう、".."などを含むキーはエラーとする
+func (s *localStorage) path(key string) (string, error) {
+	if !fs.ValidPath(key) || key == "." {
+		return "", fmt.Errorf("invalid storage key: %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+
+	cases := map[string]struct {
+		// ストレージに対する操作
+		run func(s repository.BlobStorage) error
+		// 操作後に"todos/1/a"から読み込める内容（存在しない場合は空文字列）
+		want      string
+		expectErr error
+	}{
+		"正常ケース:保存": {
+			run: func(s repository.BlobStorage) error {
+				return s.Put("todos/1/a", strings.NewReader("hello"), 5, "text/plain")
+			},
+			want: "hello",
+		},
+		"正常ケース:上書き": {
+			run: func(s repository.BlobStorage) error {
+				if err := s.Put("todos/1/a", strings.NewReader("hello"), 5, "text/plain"); err != nil {
+					return err
+				}
+				return s.Put("todos/1/a", strings.NewReader("bye"), 3, "text/plain")
+			},
+			want: "bye",
+		},
+		"正常ケース:削除": {
+			run: func(s repository.BlobStorage) error {
+				if err := s.Put("todos/1/a", strings.NewReader("hello"), 5, "text/plain"); err != nil {
+					return err
+				}
+				return s.Delete("todos/1/a")
+			},
+		},
+		"正常ケース:存在しないファイルの削除": {
+			run: func(s repository.BlobStorage) error {
+				return s.Delete("todos/1/a")
+			},
+		},
+		"異常ケース:大きさが一致しない場合は保存しない": {
+			run: func(s repository.BlobStorage) error {
+				return s.Put("todos/1/a", strings.NewReader("hello"), 10, "text/plain")
+			},
+			expectErr: errors.New("size mismatch"),
+		},
+		"異常ケース:保存先のディレクトリの外を指すキー": {
+			run: func(s repository.BlobStorage) error {
+				return s.Put("../a", strings.NewReader("hello"), 5, "text/plain")
+			},
+			expectErr: errors.New("invalid storage key"),
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			s, err := NewLocalStorage(filepath.Join(dir, "uploads"))
+			if err != nil {
+				t.Fatalf("failed to create storage: %v", err)
+			}
+
+			err = tt.run(s)
+
+			// 結果を確認
+			if tt.expectErr != nil {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.expectErr.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			r, err := s.Get("todos/1/a")
+			if tt.want == "" {
+				assert.True(t, errors.Is(err, repository.ErrBlobNotFound), err)
+			} else if assert.NoError(t, err) {
+				defer r.Close()
+				body, _ := io.ReadAll(r)
+				assert.Equal(t, tt.want, string(body))
+			}
+
+			// 一時ファイルが残らないこと
+			entries, _ := os.ReadDir(filepath.Join(dir, "uploads", "todos", "1"))
+			for _, entry := range entries {
+				assert.False(t, strings.HasPrefix(entry.Name(), ".upload-"), entry.Name())
+			}
+		})
+	}
+}
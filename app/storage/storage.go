@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+)
+
+// 設定に応じた添付ファイルの保存先を生成して返す
+func New(cfg config.StorageConfig) (repository.BlobStorage, error) {
+	switch cfg.Driver {
+	case "local":
+		return NewLocalStorage(cfg.Dir)
+	case "s3":
+		return NewS3Storage(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %q", cfg.Driver)
+	}
+}
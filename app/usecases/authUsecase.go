@@ -0,0 +1,159 @@
+package usecases
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+)
+
+// 認証に関わるエラー
+var (
+	// 登録済みのメールアドレスで登録しようとした
+	ErrEmailAlreadyUsed = models.Conflict(errors.New("email is already used"))
+	// メールアドレスかパスワードが誤っている
+	ErrInvalidCredentials = models.Unauthenticated(errors.New("email or password is incorrect"))
+	// セッションが存在しないか、失効している
+	ErrUnauthenticated = models.Unauthenticated(errors.New("not authenticated"))
+)
+
+// 認証のユースケースのインターフェイス
+type AuthUsecase interface {
+	interfaces.Closer
+	SignUp(email string, password string) (*models.Session, error)
+	Login(email string, password string) (*models.Session, error)
+	Authenticate(token string) (*models.User, error)
+	Logout(token string) error
+}
+
+// 認証に関わるユースケースの構造体
+type authUsecase struct {
+	users    repository.UserRepository
+	sessions repository.SessionRepository
+	// セッションが失効するまでの時間
+	ttl time.Duration
+	// 現在日時を返す関数（テストで差し替えられるようにする）
+	now func() time.Time
+}
+
+// AuthUsecaseの新しいインスタンスを作成して返す
+func NewAuthUsecase(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, ttl time.Duration) AuthUsecase {
+	authUsecase := authUsecase{users: userRepo, sessions: sessionRepo, ttl: ttl, now: time.Now}
+	return &authUsecase
+}
+
+// ユーザーを登録し、ログインした状態のセッションを返す
+func (uc *authUsecase) SignUp(email string, password string) (*models.Session, error) {
+	var user models.User
+	if err := user.SetEmail(email); err != nil {
+		return nil, err
+	}
+	if err := user.SetPassword(password); err != nil {
+		return nil, err
+	}
+
+	_, err := uc.users.FindByEmail(user.Email)
+	if err == nil {
+		return nil, ErrEmailAlreadyUsed
+	}
+	if models.KindOf(err) != models.KindNotFound {
+		return nil, err
+	}
+	if err := uc.users.Create(&user); err != nil {
+		// 確認から登録までの間に同じメールアドレスで登録された場合は、一意制約で競合する
+		if models.KindOf(err) == models.KindConflict {
+			return nil, ErrEmailAlreadyUsed
+		}
+		return nil, err
+	}
+	return uc.startSession(user.ID)
+}
+
+// メールアドレスとパスワードを確認し、新しいセッションを返す
+func (uc *authUsecase) Login(email string, password string) (*models.Session, error) {
+	user, err := uc.users.FindByEmail(email)
+	if err != nil {
+		if models.KindOf(err) != models.KindNotFound {
+			return nil, err
+		}
+		// 応答時間の差から登録済みのメールアドレスを推測されないよう、存在しない場合もハッシュを比較する
+		dummyUser().CheckPassword(password)
+		return nil, ErrInvalidCredentials
+	}
+	if !user.CheckPassword(password) {
+		return nil, ErrInvalidCredentials
+	}
+
+	// ログインのついでに失効したセッションを掃除する
+	if err := uc.sessions.DeleteExpired(uc.now()); err != nil {
+		slog.Warn("failed to delete expired sessions", "error", err)
+	}
+	return uc.startSession(user.ID)
+}
+
+// セッションのトークンからログイン中のユーザーを返す
+func (uc *authUsecase) Authenticate(token string) (*models.User, error) {
+	if token == "" {
+		return nil, ErrUnauthenticated
+	}
+	session, err := uc.sessions.FindById(models.HashSessionToken(token))
+	if err != nil {
+		if models.KindOf(err) == models.KindNotFound {
+			return nil, ErrUnauthenticated
+		}
+		return nil, err
+	}
+	if session.IsExpired(uc.now()) {
+		return nil, ErrUnauthenticated
+	}
+
+	user, err := uc.users.FindById(session.UserID)
+	if err != nil {
+		if models.KindOf(err) == models.KindNotFound {
+			return nil, ErrUnauthenticated
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// セッションを破棄する
+func (uc *authUsecase) Logout(token string) error {
+	if token == "" {
+		return nil
+	}
+	return uc.sessions.Delete(models.HashSessionToken(token))
+}
+
+// ユースケースの終了処理を行う
+func (uc *authUsecase) Close() error {
+	err := errors.Join(uc.users.Close(), uc.sessions.Close())
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	return err
+}
+
+// 指定されたユーザーの新しいセッションを発行して保存する
+func (uc *authUsecase) startSession(userID uint) (*models.Session, error) {
+	session, err := models.NewSession(userID, uc.now().UTC(), uc.ttl)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.sessions.Create(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// 存在しないユーザーのログイン時に比較に使用するユーザー
+// ハッシュの生成には時間がかかるため、初めて使用する時に一度だけ生成する
+var dummyUser = sync.OnceValue(func() models.User {
+	var user models.User
+	_ = user.SetPassword("dummy-password")
+	return user
+})
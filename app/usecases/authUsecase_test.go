@@ -0,0 +1,289 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_repository "github.com/MinadukiSekina/todo-go-app/app/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// テスト用の現在日時
+var authNow = time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+// モックを利用したAuthUsecaseを生成する
+func newTestAuthUsecase(users *mock_repository.MockUserRepository, sessions *mock_repository.MockSessionRepository) *authUsecase {
+	return &authUsecase{users: users, sessions: sessions, ttl: time.Hour, now: func() time.Time { return authNow }}
+}
+
+// パスワードを設定したテスト用のユーザーを生成する
+func newTestUser(t *testing.T, email string, password string) *models.User {
+	t.Helper()
+	user := models.User{Email: email}
+	if err := user.SetPassword(password); err != nil {
+		t.Fatalf("failed to set password: %v", err)
+	}
+	user.ID = 1
+	return &user
+}
+
+func TestSignUp(t *testing.T) {
+
+	type args struct {
+		email    string
+		password string
+	}
+
+	cases := map[string]struct {
+		args          args
+		prepareMockFn func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository)
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:登録してセッションを発行": {
+			args: args{email: "User@Example.com", password: "password"},
+			prepareMockFn: func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository) {
+				u.EXPECT().FindByEmail("user@example.com").Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+				u.EXPECT().Create(gomock.Any()).DoAndReturn(func(user *models.User) error {
+					user.ID = 10
+					return nil
+				})
+				s.EXPECT().Create(gomock.Cond(func(session *models.Session) bool {
+					return session.UserID == 10 && session.ExpiresAt.Equal(authNow.Add(time.Hour))
+				})).Return(nil)
+			},
+		},
+		"異常ケース:登録済みのメールアドレス": {
+			args: args{email: "user@example.com", password: "password"},
+			prepareMockFn: func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository) {
+				u.EXPECT().FindByEmail("user@example.com").Return(&models.User{Email: "user@example.com"}, nil)
+			},
+			expectErr: true,
+			err:       ErrEmailAlreadyUsed,
+		},
+		"異常ケース:確認後に同じメールアドレスで登録された": {
+			args: args{email: "user@example.com", password: "password"},
+			prepareMockFn: func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository) {
+				u.EXPECT().FindByEmail("user@example.com").Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+				u.EXPECT().Create(gomock.Any()).Return(models.Conflict(gorm.ErrDuplicatedKey))
+			},
+			expectErr: true,
+			err:       ErrEmailAlreadyUsed,
+		},
+		"異常ケース:DBに接続できない": {
+			args: args{email: "user@example.com", password: "password"},
+			prepareMockFn: func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository) {
+				u.EXPECT().FindByEmail("user@example.com").Return(nil, models.Unavailable(errors.New("connection refused")))
+			},
+			expectErr: true,
+		},
+		"異常ケース:メールアドレスの形式が不正": {
+			args:          args{email: "not an email", password: "password"},
+			prepareMockFn: func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository) {},
+			expectErr:     true,
+		},
+		"異常ケース:パスワードが短い": {
+			args:          args{email: "user@example.com", password: "short"},
+			prepareMockFn: func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository) {},
+			expectErr:     true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			users := mock_repository.NewMockUserRepository(mockCtrl)
+			sessions := mock_repository.NewMockSessionRepository(mockCtrl)
+			tt.prepareMockFn(users, sessions)
+
+			session, err := newTestAuthUsecase(users, sessions).SignUp(tt.args.email, tt.args.password)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+				if tt.err != nil {
+					assert.ErrorIs(t, err, tt.err)
+				}
+				assert.Nil(t, session)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.NotEmpty(t, session.Token)
+				assert.Equal(t, models.HashSessionToken(session.Token), session.ID)
+			}
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+
+	user := newTestUser(t, "user@example.com", "password")
+
+	type args struct {
+		email    string
+		password string
+	}
+
+	cases := map[string]struct {
+		args          args
+		prepareMockFn func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository)
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:ログインに成功": {
+			args: args{email: "user@example.com", password: "password"},
+			prepareMockFn: func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository) {
+				u.EXPECT().FindByEmail("user@example.com").Return(user, nil)
+				s.EXPECT().DeleteExpired(authNow).Return(nil)
+				s.EXPECT().Create(gomock.Any()).Return(nil)
+			},
+		},
+		"異常ケース:パスワードが誤っている": {
+			args: args{email: "user@example.com", password: "wrong-password"},
+			prepareMockFn: func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository) {
+				u.EXPECT().FindByEmail("user@example.com").Return(user, nil)
+			},
+			expectErr: true,
+			err:       ErrInvalidCredentials,
+		},
+		"異常ケース:登録されていないメールアドレス": {
+			args: args{email: "nobody@example.com", password: "password"},
+			prepareMockFn: func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository) {
+				u.EXPECT().FindByEmail("nobody@example.com").Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: true,
+			err:       ErrInvalidCredentials,
+		},
+		"異常ケース:検索に失敗": {
+			args: args{email: "user@example.com", password: "password"},
+			prepareMockFn: func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository) {
+				u.EXPECT().FindByEmail("user@example.com").Return(nil, errors.New("something is wrong"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			users := mock_repository.NewMockUserRepository(mockCtrl)
+			sessions := mock_repository.NewMockSessionRepository(mockCtrl)
+			tt.prepareMockFn(users, sessions)
+
+			session, err := newTestAuthUsecase(users, sessions).Login(tt.args.email, tt.args.password)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+				if tt.err != nil {
+					assert.ErrorIs(t, err, tt.err)
+					assert.Equal(t, models.KindUnauthenticated, models.KindOf(err))
+				}
+				assert.Nil(t, session)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, user.ID, session.UserID)
+				assert.NotEmpty(t, session.Token)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+
+	user := &models.User{Email: "user@example.com"}
+	user.ID = 1
+	token := "token"
+
+	cases := map[string]struct {
+		token         string
+		prepareMockFn func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository)
+		want          *models.User
+		err           error
+	}{
+		"正常ケース:有効なセッション": {
+			token: token,
+			prepareMockFn: func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository) {
+				s.EXPECT().FindById(models.HashSessionToken(token)).Return(&models.Session{UserID: 1, ExpiresAt: authNow.Add(time.Minute)}, nil)
+				u.EXPECT().FindById(uint(1)).Return(user, nil)
+			},
+			want: user,
+		},
+		"異常ケース:失効したセッション": {
+			token: token,
+			prepareMockFn: func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository) {
+				s.EXPECT().FindById(models.HashSessionToken(token)).Return(&models.Session{UserID: 1, ExpiresAt: authNow}, nil)
+			},
+			err: ErrUnauthenticated,
+		},
+		"異常ケース:存在しないセッション": {
+			token: token,
+			prepareMockFn: func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository) {
+				s.EXPECT().FindById(models.HashSessionToken(token)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			err: ErrUnauthenticated,
+		},
+		"異常ケース:トークンが空": {
+			token:         "",
+			prepareMockFn: func(u *mock_repository.MockUserRepository, s *mock_repository.MockSessionRepository) {},
+			err:           ErrUnauthenticated,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			users := mock_repository.NewMockUserRepository(mockCtrl)
+			sessions := mock_repository.NewMockSessionRepository(mockCtrl)
+			tt.prepareMockFn(users, sessions)
+
+			result, err := newTestAuthUsecase(users, sessions).Authenticate(tt.token)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, result)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Equal(t, models.KindUnauthenticated, models.KindOf(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+
+	// モックの呼び出しを管理するControllerを生成
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// トークンのハッシュ値で削除すること
+	users := mock_repository.NewMockUserRepository(mockCtrl)
+	sessions := mock_repository.NewMockSessionRepository(mockCtrl)
+	sessions.EXPECT().Delete(models.HashSessionToken("token")).Return(nil)
+
+	uc := newTestAuthUsecase(users, sessions)
+	assert.NoError(t, uc.Logout("token"))
+	// トークンが無い場合は何もしない
+	assert.NoError(t, uc.Logout(""))
+}
@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+)
+
+// 移動する項目がtodoのチェックリストに存在しない
+var ErrChecklistItemNotFound = models.NotFound(errors.New("checklist item not found"))
+
+// チェックリストのユースケースのインターフェイス
+type ChecklistUsecase interface {
+	interfaces.Closer
+	List(userID uint, todoID uint) (*[]models.ChecklistItem, error)
+	Add(userID uint, todoID uint, title string) (*models.ChecklistItem, error)
+	Update(userID uint, todoID uint, id uint, title *string, done *bool) (*models.ChecklistItem, error)
+	Move(userID uint, todoID uint, id uint, position int) error
+	Delete(userID uint, todoID uint, id uint) error
+}
+
+// チェックリストに関わるユースケースの構造体
+type checklistUsecase struct {
+	repos repository.ChecklistRepository
+	todos repository.TodoRepository
+}
+
+// ChecklistUsecaseの新しいインスタンスを作成して返す
+func NewChecklistUsecase(checklistRepo repository.ChecklistRepository, todoRepo repository.TodoRepository) ChecklistUsecase {
+	checklistUsecase := checklistUsecase{repos: checklistRepo, todos: todoRepo}
+	return &checklistUsecase
+}
+
+// 指定されたユーザーの、指定されたtodoのチェックリストの項目を並び順で返す
+func (uc *checklistUsecase) List(userID uint, todoID uint) (*[]models.ChecklistItem, error) {
+	if _, err := uc.todos.FindById(userID, todoID); err != nil {
+		return nil, err
+	}
+	return uc.repos.FindByTodo(userID, todoID)
+}
+
+// 指定されたユーザーの、指定されたtodoのチェックリストの末尾に項目を追加する
+func (uc *checklistUsecase) Add(userID uint, todoID uint, title string) (*models.ChecklistItem, error) {
+	item, err := models.NewChecklistItem(todoID, title)
+	if err != nil {
+		return nil, err
+	}
+	items, err := uc.List(userID, todoID)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(*items); n > 0 {
+		item.Position = (*items)[n-1].Position + 1
+	}
+	if err := uc.repos.Create(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// 指定された項目のタイトル・完了したかを変更する
+// nilの項目は変更しない
+func (uc *checklistUsecase) Update(userID uint, todoID uint, id uint, title *string, done *bool) (*models.ChecklistItem, error) {
+	item, err := uc.repos.FindById(userID, todoID, id)
+	if err != nil {
+		return nil, err
+	}
+	if title != nil {
+		if err := item.Rename(*title); err != nil {
+			return nil, err
+		}
+	}
+	if done != nil {
+		item.Done = *done
+	}
+	if err := uc.repos.Update(userID, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// 指定された項目を、チェックリストの中の指定された位置（0始まり）に移動する
+// 範囲外の位置は先頭・末尾に丸める
+func (uc *checklistUsecase) Move(userID uint, todoID uint, id uint, position int) error {
+	items, err := uc.List(userID, todoID)
+	if err != nil {
+		return err
+	}
+	ids := make([]uint, 0, len(*items))
+	found := false
+	for _, item := range *items {
+		if item.ID == id {
+			found = true
+			continue
+		}
+		ids = append(ids, item.ID)
+	}
+	if !found {
+		return ErrChecklistItemNotFound
+	}
+	position = max(0, min(position, len(ids)))
+	ids = append(ids[:position], append([]uint{id}, ids[position:]...)...)
+	return uc.repos.Reorder(userID, todoID, ids)
+}
+
+// 指定された項目を削除する
+func (uc *checklistUsecase) Delete(userID uint, todoID uint, id uint) error {
+	return uc.repos.Delete(userID, todoID, id)
+}
+
+// ユースケースの終了処理を行う
+func (uc *checklistUsecase) Close() error {
+	err := errors.Join(uc.repos.Close(), uc.todos.Close())
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	return err
+}
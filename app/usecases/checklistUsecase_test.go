@@ -0,0 +1,276 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_repository "github.com/MinadukiSekina/todo-go-app/app/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// チェックリストのテストで使用するtodoのID
+const testChecklistTodoID uint = 10
+
+// チェックリストのテストで使用する項目（並び順）を生成する
+func newChecklistItems() *[]models.ChecklistItem {
+	return &[]models.ChecklistItem{
+		{ID: 1, TodoID: testChecklistTodoID, Title: "a", Position: 0},
+		{ID: 2, TodoID: testChecklistTodoID, Title: "b", Position: 1, Done: true},
+		{ID: 3, TodoID: testChecklistTodoID, Title: "c", Position: 2},
+	}
+}
+
+func TestChecklistAdd(t *testing.T) {
+
+	cases := map[string]struct {
+		title         string
+		prepareMockFn func(items *mock_repository.MockChecklistRepository, todos *mock_repository.MockTodoRepository)
+		want          *models.ChecklistItem
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:末尾に追加": {
+			title: " d ",
+			prepareMockFn: func(items *mock_repository.MockChecklistRepository, todos *mock_repository.MockTodoRepository) {
+				todos.EXPECT().FindById(testUserID, testChecklistTodoID).Return(&models.Todo{}, nil)
+				items.EXPECT().FindByTodo(testUserID, testChecklistTodoID).Return(newChecklistItems(), nil)
+				items.EXPECT().Create(&models.ChecklistItem{TodoID: testChecklistTodoID, Title: "d", Position: 3}).Return(nil)
+			},
+			want: &models.ChecklistItem{TodoID: testChecklistTodoID, Title: "d", Position: 3},
+		},
+		"正常ケース:最初の項目": {
+			title: "a",
+			prepareMockFn: func(items *mock_repository.MockChecklistRepository, todos *mock_repository.MockTodoRepository) {
+				todos.EXPECT().FindById(testUserID, testChecklistTodoID).Return(&models.Todo{}, nil)
+				items.EXPECT().FindByTodo(testUserID, testChecklistTodoID).Return(&[]models.ChecklistItem{}, nil)
+				items.EXPECT().Create(&models.ChecklistItem{TodoID: testChecklistTodoID, Title: "a"}).Return(nil)
+			},
+			want: &models.ChecklistItem{TodoID: testChecklistTodoID, Title: "a"},
+		},
+		"異常ケース:タイトルが空": {
+			title:         " ",
+			prepareMockFn: func(items *mock_repository.MockChecklistRepository, todos *mock_repository.MockTodoRepository) {},
+			expectErr:     true,
+			err:           models.ErrInvalidChecklistItemTitle,
+		},
+		"異常ケース:todoが存在しない": {
+			title: "a",
+			prepareMockFn: func(items *mock_repository.MockChecklistRepository, todos *mock_repository.MockTodoRepository) {
+				todos.EXPECT().FindById(testUserID, testChecklistTodoID).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: true,
+			err:       gorm.ErrRecordNotFound,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			items := mock_repository.NewMockChecklistRepository(mockCtrl)
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
+			tt.prepareMockFn(items, todos)
+
+			// mockを利用してテストする
+			Usecase := NewChecklistUsecase(items, todos)
+			result, err := Usecase.Add(testUserID, testChecklistTodoID, tt.title)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, result)
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestChecklistUpdate(t *testing.T) {
+
+	title := "B"
+	empty := " "
+	done := false
+
+	cases := map[string]struct {
+		title         *string
+		done          *bool
+		prepareMockFn func(m *mock_repository.MockChecklistRepository)
+		want          *models.ChecklistItem
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:タイトルを変更": {
+			title: &title,
+			prepareMockFn: func(m *mock_repository.MockChecklistRepository) {
+				m.EXPECT().FindById(testUserID, testChecklistTodoID, uint(2)).Return(&(*newChecklistItems())[1], nil)
+				m.EXPECT().Update(testUserID, &models.ChecklistItem{ID: 2, TodoID: testChecklistTodoID, Title: "B", Position: 1, Done: true}).Return(nil)
+			},
+			want: &models.ChecklistItem{ID: 2, TodoID: testChecklistTodoID, Title: "B", Position: 1, Done: true},
+		},
+		"正常ケース:未完了に戻す": {
+			done: &done,
+			prepareMockFn: func(m *mock_repository.MockChecklistRepository) {
+				m.EXPECT().FindById(testUserID, testChecklistTodoID, uint(2)).Return(&(*newChecklistItems())[1], nil)
+				m.EXPECT().Update(testUserID, &models.ChecklistItem{ID: 2, TodoID: testChecklistTodoID, Title: "b", Position: 1}).Return(nil)
+			},
+			want: &models.ChecklistItem{ID: 2, TodoID: testChecklistTodoID, Title: "b", Position: 1},
+		},
+		"異常ケース:タイトルが空": {
+			title: &empty,
+			prepareMockFn: func(m *mock_repository.MockChecklistRepository) {
+				m.EXPECT().FindById(testUserID, testChecklistTodoID, uint(2)).Return(&(*newChecklistItems())[1], nil)
+			},
+			expectErr: true,
+			err:       models.ErrInvalidChecklistItemTitle,
+		},
+		"異常ケース:項目が存在しない": {
+			done: &done,
+			prepareMockFn: func(m *mock_repository.MockChecklistRepository) {
+				m.EXPECT().FindById(testUserID, testChecklistTodoID, uint(2)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: true,
+			err:       gorm.ErrRecordNotFound,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_repository.NewMockChecklistRepository(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			// mockを利用してテストする
+			Usecase := NewChecklistUsecase(mock, mock_repository.NewMockTodoRepository(mockCtrl))
+			result, err := Usecase.Update(testUserID, testChecklistTodoID, 2, tt.title, tt.done)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, result)
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestChecklistMove(t *testing.T) {
+
+	cases := map[string]struct {
+		id        uint
+		position  int
+		want      []uint
+		expectErr bool
+		err       error
+	}{
+		"正常ケース:先頭に移動": {
+			id:       3,
+			position: 0,
+			want:     []uint{3, 1, 2},
+		},
+		"正常ケース:中ほどに移動": {
+			id:       1,
+			position: 1,
+			want:     []uint{2, 1, 3},
+		},
+		"正常ケース:範囲外の位置は末尾に丸める": {
+			id:       1,
+			position: 10,
+			want:     []uint{2, 3, 1},
+		},
+		"正常ケース:負の位置は先頭に丸める": {
+			id:       2,
+			position: -1,
+			want:     []uint{2, 1, 3},
+		},
+		"異常ケース:項目が存在しない": {
+			id:        4,
+			position:  0,
+			expectErr: true,
+			err:       ErrChecklistItemNotFound,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			items := mock_repository.NewMockChecklistRepository(mockCtrl)
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
+			todos.EXPECT().FindById(testUserID, testChecklistTodoID).Return(&models.Todo{}, nil)
+			items.EXPECT().FindByTodo(testUserID, testChecklistTodoID).Return(newChecklistItems(), nil)
+			if tt.want != nil {
+				items.EXPECT().Reorder(testUserID, testChecklistTodoID, tt.want).Return(nil)
+			}
+
+			// mockを利用してテストする
+			Usecase := NewChecklistUsecase(items, todos)
+			err := Usecase.Move(testUserID, testChecklistTodoID, tt.id, tt.position)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestChecklistClose(t *testing.T) {
+
+	cases := map[string]struct {
+		itemsErr  error
+		todosErr  error
+		expectErr bool
+	}{
+		"正常ケース:終了処理": {},
+		"異常ケース:項目のリポジトリの終了処理に失敗": {
+			itemsErr:  errors.New("close error"),
+			expectErr: true,
+		},
+		"異常ケース:todoのリポジトリの終了処理に失敗": {
+			todosErr:  errors.New("close error"),
+			expectErr: true,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			items := mock_repository.NewMockChecklistRepository(mockCtrl)
+			items.EXPECT().Close().Return(tt.itemsErr)
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
+			todos.EXPECT().Close().Return(tt.todosErr)
+
+			// mockを利用してテストする
+			Usecase := NewChecklistUsecase(items, todos)
+			err := Usecase.Close()
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package usecases
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+)
+
+// コメントのユースケースのインターフェイス
+type CommentUsecase interface {
+	interfaces.Closer
+	List(userID uint, todoID uint) (*[]models.Comment, error)
+	Add(userID uint, todoID uint, body string) (*models.Comment, error)
+	Edit(userID uint, todoID uint, id uint, body string) (*models.Comment, error)
+	Delete(userID uint, todoID uint, id uint) error
+}
+
+// コメントに関わるユースケースの構造体
+type commentUsecase struct {
+	repos repository.CommentRepository
+	todos repository.TodoRepository
+}
+
+// CommentUsecaseの新しいインスタンスを作成して返す
+func NewCommentUsecase(commentRepo repository.CommentRepository, todoRepo repository.TodoRepository) CommentUsecase {
+	commentUsecase := commentUsecase{repos: commentRepo, todos: todoRepo}
+	return &commentUsecase
+}
+
+// 指定されたユーザーの、指定されたtodoのコメントを投稿順で返す
+func (uc *commentUsecase) List(userID uint, todoID uint) (*[]models.Comment, error) {
+	if _, err := uc.todos.FindById(userID, todoID); err != nil {
+		return nil, err
+	}
+	return uc.repos.FindByTodo(userID, todoID)
+}
+
+// 指定されたtodoに、指定されたユーザーのコメントを投稿する
+func (uc *commentUsecase) Add(userID uint, todoID uint, body string) (*models.Comment, error) {
+	comment, err := models.NewComment(todoID, userID, body)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.todos.FindById(userID, todoID); err != nil {
+		return nil, err
+	}
+	if err := uc.repos.Create(comment); err != nil {
+		return nil, err
+	}
+	// 表示用に、投稿したユーザーを含めて取得し直す
+	return uc.repos.FindById(userID, todoID, comment.ID)
+}
+
+// 指定されたコメントの本文を変更する
+// 投稿したユーザー以外はErrNotCommentAuthorを返す
+func (uc *commentUsecase) Edit(userID uint, todoID uint, id uint, body string) (*models.Comment, error) {
+	comment, err := uc.authored(userID, todoID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := comment.Edit(body); err != nil {
+		return nil, err
+	}
+	if err := uc.repos.Update(userID, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// 指定されたコメントを削除する
+// 投稿したユーザー以外はErrNotCommentAuthorを返す
+func (uc *commentUsecase) Delete(userID uint, todoID uint, id uint) error {
+	if _, err := uc.authored(userID, todoID, id); err != nil {
+		return err
+	}
+	return uc.repos.Delete(userID, todoID, id)
+}
+
+// 指定されたユーザーが投稿した、指定されたコメントを返す
+func (uc *commentUsecase) authored(userID uint, todoID uint, id uint) (*models.Comment, error) {
+	comment, err := uc.repos.FindById(userID, todoID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !comment.IsAuthor(userID) {
+		return nil, models.ErrNotCommentAuthor
+	}
+	return comment, nil
+}
+
+// ユースケースの終了処理を行う
+func (uc *commentUsecase) Close() error {
+	err := errors.Join(uc.repos.Close(), uc.todos.Close())
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	return err
+}
@@ -0,0 +1,198 @@
+package usecases
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_repository "github.com/MinadukiSekina/todo-go-app/app/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// コメントのテストで使用するtodoのID
+const testCommentTodoID uint = 20
+
+// コメントのテストで使用する、テスト用のユーザーが投稿したコメントを生成する
+func newTestComment(id uint, userID uint, body string) *models.Comment {
+	return &models.Comment{ID: id, TodoID: testCommentTodoID, UserID: userID, Body: body}
+}
+
+func TestCommentAdd(t *testing.T) {
+
+	cases := map[string]struct {
+		body          string
+		prepareMockFn func(comments *mock_repository.MockCommentRepository, todos *mock_repository.MockTodoRepository)
+		want          *models.Comment
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:投稿": {
+			body: " 確認しました ",
+			prepareMockFn: func(comments *mock_repository.MockCommentRepository, todos *mock_repository.MockTodoRepository) {
+				todos.EXPECT().FindById(testUserID, testCommentTodoID).Return(&models.Todo{}, nil)
+				comments.EXPECT().Create(&models.Comment{TodoID: testCommentTodoID, UserID: testUserID, Body: "確認しました"}).
+					DoAndReturn(func(comment *models.Comment) error {
+						comment.ID = 1
+						return nil
+					})
+				comments.EXPECT().FindById(testUserID, testCommentTodoID, uint(1)).Return(newTestComment(1, testUserID, "確認しました"), nil)
+			},
+			want: newTestComment(1, testUserID, "確認しました"),
+		},
+		"異常ケース:本文が空": {
+			body:          " ",
+			prepareMockFn: func(comments *mock_repository.MockCommentRepository, todos *mock_repository.MockTodoRepository) {},
+			expectErr:     true,
+			err:           models.ErrInvalidCommentBody,
+		},
+		"異常ケース:todoが存在しない": {
+			body: "a",
+			prepareMockFn: func(comments *mock_repository.MockCommentRepository, todos *mock_repository.MockTodoRepository) {
+				todos.EXPECT().FindById(testUserID, testCommentTodoID).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: true,
+			err:       gorm.ErrRecordNotFound,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			comments := mock_repository.NewMockCommentRepository(mockCtrl)
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
+			tt.prepareMockFn(comments, todos)
+
+			// mockを利用してテストする
+			Usecase := NewCommentUsecase(comments, todos)
+			result, err := Usecase.Add(testUserID, testCommentTodoID, tt.body)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, result)
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCommentEdit(t *testing.T) {
+
+	cases := map[string]struct {
+		body          string
+		prepareMockFn func(m *mock_repository.MockCommentRepository)
+		want          *models.Comment
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:本文を変更": {
+			body: "修正しました",
+			prepareMockFn: func(m *mock_repository.MockCommentRepository) {
+				m.EXPECT().FindById(testUserID, testCommentTodoID, uint(1)).Return(newTestComment(1, testUserID, "a"), nil)
+				m.EXPECT().Update(testUserID, newTestComment(1, testUserID, "修正しました")).Return(nil)
+			},
+			want: newTestComment(1, testUserID, "修正しました"),
+		},
+		"異常ケース:本文が長すぎる": {
+			body: strings.Repeat("a", models.CommentBodyMaxLength+1),
+			prepareMockFn: func(m *mock_repository.MockCommentRepository) {
+				m.EXPECT().FindById(testUserID, testCommentTodoID, uint(1)).Return(newTestComment(1, testUserID, "a"), nil)
+			},
+			expectErr: true,
+			err:       models.ErrInvalidCommentBody,
+		},
+		"異常ケース:他のユーザーが投稿したコメント": {
+			body: "修正しました",
+			prepareMockFn: func(m *mock_repository.MockCommentRepository) {
+				m.EXPECT().FindById(testUserID, testCommentTodoID, uint(1)).Return(newTestComment(1, testUserID+1, "a"), nil)
+			},
+			expectErr: true,
+			err:       models.ErrNotCommentAuthor,
+		},
+		"異常ケース:コメントが存在しない": {
+			body: "修正しました",
+			prepareMockFn: func(m *mock_repository.MockCommentRepository) {
+				m.EXPECT().FindById(testUserID, testCommentTodoID, uint(1)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: true,
+			err:       gorm.ErrRecordNotFound,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			comments := mock_repository.NewMockCommentRepository(mockCtrl)
+			tt.prepareMockFn(comments)
+
+			// mockを利用してテストする
+			Usecase := NewCommentUsecase(comments, mock_repository.NewMockTodoRepository(mockCtrl))
+			result, err := Usecase.Edit(testUserID, testCommentTodoID, 1, tt.body)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, result)
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCommentDelete(t *testing.T) {
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_repository.MockCommentRepository)
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:削除": {
+			prepareMockFn: func(m *mock_repository.MockCommentRepository) {
+				m.EXPECT().FindById(testUserID, testCommentTodoID, uint(1)).Return(newTestComment(1, testUserID, "a"), nil)
+				m.EXPECT().Delete(testUserID, testCommentTodoID, uint(1)).Return(nil)
+			},
+		},
+		"異常ケース:他のユーザーが投稿したコメント": {
+			prepareMockFn: func(m *mock_repository.MockCommentRepository) {
+				m.EXPECT().FindById(testUserID, testCommentTodoID, uint(1)).Return(newTestComment(1, testUserID+1, "a"), nil)
+			},
+			expectErr: true,
+			err:       models.ErrNotCommentAuthor,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			comments := mock_repository.NewMockCommentRepository(mockCtrl)
+			tt.prepareMockFn(comments)
+
+			// mockを利用してテストする
+			Usecase := NewCommentUsecase(comments, mock_repository.NewMockTodoRepository(mockCtrl))
+			err := Usecase.Delete(testUserID, testCommentTodoID, 1)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+)
+
+// プロジェクトに関わるエラー
+var (
+	// 同じ名前のプロジェクトが既に存在する
+	ErrProjectAlreadyExists = models.Conflict(errors.New("project already exists"))
+	// 指定されたプロジェクトが存在しないか、他のユーザーのもの
+	ErrProjectNotFound = models.Validation(errors.New("project not found"))
+)
+
+// プロジェクトのユースケースのインターフェイス
+type ProjectUsecase interface {
+	interfaces.Closer
+	List(userID uint) (*[]models.Project, error)
+	SearchByID(userID uint, id uint) (*models.Project, error)
+	Add(userID uint, name string) (*models.Project, error)
+	Rename(userID uint, id uint, name string) (*models.Project, error)
+	Delete(userID uint, id uint) error
+}
+
+// プロジェクトに関わるユースケースの構造体
+type projectUsecase struct {
+	repos repository.ProjectRepository
+}
+
+// ProjectUsecaseの新しいインスタンスを作成して返す
+func NewProjectUsecase(projectRepo repository.ProjectRepository) ProjectUsecase {
+	projectUsecase := projectUsecase{repos: projectRepo}
+	return &projectUsecase
+}
+
+// 指定されたユーザーのプロジェクトの一覧を、todoの件数と共に返す（Inboxが先頭）
+// Inboxがまだ無い場合は作成する
+func (uc *projectUsecase) List(userID uint) (*[]models.Project, error) {
+	if _, err := uc.repos.FindInbox(userID); err != nil {
+		return nil, err
+	}
+	return uc.repos.FindAll(userID)
+}
+
+// 指定されたユーザーの、指定されたIDのプロジェクトを返す
+func (uc *projectUsecase) SearchByID(userID uint, id uint) (project *models.Project, err error) {
+	project, err = uc.repos.FindById(userID, id)
+	return
+}
+
+// 指定されたユーザーのプロジェクトを、指定された名前で新規作成する
+// 同じ名前のプロジェクトが既に存在する場合はErrProjectAlreadyExistsを返す
+func (uc *projectUsecase) Add(userID uint, name string) (*models.Project, error) {
+	project, err := models.NewProject(userID, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkNameUnused(project); err != nil {
+		return nil, err
+	}
+	if err := uc.repos.Create(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// 指定されたユーザーの、指定されたIDのプロジェクトの名前を変更する
+// Inboxの名前は変更できない
+func (uc *projectUsecase) Rename(userID uint, id uint, name string) (*models.Project, error) {
+	project, err := uc.repos.FindById(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := project.Rename(name); err != nil {
+		return nil, err
+	}
+	if err := uc.checkNameUnused(project); err != nil {
+		return nil, err
+	}
+	if err := uc.repos.Update(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// 指定されたユーザーの、指定されたIDのプロジェクトを削除する
+// プロジェクトのtodoはInboxに移す（Inbox自体は削除できない）
+func (uc *projectUsecase) Delete(userID uint, id uint) error {
+	project, err := uc.repos.FindById(userID, id)
+	if err != nil {
+		return err
+	}
+	if project.Inbox {
+		return models.ErrInboxProject
+	}
+	inbox, err := uc.repos.FindInbox(userID)
+	if err != nil {
+		return err
+	}
+	return uc.repos.Delete(userID, id, inbox.ID)
+}
+
+// ユースケースの終了処理を行う
+func (uc *projectUsecase) Close() error {
+	err := uc.repos.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	return err
+}
+
+// 同じユーザーの他のプロジェクトに、同じ名前のものが無いかを確認する
+func (uc *projectUsecase) checkNameUnused(project *models.Project) error {
+	projects, err := uc.repos.FindAll(project.UserID)
+	if err != nil {
+		return err
+	}
+	for _, other := range *projects {
+		if other.ID != project.ID && other.Name == project.Name {
+			return ErrProjectAlreadyExists
+		}
+	}
+	return nil
+}
+
+// todoの所属するプロジェクトを確認する
+// 未指定の場合はInboxとし、所有者と異なるユーザーのプロジェクトの場合はErrProjectNotFoundを返す
+func assignProject(repos repository.ProjectRepository, todo *models.Todo) error {
+	if todo.ProjectID == 0 {
+		inbox, err := repos.FindInbox(todo.UserID)
+		if err != nil {
+			return err
+		}
+		todo.ProjectID = inbox.ID
+		return nil
+	}
+	if _, err := repos.FindById(todo.UserID, todo.ProjectID); err != nil {
+		if models.KindOf(err) == models.KindNotFound {
+			return ErrProjectNotFound
+		}
+		return err
+	}
+	return nil
+}
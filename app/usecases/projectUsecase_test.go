@@ -0,0 +1,330 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_repository "github.com/MinadukiSekina/todo-go-app/app/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// テストで使用するInboxのID
+const testInboxID uint = 1
+
+// todoのユースケースのテストで使用する、Inboxのみを返すプロジェクトのリポジトリのモックを生成する
+func newProjectRepositoryMock(ctrl *gomock.Controller) *mock_repository.MockProjectRepository {
+	m := mock_repository.NewMockProjectRepository(ctrl)
+	m.EXPECT().FindInbox(gomock.Any()).DoAndReturn(func(userID uint) (*models.Project, error) {
+		return &models.Project{ID: testInboxID, UserID: userID, Name: models.InboxProjectName, Inbox: true}, nil
+	}).AnyTimes()
+	m.EXPECT().FindById(gomock.Any(), testInboxID).DoAndReturn(func(userID uint, id uint) (*models.Project, error) {
+		return &models.Project{ID: id, UserID: userID, Name: models.InboxProjectName, Inbox: true}, nil
+	}).AnyTimes()
+	m.EXPECT().Close().Return(nil).AnyTimes()
+	return m
+}
+
+func TestProjectList(t *testing.T) {
+
+	projects := []models.Project{
+		{ID: testInboxID, UserID: testUserID, Name: models.InboxProjectName, Inbox: true},
+		{ID: 2, UserID: testUserID, Name: "仕事", TodoCount: 3},
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(m *mock_repository.MockProjectRepository)
+		want          *[]models.Project
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:一覧を取得": {
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {
+				m.EXPECT().FindInbox(testUserID).Return(&projects[0], nil)
+				m.EXPECT().FindAll(testUserID).Return(&projects, nil)
+			},
+			want: &projects,
+		},
+		"異常ケース:Inboxの作成に失敗": {
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {
+				m.EXPECT().FindInbox(testUserID).Return(nil, errors.New("failed to create inbox"))
+			},
+			expectErr: true,
+			err:       errors.New("failed to create inbox"),
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_repository.NewMockProjectRepository(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			// mockを利用してテストする
+			Usecase := NewProjectUsecase(mock)
+			result, err := Usecase.List(testUserID)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, result)
+			if tt.expectErr {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProjectAdd(t *testing.T) {
+
+	existing := []models.Project{
+		{ID: testInboxID, UserID: testUserID, Name: models.InboxProjectName, Inbox: true},
+		{ID: 2, UserID: testUserID, Name: "仕事"},
+	}
+
+	cases := map[string]struct {
+		name          string
+		prepareMockFn func(m *mock_repository.MockProjectRepository)
+		want          *models.Project
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:登録完了": {
+			name: "  趣味 ",
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {
+				m.EXPECT().FindAll(testUserID).Return(&existing, nil)
+				m.EXPECT().Create(&models.Project{UserID: testUserID, Name: "趣味"}).Return(nil)
+			},
+			want: &models.Project{UserID: testUserID, Name: "趣味"},
+		},
+		"異常ケース:同じ名前のプロジェクトが存在する": {
+			name: "仕事",
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {
+				m.EXPECT().FindAll(testUserID).Return(&existing, nil)
+			},
+			expectErr: true,
+			err:       ErrProjectAlreadyExists,
+		},
+		"異常ケース:Inboxと同じ名前": {
+			name:          "Inbox",
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {},
+			expectErr:     true,
+			err:           models.ErrInvalidProjectName,
+		},
+		"異常ケース:名前が空": {
+			name:          " ",
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {},
+			expectErr:     true,
+			err:           models.ErrInvalidProjectName,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_repository.NewMockProjectRepository(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			// mockを利用してテストする
+			Usecase := NewProjectUsecase(mock)
+			result, err := Usecase.Add(testUserID, tt.name)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, result)
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProjectRename(t *testing.T) {
+
+	cases := map[string]struct {
+		name          string
+		prepareMockFn func(m *mock_repository.MockProjectRepository)
+		want          *models.Project
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:名前を変更": {
+			name: "趣味",
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {
+				m.EXPECT().FindById(testUserID, uint(2)).Return(&models.Project{ID: 2, UserID: testUserID, Name: "仕事"}, nil)
+				m.EXPECT().FindAll(testUserID).Return(&[]models.Project{{ID: 2, UserID: testUserID, Name: "仕事"}}, nil)
+				m.EXPECT().Update(&models.Project{ID: 2, UserID: testUserID, Name: "趣味"}).Return(nil)
+			},
+			want: &models.Project{ID: 2, UserID: testUserID, Name: "趣味"},
+		},
+		"異常ケース:Inboxの名前を変更": {
+			name: "受信箱",
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {
+				m.EXPECT().FindById(testUserID, uint(2)).Return(&models.Project{ID: 2, UserID: testUserID, Name: models.InboxProjectName, Inbox: true}, nil)
+			},
+			expectErr: true,
+			err:       models.ErrInboxProject,
+		},
+		"異常ケース:プロジェクトが存在しない": {
+			name: "趣味",
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {
+				m.EXPECT().FindById(testUserID, uint(2)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: true,
+			err:       gorm.ErrRecordNotFound,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_repository.NewMockProjectRepository(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			// mockを利用してテストする
+			Usecase := NewProjectUsecase(mock)
+			result, err := Usecase.Rename(testUserID, 2, tt.name)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, result)
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProjectDelete(t *testing.T) {
+
+	inbox := models.Project{ID: testInboxID, UserID: testUserID, Name: models.InboxProjectName, Inbox: true}
+
+	cases := map[string]struct {
+		id            uint
+		prepareMockFn func(m *mock_repository.MockProjectRepository)
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:todoをInboxに移して削除": {
+			id: 2,
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {
+				m.EXPECT().FindById(testUserID, uint(2)).Return(&models.Project{ID: 2, UserID: testUserID, Name: "仕事"}, nil)
+				m.EXPECT().FindInbox(testUserID).Return(&inbox, nil)
+				m.EXPECT().Delete(testUserID, uint(2), testInboxID).Return(nil)
+			},
+		},
+		"異常ケース:Inboxを削除": {
+			id: testInboxID,
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {
+				m.EXPECT().FindById(testUserID, testInboxID).Return(&inbox, nil)
+			},
+			expectErr: true,
+			err:       models.ErrInboxProject,
+		},
+		"異常ケース:プロジェクトが存在しない": {
+			id: 2,
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {
+				m.EXPECT().FindById(testUserID, uint(2)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: true,
+			err:       gorm.ErrRecordNotFound,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_repository.NewMockProjectRepository(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			// mockを利用してテストする
+			Usecase := NewProjectUsecase(mock)
+			err := Usecase.Delete(testUserID, tt.id)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAssignProject(t *testing.T) {
+
+	cases := map[string]struct {
+		projectID     uint
+		prepareMockFn func(m *mock_repository.MockProjectRepository)
+		want          uint
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:未指定の場合はInbox": {
+			projectID: 0,
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {
+				m.EXPECT().FindInbox(testUserID).Return(&models.Project{ID: testInboxID, UserID: testUserID, Inbox: true}, nil)
+			},
+			want: testInboxID,
+		},
+		"正常ケース:自分のプロジェクト": {
+			projectID: 2,
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {
+				m.EXPECT().FindById(testUserID, uint(2)).Return(&models.Project{ID: 2, UserID: testUserID, Name: "仕事"}, nil)
+			},
+			want: 2,
+		},
+		"異常ケース:他のユーザーのプロジェクト": {
+			projectID: 3,
+			prepareMockFn: func(m *mock_repository.MockProjectRepository) {
+				m.EXPECT().FindById(testUserID, uint(3)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			want:      3,
+			expectErr: true,
+			err:       ErrProjectNotFound,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_repository.NewMockProjectRepository(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			todo := models.Todo{UserID: testUserID, ProjectID: tt.projectID, Title: "test"}
+			err := assignProject(mock, &todo)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, todo.ProjectID)
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"errors"
+	"log/slog"
+	"slices"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
+)
+
+// タグに関わるエラー
+var (
+	// 同じ名前のタグが既に存在する（統合を使用する）
+	ErrTagAlreadyExists = models.Conflict(errors.New("tag already exists"))
+	// 同じタグ同士を統合しようとした
+	ErrMergeIntoSameTag = models.Validation(errors.New("cannot merge a tag into itself"))
+)
+
+// タグのユースケースのインターフェイス
+type TagUsecase interface {
+	interfaces.Closer
+	List(userID uint) (*[]models.Tag, error)
+	Rename(userID uint, id uint, name string) (*models.Tag, error)
+	Merge(userID uint, fromID uint, intoID uint) error
+	Delete(userID uint, id uint) error
+}
+
+// タグに関わるユースケースの構造体
+type tagUsecase struct {
+	repos repository.TagRepository
+}
+
+// TagUsecaseの新しいインスタンスを作成して返す
+func NewTagUsecase(tagRepo repository.TagRepository) TagUsecase {
+	tagUsecase := tagUsecase{repos: tagRepo}
+	return &tagUsecase
+}
+
+// 指定されたユーザーのタグの一覧を、付いているtodoの件数と共に名前順で返す
+func (uc *tagUsecase) List(userID uint) (tags *[]models.Tag, err error) {
+	tags, err = uc.repos.FindAll(userID)
+	return
+}
+
+// 指定されたユーザーの、指定されたIDのタグの名前を変更する
+// 付いている全てのtodoに反映される
+// 同じ名前のタグが既に存在する場合はErrTagAlreadyExistsを返す
+func (uc *tagUsecase) Rename(userID uint, id uint, name string) (*models.Tag, error) {
+	tag, err := uc.repos.FindById(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tag.Rename(name); err != nil {
+		return nil, err
+	}
+	same, err := uc.repos.FindByNames(userID, []string{tag.Name})
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range *same {
+		if other.ID != tag.ID {
+			return nil, ErrTagAlreadyExists
+		}
+	}
+	if err := uc.repos.Update(tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// 指定されたユーザーの、fromのタグをintoのタグに統合する
+// fromのタグが付いていたtodoにはintoのタグが付き、fromのタグは削除される
+func (uc *tagUsecase) Merge(userID uint, fromID uint, intoID uint) error {
+	if fromID == intoID {
+		return ErrMergeIntoSameTag
+	}
+	return uc.repos.Merge(userID, fromID, intoID)
+}
+
+// 指定されたユーザーの、指定されたIDのタグを削除する
+// 付いていたtodoからは外れる
+func (uc *tagUsecase) Delete(userID uint, id uint) (err error) {
+	err = uc.repos.Delete(userID, id)
+	return
+}
+
+// ユースケースの終了処理を行う
+func (uc *tagUsecase) Close() error {
+	err := uc.repos.Close()
+	if err != nil {
+		slog.Error(err.Error())
+	}
+	return err
+}
+
+// 指定された名前のタグを返す
+// 存在しない名前のタグは保存せず、IDが0のタグとして返す（重複した名前は1つにまとめる）
+func resolveTags(repos repository.TagRepository, userID uint, names []string) ([]models.Tag, error) {
+	tags := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		tag, err := models.NewTag(userID, name)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.ContainsFunc(tags, func(t models.Tag) bool { return t.Name == tag.Name }) {
+			tags = append(tags, *tag)
+		}
+	}
+	if len(tags) == 0 {
+		return tags, nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		normalized = append(normalized, tag.Name)
+	}
+	existing, err := repos.FindByNames(userID, normalized)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tags {
+		for _, found := range *existing {
+			if found.Name == tags[i].Name {
+				tags[i] = found
+				break
+			}
+		}
+	}
+	return tags, nil
+}
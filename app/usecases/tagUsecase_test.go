@@ -0,0 +1,171 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	mock_repository "github.com/MinadukiSekina/todo-go-app/app/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestTagRename(t *testing.T) {
+
+	cases := map[string]struct {
+		name          string
+		prepareMockFn func(m *mock_repository.MockTagRepository)
+		want          *models.Tag
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:名前を変更": {
+			name: "  back   end ",
+			prepareMockFn: func(m *mock_repository.MockTagRepository) {
+				m.EXPECT().FindById(testUserID, uint(1)).Return(&models.Tag{ID: 1, UserID: testUserID, Name: "backend"}, nil)
+				m.EXPECT().FindByNames(testUserID, []string{"back end"}).Return(&[]models.Tag{}, nil)
+				m.EXPECT().Update(&models.Tag{ID: 1, UserID: testUserID, Name: "back end"}).Return(nil)
+			},
+			want: &models.Tag{ID: 1, UserID: testUserID, Name: "back end"},
+		},
+		"正常ケース:同じ名前のまま変更": {
+			name: "backend",
+			prepareMockFn: func(m *mock_repository.MockTagRepository) {
+				m.EXPECT().FindById(testUserID, uint(1)).Return(&models.Tag{ID: 1, UserID: testUserID, Name: "backend"}, nil)
+				m.EXPECT().FindByNames(testUserID, []string{"backend"}).Return(&[]models.Tag{{ID: 1, UserID: testUserID, Name: "backend"}}, nil)
+				m.EXPECT().Update(&models.Tag{ID: 1, UserID: testUserID, Name: "backend"}).Return(nil)
+			},
+			want: &models.Tag{ID: 1, UserID: testUserID, Name: "backend"},
+		},
+		"異常ケース:同じ名前のタグが存在する": {
+			name: "urgent",
+			prepareMockFn: func(m *mock_repository.MockTagRepository) {
+				m.EXPECT().FindById(testUserID, uint(1)).Return(&models.Tag{ID: 1, UserID: testUserID, Name: "backend"}, nil)
+				m.EXPECT().FindByNames(testUserID, []string{"urgent"}).Return(&[]models.Tag{{ID: 2, UserID: testUserID, Name: "urgent"}}, nil)
+			},
+			expectErr: true,
+			err:       ErrTagAlreadyExists,
+		},
+		"異常ケース:名前が不正": {
+			name: " ",
+			prepareMockFn: func(m *mock_repository.MockTagRepository) {
+				m.EXPECT().FindById(testUserID, uint(1)).Return(&models.Tag{ID: 1, UserID: testUserID, Name: "backend"}, nil)
+			},
+			expectErr: true,
+			err:       models.ErrInvalidTagName,
+		},
+		"異常ケース:タグが存在しない": {
+			name: "urgent",
+			prepareMockFn: func(m *mock_repository.MockTagRepository) {
+				m.EXPECT().FindById(testUserID, uint(1)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: true,
+			err:       gorm.ErrRecordNotFound,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_repository.NewMockTagRepository(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			// mockを利用してテストする
+			Usecase := NewTagUsecase(mock)
+			result, err := Usecase.Rename(testUserID, 1, tt.name)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, result)
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTagMerge(t *testing.T) {
+
+	cases := map[string]struct {
+		fromID        uint
+		intoID        uint
+		prepareMockFn func(m *mock_repository.MockTagRepository)
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:統合完了": {
+			fromID: 1,
+			intoID: 2,
+			prepareMockFn: func(m *mock_repository.MockTagRepository) {
+				m.EXPECT().Merge(testUserID, uint(1), uint(2)).Return(nil)
+			},
+		},
+		"異常ケース:同じタグに統合": {
+			fromID:        1,
+			intoID:        1,
+			prepareMockFn: func(m *mock_repository.MockTagRepository) {},
+			expectErr:     true,
+			err:           ErrMergeIntoSameTag,
+		},
+		"異常ケース:統合失敗": {
+			fromID: 1,
+			intoID: 2,
+			prepareMockFn: func(m *mock_repository.MockTagRepository) {
+				m.EXPECT().Merge(testUserID, uint(1), uint(2)).Return(models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: true,
+			err:       gorm.ErrRecordNotFound,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_repository.NewMockTagRepository(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			// mockを利用してテストする
+			Usecase := NewTagUsecase(mock)
+			err := Usecase.Merge(testUserID, tt.fromID, tt.intoID)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTagListAndDelete(t *testing.T) {
+
+	tags := []models.Tag{{ID: 1, UserID: testUserID, Name: "backend", TodoCount: 2}}
+
+	// モックの呼び出しを管理するControllerを生成
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mock := mock_repository.NewMockTagRepository(mockCtrl)
+	mock.EXPECT().FindAll(testUserID).Return(&tags, nil)
+	mock.EXPECT().Delete(testUserID, uint(1)).Return(nil)
+	mock.EXPECT().Delete(testUserID, uint(2)).Return(errors.New("Record Not found"))
+
+	Usecase := NewTagUsecase(mock)
+	result, err := Usecase.List(testUserID)
+	assert.NoError(t, err)
+	assert.Equal(t, &tags, result)
+
+	assert.NoError(t, Usecase.Delete(testUserID, 1))
+	assert.Error(t, Usecase.Delete(testUserID, 2))
+}
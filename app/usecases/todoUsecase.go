@@ -1,67 +1,526 @@
 package usecases
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"strconv"
+	"time"
 
 	"github.com/MinadukiSekina/todo-go-app/app/domain/interfaces"
 	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
 	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
 )
 
+// 並び替えの移動先が不正（隣のtodoが指定されていないか存在しない、または前後が逆）
+var ErrInvalidMove = models.Validation(errors.New("invalid move"))
+
+// 一括操作で指定されたtodoが存在しない（他のユーザーのtodoを含む）
+var ErrTodoNotFound = models.NotFound(errors.New("todo not found"))
+
 // ユースケースのインターフェイス
 type TodoUsecase interface {
 	interfaces.Closer
-	SearchByID(uint) (*models.Todo, error)
-	Show() (todos *[]models.Todo, err error)
+	SearchByID(userID uint, id uint) (*models.Todo, error)
+	Show(userID uint) (todos *[]models.Todo, err error)
 	Add(todo *models.Todo) error
 	Edit(todo *models.Todo) error
-	Delete(id uint) error
+	Delete(userID uint, id uint) error
+	Trash(userID uint) (*[]models.Todo, error)
+	Restore(userID uint, id uint) error
+	Purge(userID uint, id uint) error
+	PurgeExpired(before time.Time) (int, error)
+	Search(query models.TodoQuery) (*models.TodoPage, error)
+	SetTags(userID uint, todoID uint, names []string) error
+	Move(userID uint, id uint, afterID uint, beforeID uint) error
+	Attach(userID uint, todoID uint, fileName string, size int64, r io.Reader) (*models.Attachment, error)
+	OpenAttachment(userID uint, todoID uint, id uint) (*models.Attachment, io.ReadCloser, error)
+	Detach(userID uint, todoID uint, id uint) error
+	History(userID uint, todoID uint) (*[]models.TodoHistory, error)
+	Bulk(req *models.BulkRequest) (models.BulkResults, error)
 }
 
 // todoに関わるユースケースの構造体
 type todoUsecase struct {
-	repos repository.TodoRepository
+	repos    repository.TodoRepository
+	tags     repository.TagRepository
+	projects repository.ProjectRepository
+	// 添付ファイルの情報と、その内容の保存先
+	attachments repository.AttachmentRepository
+	storage     repository.BlobStorage
+	// 変更履歴（記録はtodoの変更と同じトランザクションで、TodoRepositoryで行う）
+	histories repository.TodoHistoryRepository
+	// 現在日時を返す関数（テストで差し替えられるようにする）
+	now func() time.Time
 }
 
 // TodoUsecaseの新しいインスタンスを作成して返す
-func NewTodoUsecase(todoRepo repository.TodoRepository) TodoUsecase {
-	todoUsecase := todoUsecase{repos: todoRepo}
+func NewTodoUsecase(todoRepo repository.TodoRepository, tagRepo repository.TagRepository, projectRepo repository.ProjectRepository, attachmentRepo repository.AttachmentRepository, storage repository.BlobStorage, historyRepo repository.TodoHistoryRepository) TodoUsecase {
+	todoUsecase := todoUsecase{repos: todoRepo, tags: tagRepo, projects: projectRepo, attachments: attachmentRepo, storage: storage, histories: historyRepo, now: time.Now}
 	return &todoUsecase
 }
 
-// 指定されたIDのtodoを検索して結果を返す
-func (uc *todoUsecase) SearchByID(id uint) (todo *models.Todo, err error) {
-	todo, err = uc.repos.FindById(id)
+// 指定されたユーザーの、指定されたIDのtodoを検索して結果を返す
+func (uc *todoUsecase) SearchByID(userID uint, id uint) (todo *models.Todo, err error) {
+	todo, err = uc.repos.FindById(userID, id)
 	return
 }
 
-// todoの一覧を検索して返す
-func (uc *todoUsecase) Show() (todos *[]models.Todo, err error) {
-	todos, err = uc.repos.FindAll()
+// 指定されたユーザーのtodoの一覧を検索して返す
+func (uc *todoUsecase) Show(userID uint) (todos *[]models.Todo, err error) {
+	todos, err = uc.repos.FindAll(userID)
 	return
 }
 
 // 渡されたtodoを新規作成して保存する
-func (uc *todoUsecase) Add(todo *models.Todo) (err error) {
-	err = uc.repos.Create(todo)
-	return
+// 所有者はtodoのUserIDで指定する
+// プロジェクトが未指定の場合はInboxに作成し、他のユーザーのプロジェクトの場合はErrProjectNotFoundを返す
+// タイトル・説明が不正な場合は、項目ごとのエラー（models.FieldErrors）を返す
+func (uc *todoUsecase) Add(todo *models.Todo) error {
+	if err := todo.Validate(nil); err != nil {
+		return err
+	}
+	if err := assignProject(uc.projects, todo); err != nil {
+		return err
+	}
+	return uc.repos.Create(todo, models.NewTodoHistory(0, todo.UserID, models.HistoryCreated))
 }
 
 // 渡されたtodoを更新して保存する
-func (uc *todoUsecase) Edit(todo *models.Todo) (err error) {
-	err = uc.repos.Update(todo)
-	return
+// プロジェクトを変更する場合は、所有者が同じプロジェクトにのみ移せる
+// 渡されたtodoのバージョンが保存されているものと異なる場合は、更新せずに*models.TodoConflictErrorを返す
+// 変更したタイトル・説明が不正な場合や、許可されていない状態の変更の場合は、項目ごとのエラー（models.FieldErrors）を返す
+// 保存されているチェックリストに未完了の項目が残っている場合は、完了にできない（models.ErrOpenChecklistItems）
+// 変更した項目ごとに変更履歴を記録する
+// 繰り返しのtodoを完了にした場合は、次の繰り返しのtodoを作成する
+// 更新・変更履歴・次の繰り返しのtodoは、1つのトランザクションで保存する
+func (uc *todoUsecase) Edit(todo *models.Todo) error {
+	if err := assignProject(uc.projects, todo); err != nil {
+		return err
+	}
+	// 比較・検証にはコメントなどの詳細は不要なため、タグ・チェックリストのみ読み込む
+	current, err := uc.repos.FindSummaryById(todo.UserID, todo.ID)
+	if err != nil {
+		return err
+	}
+	if todo.Version != current.Version {
+		return uc.latestConflict(todo)
+	}
+	if err := todo.Validate(current); err != nil {
+		return err
+	}
+	if err := uc.repos.Update(todo, uc.changes(current, todo), uc.nextOccurrence(current, todo)); err != nil {
+		if !errors.Is(err, models.ErrTodoConflict) {
+			return err
+		}
+		// 取得してから保存するまでの間に更新された場合も、最新の内容と比較する
+		return uc.latestConflict(todo)
+	}
+	return nil
 }
 
-// 指定されたIDのtodoを削除する
-func (uc *todoUsecase) Delete(id uint) (err error) {
-	err = uc.repos.Delete(id)
-	return
+// 繰り返しのtodoを、完了以外の状態から完了にする更新かを返す
+// 完了のまま他の項目を更新した場合は、次の繰り返しを重ねて作成しないようfalseを返す
+func isCompleting(current *models.Todo, todo *models.Todo) bool {
+	return todo.IsRecurring() && todo.Status == models.Done && current.Status != models.Done
+}
+
+// todoの更新前後を比較し、変更した項目ごとの変更履歴を返す
+// プロジェクトはIDではなく名前を記録する
+func (uc *todoUsecase) changes(current *models.Todo, todo *models.Todo) []models.TodoHistory {
+	changes := models.TodoChanges(current, todo, todo.UserID)
+	for i := range changes {
+		if changes[i].Field == models.HistoryFieldProject {
+			changes[i].OldValue = uc.projectName(todo.UserID, current.ProjectID)
+			changes[i].NewValue = uc.projectName(todo.UserID, todo.ProjectID)
+		}
+	}
+	return changes
+}
+
+// 保存されている最新のtodoを詳細とともに取得し、更新の競合を表すエラーを返す
+func (uc *todoUsecase) latestConflict(todo *models.Todo) error {
+	latest, err := uc.repos.FindById(todo.UserID, todo.ID)
+	if err != nil {
+		return err
+	}
+	return &models.TodoConflictError{Current: latest, Changes: uc.changes(latest, todo)}
+}
+
+// 変更履歴に記録するプロジェクトの名前を返す
+// 取得できない場合はIDを返す
+func (uc *todoUsecase) projectName(userID uint, id uint) string {
+	if id == 0 {
+		return ""
+	}
+	project, err := uc.projects.FindById(userID, id)
+	if err != nil {
+		return strconv.FormatUint(uint64(id), 10)
+	}
+	return project.Name
+}
+
+// 完了した繰り返しのtodoの、次の繰り返しのtodoを返す
+// 繰り返しのtodoを完了にする更新でない場合や、繰り返しが終了している場合はnilを返す
+// 元のtodoと同じタグを付け、並び順は末尾とする（リポジトリで保存する）
+func (uc *todoUsecase) nextOccurrence(current *models.Todo, todo *models.Todo) *models.Todo {
+	if !isCompleting(current, todo) {
+		return nil
+	}
+	next, _ := todo.NextOccurrence(uc.now())
+	return next
+}
+
+// 指定されたユーザーの、指定されたIDのtodoを削除する（ゴミ箱に移す）
+// 添付ファイルはRestoreで元に戻せるよう、ストレージには残す
+func (uc *todoUsecase) Delete(userID uint, id uint) error {
+	return uc.repos.Delete(userID, id, models.NewTodoHistory(id, userID, models.HistoryDeleted))
+}
+
+// 指定されたユーザーのゴミ箱のtodoの一覧を、削除した日時の新しい順で返す
+func (uc *todoUsecase) Trash(userID uint) (*[]models.Todo, error) {
+	return uc.repos.FindTrash(userID)
+}
+
+// 指定されたユーザーのゴミ箱にある、指定されたIDのtodoを元に戻す
+func (uc *todoUsecase) Restore(userID uint, id uint) error {
+	return uc.repos.Restore(userID, id, models.NewTodoHistory(id, userID, models.HistoryRestored))
+}
+
+// 指定されたユーザーのゴミ箱にある、指定されたIDのtodoを完全に削除する
+// 添付ファイルがある場合は、todoの削除後にストレージからも削除する
+func (uc *todoUsecase) Purge(userID uint, id uint) error {
+	todo, err := uc.repos.FindTrashedById(userID, id)
+	if err != nil {
+		return err
+	}
+	return uc.purge(todo)
+}
+
+// 全てのユーザーのゴミ箱のtodoのうち、指定された日時より前に削除されたものを完全に削除する
+// 途中で失敗したtodoがあっても残りの削除は続け、削除した件数と全てのエラーを返す
+func (uc *todoUsecase) PurgeExpired(before time.Time) (int, error) {
+	todos, err := uc.repos.FindTrashedBefore(before)
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+	var errs []error
+	for i := range *todos {
+		if err := uc.purge(&(*todos)[i]); err != nil {
+			errs = append(errs, fmt.Errorf("todo %d: %w", (*todos)[i].ID, err))
+			continue
+		}
+		purged++
+	}
+	return purged, errors.Join(errs...)
+}
+
+// ゴミ箱のtodoを完全に削除し、添付ファイルの内容をストレージから削除する
+func (uc *todoUsecase) purge(todo *models.Todo) error {
+	if err := uc.repos.Purge(todo.UserID, todo.ID, models.NewTodoHistory(todo.ID, todo.UserID, models.HistoryPurged)); err != nil {
+		return err
+	}
+	for _, attachment := range todo.Attachments {
+		uc.removeBlob(attachment.StorageKey)
+	}
+	return nil
+}
+
+// 検索条件に一致するtodoのうち、指定されたページの分を返す
+// 期限による絞り込みは現在日時から期限の範囲に変換し、状態の指定が無い場合は完了・中止したものを除く
+// 今日・今週の境界は、検索条件のタイムゾーンの0時とする
+func (uc *todoUsecase) Search(query models.TodoQuery) (*models.TodoPage, error) {
+	query = query.Normalize()
+
+	if query.Due != models.DueAny {
+		now := uc.now().In(query.Location())
+		start := models.StartOfDay(now)
+		switch query.Due {
+		case models.DueOverdue:
+			query.OverdueAt = &now
+		case models.DueToday:
+			end := start.AddDate(0, 0, 1)
+			query.DueFrom, query.DueTo = laterTime(query.DueFrom, start), earlierTime(query.DueTo, end)
+		case models.DueThisWeek:
+			end := models.EndOfWeek(now)
+			query.DueFrom, query.DueTo = laterTime(query.DueFrom, start), earlierTime(query.DueTo, end)
+		}
+		if len(query.Statuses) == 0 {
+			query.Statuses = models.OpenStatuses()
+		}
+	}
+
+	todos, total, err := uc.repos.Search(query)
+	if err != nil {
+		return nil, err
+	}
+	return &models.TodoPage{Todos: *todos, Total: total, Page: query.Page, Limit: query.Limit}, nil
+}
+
+// 指定されたユーザーの、指定されたIDのtodoに付けるタグを、指定された名前の一覧に置き換える
+// 存在しない名前のタグは新規作成する
+// タグを変更した場合は変更履歴を記録し、タグの作成・付け替えとともに1つのトランザクションで保存する
+func (uc *todoUsecase) SetTags(userID uint, todoID uint, names []string) error {
+	todo, err := uc.repos.FindSummaryById(userID, todoID)
+	if err != nil {
+		return err
+	}
+	wanted, err := resolveTags(uc.tags, userID, names)
+	if err != nil {
+		return err
+	}
+	return uc.repos.ReplaceTags(todo, wanted, models.TagChanges(todo.ID, todo.Tags, wanted, userID)...)
+}
+
+// 複数のtodoに一括で操作し、todoごとの結果を指定されたIDの順で返す
+// 変更・変更履歴・タグは1つのトランザクションで保存する
+// 存在しないtodo・状態を変更できないtodo・保存中に他の操作で更新されたtodoは失敗とする
+// Atomicの場合は1件でも失敗したら何も保存せず、結果とともに失敗したtodoのエラーを返す（他のtodoはErrBulkRolledBack）
+// それ以外の場合は成功したものだけを保存し、保存自体ができない場合のみエラーを返す
+func (uc *todoUsecase) Bulk(req *models.BulkRequest) (models.BulkResults, error) {
+	todos, err := uc.repos.FindByIds(req.UserID, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*models.Todo, len(*todos))
+	for i := range *todos {
+		byID[(*todos)[i].ID] = &(*todos)[i]
+	}
+
+	bulk := &models.TodoBulk{UserID: req.UserID, Atomic: req.Atomic}
+	if req.Action == models.BulkRetag {
+		// 新しいタグは、一括操作と同じトランザクションで作成する
+		if bulk.Tags, err = resolveTags(uc.tags, req.UserID, req.Tags); err != nil {
+			return nil, err
+		}
+	}
+	results := make(models.BulkResults, len(req.IDs))
+	// 保存する変更ごとの、結果の位置
+	indexes := []int{}
+	for i, id := range req.IDs {
+		results[i].ID = id
+		todo, ok := byID[id]
+		if !ok {
+			results[i].Err = ErrTodoNotFound
+			continue
+		}
+		change, err := uc.bulkChange(req.Action, todo, bulk.Tags)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		bulk.Changes = append(bulk.Changes, change)
+		indexes = append(indexes, i)
+	}
+	if req.Atomic && len(results.Failed()) > 0 {
+		return rollBackBulk(results)
+	}
+	if len(bulk.Changes) == 0 {
+		return results, nil
+	}
+
+	errs, err := uc.repos.Bulk(bulk)
+	for j, i := range indexes {
+		results[i].Err = errs[j]
+	}
+	if err == nil {
+		return results, nil
+	}
+	if !req.Atomic {
+		return nil, err
+	}
+	if len(results.Failed()) == 0 {
+		// 個々のtodoではなく、タグの作成などで失敗した場合
+		return nil, err
+	}
+	return rollBackBulk(results)
+}
+
+// 全て取り消した一括操作の結果を返す
+// 成功していたtodoの結果はErrBulkRolledBackとし、最初に失敗したtodoのエラーを返す
+func rollBackBulk(results models.BulkResults) (models.BulkResults, error) {
+	var first error
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Err = models.ErrBulkRolledBack
+		} else if first == nil {
+			first = fmt.Errorf("todo %d: %w", results[i].ID, results[i].Err)
+		}
+	}
+	return results, first
+}
+
+// 一括操作で、1件のtodoについて保存する内容を返す
+// 状態が既に操作後のものと同じ場合は、何も変更しない
+// 繰り返しのtodoを完了にする場合は、Editと同じく次の繰り返しのtodoを作成する
+// タグを付け替える場合は、付け替え後のタグ（tags）と比較して変更履歴を記録する
+func (uc *todoUsecase) bulkChange(action models.BulkAction, todo *models.Todo, tags []models.Tag) (models.TodoBulkChange, error) {
+	change := models.TodoBulkChange{Todo: todo}
+	switch action {
+	case models.BulkComplete, models.BulkReopen:
+		status := models.Done
+		if action == models.BulkReopen {
+			// 完了・中止したもののみ未着手に戻し、作業中のものはそのままとする
+			if !todo.Status.IsClosed() {
+				return change, nil
+			}
+			status = models.NotStarted
+		}
+		current := *todo
+		if err := todo.ChangeStatus(status); err != nil {
+			return change, err
+		}
+		if todo.Status == current.Status {
+			return change, nil
+		}
+		change.Update = true
+		change.Histories = uc.changes(&current, todo)
+		change.Next = uc.nextOccurrence(&current, todo)
+	case models.BulkDelete:
+		change.Delete = true
+		change.Histories = []models.TodoHistory{models.NewTodoHistory(todo.ID, todo.UserID, models.HistoryDeleted)}
+	case models.BulkRetag:
+		change.Histories = models.TagChanges(todo.ID, todo.Tags, tags, todo.UserID)
+	}
+	return change, nil
+}
+
+// 指定されたIDのtodoを、afterIDのtodoの直後・beforeIDのtodoの直前に移動する
+// 先頭・末尾に移動する場合は、片方を0とする
+// 移動したtodoの並び順のみを更新し、他のtodoは変更しない
+func (uc *todoUsecase) Move(userID uint, id uint, afterID uint, beforeID uint) error {
+	if (afterID == 0 && beforeID == 0) || afterID == id || beforeID == id {
+		return ErrInvalidMove
+	}
+	if _, err := uc.repos.FindSummaryById(userID, id); err != nil {
+		return err
+	}
+	lower, err := uc.neighborPosition(userID, afterID)
+	if err != nil {
+		return err
+	}
+	upper, err := uc.neighborPosition(userID, beforeID)
+	if err != nil {
+		return err
+	}
+	position, err := models.RankBetween(lower, upper)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidMove, err)
+	}
+	return uc.repos.UpdatePosition(userID, id, position)
+}
+
+// 移動先の隣のtodoの並び順を返す（IDが0の場合は空文字列）
+// 隣のtodoが存在しない場合はErrInvalidMoveを返す
+func (uc *todoUsecase) neighborPosition(userID uint, id uint) (string, error) {
+	if id == 0 {
+		return "", nil
+	}
+	todo, err := uc.repos.FindSummaryById(userID, id)
+	if models.KindOf(err) == models.KindNotFound {
+		return "", fmt.Errorf("%w: todo %d not found", ErrInvalidMove, id)
+	}
+	if err != nil {
+		return "", err
+	}
+	return todo.Position, nil
+}
+
+// 指定されたユーザーの、指定されたIDのtodoにファイルを添付する
+// ファイルの種類はrの先頭の内容から判定し、添付できない種類や大きすぎる場合はエラーを返す
+func (uc *todoUsecase) Attach(userID uint, todoID uint, fileName string, size int64, r io.Reader) (*models.Attachment, error) {
+	if _, err := uc.repos.FindSummaryById(userID, todoID); err != nil {
+		return nil, err
+	}
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	head = head[:n]
+	attachment, err := models.NewAttachment(todoID, userID, fileName, head, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// 種類の判定に読み込んだ先頭と、残りを続けて保存する
+	if err := uc.storage.Put(attachment.StorageKey, io.MultiReader(bytes.NewReader(head), r), size, attachment.ContentType); err != nil {
+		return nil, err
+	}
+	if err := uc.attachments.Create(attachment); err != nil {
+		// 情報を保存できなかったファイルは参照できないため、削除しておく
+		uc.removeBlob(attachment.StorageKey)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// 指定されたユーザーの、指定されたtodoの、指定されたIDの添付ファイルの情報と、内容を読み込むReaderを返す
+// Readerは呼び出し側で閉じること
+func (uc *todoUsecase) OpenAttachment(userID uint, todoID uint, id uint) (*models.Attachment, io.ReadCloser, error) {
+	attachment, err := uc.attachments.FindById(userID, todoID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := uc.storage.Get(attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, r, nil
+}
+
+// 指定されたユーザーの、指定されたtodoの、指定されたIDの添付ファイルを削除する
+func (uc *todoUsecase) Detach(userID uint, todoID uint, id uint) error {
+	attachment, err := uc.attachments.FindById(userID, todoID, id)
+	if err != nil {
+		return err
+	}
+	if err := uc.attachments.Delete(userID, todoID, id); err != nil {
+		return err
+	}
+	uc.removeBlob(attachment.StorageKey)
+	return nil
+}
+
+// 指定されたユーザーの、指定されたtodoの変更履歴を新しい順で返す
+// todoが存在しない場合は、種類がKindNotFoundのエラーを返す
+func (uc *todoUsecase) History(userID uint, todoID uint) (*[]models.TodoHistory, error) {
+	if _, err := uc.repos.FindSummaryById(userID, todoID); err != nil {
+		return nil, err
+	}
+	return uc.histories.FindByTodo(userID, todoID)
+}
+
+// ストレージから添付ファイルの内容を削除する
+// 情報は削除済みで参照されないため、失敗した場合もログの出力のみとする
+func (uc *todoUsecase) removeBlob(key string) {
+	if err := uc.storage.Delete(key); err != nil {
+		slog.Error("failed to delete attachment from storage", "key", key, "error", err)
+	}
+}
+
+// 範囲の開始として、指定された日時のうち遅い方を返す
+func laterTime(current *time.Time, t time.Time) *time.Time {
+	if current != nil && current.After(t) {
+		return current
+	}
+	return &t
+}
+
+// 範囲の終了として、指定された日時のうち早い方を返す
+func earlierTime(current *time.Time, t time.Time) *time.Time {
+	if current != nil && current.Before(t) {
+		return current
+	}
+	return &t
 }
 
 // ユースケースの終了処理を行う
 func (uc *todoUsecase) Close() error {
-	err := uc.repos.Close()
+	err := errors.Join(uc.repos.Close(), uc.tags.Close(), uc.projects.Close(), uc.attachments.Close(), uc.storage.Close(), uc.histories.Close())
 	if err != nil {
 		slog.Error(err.Error())
 	}
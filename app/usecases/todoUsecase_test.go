@@ -3,15 +3,31 @@ package usecases
 import (
 	"bytes"
 	"errors"
+	"io"
 	"log/slog"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/MinadukiSekina/todo-go-app/app/domain/models"
+	"github.com/MinadukiSekina/todo-go-app/app/domain/repository"
 	mock_repository "github.com/MinadukiSekina/todo-go-app/app/mock/repository"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
+// テストで使用するtodoの所有者
+const testUserID uint = 1
+
+// 変更履歴を取得しないテストで使用するモックを生成する
+// 変更履歴の保存はTodoRepositoryで行うため、終了処理のみを許可する
+func newHistoryRepositoryMock(ctrl *gomock.Controller) *mock_repository.MockTodoHistoryRepository {
+	m := mock_repository.NewMockTodoHistoryRepository(ctrl)
+	m.EXPECT().Close().Return(nil).AnyTimes()
+	return m
+}
+
 func TestSearchByID(t *testing.T) {
 
 	type args struct {
@@ -51,11 +67,11 @@ func TestSearchByID(t *testing.T) {
 			mock := mock_repository.NewMockTodoRepository(mockCtrl)
 			// テスト中に呼ばれるべき関数と帰り値を指定
 			// 違う引数で呼び出すとエラーになるらしい
-			mock.EXPECT().FindById(tt.args.ID).Return(tt.want, tt.err)
+			mock.EXPECT().FindById(testUserID, tt.args.ID).Return(tt.want, tt.err)
 
 			// mockを利用してテストする
-			Usecase := NewTodoUsecase(mock)
-			result, err := Usecase.SearchByID(tt.args.ID)
+			Usecase := NewTodoUsecase(mock, mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), mock_repository.NewMockAttachmentRepository(mockCtrl), mock_repository.NewMockBlobStorage(mockCtrl), newHistoryRepositoryMock(mockCtrl))
+			result, err := Usecase.SearchByID(testUserID, tt.args.ID)
 
 			// 結果を確認
 			assert.Equal(t, tt.want, result)
@@ -119,11 +135,11 @@ func TestShow(t *testing.T) {
 			mock := mock_repository.NewMockTodoRepository(mockCtrl)
 			// テスト中に呼ばれるべき関数と帰り値を指定
 			// 違う引数で呼び出すとエラーになるらしい
-			mock.EXPECT().FindAll().Return(tt.want, tt.err)
+			mock.EXPECT().FindAll(testUserID).Return(tt.want, tt.err)
 
 			// mockを利用してテストする
-			Usecase := NewTodoUsecase(mock)
-			result, err := Usecase.Show()
+			Usecase := NewTodoUsecase(mock, mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), mock_repository.NewMockAttachmentRepository(mockCtrl), mock_repository.NewMockBlobStorage(mockCtrl), newHistoryRepositoryMock(mockCtrl))
+			result, err := Usecase.Show(testUserID)
 
 			// 結果を確認
 			assert.Equal(t, tt.want, result)
@@ -156,7 +172,7 @@ func TestAdd(t *testing.T) {
 			err:       nil,
 		},
 		"異常ケース:登録失敗": {
-			args:      args{},
+			args:      args{&models.Todo{Title: "test", Status: models.NotStarted}},
 			expectErr: true,
 			err:       errors.New("Save todo is failed"),
 		},
@@ -172,10 +188,10 @@ func TestAdd(t *testing.T) {
 			mock := mock_repository.NewMockTodoRepository(mockCtrl)
 			// テスト中に呼ばれるべき関数と帰り値を指定
 			// 違う引数で呼び出すとエラーになるらしい
-			mock.EXPECT().Create(tt.args.todo).Return(tt.err)
+			mock.EXPECT().Create(tt.args.todo, models.NewTodoHistory(0, tt.args.todo.UserID, models.HistoryCreated)).Return(tt.err)
 
 			// mockを利用してテストする
-			Usecase := NewTodoUsecase(mock)
+			Usecase := NewTodoUsecase(mock, mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), mock_repository.NewMockAttachmentRepository(mockCtrl), mock_repository.NewMockBlobStorage(mockCtrl), newHistoryRepositoryMock(mockCtrl))
 			err := Usecase.Add(tt.args.todo)
 
 			// 結果を確認
@@ -223,10 +239,12 @@ func TestEdit(t *testing.T) {
 			mock := mock_repository.NewMockTodoRepository(mockCtrl)
 			// テスト中に呼ばれるべき関数と帰り値を指定
 			// 違う引数で呼び出すとエラーになるらしい
-			mock.EXPECT().Update(tt.args.todo).Return(tt.err)
+			current := *tt.args.todo
+			mock.EXPECT().FindSummaryById(tt.args.todo.UserID, tt.args.todo.ID).Return(&current, nil)
+			mock.EXPECT().Update(tt.args.todo, gomock.Any(), gomock.Nil()).Return(tt.err)
 
 			// mockを利用してテストする
-			Usecase := NewTodoUsecase(mock)
+			Usecase := NewTodoUsecase(mock, mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), mock_repository.NewMockAttachmentRepository(mockCtrl), mock_repository.NewMockBlobStorage(mockCtrl), newHistoryRepositoryMock(mockCtrl))
 			err := Usecase.Edit(tt.args.todo)
 
 			// 結果を確認
@@ -239,26 +257,152 @@ func TestEdit(t *testing.T) {
 	}
 }
 
-func TestDelete(t *testing.T) {
+func TestValidateTodo(t *testing.T) {
 
-	type args struct {
-		ID uint
+	cases := map[string]struct {
+		// 保存されているtodoのタイトル（空の場合は新規作成）
+		current string
+		title   string
+		// 保存するタイトル（空の場合はtitleのまま）
+		want string
+		// 検証エラーになる場合の、タイトルのエラー
+		err error
+	}{
+		"正常ケース:新規作成": {
+			title: "test",
+		},
+		"正常ケース:新規作成でタイトルを揃えて保存する": {
+			title: "  Cafe\u0301\u3000",
+			want:  "Caf\u00e9",
+		},
+		"異常ケース:新規作成でタイトルが空": {
+			title: "",
+			err:   models.ErrTitleRequired,
+		},
+		"異常ケース:新規作成でタイトルが空白のみ": {
+			title: "   ",
+			err:   models.ErrTitleRequired,
+		},
+		"異常ケース:タイトルを空白のみに変更": {
+			current: "test",
+			title:   " \t ",
+			err:     models.ErrTitleRequired,
+		},
+		"異常ケース:新規作成でタイトルに制御文字を含む": {
+			title: "test\x00",
+			err:   models.ErrControlCharacter,
+		},
+		"正常ケース:以前から保存されているタイトルは変更しなければ更新できる": {
+			current: " ",
+			title:   " ",
+		},
+		"異常ケース:タイトルを長すぎる値に変更": {
+			current: "test",
+			title:   strings.Repeat("あ", models.TitleMaxLength+1),
+			err:     models.ErrTitleTooLong,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_repository.NewMockTodoRepository(mockCtrl)
+			todo := &models.Todo{Title: tt.title, Status: models.NotStarted}
+			if tt.current != "" {
+				current := models.Todo{Title: tt.current, Status: models.NotStarted}
+				mock.EXPECT().FindSummaryById(todo.UserID, todo.ID).Return(&current, nil)
+			}
+			// 検証エラーの場合は保存しない
+			if tt.err == nil {
+				if tt.current != "" {
+					mock.EXPECT().Update(todo, gomock.Any(), gomock.Nil()).Return(nil)
+				} else {
+					mock.EXPECT().Create(todo, gomock.Any()).Return(nil)
+				}
+			}
+
+			// mockを利用してテストする
+			Usecase := NewTodoUsecase(mock, mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), mock_repository.NewMockAttachmentRepository(mockCtrl), mock_repository.NewMockBlobStorage(mockCtrl), newHistoryRepositoryMock(mockCtrl))
+			var err error
+			if tt.current != "" {
+				err = Usecase.Edit(todo)
+			} else {
+				err = Usecase.Add(todo)
+			}
+
+			// 結果を確認
+			if tt.err == nil {
+				assert.NoError(t, err)
+				if tt.want != "" {
+					assert.Equal(t, tt.want, todo.Title)
+				}
+				return
+			}
+			var fieldErrs models.FieldErrors
+			if assert.ErrorAs(t, err, &fieldErrs) {
+				assert.ErrorIs(t, fieldErrs.Get(models.FieldTitle), tt.err)
+			}
+			assert.Equal(t, models.KindValidation, models.KindOf(err))
+		})
 	}
+}
+
+func TestValidateTodoStatus(t *testing.T) {
 
 	cases := map[string]struct {
-		args      args
-		expectErr bool
-		err       error
+		// 更新の場合は保存されているtodoの状態（falseの場合は新規作成）
+		edit    bool
+		current models.Status
+		// 保存されているtodoのチェックリスト
+		checklist []models.ChecklistItem
+		status    models.Status
+		// 検証エラーになる場合の、状態のエラー
+		err error
 	}{
-		"正常ケース:更新完了": {
-			args:      args{ID: 1},
-			expectErr: false,
-			err:       nil,
+		"正常ケース:作業中の状態で新規作成": {
+			status: models.InProgress,
 		},
-		"異常ケース:更新失敗": {
-			args:      args{ID: 1},
-			expectErr: true,
-			err:       errors.New("Save todo is failed"),
+		"異常ケース:完了の状態で新規作成": {
+			status: models.Done,
+			err:    models.ErrInvalidInitialStatus,
+		},
+		"異常ケース:中止の状態で新規作成": {
+			status: models.Cancelled,
+			err:    models.ErrInvalidInitialStatus,
+		},
+		"正常ケース:許可された遷移": {
+			edit:    true,
+			current: models.Blocked,
+			status:  models.InProgress,
+		},
+		"異常ケース:完了から再開せずに進行中に変更": {
+			edit:    true,
+			current: models.Done,
+			status:  models.InProgress,
+			err:     models.ErrInvalidStatusTransition,
+		},
+		"異常ケース:チェックリストに未完了の項目が残っている状態で完了に変更": {
+			edit:      true,
+			current:   models.InProgress,
+			checklist: []models.ChecklistItem{{Title: "牛乳", Done: true}, {Title: "卵"}},
+			status:    models.Done,
+			err:       models.ErrOpenChecklistItems,
+		},
+		"正常ケース:チェックリストの項目が全て完了している状態で完了に変更": {
+			edit:      true,
+			current:   models.InProgress,
+			checklist: []models.ChecklistItem{{Title: "牛乳", Done: true}},
+			status:    models.Done,
+		},
+		"異常ケース:ブロック中から完了に変更": {
+			edit:    true,
+			current: models.Blocked,
+			status:  models.Done,
+			err:     models.ErrInvalidStatusTransition,
 		},
 	}
 	for name, tt := range cases {
@@ -270,13 +414,172 @@ func TestDelete(t *testing.T) {
 
 			// モックの生成
 			mock := mock_repository.NewMockTodoRepository(mockCtrl)
+			todo := &models.Todo{Title: "test", Status: tt.status}
+			if tt.edit {
+				current := models.Todo{Title: "test", Status: tt.current, ChecklistItems: tt.checklist}
+				mock.EXPECT().FindSummaryById(todo.UserID, todo.ID).Return(&current, nil)
+			}
+			// 検証エラーの場合は保存しない
+			if tt.err == nil {
+				if tt.edit {
+					mock.EXPECT().Update(todo, gomock.Any(), gomock.Nil()).Return(nil)
+				} else {
+					mock.EXPECT().Create(todo, gomock.Any()).Return(nil)
+				}
+			}
+
+			// mockを利用してテストする
+			Usecase := NewTodoUsecase(mock, mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), mock_repository.NewMockAttachmentRepository(mockCtrl), mock_repository.NewMockBlobStorage(mockCtrl), newHistoryRepositoryMock(mockCtrl))
+			var err error
+			if tt.edit {
+				err = Usecase.Edit(todo)
+			} else {
+				err = Usecase.Add(todo)
+			}
+
+			// 結果を確認
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var fieldErrs models.FieldErrors
+			if assert.ErrorAs(t, err, &fieldErrs) {
+				assert.ErrorIs(t, fieldErrs.Get(models.FieldStatus), tt.err)
+			}
+			assert.Equal(t, models.KindValidation, models.KindOf(err))
+		})
+	}
+}
+
+func TestEditRecurring(t *testing.T) {
+
+	now := time.Date(2025, 3, 12, 15, 0, 0, 0, time.UTC)
+
+	// 月末に繰り返すtodoを、指定された状態から完了にしたものを返す
+	newDoneTodo := func() *models.Todo {
+		todo := &models.Todo{UserID: testUserID, ProjectID: testInboxID, Title: "家賃の支払い", Status: models.Done, Tags: []models.Tag{{Name: "家計"}}}
+		todo.ID = 1
+		todo.Tags[0].ID = 5
+		_ = todo.SetDue("2025-01-31", "", "UTC")
+		_ = todo.SetRecurrence("FREQ=MONTHLY;BYMONTHDAY=-1")
+		return todo
+	}
+
+	cases := map[string]struct {
+		// 更新前に保存されていた状態
+		current    models.Status
+		recurrence string
+		updateErr  error
+		wantNext   bool
+		wantDue    string
+		expectErr  bool
+	}{
+		"正常ケース:完了にすると次の繰り返しを作成": {
+			current:    models.InProgress,
+			recurrence: "FREQ=MONTHLY;BYMONTHDAY=-1",
+			wantNext:   true,
+			wantDue:    "2025-02-28",
+		},
+		"正常ケース:完了のままの更新では作成しない": {
+			current:    models.Done,
+			recurrence: "FREQ=MONTHLY;BYMONTHDAY=-1",
+		},
+		"正常ケース:繰り返しが終了している場合は作成しない": {
+			current:    models.NotStarted,
+			recurrence: "FREQ=MONTHLY;BYMONTHDAY=-1;UNTIL=20250201",
+		},
+		"異常ケース:次の繰り返しの作成に失敗すると更新も失敗": {
+			current:    models.NotStarted,
+			recurrence: "FREQ=MONTHLY;BYMONTHDAY=-1",
+			updateErr:  errors.New("Create todo is failed"),
+			wantNext:   true,
+			expectErr:  true,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			todo := newDoneTodo()
+			_ = todo.SetRecurrence(tt.recurrence)
+			stored := newDoneTodo()
+			stored.Status = tt.current
+
+			// モックの生成
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
+			tags := mock_repository.NewMockTagRepository(mockCtrl)
+			todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(stored, nil)
+			// 次の繰り返しのtodoは、更新と同じトランザクションで作成する
+			var created *models.Todo
+			todos.EXPECT().Update(todo, gomock.Any(), gomock.Any()).DoAndReturn(func(_ *models.Todo, _ []models.TodoHistory, next *models.Todo) error {
+				created = next
+				return tt.updateErr
+			})
+
+			// mockを利用してテストする
+			Usecase := &todoUsecase{repos: todos, tags: tags, projects: newProjectRepositoryMock(mockCtrl), histories: newHistoryRepositoryMock(mockCtrl), now: func() time.Time { return now }}
+			err := Usecase.Edit(todo)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.updateErr)
+				return
+			}
+			assert.NoError(t, err)
+			if !tt.wantNext {
+				assert.Nil(t, created)
+				return
+			}
+			if assert.NotNil(t, created) {
+				assert.Equal(t, models.NotStarted, created.Status)
+				assert.Equal(t, todo.Tags, created.Tags)
+				assert.Equal(t, todo.Title, created.Title)
+				assert.Equal(t, todo.Recurrence, created.Recurrence)
+				assert.Equal(t, tt.wantDue, created.DueDateString())
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+
+	cases := map[string]struct {
+		prepareMockFn func(todos *mock_repository.MockTodoRepository)
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:削除完了": {
+			// ゴミ箱に移すだけなので、添付ファイルはストレージから削除しない
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository) {
+				todos.EXPECT().Delete(testUserID, uint(1), models.NewTodoHistory(1, testUserID, models.HistoryDeleted)).Return(nil)
+			},
+		},
+		"異常ケース:削除失敗": {
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository) {
+				todos.EXPECT().Delete(testUserID, uint(1), models.NewTodoHistory(1, testUserID, models.HistoryDeleted)).Return(errors.New("Delete todo is failed"))
+			},
+			expectErr: true,
+			err:       errors.New("Delete todo is failed"),
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
 			// テスト中に呼ばれるべき関数と帰り値を指定
-			// 違う引数で呼び出すとエラーになるらしい
-			mock.EXPECT().Delete(tt.args.ID).Return(tt.err)
+			tt.prepareMockFn(todos)
 
 			// mockを利用してテストする
-			Usecase := NewTodoUsecase(mock)
-			err := Usecase.Delete(tt.args.ID)
+			Usecase := NewTodoUsecase(todos, mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), mock_repository.NewMockAttachmentRepository(mockCtrl), mock_repository.NewMockBlobStorage(mockCtrl), newHistoryRepositoryMock(mockCtrl))
+			err := Usecase.Delete(testUserID, 1)
 
 			// 結果を確認
 			if tt.expectErr {
@@ -288,6 +591,180 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestRestore(t *testing.T) {
+
+	cases := map[string]struct {
+		prepareMockFn func(todos *mock_repository.MockTodoRepository)
+		expectErr     error
+	}{
+		"正常ケース:元に戻す": {
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository) {
+				todos.EXPECT().Restore(testUserID, uint(1), models.NewTodoHistory(1, testUserID, models.HistoryRestored)).Return(nil)
+			},
+		},
+		"異常ケース:ゴミ箱に無い": {
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository) {
+				todos.EXPECT().Restore(testUserID, uint(1), models.NewTodoHistory(1, testUserID, models.HistoryRestored)).Return(models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: gorm.ErrRecordNotFound,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(todos)
+
+			// mockを利用してテストする
+			Usecase := NewTodoUsecase(todos, mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), mock_repository.NewMockAttachmentRepository(mockCtrl), mock_repository.NewMockBlobStorage(mockCtrl), newHistoryRepositoryMock(mockCtrl))
+			err := Usecase.Restore(testUserID, 1)
+
+			// 結果を確認
+			assert.ErrorIs(t, err, tt.expectErr)
+		})
+	}
+}
+
+func TestPurge(t *testing.T) {
+
+	trashed := &models.Todo{
+		Model:  gorm.Model{ID: 1},
+		UserID: testUserID,
+		Attachments: []models.Attachment{
+			{ID: 1, TodoID: 1, StorageKey: "todos/1/a"},
+			{ID: 2, TodoID: 1, StorageKey: "todos/1/b"},
+		},
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(todos *mock_repository.MockTodoRepository, storage *mock_repository.MockBlobStorage)
+		expectErr     error
+	}{
+		"正常ケース:添付ファイルもストレージから削除する": {
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, storage *mock_repository.MockBlobStorage) {
+				todos.EXPECT().FindTrashedById(testUserID, uint(1)).Return(trashed, nil)
+				gomock.InOrder(
+					todos.EXPECT().Purge(testUserID, uint(1), models.NewTodoHistory(1, testUserID, models.HistoryPurged)).Return(nil),
+					storage.EXPECT().Delete("todos/1/a").Return(nil),
+					// ストレージからの削除に失敗しても、todoの削除は成功とする
+					storage.EXPECT().Delete("todos/1/b").Return(errors.New("storage is unavailable")),
+				)
+			},
+		},
+		"異常ケース:ゴミ箱に無い": {
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, storage *mock_repository.MockBlobStorage) {
+				todos.EXPECT().FindTrashedById(testUserID, uint(1)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: gorm.ErrRecordNotFound,
+		},
+		"異常ケース:削除失敗": {
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, storage *mock_repository.MockBlobStorage) {
+				// todoを削除できない場合は、ストレージからも削除しない
+				todos.EXPECT().FindTrashedById(testUserID, uint(1)).Return(trashed, nil)
+				todos.EXPECT().Purge(testUserID, uint(1), models.NewTodoHistory(1, testUserID, models.HistoryPurged)).Return(gorm.ErrInvalidTransaction)
+			},
+			expectErr: gorm.ErrInvalidTransaction,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
+			storage := mock_repository.NewMockBlobStorage(mockCtrl)
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(todos, storage)
+
+			// mockを利用してテストする
+			Usecase := NewTodoUsecase(todos, mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), mock_repository.NewMockAttachmentRepository(mockCtrl), storage, newHistoryRepositoryMock(mockCtrl))
+			err := Usecase.Purge(testUserID, 1)
+
+			// 結果を確認
+			assert.ErrorIs(t, err, tt.expectErr)
+		})
+	}
+}
+
+func TestPurgeExpired(t *testing.T) {
+
+	before := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	expired := []models.Todo{
+		{Model: gorm.Model{ID: 1}, UserID: testUserID, Attachments: []models.Attachment{{ID: 1, TodoID: 1, StorageKey: "todos/1/a"}}},
+		{Model: gorm.Model{ID: 2}, UserID: testUserID + 1},
+		{Model: gorm.Model{ID: 3}, UserID: testUserID},
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(todos *mock_repository.MockTodoRepository, storage *mock_repository.MockBlobStorage)
+		wantPurged    int
+		expectErr     error
+	}{
+		"正常ケース:全てのユーザーの期限切れのtodoを削除する": {
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, storage *mock_repository.MockBlobStorage) {
+				todos.EXPECT().FindTrashedBefore(before).Return(&expired, nil)
+				todos.EXPECT().Purge(testUserID, uint(1), models.NewTodoHistory(1, testUserID, models.HistoryPurged)).Return(nil)
+				storage.EXPECT().Delete("todos/1/a").Return(nil)
+				todos.EXPECT().Purge(testUserID+1, uint(2), models.NewTodoHistory(2, testUserID+1, models.HistoryPurged)).Return(nil)
+				todos.EXPECT().Purge(testUserID, uint(3), models.NewTodoHistory(3, testUserID, models.HistoryPurged)).Return(nil)
+			},
+			wantPurged: 3,
+		},
+		"正常ケース:期限切れのtodoが無い": {
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, storage *mock_repository.MockBlobStorage) {
+				todos.EXPECT().FindTrashedBefore(before).Return(&[]models.Todo{}, nil)
+			},
+		},
+		"異常ケース:削除に失敗しても残りは削除する": {
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, storage *mock_repository.MockBlobStorage) {
+				todos.EXPECT().FindTrashedBefore(before).Return(&expired, nil)
+				todos.EXPECT().Purge(testUserID, uint(1), models.NewTodoHistory(1, testUserID, models.HistoryPurged)).Return(gorm.ErrInvalidTransaction)
+				todos.EXPECT().Purge(testUserID+1, uint(2), models.NewTodoHistory(2, testUserID+1, models.HistoryPurged)).Return(nil)
+				todos.EXPECT().Purge(testUserID, uint(3), models.NewTodoHistory(3, testUserID, models.HistoryPurged)).Return(nil)
+			},
+			wantPurged: 2,
+			expectErr:  gorm.ErrInvalidTransaction,
+		},
+		"異常ケース:検索失敗": {
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, storage *mock_repository.MockBlobStorage) {
+				todos.EXPECT().FindTrashedBefore(before).Return(nil, gorm.ErrInvalidDB)
+			},
+			expectErr: gorm.ErrInvalidDB,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
+			storage := mock_repository.NewMockBlobStorage(mockCtrl)
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(todos, storage)
+
+			// mockを利用してテストする
+			Usecase := NewTodoUsecase(todos, mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), mock_repository.NewMockAttachmentRepository(mockCtrl), storage, newHistoryRepositoryMock(mockCtrl))
+			purged, err := Usecase.PurgeExpired(before)
+
+			// 結果を確認
+			assert.Equal(t, tt.wantPurged, purged)
+			assert.ErrorIs(t, err, tt.expectErr)
+		})
+	}
+}
+
 func TestClose(t *testing.T) {
 
 	cases := map[string]struct {
@@ -335,8 +812,19 @@ func TestClose(t *testing.T) {
 			slog.SetDefault(logger)
 			defer slog.SetDefault(originalLogger)
 
+			tags := mock_repository.NewMockTagRepository(mockCtrl)
+			tags.EXPECT().Close().Return(nil)
+			projects := mock_repository.NewMockProjectRepository(mockCtrl)
+			projects.EXPECT().Close().Return(nil)
+			attachments := mock_repository.NewMockAttachmentRepository(mockCtrl)
+			attachments.EXPECT().Close().Return(nil)
+			storage := mock_repository.NewMockBlobStorage(mockCtrl)
+			storage.EXPECT().Close().Return(nil)
+			histories := mock_repository.NewMockTodoHistoryRepository(mockCtrl)
+			histories.EXPECT().Close().Return(nil)
+
 			// mockを利用してテストする
-			Usecase := NewTodoUsecase(mock)
+			Usecase := NewTodoUsecase(mock, tags, projects, attachments, storage, histories)
 			err := Usecase.Close()
 
 			// 結果を確認
@@ -350,3 +838,1146 @@ func TestClose(t *testing.T) {
 		})
 	}
 }
+
+func TestSearch(t *testing.T) {
+
+	// サーバーのタイムゾーンをUTCに固定する
+	local := time.Local
+	time.Local = time.UTC
+	defer func() { time.Local = local }()
+
+	// 2025-04-02は水曜日
+	now := time.Date(2025, 4, 2, 15, 30, 0, 0, time.UTC)
+	today := time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)
+	tomorrow := time.Date(2025, 4, 3, 0, 0, 0, 0, time.UTC)
+	nextMonday := time.Date(2025, 4, 7, 0, 0, 0, 0, time.UTC)
+
+	todo := models.Todo{Title: "test", Status: models.NotStarted}
+	found := &[]models.Todo{todo}
+
+	cases := map[string]struct {
+		query     models.TodoQuery
+		wantQuery models.TodoQuery
+		found     *[]models.Todo
+		total     int64
+		want      *models.TodoPage
+		expectErr bool
+		err       error
+	}{
+		"正常ケース:省略された条件は既定値": {
+			query:     models.TodoQuery{UserID: testUserID},
+			wantQuery: models.TodoQuery{UserID: testUserID, Sort: models.SortByStatus, Direction: models.Asc, Page: 1, Limit: models.DefaultPageSize},
+			found:     found,
+			total:     1,
+			want:      &models.TodoPage{Todos: *found, Total: 1, Page: 1, Limit: models.DefaultPageSize},
+		},
+		"正常ケース:期限切れ": {
+			query: models.TodoQuery{UserID: testUserID, Due: models.DueOverdue, Page: 2, Limit: 10},
+			wantQuery: models.TodoQuery{
+				UserID: testUserID, Due: models.DueOverdue, OverdueAt: &now, Statuses: models.OpenStatuses(),
+				Sort: models.SortByStatus, Direction: models.Asc, Page: 2, Limit: 10,
+			},
+			found: found,
+			total: 11,
+			want:  &models.TodoPage{Todos: *found, Total: 11, Page: 2, Limit: 10},
+		},
+		"正常ケース:今日": {
+			query: models.TodoQuery{UserID: testUserID, Due: models.DueToday},
+			wantQuery: models.TodoQuery{
+				UserID: testUserID, Due: models.DueToday, DueFrom: &today, DueTo: &tomorrow, Statuses: models.OpenStatuses(),
+				Sort: models.SortByStatus, Direction: models.Asc, Page: 1, Limit: models.DefaultPageSize,
+			},
+			found: found,
+			total: 1,
+			want:  &models.TodoPage{Todos: *found, Total: 1, Page: 1, Limit: models.DefaultPageSize},
+		},
+		"正常ケース:今週は指定された期限の範囲と重なる部分": {
+			query: models.TodoQuery{
+				UserID: testUserID, Due: models.DueThisWeek, DueTo: &tomorrow, Statuses: []models.Status{models.Done},
+			},
+			wantQuery: models.TodoQuery{
+				UserID: testUserID, Due: models.DueThisWeek, DueFrom: &today, DueTo: &tomorrow, Statuses: []models.Status{models.Done},
+				Sort: models.SortByStatus, Direction: models.Asc, Page: 1, Limit: models.DefaultPageSize,
+			},
+			found: found,
+			total: 1,
+			want:  &models.TodoPage{Todos: *found, Total: 1, Page: 1, Limit: models.DefaultPageSize},
+		},
+		"正常ケース:今週": {
+			query: models.TodoQuery{UserID: testUserID, Due: models.DueThisWeek},
+			wantQuery: models.TodoQuery{
+				UserID: testUserID, Due: models.DueThisWeek, DueFrom: &today, DueTo: &nextMonday, Statuses: models.OpenStatuses(),
+				Sort: models.SortByStatus, Direction: models.Asc, Page: 1, Limit: models.DefaultPageSize,
+			},
+			found: &[]models.Todo{},
+			total: 0,
+			want:  &models.TodoPage{Todos: []models.Todo{}, Total: 0, Page: 1, Limit: models.DefaultPageSize},
+		},
+		"異常ケース:エラーあり": {
+			query:     models.TodoQuery{UserID: testUserID},
+			wantQuery: models.TodoQuery{UserID: testUserID, Sort: models.SortByStatus, Direction: models.Asc, Page: 1, Limit: models.DefaultPageSize},
+			found:     nil,
+			want:      nil,
+			expectErr: true,
+			err:       errors.New("Some error is occured"),
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_repository.NewMockTodoRepository(mockCtrl)
+			// 期限による絞り込みが正しく変換されていることを引数で確認する
+			mock.EXPECT().Search(tt.wantQuery).Return(tt.found, tt.total, tt.err)
+
+			// 現在日時を固定してテストする
+			Usecase := &todoUsecase{repos: mock, now: func() time.Time { return now }}
+			result, err := Usecase.Search(tt.query)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, result)
+
+			// エラーの確認
+			if tt.expectErr {
+				assert.Equal(t, tt.err.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSearchTimezone(t *testing.T) {
+
+	// サーバーのタイムゾーンをUTCに固定する
+	local := time.Local
+	time.Local = time.UTC
+	defer func() { time.Local = local }()
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	// UTCでは2025-04-02だが、東京では2025-04-03（木曜日）
+	now := time.Date(2025, 4, 2, 15, 30, 0, 0, time.UTC)
+
+	// 東京で終日の期限を設定したtodoを返す
+	dueOn := func(date string) models.Todo {
+		todo := models.Todo{Title: date, Status: models.NotStarted}
+		if err := todo.SetDue(date, "", "Asia/Tokyo"); err != nil {
+			t.Fatal(err)
+		}
+		return todo
+	}
+
+	cases := map[string]struct {
+		due      models.DueFilter
+		wantFrom time.Time
+		wantTo   time.Time
+		included []string
+		excluded []string
+	}{
+		"正常ケース:今日は検索条件のタイムゾーンの日付で求める": {
+			due:      models.DueToday,
+			wantFrom: time.Date(2025, 4, 3, 0, 0, 0, 0, tokyo),
+			wantTo:   time.Date(2025, 4, 4, 0, 0, 0, 0, tokyo),
+			included: []string{"2025-04-03"},
+			excluded: []string{"2025-04-02", "2025-04-04"},
+		},
+		"正常ケース:今週は検索条件のタイムゾーンの週で求める": {
+			due:      models.DueThisWeek,
+			wantFrom: time.Date(2025, 4, 3, 0, 0, 0, 0, tokyo),
+			wantTo:   time.Date(2025, 4, 7, 0, 0, 0, 0, tokyo),
+			included: []string{"2025-04-03", "2025-04-06"},
+			excluded: []string{"2025-04-02", "2025-04-07"},
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// 変換された期限の範囲を受け取る
+			var got models.TodoQuery
+			mock := mock_repository.NewMockTodoRepository(mockCtrl)
+			mock.EXPECT().Search(gomock.Any()).DoAndReturn(func(query models.TodoQuery) (*[]models.Todo, int64, error) {
+				got = query
+				return &[]models.Todo{}, 0, nil
+			})
+
+			// 現在日時を固定してテストする
+			Usecase := &todoUsecase{repos: mock, now: func() time.Time { return now }}
+			_, err := Usecase.Search(models.TodoQuery{UserID: testUserID, Due: tt.due, Timezone: "Asia/Tokyo"})
+
+			// 結果を確認
+			assert.NoError(t, err)
+			if assert.NotNil(t, got.DueFrom) && assert.NotNil(t, got.DueTo) {
+				assert.True(t, tt.wantFrom.Equal(*got.DueFrom), "from: %s", got.DueFrom)
+				assert.True(t, tt.wantTo.Equal(*got.DueTo), "to: %s", got.DueTo)
+				// 東京の終日の期限が、範囲に含まれるかを確認する
+				for _, date := range tt.included {
+					todo := dueOn(date)
+					assert.True(t, !todo.DueAt.Before(*got.DueFrom) && todo.DueAt.Before(*got.DueTo), "included: %s", date)
+				}
+				for _, date := range tt.excluded {
+					todo := dueOn(date)
+					assert.False(t, !todo.DueAt.Before(*got.DueFrom) && todo.DueAt.Before(*got.DueTo), "excluded: %s", date)
+				}
+			}
+		})
+	}
+}
+
+func TestSetTags(t *testing.T) {
+
+	backend := models.Tag{ID: 1, UserID: testUserID, Name: "backend"}
+	urgent := models.Tag{ID: 2, UserID: testUserID, Name: "urgent"}
+
+	// 指定されたタグが付いたtodoを返す
+	newTodo := func(tags ...models.Tag) *models.Todo {
+		todo := &models.Todo{UserID: testUserID, Title: "test", Status: models.NotStarted, Tags: tags}
+		todo.ID = 1
+		return todo
+	}
+	// タグの変更履歴を返す
+	tagHistory := func(old string, new string) models.TodoHistory {
+		history := models.NewTodoHistory(1, testUserID, models.HistoryUpdated)
+		history.Field = models.HistoryFieldTags
+		history.OldValue = old
+		history.NewValue = new
+		return history
+	}
+
+	cases := map[string]struct {
+		names         []string
+		prepareMockFn func(todos *mock_repository.MockTodoRepository, tags *mock_repository.MockTagRepository)
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:既存のタグを付け替える": {
+			names: []string{"backend"},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, tags *mock_repository.MockTagRepository) {
+				todo := newTodo(urgent)
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(todo, nil)
+				tags.EXPECT().FindByNames(testUserID, []string{"backend"}).Return(&[]models.Tag{backend}, nil)
+				todos.EXPECT().ReplaceTags(todo, []models.Tag{backend}, tagHistory("urgent", "backend")).Return(nil)
+			},
+		},
+		"正常ケース:存在しないタグは付け替えと同じトランザクションで作成する": {
+			names: []string{"  new   tag ", "backend"},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, tags *mock_repository.MockTagRepository) {
+				todo := newTodo(backend)
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(todo, nil)
+				tags.EXPECT().FindByNames(testUserID, []string{"new tag", "backend"}).Return(&[]models.Tag{backend}, nil)
+				todos.EXPECT().ReplaceTags(todo, []models.Tag{{UserID: testUserID, Name: "new tag"}, backend}, tagHistory("backend", "backend, new tag")).Return(nil)
+			},
+		},
+		"正常ケース:全てのタグを外す": {
+			names: []string{},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, tags *mock_repository.MockTagRepository) {
+				todo := newTodo(backend, urgent)
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(todo, nil)
+				todos.EXPECT().ReplaceTags(todo, []models.Tag{}, tagHistory("backend, urgent", "")).Return(nil)
+			},
+		},
+		"正常ケース:タグが変わらない場合は変更履歴を記録しない": {
+			names: []string{"urgent", "backend"},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, tags *mock_repository.MockTagRepository) {
+				todo := newTodo(backend, urgent)
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(todo, nil)
+				tags.EXPECT().FindByNames(testUserID, []string{"urgent", "backend"}).Return(&[]models.Tag{backend, urgent}, nil)
+				todos.EXPECT().ReplaceTags(todo, []models.Tag{urgent, backend}).Return(nil)
+			},
+		},
+		"異常ケース:todoが存在しない": {
+			names: []string{"backend"},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, tags *mock_repository.MockTagRepository) {
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(nil, errors.New("Record Not found"))
+			},
+			expectErr: true,
+			err:       errors.New("Record Not found"),
+		},
+		"異常ケース:タグの名前が不正": {
+			names: []string{"backend", "a,b"},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, tags *mock_repository.MockTagRepository) {
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(newTodo(), nil)
+			},
+			expectErr: true,
+			err:       models.ErrInvalidTagName,
+		},
+		"異常ケース:保存に失敗": {
+			names: []string{"backend"},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, tags *mock_repository.MockTagRepository) {
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(newTodo(), nil)
+				tags.EXPECT().FindByNames(testUserID, []string{"backend"}).Return(&[]models.Tag{backend}, nil)
+				todos.EXPECT().ReplaceTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("Commit is failed"))
+			},
+			expectErr: true,
+			err:       errors.New("Commit is failed"),
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
+			tags := mock_repository.NewMockTagRepository(mockCtrl)
+			tt.prepareMockFn(todos, tags)
+
+			// mockを利用してテストする
+			Usecase := NewTodoUsecase(todos, tags, newProjectRepositoryMock(mockCtrl), mock_repository.NewMockAttachmentRepository(mockCtrl), mock_repository.NewMockBlobStorage(mockCtrl), newHistoryRepositoryMock(mockCtrl))
+			err := Usecase.SetTags(testUserID, 1, tt.names)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.ErrorContains(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMove(t *testing.T) {
+
+	// テスト用のtodo（IDをキーにする）
+	todos := map[uint]*models.Todo{
+		1: {UserID: testUserID, Title: "first", Position: "a0"},
+		2: {UserID: testUserID, Title: "second", Position: "a1"},
+		3: {UserID: testUserID, Title: "third", Position: "a2"},
+	}
+	// 存在するtodoのみ返すようにモックを設定する
+	findById := func(m *mock_repository.MockTodoRepository) {
+		m.EXPECT().FindSummaryById(testUserID, gomock.Any()).DoAndReturn(func(userID uint, id uint) (*models.Todo, error) {
+			if todo, ok := todos[id]; ok {
+				return todo, nil
+			}
+			return nil, models.NotFound(gorm.ErrRecordNotFound)
+		}).AnyTimes()
+	}
+
+	type args struct {
+		id       uint
+		afterID  uint
+		beforeID uint
+	}
+
+	cases := map[string]struct {
+		args          args
+		prepareMockFn func(m *mock_repository.MockTodoRepository)
+		expectErr     bool
+		err           error
+	}{
+		"正常ケース:間に移動": {
+			args: args{id: 3, afterID: 1, beforeID: 2},
+			prepareMockFn: func(m *mock_repository.MockTodoRepository) {
+				findById(m)
+				m.EXPECT().UpdatePosition(testUserID, uint(3), "a0V").Return(nil)
+			},
+		},
+		"正常ケース:先頭に移動": {
+			args: args{id: 3, beforeID: 1},
+			prepareMockFn: func(m *mock_repository.MockTodoRepository) {
+				findById(m)
+				m.EXPECT().UpdatePosition(testUserID, uint(3), "Zz").Return(nil)
+			},
+		},
+		"正常ケース:末尾に移動": {
+			args: args{id: 1, afterID: 3},
+			prepareMockFn: func(m *mock_repository.MockTodoRepository) {
+				findById(m)
+				m.EXPECT().UpdatePosition(testUserID, uint(1), "a3").Return(nil)
+			},
+		},
+		"異常ケース:隣のtodoの指定が無い": {
+			args:          args{id: 1},
+			prepareMockFn: func(m *mock_repository.MockTodoRepository) {},
+			expectErr:     true,
+			err:           ErrInvalidMove,
+		},
+		"異常ケース:自分自身を隣に指定": {
+			args:          args{id: 1, afterID: 1},
+			prepareMockFn: func(m *mock_repository.MockTodoRepository) {},
+			expectErr:     true,
+			err:           ErrInvalidMove,
+		},
+		"異常ケース:前後が逆": {
+			args: args{id: 1, afterID: 3, beforeID: 2},
+			prepareMockFn: func(m *mock_repository.MockTodoRepository) {
+				findById(m)
+			},
+			expectErr: true,
+			err:       ErrInvalidMove,
+		},
+		"異常ケース:隣のtodoが存在しない": {
+			args: args{id: 1, afterID: 9},
+			prepareMockFn: func(m *mock_repository.MockTodoRepository) {
+				findById(m)
+			},
+			expectErr: true,
+			err:       ErrInvalidMove,
+		},
+		"異常ケース:移動するtodoが存在しない": {
+			args: args{id: 9, afterID: 1},
+			prepareMockFn: func(m *mock_repository.MockTodoRepository) {
+				findById(m)
+			},
+			expectErr: true,
+			err:       gorm.ErrRecordNotFound,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			mock := mock_repository.NewMockTodoRepository(mockCtrl)
+			tt.prepareMockFn(mock)
+
+			// mockを利用してテストする
+			Usecase := NewTodoUsecase(mock, mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), mock_repository.NewMockAttachmentRepository(mockCtrl), mock_repository.NewMockBlobStorage(mockCtrl), newHistoryRepositoryMock(mockCtrl))
+			err := Usecase.Move(testUserID, tt.args.id, tt.args.afterID, tt.args.beforeID)
+
+			// 結果を確認
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAttach(t *testing.T) {
+
+	// PNGのファイル
+	png := "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR" + strings.Repeat("x", 1000)
+	todo := models.Todo{Title: "test"}
+	todo.ID = 1
+
+	type args struct {
+		fileName string
+		content  string
+	}
+
+	cases := map[string]struct {
+		args          args
+		prepareMockFn func(todos *mock_repository.MockTodoRepository, attachments *mock_repository.MockAttachmentRepository, storage *mock_repository.MockBlobStorage)
+		wantType      string
+		expectErr     error
+	}{
+		"正常ケース:画像を添付": {
+			args: args{fileName: "screenshot.png", content: png},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, attachments *mock_repository.MockAttachmentRepository, storage *mock_repository.MockBlobStorage) {
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(&todo, nil)
+				// 種類の判定に読み込んだ先頭も含めて、全ての内容を保存すること
+				storage.EXPECT().Put(gomock.Any(), gomock.Any(), int64(len(png)), "image/png").DoAndReturn(func(key string, r io.Reader, size int64, contentType string) error {
+					body, _ := io.ReadAll(r)
+					assert.Equal(t, png, string(body))
+					return nil
+				})
+				attachments.EXPECT().Create(gomock.Any()).Return(nil)
+			},
+			wantType: "image/png",
+		},
+		"正常ケース:先頭の判定に必要な大きさに満たないファイル": {
+			args: args{fileName: "memo.txt", content: "メモ"},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, attachments *mock_repository.MockAttachmentRepository, storage *mock_repository.MockBlobStorage) {
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(&todo, nil)
+				storage.EXPECT().Put(gomock.Any(), gomock.Any(), int64(len("メモ")), "text/plain").Return(nil)
+				attachments.EXPECT().Create(gomock.Any()).Return(nil)
+			},
+			wantType: "text/plain",
+		},
+		"異常ケース:添付できない種類": {
+			args: args{fileName: "index.html", content: "<html><script>alert(1)</script></html>"},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, attachments *mock_repository.MockAttachmentRepository, storage *mock_repository.MockBlobStorage) {
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(&todo, nil)
+			},
+			expectErr: models.ErrAttachmentTypeNotAllowed,
+		},
+		"異常ケース:todoが存在しない": {
+			args: args{fileName: "screenshot.png", content: png},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, attachments *mock_repository.MockAttachmentRepository, storage *mock_repository.MockBlobStorage) {
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: gorm.ErrRecordNotFound,
+		},
+		"異常ケース:情報を保存できない場合はストレージからも削除する": {
+			args: args{fileName: "screenshot.png", content: png},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, attachments *mock_repository.MockAttachmentRepository, storage *mock_repository.MockBlobStorage) {
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(&todo, nil)
+				var saved string
+				storage.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(key string, r io.Reader, size int64, contentType string) error {
+					saved = key
+					return nil
+				})
+				attachments.EXPECT().Create(gomock.Any()).Return(errors.New("something is wrong"))
+				storage.EXPECT().Delete(gomock.Any()).DoAndReturn(func(key string) error {
+					assert.Equal(t, saved, key)
+					return nil
+				})
+			},
+			expectErr: errors.New("something is wrong"),
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
+			attachments := mock_repository.NewMockAttachmentRepository(mockCtrl)
+			storage := mock_repository.NewMockBlobStorage(mockCtrl)
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(todos, attachments, storage)
+
+			// mockを利用してテストする
+			Usecase := NewTodoUsecase(todos, mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), attachments, storage, newHistoryRepositoryMock(mockCtrl))
+			attachment, err := Usecase.Attach(testUserID, 1, tt.args.fileName, int64(len(tt.args.content)), strings.NewReader(tt.args.content))
+
+			// 結果を確認
+			if tt.expectErr != nil {
+				if assert.Error(t, err) {
+					assert.True(t, errors.Is(err, tt.expectErr) || err.Error() == tt.expectErr.Error(), err)
+				}
+				assert.Nil(t, attachment)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.args.fileName, attachment.FileName)
+				assert.Equal(t, tt.wantType, attachment.ContentType)
+				assert.Equal(t, testUserID, attachment.UserID)
+			}
+		})
+	}
+}
+
+func TestOpenAttachment(t *testing.T) {
+
+	file := models.Attachment{ID: 2, TodoID: 1, FileName: "a.txt", StorageKey: "todos/1/a"}
+
+	cases := map[string]struct {
+		prepareMockFn func(attachments *mock_repository.MockAttachmentRepository, storage *mock_repository.MockBlobStorage)
+		want          string
+		expectErr     error
+	}{
+		"正常ケース:内容を読み込む": {
+			prepareMockFn: func(attachments *mock_repository.MockAttachmentRepository, storage *mock_repository.MockBlobStorage) {
+				attachments.EXPECT().FindById(testUserID, uint(1), uint(2)).Return(&file, nil)
+				storage.EXPECT().Get("todos/1/a").Return(io.NopCloser(strings.NewReader("hello")), nil)
+			},
+			want: "hello",
+		},
+		"異常ケース:情報が存在しない": {
+			prepareMockFn: func(attachments *mock_repository.MockAttachmentRepository, storage *mock_repository.MockBlobStorage) {
+				attachments.EXPECT().FindById(testUserID, uint(1), uint(2)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: gorm.ErrRecordNotFound,
+		},
+		"異常ケース:ストレージに内容が存在しない": {
+			prepareMockFn: func(attachments *mock_repository.MockAttachmentRepository, storage *mock_repository.MockBlobStorage) {
+				attachments.EXPECT().FindById(testUserID, uint(1), uint(2)).Return(&file, nil)
+				storage.EXPECT().Get("todos/1/a").Return(nil, repository.ErrBlobNotFound)
+			},
+			expectErr: repository.ErrBlobNotFound,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			attachments := mock_repository.NewMockAttachmentRepository(mockCtrl)
+			storage := mock_repository.NewMockBlobStorage(mockCtrl)
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(attachments, storage)
+
+			// mockを利用してテストする
+			Usecase := NewTodoUsecase(mock_repository.NewMockTodoRepository(mockCtrl), mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), attachments, storage, newHistoryRepositoryMock(mockCtrl))
+			attachment, r, err := Usecase.OpenAttachment(testUserID, 1, 2)
+
+			// 結果を確認
+			if tt.expectErr != nil {
+				assert.True(t, errors.Is(err, tt.expectErr), err)
+				assert.Nil(t, attachment)
+				assert.Nil(t, r)
+				return
+			}
+			if assert.NoError(t, err) {
+				defer r.Close()
+				body, _ := io.ReadAll(r)
+				assert.Equal(t, tt.want, string(body))
+				assert.Equal(t, &file, attachment)
+			}
+		})
+	}
+}
+
+func TestDetach(t *testing.T) {
+
+	file := models.Attachment{ID: 2, TodoID: 1, FileName: "a.txt", StorageKey: "todos/1/a"}
+
+	cases := map[string]struct {
+		prepareMockFn func(attachments *mock_repository.MockAttachmentRepository, storage *mock_repository.MockBlobStorage)
+		expectErr     error
+	}{
+		"正常ケース:情報と内容を削除": {
+			prepareMockFn: func(attachments *mock_repository.MockAttachmentRepository, storage *mock_repository.MockBlobStorage) {
+				attachments.EXPECT().FindById(testUserID, uint(1), uint(2)).Return(&file, nil)
+				gomock.InOrder(
+					attachments.EXPECT().Delete(testUserID, uint(1), uint(2)).Return(nil),
+					storage.EXPECT().Delete("todos/1/a").Return(nil),
+				)
+			},
+		},
+		"異常ケース:情報が存在しない": {
+			prepareMockFn: func(attachments *mock_repository.MockAttachmentRepository, storage *mock_repository.MockBlobStorage) {
+				attachments.EXPECT().FindById(testUserID, uint(1), uint(2)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: gorm.ErrRecordNotFound,
+		},
+		"異常ケース:情報を削除できない場合は内容も削除しない": {
+			prepareMockFn: func(attachments *mock_repository.MockAttachmentRepository, storage *mock_repository.MockBlobStorage) {
+				attachments.EXPECT().FindById(testUserID, uint(1), uint(2)).Return(&file, nil)
+				attachments.EXPECT().Delete(testUserID, uint(1), uint(2)).Return(gorm.ErrInvalidTransaction)
+			},
+			expectErr: gorm.ErrInvalidTransaction,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			attachments := mock_repository.NewMockAttachmentRepository(mockCtrl)
+			storage := mock_repository.NewMockBlobStorage(mockCtrl)
+			// テスト中に呼ばれるべき関数と帰り値を指定
+			tt.prepareMockFn(attachments, storage)
+
+			// mockを利用してテストする
+			Usecase := NewTodoUsecase(mock_repository.NewMockTodoRepository(mockCtrl), mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), attachments, storage, newHistoryRepositoryMock(mockCtrl))
+			err := Usecase.Detach(testUserID, 1, 2)
+
+			// 結果を確認
+			if tt.expectErr != nil {
+				assert.True(t, errors.Is(err, tt.expectErr), err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRecordHistory(t *testing.T) {
+
+	due := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		// テストする操作
+		run func(uc TodoUsecase) error
+		// todoの変更とともにリポジトリに渡された変更履歴をgotに設定し、errを返すモックを準備する
+		prepareMockFn func(todos *mock_repository.MockTodoRepository, got *[]models.TodoHistory, err error)
+		// 保存する変更履歴
+		want      []models.TodoHistory
+		saveErr   error
+		expectErr error
+	}{
+		"正常ケース:登録時に作成を記録": {
+			run: func(uc TodoUsecase) error {
+				return uc.Add(&models.Todo{UserID: testUserID, Title: "test", Status: models.NotStarted})
+			},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, got *[]models.TodoHistory, err error) {
+				todos.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *models.Todo, histories ...models.TodoHistory) error {
+					*got = histories
+					return err
+				})
+			},
+			// 作成したtodoのIDは、リポジトリで設定する
+			want: []models.TodoHistory{{UserID: testUserID, Action: models.HistoryCreated}},
+		},
+		"正常ケース:更新時に変更した項目を記録": {
+			run: func(uc TodoUsecase) error {
+				return uc.Edit(&models.Todo{Model: gorm.Model{ID: 1}, UserID: testUserID, ProjectID: 2, Title: "after", Status: models.Done, DueAt: &due})
+			},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, got *[]models.TodoHistory, err error) {
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(&models.Todo{Model: gorm.Model{ID: 1}, UserID: testUserID, ProjectID: testInboxID, Title: "before", Status: models.NotStarted}, nil)
+				todos.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Nil()).DoAndReturn(func(_ *models.Todo, histories []models.TodoHistory, _ *models.Todo) error {
+					*got = histories
+					return err
+				})
+			},
+			// プロジェクトは名前を記録する
+			want: []models.TodoHistory{
+				{TodoID: 1, UserID: testUserID, Action: models.HistoryUpdated, Field: models.HistoryFieldTitle, OldValue: "before", NewValue: "after"},
+				{TodoID: 1, UserID: testUserID, Action: models.HistoryUpdated, Field: models.HistoryFieldStatus, OldValue: models.NotStarted.Key(), NewValue: models.Done.Key()},
+				{TodoID: 1, UserID: testUserID, Action: models.HistoryUpdated, Field: models.HistoryFieldDue, OldValue: "", NewValue: "2025-06-01"},
+				{TodoID: 1, UserID: testUserID, Action: models.HistoryUpdated, Field: models.HistoryFieldProject, OldValue: models.InboxProjectName, NewValue: "仕事"},
+			},
+		},
+		"正常ケース:変更が無い場合は記録しない": {
+			run: func(uc TodoUsecase) error {
+				return uc.Edit(&models.Todo{Model: gorm.Model{ID: 1}, UserID: testUserID, ProjectID: testInboxID, Title: "test"})
+			},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, got *[]models.TodoHistory, err error) {
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(&models.Todo{Model: gorm.Model{ID: 1}, UserID: testUserID, ProjectID: testInboxID, Title: "test"}, nil)
+				todos.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Nil()).DoAndReturn(func(_ *models.Todo, histories []models.TodoHistory, _ *models.Todo) error {
+					*got = histories
+					return err
+				})
+			},
+			want: []models.TodoHistory{},
+		},
+		"正常ケース:削除時にゴミ箱への移動を記録": {
+			run: func(uc TodoUsecase) error {
+				return uc.Delete(testUserID, 1)
+			},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, got *[]models.TodoHistory, err error) {
+				todos.EXPECT().Delete(testUserID, uint(1), gomock.Any()).DoAndReturn(func(_ uint, _ uint, histories ...models.TodoHistory) error {
+					*got = histories
+					return err
+				})
+			},
+			want: []models.TodoHistory{{TodoID: 1, UserID: testUserID, Action: models.HistoryDeleted}},
+		},
+		"正常ケース:元に戻した時に記録": {
+			run: func(uc TodoUsecase) error {
+				return uc.Restore(testUserID, 1)
+			},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, got *[]models.TodoHistory, err error) {
+				todos.EXPECT().Restore(testUserID, uint(1), gomock.Any()).DoAndReturn(func(_ uint, _ uint, histories ...models.TodoHistory) error {
+					*got = histories
+					return err
+				})
+			},
+			want: []models.TodoHistory{{TodoID: 1, UserID: testUserID, Action: models.HistoryRestored}},
+		},
+		"異常ケース:記録に失敗": {
+			run: func(uc TodoUsecase) error {
+				return uc.Delete(testUserID, 1)
+			},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, got *[]models.TodoHistory, err error) {
+				todos.EXPECT().Delete(testUserID, uint(1), gomock.Any()).DoAndReturn(func(_ uint, _ uint, histories ...models.TodoHistory) error {
+					*got = histories
+					return err
+				})
+			},
+			want:      []models.TodoHistory{{TodoID: 1, UserID: testUserID, Action: models.HistoryDeleted}},
+			saveErr:   gorm.ErrInvalidTransaction,
+			expectErr: gorm.ErrInvalidTransaction,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			// 変更履歴はtodoの変更と同じトランザクションで保存するため、TodoRepositoryに渡す
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
+			var got []models.TodoHistory
+			tt.prepareMockFn(todos, &got, tt.saveErr)
+			projects := newProjectRepositoryMock(mockCtrl)
+			projects.EXPECT().FindById(testUserID, uint(2)).Return(&models.Project{ID: 2, UserID: testUserID, Name: "仕事"}, nil).AnyTimes()
+
+			// mockを利用してテストする
+			Usecase := NewTodoUsecase(todos, mock_repository.NewMockTagRepository(mockCtrl), projects, mock_repository.NewMockAttachmentRepository(mockCtrl), mock_repository.NewMockBlobStorage(mockCtrl), newHistoryRepositoryMock(mockCtrl))
+			err := tt.run(Usecase)
+
+			// 結果を確認
+			assert.Equal(t, tt.want, got)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHistory(t *testing.T) {
+
+	histories := []models.TodoHistory{
+		{ID: 2, TodoID: 1, UserID: testUserID, Action: models.HistoryUpdated, Field: models.HistoryFieldTitle, OldValue: "a", NewValue: "b"},
+		{ID: 1, TodoID: 1, UserID: testUserID, Action: models.HistoryCreated},
+	}
+
+	cases := map[string]struct {
+		prepareMockFn func(todos *mock_repository.MockTodoRepository, repos *mock_repository.MockTodoHistoryRepository)
+		want          *[]models.TodoHistory
+		expectErr     error
+	}{
+		"正常ケース:変更履歴を取得": {
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, repos *mock_repository.MockTodoHistoryRepository) {
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(&models.Todo{Model: gorm.Model{ID: 1}, UserID: testUserID}, nil)
+				repos.EXPECT().FindByTodo(testUserID, uint(1)).Return(&histories, nil)
+			},
+			want: &histories,
+		},
+		"異常ケース:todoが存在しない": {
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, repos *mock_repository.MockTodoHistoryRepository) {
+				todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(nil, models.NotFound(gorm.ErrRecordNotFound))
+			},
+			expectErr: gorm.ErrRecordNotFound,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
+			repos := mock_repository.NewMockTodoHistoryRepository(mockCtrl)
+			tt.prepareMockFn(todos, repos)
+
+			// mockを利用してテストする
+			Usecase := NewTodoUsecase(todos, mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), mock_repository.NewMockAttachmentRepository(mockCtrl), mock_repository.NewMockBlobStorage(mockCtrl), repos)
+			got, err := Usecase.History(testUserID, 1)
+
+			// 結果を確認
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				assert.Nil(t, got)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEditConflict(t *testing.T) {
+
+	// 更新しようとした内容（バージョン1の時点で取得したもの）
+	newTodo := func() *models.Todo {
+		return &models.Todo{Model: gorm.Model{ID: 1}, UserID: testUserID, ProjectID: testInboxID, Title: "mine", Version: 1}
+	}
+	// 他の操作で更新された最新のtodo
+	latest := &models.Todo{Model: gorm.Model{ID: 1}, UserID: testUserID, ProjectID: testInboxID, Title: "theirs", Version: 2}
+
+	cases := map[string]struct {
+		prepareMockFn func(todos *mock_repository.MockTodoRepository)
+	}{
+		"異常ケース:取得時のバージョンが古い": {
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository) {
+				// 更新はせず、競合の表示用に詳細とともに最新の内容を取得すること
+				gomock.InOrder(
+					todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(latest, nil),
+					todos.EXPECT().FindById(testUserID, uint(1)).Return(latest, nil),
+				)
+			},
+		},
+		"異常ケース:保存までの間に更新された": {
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository) {
+				gomock.InOrder(
+					todos.EXPECT().FindSummaryById(testUserID, uint(1)).Return(newTodo(), nil),
+					todos.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.ErrTodoConflict),
+					todos.EXPECT().FindById(testUserID, uint(1)).Return(latest, nil),
+				)
+			},
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
+			tt.prepareMockFn(todos)
+			// 変更履歴は記録しないこと
+			histories := mock_repository.NewMockTodoHistoryRepository(mockCtrl)
+
+			// mockを利用してテストする
+			Usecase := NewTodoUsecase(todos, mock_repository.NewMockTagRepository(mockCtrl), newProjectRepositoryMock(mockCtrl), mock_repository.NewMockAttachmentRepository(mockCtrl), mock_repository.NewMockBlobStorage(mockCtrl), histories)
+			err := Usecase.Edit(newTodo())
+
+			// 結果を確認
+			assert.ErrorIs(t, err, models.ErrTodoConflict)
+			var conflict *models.TodoConflictError
+			if assert.ErrorAs(t, err, &conflict) {
+				assert.Equal(t, latest, conflict.Current)
+				assert.Equal(t, []models.TodoHistory{
+					{TodoID: 1, UserID: testUserID, Action: models.HistoryUpdated, Field: models.HistoryFieldTitle, OldValue: "theirs", NewValue: "mine"},
+				}, conflict.Changes)
+			}
+		})
+	}
+}
+
+func TestBulk(t *testing.T) {
+
+	now := time.Date(2025, 3, 12, 15, 0, 0, 0, time.UTC)
+
+	// 保存されているtodoを返す（IDごとに状態を指定する）
+	newTodo := func(id uint, status models.Status) models.Todo {
+		return models.Todo{Model: gorm.Model{ID: id}, UserID: testUserID, ProjectID: testInboxID, Title: "test", Status: status, Version: 1}
+	}
+	backend := models.Tag{ID: 1, UserID: testUserID, Name: "backend"}
+
+	type args struct {
+		action string
+		ids    []uint
+		tags   []string
+		atomic bool
+	}
+
+	cases := map[string]struct {
+		args args
+		// FindByIdsで返すtodo
+		stored        []models.Todo
+		prepareMockFn func(todos *mock_repository.MockTodoRepository, tags *mock_repository.MockTagRepository)
+		// リポジトリに渡す内容の確認（リポジトリを呼ばない場合はnil）
+		checkBulk func(t *testing.T, bulk *models.TodoBulk)
+		// リポジトリが返すtodoごとの結果とエラー
+		repoErrs []error
+		repoErr  error
+		want     []error
+		err      error
+	}{
+		"正常ケース:完了にして変更履歴を記録する（既に完了のものは変更しない）": {
+			args:   args{action: "complete", ids: []uint{1, 2}},
+			stored: []models.Todo{newTodo(2, models.Done), newTodo(1, models.InProgress)},
+			checkBulk: func(t *testing.T, bulk *models.TodoBulk) {
+				assert.Nil(t, bulk.Tags)
+				assert.False(t, bulk.Atomic)
+				if assert.Len(t, bulk.Changes, 2) {
+					assert.Equal(t, uint(1), bulk.Changes[0].Todo.ID)
+					assert.Equal(t, models.Done, bulk.Changes[0].Todo.Status)
+					assert.True(t, bulk.Changes[0].Update)
+					assert.Equal(t, []models.TodoHistory{
+						{TodoID: 1, UserID: testUserID, Action: models.HistoryUpdated, Field: models.HistoryFieldStatus, OldValue: models.InProgress.Key(), NewValue: models.Done.Key()},
+					}, bulk.Changes[0].Histories)
+					assert.Nil(t, bulk.Changes[0].Next)
+					assert.Equal(t, uint(2), bulk.Changes[1].Todo.ID)
+					assert.False(t, bulk.Changes[1].Update)
+					assert.Empty(t, bulk.Changes[1].Histories)
+				}
+			},
+			repoErrs: []error{nil, nil},
+			want:     []error{nil, nil},
+		},
+		"正常ケース:繰り返しのtodoを完了にすると次の繰り返しを作成する": {
+			args: args{action: "complete", ids: []uint{1}},
+			stored: func() []models.Todo {
+				todo := newTodo(1, models.NotStarted)
+				_ = todo.SetDue("2025-03-12", "", "UTC")
+				_ = todo.SetRecurrence("FREQ=DAILY")
+				return []models.Todo{todo}
+			}(),
+			checkBulk: func(t *testing.T, bulk *models.TodoBulk) {
+				if assert.Len(t, bulk.Changes, 1) && assert.NotNil(t, bulk.Changes[0].Next) {
+					assert.Equal(t, models.NotStarted, bulk.Changes[0].Next.Status)
+					assert.Equal(t, "2025-03-13", bulk.Changes[0].Next.DueDateString())
+				}
+			},
+			repoErrs: []error{nil},
+			want:     []error{nil},
+		},
+		"正常ケース:未着手に戻すのは完了・中止したもののみ": {
+			args:   args{action: "reopen", ids: []uint{1, 2, 3}},
+			stored: []models.Todo{newTodo(1, models.Done), newTodo(2, models.InProgress), newTodo(3, models.Cancelled)},
+			checkBulk: func(t *testing.T, bulk *models.TodoBulk) {
+				if assert.Len(t, bulk.Changes, 3) {
+					assert.True(t, bulk.Changes[0].Update)
+					assert.Equal(t, models.NotStarted, bulk.Changes[0].Todo.Status)
+					assert.False(t, bulk.Changes[1].Update)
+					assert.Equal(t, models.InProgress, bulk.Changes[1].Todo.Status)
+					assert.True(t, bulk.Changes[2].Update)
+					assert.Equal(t, models.NotStarted, bulk.Changes[2].Todo.Status)
+				}
+			},
+			repoErrs: []error{nil, nil, nil},
+			want:     []error{nil, nil, nil},
+		},
+		"正常ケース:ゴミ箱に移して変更履歴を記録する": {
+			args:   args{action: "delete", ids: []uint{1}},
+			stored: []models.Todo{newTodo(1, models.NotStarted)},
+			checkBulk: func(t *testing.T, bulk *models.TodoBulk) {
+				if assert.Len(t, bulk.Changes, 1) {
+					assert.True(t, bulk.Changes[0].Delete)
+					assert.Equal(t, []models.TodoHistory{models.NewTodoHistory(1, testUserID, models.HistoryDeleted)}, bulk.Changes[0].Histories)
+				}
+			},
+			repoErrs: []error{nil},
+			want:     []error{nil},
+		},
+		"正常ケース:タグの付け替えで存在しないタグは作成し、変更履歴を記録する": {
+			args: args{action: "retag", ids: []uint{1, 2}, tags: []string{"backend", "new"}},
+			stored: func() []models.Todo {
+				tagged := newTodo(2, models.NotStarted)
+				tagged.Tags = []models.Tag{backend}
+				return []models.Todo{newTodo(1, models.NotStarted), tagged}
+			}(),
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, tags *mock_repository.MockTagRepository) {
+				tags.EXPECT().FindByNames(testUserID, []string{"backend", "new"}).Return(&[]models.Tag{backend}, nil)
+			},
+			checkBulk: func(t *testing.T, bulk *models.TodoBulk) {
+				assert.Equal(t, []models.Tag{backend, {UserID: testUserID, Name: "new"}}, bulk.Tags)
+				if assert.Len(t, bulk.Changes, 2) {
+					assert.False(t, bulk.Changes[0].Update)
+					assert.False(t, bulk.Changes[0].Delete)
+					// タグの名前の一覧を変更前後で記録する
+					assert.Equal(t, []models.TodoHistory{
+						{TodoID: 1, UserID: testUserID, Action: models.HistoryUpdated, Field: models.HistoryFieldTags, OldValue: "", NewValue: "backend, new"},
+					}, bulk.Changes[0].Histories)
+					assert.Equal(t, []models.TodoHistory{
+						{TodoID: 2, UserID: testUserID, Action: models.HistoryUpdated, Field: models.HistoryFieldTags, OldValue: "backend", NewValue: "backend, new"},
+					}, bulk.Changes[1].Histories)
+				}
+			},
+			repoErrs: []error{nil, nil},
+			want:     []error{nil, nil},
+		},
+		"正常ケース:タグの付け替えで全てのタグを外す": {
+			args:   args{action: "retag", ids: []uint{1}},
+			stored: []models.Todo{newTodo(1, models.NotStarted)},
+			checkBulk: func(t *testing.T, bulk *models.TodoBulk) {
+				assert.Equal(t, []models.Tag{}, bulk.Tags)
+				if assert.Len(t, bulk.Changes, 1) {
+					// 付いていたタグが無い場合は、変更履歴を記録しない
+					assert.Empty(t, bulk.Changes[0].Histories)
+				}
+			},
+			repoErrs: []error{nil},
+			want:     []error{nil},
+		},
+		"正常ケース:存在しないtodo・状態を変更できないtodoは失敗とし、他は保存する": {
+			args: args{action: "complete", ids: []uint{1, 9, 2}},
+			stored: func() []models.Todo {
+				open := newTodo(2, models.InProgress)
+				open.ChecklistItems = []models.ChecklistItem{{ID: 1, TodoID: 2, Title: "item"}}
+				return []models.Todo{newTodo(1, models.NotStarted), open}
+			}(),
+			checkBulk: func(t *testing.T, bulk *models.TodoBulk) {
+				if assert.Len(t, bulk.Changes, 1) {
+					assert.Equal(t, uint(1), bulk.Changes[0].Todo.ID)
+				}
+			},
+			repoErrs: []error{nil},
+			want:     []error{nil, ErrTodoNotFound, models.ErrOpenChecklistItems},
+		},
+		"正常ケース:保存中に更新されたtodoのみ失敗とする": {
+			args:     args{action: "delete", ids: []uint{1, 2}},
+			stored:   []models.Todo{newTodo(1, models.NotStarted), newTodo(2, models.NotStarted)},
+			repoErrs: []error{models.ErrTodoConflict, nil},
+			want:     []error{models.ErrTodoConflict, nil},
+		},
+		"正常ケース:全て失敗した場合は保存しない": {
+			args: args{action: "delete", ids: []uint{9}},
+			want: []error{ErrTodoNotFound},
+		},
+		"異常ケース:全て取り消す指定で、存在しないtodoがある": {
+			args:   args{action: "complete", ids: []uint{1, 9}, atomic: true},
+			stored: []models.Todo{newTodo(1, models.NotStarted)},
+			want:   []error{models.ErrBulkRolledBack, ErrTodoNotFound},
+			err:    ErrTodoNotFound,
+		},
+		"異常ケース:全て取り消す指定で、保存中に更新されたtodoがある": {
+			args:   args{action: "delete", ids: []uint{1, 2}, atomic: true},
+			stored: []models.Todo{newTodo(1, models.NotStarted), newTodo(2, models.NotStarted)},
+			checkBulk: func(t *testing.T, bulk *models.TodoBulk) {
+				assert.True(t, bulk.Atomic)
+			},
+			repoErrs: []error{nil, models.ErrTodoConflict},
+			repoErr:  models.ErrTodoConflict,
+			want:     []error{models.ErrBulkRolledBack, models.ErrTodoConflict},
+			err:      models.ErrTodoConflict,
+		},
+		"異常ケース:todoの取得に失敗": {
+			args: args{action: "complete", ids: []uint{1}},
+			prepareMockFn: func(todos *mock_repository.MockTodoRepository, tags *mock_repository.MockTagRepository) {
+				todos.EXPECT().FindByIds(testUserID, []uint{1}).Return(nil, errors.New("Find todos is failed"))
+			},
+			err: errors.New("Find todos is failed"),
+		},
+		"異常ケース:保存に失敗": {
+			args:     args{action: "delete", ids: []uint{1}},
+			stored:   []models.Todo{newTodo(1, models.NotStarted)},
+			repoErrs: []error{nil},
+			repoErr:  errors.New("Commit is failed"),
+			err:      errors.New("Commit is failed"),
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+
+			// モックの呼び出しを管理するControllerを生成
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// モックの生成
+			todos := mock_repository.NewMockTodoRepository(mockCtrl)
+			tags := mock_repository.NewMockTagRepository(mockCtrl)
+			if tt.prepareMockFn != nil {
+				tt.prepareMockFn(todos, tags)
+			}
+			if tt.stored != nil || tt.want != nil {
+				stored := tt.stored
+				todos.EXPECT().FindByIds(testUserID, tt.args.ids).Return(&stored, nil)
+			}
+			if tt.repoErrs != nil {
+				todos.EXPECT().Bulk(gomock.Any()).DoAndReturn(func(bulk *models.TodoBulk) ([]error, error) {
+					if tt.checkBulk != nil {
+						tt.checkBulk(t, bulk)
+					}
+					return tt.repoErrs, tt.repoErr
+				})
+			}
+
+			// mockを利用してテストする
+			Usecase := &todoUsecase{repos: todos, tags: tags, projects: newProjectRepositoryMock(mockCtrl), histories: newHistoryRepositoryMock(mockCtrl), now: func() time.Time { return now }}
+			req, err := models.NewBulkRequest(testUserID, tt.args.action, tt.args.ids, tt.args.tags, tt.args.atomic)
+			if !assert.NoError(t, err) {
+				return
+			}
+			results, err := Usecase.Bulk(req)
+
+			// 結果を確認
+			if tt.err != nil {
+				assert.ErrorContains(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.want == nil {
+				assert.Nil(t, results)
+				return
+			}
+			if assert.Len(t, results, len(tt.want)) {
+				for i, want := range tt.want {
+					assert.Equal(t, tt.args.ids[i], results[i].ID)
+					if want == nil {
+						assert.NoError(t, results[i].Err)
+					} else {
+						assert.ErrorIs(t, results[i].Err, want)
+					}
+				}
+			}
+		})
+	}
+}
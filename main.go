@@ -1,10 +1,51 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	// 期限のタイムゾーンを解決できるよう、タイムゾーンのデータベースを埋め込む
+	_ "time/tzdata"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
 	"github.com/MinadukiSekina/todo-go-app/app/handlers/web/route"
 )
 
 func main() {
+	// 設定の読み込み
+	cfg, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// ログの出力レベルの設定
+	level, _ := cfg.Log.SlogLevel()
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+
+	// サブコマンドの実行
+	if len(args) > 0 {
+		if args[0] != "migrate" {
+			fmt.Fprintf(os.Stderr, "unknown command: %s\n", args[0])
+			os.Exit(2)
+		}
+		if err := runMigrate(cfg, args[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// SIGINT・SIGTERMを受け取ったら、処理中のリクエストの完了を待って終了する
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// ルーティングの設定
-	route.SetRouting()
+	if err := route.SetRouting(ctx, cfg); err != nil {
+		slog.Error(err.Error())
+		os.Exit(1)
+	}
 }
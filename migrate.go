@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/MinadukiSekina/todo-go-app/app/config"
+	"github.com/MinadukiSekina/todo-go-app/app/db"
+)
+
+// migrateサブコマンドの使い方
+const migrateUsage = `usage: todo-go-app [flags] migrate <command>
+
+commands:
+  up [N]     apply N pending migrations (all if omitted)
+  down [N]   roll back N applied migrations (1 if omitted)
+  status     show applied and pending migrations
+  adopt EMAIL
+             assign todos created before user accounts existed to the user`
+
+// migrateサブコマンドを実行する
+func runMigrate(cfg *config.Config, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	if args[0] == "adopt" {
+		return runAdopt(cfg, args[1:], out)
+	}
+
+	// 適用・取り消しする件数
+	steps := 0
+	if args[0] == "down" {
+		steps = 1
+	}
+	if len(args) > 1 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid number of migrations: %s", args[1])
+		}
+		steps = n
+	}
+
+	conn, err := db.Open(cfg.DB)
+	if err != nil {
+		return err
+	}
+	if sqlDB, err := conn.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+	migrator, err := db.NewMigrator(conn, cfg.DB.Driver)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		done, err := migrator.Up(steps)
+		for _, mig := range done {
+			fmt.Fprintf(out, "applied  %04d_%s\n", mig.Version, mig.Name)
+		}
+		if err == nil && len(done) == 0 {
+			fmt.Fprintln(out, "no pending migrations")
+		}
+		return err
+	case "down":
+		done, err := migrator.Down(steps)
+		for _, mig := range done {
+			fmt.Fprintf(out, "reverted %04d_%s\n", mig.Version, mig.Name)
+		}
+		if err == nil && len(done) == 0 {
+			fmt.Fprintln(out, "no applied migrations")
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			if s.Applied {
+				fmt.Fprintf(out, "applied  %04d_%s (%s)\n", s.Version, s.Name, s.AppliedAt.Format("2006-01-02 15:04:05"))
+			} else {
+				fmt.Fprintf(out, "pending  %04d_%s\n", s.Version, s.Name)
+			}
+		}
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+// 所有者の無いtodoを、指定されたメールアドレスのユーザーに引き継ぐ
+func runAdopt(cfg *config.Config, args []string, out io.Writer) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+	conn, err := db.Open(cfg.DB)
+	if err != nil {
+		return err
+	}
+	if sqlDB, err := conn.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+	adopted, err := db.AdoptOrphanedTodos(conn, args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "adopted %d todo(s) for %s\n", adopted, args[0])
+	return nil
+}